@@ -14,6 +14,15 @@ var Commands = []*Command{
 	cmdCopy,
 	cmdFix,
 	cmdFilerExport,
+	cmdFilerMetaMigrate,
+	cmdFilerMetaBackup,
+	cmdFilerMetaRestore,
+	cmdFilerFsck,
+	cmdFilerDefrag,
+	cmdFilerQuota,
+	cmdFilerSnapshot,
+	cmdFilerTrash,
+	cmdFilerVersioning,
 	cmdFilerReplicate,
 	cmdServer,
 	cmdMaster,
@@ -33,6 +33,13 @@ type FilerOptions struct {
 	dataCenter              *string
 	enableNotification      *bool
 	whiteList				*string
+	metaLogDir              *string
+	bucketsPath             *string
+	trashRetention          *time.Duration
+	inlineMaxBytes          *int
+	appendCompactChunks     *int
+	defragMinChunks         *int
+	chunkManifest           *bool
 }
 
 func init() {
@@ -51,6 +58,13 @@ func init() {
 	f.dirListingLimit = cmdFiler.Flag.Int("dirListLimit", 1000, "limit sub dir listing size")
 	f.dataCenter = cmdFiler.Flag.String("dataCenter", "", "prefer to write to volumes in this data center")
 	f.whiteList = cmdFiler.Flag.String("whiteList", "", "comma separated Ip addresses having write permission. No limit if empty.")
+	f.metaLogDir = cmdFiler.Flag.String("metaLog.dir", "", "directory to keep the metadata change log for subscribers, one for each filer, disabled if empty")
+	f.bucketsPath = cmdFiler.Flag.String("dir.buckets", "/buckets", "folder on filer to store all buckets, entries deleted in a bucket go to the .trash folder of the bucket")
+	f.trashRetention = cmdFiler.Flag.Duration("trash.retention", 0, "keep deleted entries in the trash for this long before deleting the file content, disabled if 0")
+	f.inlineMaxBytes = cmdFiler.Flag.Int("inline.maxBytes", 0, "keep the content of files up to this size in the filer store instead of the volume servers, disabled if 0")
+	f.appendCompactChunks = cmdFiler.Flag.Int("append.compactChunks", 0, "merge the small chunks of a file appended to once it has this many chunks, disabled if 0")
+	f.defragMinChunks = cmdFiler.Flag.Int("defrag.minChunks", 0, "periodically rewrite the files with at least this many chunks into fewer large chunks, disabled if 0")
+	f.chunkManifest = cmdFiler.Flag.Bool("chunk.manifest", false, "fold the chunk lists of huge files into manifest chunks, which older clients would read as file content")
 }
 
 var cmdFiler = &Command{
@@ -91,16 +105,23 @@ func (fo *FilerOptions) startFiler() {
 	}
 
 	fs, nfs_err := weed_server.NewFilerServer(defaultMux, publicVolumeMux, &weed_server.FilerOption{
-		Masters:            strings.Split(*f.masters, ","),
-		Collection:         *fo.collection,
-		DefaultReplication: *fo.defaultReplicaPlacement,
-		RedirectOnRead:     *fo.redirectOnRead,
-		DisableDirListing:  *fo.disableDirListing,
-		MaxMB:              *fo.maxMB,
-		SecretKey:          *fo.secretKey,
-		DirListingLimit:    *fo.dirListingLimit,
-		DataCenter:         *fo.dataCenter,
+		Masters:             strings.Split(*f.masters, ","),
+		Collection:          *fo.collection,
+		DefaultReplication:  *fo.defaultReplicaPlacement,
+		RedirectOnRead:      *fo.redirectOnRead,
+		DisableDirListing:   *fo.disableDirListing,
+		MaxMB:               *fo.maxMB,
+		SecretKey:           *fo.secretKey,
+		DirListingLimit:     *fo.dirListingLimit,
+		DataCenter:          *fo.dataCenter,
 		WhiteList:			strings.Split(*f.whiteList, ","),
+		MetaLogDir:          *fo.metaLogDir,
+		BucketsPath:         *fo.bucketsPath,
+		TrashRetention:      *fo.trashRetention,
+		InlineMaxBytes:      *fo.inlineMaxBytes,
+		AppendCompactChunks: *fo.appendCompactChunks,
+		DefragMinChunks:     *fo.defragMinChunks,
+		ChunkManifest:       *fo.chunkManifest,
 	})
 	if nfs_err != nil {
 		glog.Fatalf("Filer startup error: %v", nfs_err)
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	cmdFilerDefrag.Run = runFilerDefrag // break init cycle
+}
+
+var cmdFilerDefrag = &Command{
+	UsageLine: "filer.defrag -filer=localhost:8888 [-minChunks=2] [-maxMB=32] /some/dir/or/file",
+	Short:     "rewrite fragmented filer files into fewer large chunks",
+	Long: `Rewrite the visible content of fragmented files into non-overlapping chunks of maxMB,
+	e.g. the files written randomly through "weed mount", to read them with fewer requests.
+
+	For a directory, all files under it with at least minChunks chunks are rewritten.
+	A file is only rewritten if it needs fewer chunks afterwards, and the new chunks are
+	only swapped in if the file is not changed meanwhile. The replaced chunks are deleted.
+
+	The filer can also do this periodically for all files, with "weed filer -defrag.minChunks".
+
+  `,
+}
+
+var (
+	defragFiler         = cmdFilerDefrag.Flag.String("filer", "localhost:8888", "filer hostname:port")
+	defragFilerGrpcPort = cmdFilerDefrag.Flag.Int("filer.port.grpc", 0, "filer grpc server listen port, default to filer port + 10000")
+	defragMinChunks     = cmdFilerDefrag.Flag.Int("minChunks", 2, "only rewrite the files under the directory with at least this many chunks")
+	defragMaxMB         = cmdFilerDefrag.Flag.Int("maxMB", 0, "the size of the new chunks, default to the filer -maxMB or 32")
+)
+
+func runFilerDefrag(cmd *Command, args []string) bool {
+
+	if len(args) != 1 {
+		return false
+	}
+
+	filerGrpcAddress, err := parseFilerGrpcAddress(*defragFiler, *defragFilerGrpcPort)
+	if err != nil {
+		glog.Errorf("%v", err)
+		return false
+	}
+
+	err = withFilerClient(filerGrpcAddress, func(client filer_pb.SeaweedFilerClient) error {
+
+		p := filer2.FullPath(args[0])
+		request := &filer_pb.DefragmentRequest{
+			Directory: string(p),
+			MinChunks: uint32(*defragMinChunks),
+			MaxMb:     int32(*defragMaxMB),
+		}
+
+		if p != "/" {
+			dir, name := p.DirAndName()
+			lookup, err := client.LookupDirectoryEntry(context.Background(), &filer_pb.LookupDirectoryEntryRequest{
+				Directory: dir,
+				Name:      name,
+			})
+			if err != nil {
+				return fmt.Errorf("lookup %s: %v", p, err)
+			}
+			if !lookup.Entry.IsDirectory {
+				request.Directory, request.Name = dir, name
+			}
+		}
+
+		resp, err := client.Defragment(context.Background(), request)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("defragmented %d files from %d chunks into %d\n", resp.FileCount, resp.OldChunkCount, resp.NewChunkCount)
+		return nil
+	})
+	if err != nil {
+		glog.Errorf("filer defrag on %s: %v", *defragFiler, err)
+		return false
+	}
+
+	return true
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"github.com/draleyva/seaweedfs/weed/filer2"
 	"github.com/draleyva/seaweedfs/weed/glog"
 	"github.com/draleyva/seaweedfs/weed/notification"
@@ -124,7 +125,7 @@ func runFilerExport(cmd *Command, args []string) bool {
 			if *dryRun {
 				return nil
 			}
-			return targetStore.InsertEntry(entry)
+			return targetStore.InsertEntry(context.Background(), entry)
 		}
 	}
 
@@ -140,7 +141,7 @@ func doTraverse(stat *statistics, filerStore filer2.FilerStore, parentPath filer
 	limit := *dirListLimit
 	lastEntryName := ""
 	for {
-		entries, err := filerStore.ListDirectoryEntries(parentPath, lastEntryName, false, limit)
+		entries, err := filerStore.ListDirectoryEntries(context.Background(), parentPath, lastEntryName, false, limit)
 		if err != nil {
 			break
 		}
@@ -0,0 +1,343 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/operation"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
+	"github.com/draleyva/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/draleyva/seaweedfs/weed/storage"
+	"github.com/draleyva/seaweedfs/weed/storage/types"
+)
+
+func init() {
+	cmdFilerFsck.Run = runFilerFsck // break init cycle
+}
+
+var cmdFilerFsck = &Command{
+	UsageLine: "filer.fsck -filer=localhost:8888 -master=localhost:9333 [-collection=name] [-delete -cutoff=24h]",
+	Short:     "find the orphan and missing file chunks of the filer",
+	Long: `Walk the whole filer namespace to collect the file chunks referenced by the entries,
+	including the trash, the versions, the snapshots, the hard links, and the chunks folded
+	in the chunk manifests, and compare them with the needles in each volume replica.
+
+	An orphan is a needle in a volume not referenced by any filer entry.
+	A missing chunk is referenced by a filer entry, but not found in a volume replica.
+
+	Only the volumes of the collections referenced by the filer are checked, or only the
+	volumes of -collection if specified. Volumes written without the filer should not be in
+	these collections, since all their needles would be reported as orphans.
+
+	With -delete, the orphans appended before the walk started, minus the -cutoff duration,
+	are deleted. The cutoff protects the chunks being uploaded, which are not referenced yet.
+	The namespace is walked again before deleting, and the orphans referenced by then are kept.
+	Nothing is deleted if any chunk manifest can not be read or any file id is bad, since
+	the chunks referenced by them are unknown.
+
+  `,
+}
+
+var (
+	fsckFiler         = cmdFilerFsck.Flag.String("filer", "localhost:8888", "filer hostname:port")
+	fsckFilerGrpcPort = cmdFilerFsck.Flag.Int("filer.port.grpc", 0, "filer grpc server listen port, default to filer port + 10000")
+	fsckMaster        = cmdFilerFsck.Flag.String("master", "localhost:9333", "master hostname:port")
+	fsckCollection    = cmdFilerFsck.Flag.String("collection", "", "only check the volumes of this collection")
+	fsckDelete        = cmdFilerFsck.Flag.Bool("delete", false, "delete the orphans older than the cutoff")
+	fsckCutoff        = cmdFilerFsck.Flag.Duration("cutoff", 24*time.Hour, "only delete the orphans appended this long before the check started")
+)
+
+// fsckChunks maps each referenced needle to one of the chunks referencing it, by volume.
+type fsckChunks map[storage.VolumeId]map[types.NeedleId]fsckChunk
+
+type fsckChunk struct {
+	fileId string
+	path   string
+}
+
+type fsckVolume struct {
+	collection string
+	servers    []string
+}
+
+func runFilerFsck(cmd *Command, args []string) bool {
+
+	filerGrpcAddress, err := parseFilerGrpcAddress(*fsckFiler, *fsckFilerGrpcPort)
+	if err != nil {
+		glog.Errorf("%v", err)
+		return false
+	}
+
+	startTime := time.Now()
+	cutoffTsNs := uint64(startTime.Add(-*fsckCutoff).UnixNano())
+
+	chunks := make(fsckChunks)
+	var chunkCount, unresolvedCount int
+	lookupFileIdFn := filer2.LookupFileIdWithFiler(func(fn func(filer_pb.SeaweedFilerClient) error) error {
+		return withFilerClient(filerGrpcAddress, fn)
+	})
+	err = withFilerClient(filerGrpcAddress, func(client filer_pb.SeaweedFilerClient) error {
+		return collectFsckChunks(client, lookupFileIdFn, "/", chunks, &chunkCount, &unresolvedCount)
+	})
+	if err != nil {
+		glog.Errorf("filer fsck on %s: %v", *fsckFiler, err)
+		return false
+	}
+
+	// the chunks referenced by the unresolved ones are unknown, and would be taken as orphans
+	deleteOrphans := *fsckDelete
+	if unresolvedCount > 0 && deleteOrphans {
+		glog.Warningf("filer fsck: %d referenced chunks can not be resolved, skip deleting the orphans", unresolvedCount)
+		deleteOrphans = false
+	}
+
+	topologyInfo, err := operation.ListVolumes(*fsckMaster)
+	if err != nil {
+		glog.Errorf("filer fsck list volumes on %s: %v", *fsckMaster, err)
+		return false
+	}
+	volumes := listFsckVolumes(topologyInfo)
+
+	collections := make(map[string]bool)
+	if *fsckCollection != "" {
+		collections[*fsckCollection] = true
+	} else {
+		for vid := range chunks {
+			if v, found := volumes[vid]; found {
+				collections[v.collection] = true
+			}
+		}
+	}
+
+	var missingCount, orphanCount int
+	var orphanSize uint64
+	orphans := make(map[string]bool)
+
+	for vid, needles := range chunks {
+		if _, found := volumes[vid]; found {
+			continue
+		}
+		for _, chunk := range needles {
+			fmt.Printf("missing\t%s\t%s\tvolume not found\n", chunk.fileId, chunk.path)
+			missingCount++
+		}
+	}
+
+	var vids []storage.VolumeId
+	for vid, v := range volumes {
+		if collections[v.collection] {
+			vids = append(vids, vid)
+		}
+	}
+	sort.Slice(vids, func(i, j int) bool {
+		return vids[i] < vids[j]
+	})
+
+	for _, vid := range vids {
+		referenced := chunks[vid]
+		for _, server := range volumes[vid].servers {
+			present := make(map[types.NeedleId]bool)
+			err := operation.GetVolumeNeedleIndex(server, uint32(vid), func(entry *volume_server_pb.NeedleIndexEntry) {
+				needleId := types.Uint64ToNeedleId(entry.NeedleId)
+				present[needleId] = true
+				if _, found := referenced[needleId]; found {
+					return
+				}
+				fileId := storage.NewFileId(vid, entry.NeedleId, entry.Cookie).String()
+				fmt.Printf("orphan\t%s\t%s\t%d bytes\t%s\n", fileId, server, entry.Size,
+					time.Unix(0, int64(entry.AppendAtNs)).Format(time.RFC3339))
+				orphanCount++
+				orphanSize += uint64(entry.Size)
+				if deleteOrphans && entry.AppendAtNs < cutoffTsNs {
+					orphans[fileId] = true
+				}
+			})
+			if err != nil {
+				glog.Errorf("filer fsck volume %d on %s: %v", vid, server, err)
+				return false
+			}
+			for needleId, chunk := range referenced {
+				if !present[needleId] {
+					fmt.Printf("missing\t%s\t%s\t%s\n", chunk.fileId, chunk.path, server)
+					missingCount++
+				}
+			}
+		}
+	}
+
+	// the orphans may be referenced during the check, e.g. by a restored entry
+	if len(orphans) > 0 {
+		err = withFilerClient(filerGrpcAddress, func(client filer_pb.SeaweedFilerClient) error {
+			return recheckFsckOrphans(client, lookupFileIdFn, orphans)
+		})
+		if err != nil {
+			glog.Errorf("filer fsck recheck orphans, skip deleting them: %v", err)
+			orphans = nil
+		}
+	}
+
+	deletedCount, err := deleteFsckOrphans(orphans)
+	if err != nil {
+		glog.Errorf("filer fsck delete orphans: %v", err)
+	}
+
+	fmt.Printf("%d chunks referenced, %d unresolved, %d orphans of %d bytes, %d missing, %d orphans deleted\n",
+		chunkCount, unresolvedCount, orphanCount, orphanSize, missingCount, deletedCount)
+
+	return err == nil && unresolvedCount == 0
+}
+
+// collectFsckChunks walks the directory, including the hidden system directories, for the referenced file chunks,
+// including the chunks folded in the manifests. The chunks with bad file ids and the manifests which can not be read
+// are counted as unresolved.
+func collectFsckChunks(client filer_pb.SeaweedFilerClient, lookupFileIdFn filer2.LookupFileIdFunctionType, dir filer2.FullPath, chunks fsckChunks, count, unresolved *int) error {
+	lastFileName := ""
+	for {
+		resp, err := client.ListEntries(context.Background(), &filer_pb.ListEntriesRequest{
+			Directory:         string(dir),
+			StartFromFileName: lastFileName,
+			Limit:             1024,
+		})
+		if err != nil {
+			return fmt.Errorf("list %s: %v", dir, err)
+		}
+		for _, entry := range resp.Entries {
+			lastFileName = entry.Name
+			fullpath := filer2.NewFullPath(string(dir), entry.Name)
+			resolved, unresolvedManifests := resolveFsckChunks(lookupFileIdFn, fullpath, entry.Chunks)
+			*unresolved += unresolvedManifests
+			for _, chunk := range resolved {
+				vid, needleId, err := parseFsckFileId(chunk.FileId)
+				if err != nil {
+					glog.Warningf("%s chunk %s: %v", fullpath, chunk.FileId, err)
+					*unresolved++
+					continue
+				}
+				if chunks[vid] == nil {
+					chunks[vid] = make(map[types.NeedleId]fsckChunk)
+				}
+				if _, found := chunks[vid][needleId]; !found {
+					chunks[vid][needleId] = fsckChunk{fileId: chunk.FileId, path: string(fullpath)}
+					*count++
+				}
+			}
+			if entry.IsDirectory {
+				if err := collectFsckChunks(client, lookupFileIdFn, fullpath, chunks, count, unresolved); err != nil {
+					return err
+				}
+			}
+		}
+		if len(resp.Entries) < 1024 {
+			return nil
+		}
+	}
+}
+
+// resolveFsckChunks returns the chunks with the chunks folded in the manifests, and the number of manifests
+// which can not be read. Such a manifest is still returned, so it is reported if missing.
+func resolveFsckChunks(lookupFileIdFn filer2.LookupFileIdFunctionType, fullpath filer2.FullPath, chunks []*filer_pb.FileChunk) (resolved []*filer_pb.FileChunk, unresolved int) {
+	for _, chunk := range chunks {
+		resolved = append(resolved, chunk)
+		if !chunk.IsChunkManifest {
+			continue
+		}
+		folded, err := filer2.ResolveOneChunkManifest(lookupFileIdFn, chunk)
+		if err != nil {
+			glog.Warningf("%s chunk %s: %v", fullpath, chunk.FileId, err)
+			unresolved++
+			continue
+		}
+		foldedResolved, foldedUnresolved := resolveFsckChunks(lookupFileIdFn, fullpath, folded)
+		resolved, unresolved = append(resolved, foldedResolved...), unresolved+foldedUnresolved
+	}
+	return
+}
+
+func parseFsckFileId(fileId string) (storage.VolumeId, types.NeedleId, error) {
+	vid, keyCookie, err := operation.ParseFileId(fileId)
+	if err != nil {
+		return 0, types.NeedleIdEmpty, err
+	}
+	volumeId, err := storage.NewVolumeId(vid)
+	if err != nil {
+		return 0, types.NeedleIdEmpty, err
+	}
+	needleId, _, err := storage.ParseNeedleIdCookie(keyCookie)
+	return volumeId, needleId, err
+}
+
+func listFsckVolumes(topologyInfo *master_pb.TopologyInfo) map[storage.VolumeId]*fsckVolume {
+	volumes := make(map[storage.VolumeId]*fsckVolume)
+	for _, dc := range topologyInfo.DataCenterInfos {
+		for _, rack := range dc.RackInfos {
+			for _, dn := range rack.DataNodeInfos {
+				for _, vi := range dn.VolumeInfos {
+					vid := storage.VolumeId(vi.Id)
+					if volumes[vid] == nil {
+						volumes[vid] = &fsckVolume{collection: vi.Collection}
+					}
+					volumes[vid].servers = append(volumes[vid].servers, dn.Url)
+				}
+			}
+		}
+	}
+	return volumes
+}
+
+// recheckFsckOrphans walks the filer again, and drops the orphans referenced by now.
+func recheckFsckOrphans(client filer_pb.SeaweedFilerClient, lookupFileIdFn filer2.LookupFileIdFunctionType, orphans map[string]bool) error {
+	chunks := make(fsckChunks)
+	var chunkCount, unresolvedCount int
+	if err := collectFsckChunks(client, lookupFileIdFn, "/", chunks, &chunkCount, &unresolvedCount); err != nil {
+		return err
+	}
+	if unresolvedCount > 0 {
+		return fmt.Errorf("%d referenced chunks can not be resolved", unresolvedCount)
+	}
+	for fileId := range orphans {
+		vid, needleId, err := parseFsckFileId(fileId)
+		if err != nil {
+			return fmt.Errorf("orphan %s: %v", fileId, err)
+		}
+		if chunk, found := chunks[vid][needleId]; found {
+			glog.V(0).Infof("orphan %s is referenced by %s now, skip deleting it", fileId, chunk.path)
+			delete(orphans, fileId)
+		}
+	}
+	return nil
+}
+
+// deleteFsckOrphans deletes the orphans from all volume replicas, and returns the number of orphans deleted.
+func deleteFsckOrphans(orphans map[string]bool) (count int, err error) {
+	var fileIds []string
+	for fileId := range orphans {
+		fileIds = append(fileIds, fileId)
+	}
+	sort.Strings(fileIds)
+	for len(fileIds) > 0 {
+		batch := fileIds
+		if len(batch) > 1024 {
+			batch = batch[:1024]
+		}
+		fileIds = fileIds[len(batch):]
+		results, err := operation.DeleteFiles(*fsckMaster, batch)
+		if err != nil {
+			return count, err
+		}
+		deleted := make(map[string]bool)
+		for _, result := range results {
+			if result.Error == "" {
+				deleted[result.FileId] = true
+			} else {
+				glog.Errorf("delete orphan %s: %s", result.FileId, result.Error)
+			}
+		}
+		count += len(deleted)
+	}
+	return count, nil
+}
@@ -0,0 +1,307 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+const (
+	metaBackupSnapshotSuffix = ".snapshot"
+	metaBackupEventsSuffix   = ".events"
+	// the events are subscribed a bit before the snapshot starts, in case the filer clock is behind.
+	// Replaying the extra events is harmless, since each event carries the complete new entry.
+	metaBackupClockSkew = time.Minute
+)
+
+func init() {
+	cmdFilerMetaBackup.Run = runFilerMetaBackup // break init cycle
+}
+
+var cmdFilerMetaBackup = &Command{
+	UsageLine: "filer.meta.backup -filer=localhost:8888 -dir=/path/to/backup",
+	Short:     "continuously back up the filer meta data to local files",
+	Long: `Take a snapshot of all filer entries, and then keep appending the metadata change events.
+
+	The backup directory contains:
+        * <startTsNs>_<doneTsNs>.snapshot  all entries listed from the filer, between the two times
+        * <startTsNs>.events               the metadata change events after the snapshot started
+
+	Both files are a sequence of records, each is a 4-byte big endian length,
+	followed by a protobuf encoded filer_pb.SubscribeMetadataResponse.
+	Snapshot entries are saved as events creating the entry.
+
+	If the backup directory already has a snapshot, the backup resumes appending events
+	after the last saved event, unless -newSnapshot is set.
+	The changes of the filer system entries, e.g. the hard link records, the quotas, the snapshots
+	and the versions, are only in the metadata log when backing up the whole filer with -path=/.
+	The filer must be started with -metaLog.dir to keep the metadata log, and only keeps it for a few days.
+
+	Use "weed filer.meta.restore" to rebuild a filer store from the backup.
+
+  `,
+}
+
+var (
+	metaBackupFiler         = cmdFilerMetaBackup.Flag.String("filer", "localhost:8888", "filer hostname:port")
+	metaBackupFilerGrpcPort = cmdFilerMetaBackup.Flag.Int("filer.port.grpc", 0, "filer grpc server listen port, default to filer port + 10000")
+	metaBackupDir           = cmdFilerMetaBackup.Flag.String("dir", "", "directory to store the backup files")
+	metaBackupPath          = cmdFilerMetaBackup.Flag.String("path", "/", "only back up entries under this path")
+	metaBackupNewSnapshot   = cmdFilerMetaBackup.Flag.Bool("newSnapshot", false, "take a new snapshot even if the backup directory already has one")
+)
+
+func runFilerMetaBackup(cmd *Command, args []string) bool {
+
+	if *metaBackupDir == "" {
+		glog.Errorf("the backup directory -dir is required")
+		return false
+	}
+	if err := os.MkdirAll(*metaBackupDir, 0755); err != nil {
+		glog.Errorf("create backup directory: %v", err)
+		return false
+	}
+
+	path := *metaBackupPath
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	filerGrpcAddress, err := parseFilerGrpcAddress(*metaBackupFiler, *metaBackupFilerGrpcPort)
+	if err != nil {
+		glog.Errorf("%v", err)
+		return false
+	}
+
+	snapshots, err := listMetaBackupSnapshots(*metaBackupDir)
+	if err != nil {
+		glog.Errorf("%v", err)
+		return false
+	}
+
+	var snapshot metaBackupSnapshot
+	var sinceNs int64
+	if len(snapshots) == 0 || *metaBackupNewSnapshot {
+		if snapshot, err = takeMetaBackupSnapshot(filerGrpcAddress, *metaBackupDir, path); err != nil {
+			glog.Errorf("snapshot: %v", err)
+			return false
+		}
+		sinceNs = snapshot.startTsNs - int64(metaBackupClockSkew)
+	} else {
+		snapshot = snapshots[len(snapshots)-1]
+		if sinceNs, err = lastMetaBackupEventTsNs(snapshot.eventsFileName()); err != nil {
+			glog.Errorf("read %s: %v", snapshot.eventsFileName(), err)
+			return false
+		}
+		if sinceNs == 0 {
+			sinceNs = snapshot.startTsNs - int64(metaBackupClockSkew)
+		}
+		glog.V(0).Infof("resume backing up events after %v", time.Unix(0, sinceNs))
+	}
+
+	eventsFile, err := os.OpenFile(snapshot.eventsFileName(), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		glog.Errorf("open %s: %v", snapshot.eventsFileName(), err)
+		return false
+	}
+	defer eventsFile.Close()
+
+	for {
+		err = withFilerClient(filerGrpcAddress, func(client filer_pb.SeaweedFilerClient) error {
+			stream, err := client.SubscribeMetadata(context.Background(), &filer_pb.SubscribeMetadataRequest{
+				ClientName: "filer.meta.backup",
+				PathPrefix: path,
+				SinceNs:    sinceNs,
+			})
+			if err != nil {
+				return err
+			}
+			for {
+				resp, err := stream.Recv()
+				if err != nil {
+					return err
+				}
+				if err = filer2.WriteMetaLogRecord(eventsFile, resp); err != nil {
+					return fmt.Errorf("write %s: %v", eventsFile.Name(), err)
+				}
+				sinceNs = resp.TsNs
+			}
+		})
+		glog.V(0).Infof("subscribe metadata from %s: %v", filerGrpcAddress, err)
+		time.Sleep(5 * time.Second)
+
+		// drop a partially written record, and resubscribe after the last saved event
+		if lastTsNs, err := lastMetaBackupEventTsNs(snapshot.eventsFileName()); err != nil {
+			glog.Errorf("read %s: %v", snapshot.eventsFileName(), err)
+		} else if lastTsNs > 0 {
+			sinceNs = lastTsNs
+		}
+	}
+}
+
+type metaBackupSnapshot struct {
+	dir       string
+	startTsNs int64
+	doneTsNs  int64
+}
+
+func (s metaBackupSnapshot) snapshotFileName() string {
+	return filepath.Join(s.dir, fmt.Sprintf("%019d_%019d%s", s.startTsNs, s.doneTsNs, metaBackupSnapshotSuffix))
+}
+
+func (s metaBackupSnapshot) eventsFileName() string {
+	return filepath.Join(s.dir, fmt.Sprintf("%019d%s", s.startTsNs, metaBackupEventsSuffix))
+}
+
+// listMetaBackupSnapshots returns the completed snapshots in the backup directory, oldest first.
+func listMetaBackupSnapshots(dir string) (snapshots []metaBackupSnapshot, err error) {
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("list backup directory %s: %v", dir, err)
+	}
+	for _, fileInfo := range fileInfos {
+		name := fileInfo.Name()
+		if !strings.HasSuffix(name, metaBackupSnapshotSuffix) {
+			continue
+		}
+		parts := strings.Split(strings.TrimSuffix(name, metaBackupSnapshotSuffix), "_")
+		if len(parts) != 2 {
+			continue
+		}
+		startTsNs, startErr := strconv.ParseInt(parts[0], 10, 64)
+		doneTsNs, doneErr := strconv.ParseInt(parts[1], 10, 64)
+		if startErr != nil || doneErr != nil {
+			continue
+		}
+		snapshots = append(snapshots, metaBackupSnapshot{dir: dir, startTsNs: startTsNs, doneTsNs: doneTsNs})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].startTsNs < snapshots[j].startTsNs
+	})
+	return snapshots, nil
+}
+
+func takeMetaBackupSnapshot(filerGrpcAddress, dir, path string) (snapshot metaBackupSnapshot, err error) {
+
+	snapshot = metaBackupSnapshot{dir: dir, startTsNs: time.Now().UnixNano()}
+	glog.V(0).Infof("taking snapshot of %s%s", filerGrpcAddress, path)
+
+	tmpFileName := filepath.Join(dir, fmt.Sprintf("%019d%s.tmp", snapshot.startTsNs, metaBackupSnapshotSuffix))
+	file, err := os.Create(tmpFileName)
+	if err != nil {
+		return snapshot, err
+	}
+	defer os.Remove(tmpFileName)
+
+	var count int64
+	err = withFilerClient(filerGrpcAddress, func(client filer_pb.SeaweedFilerClient) error {
+		if err := snapshotMetaBackupParentEntries(client, file, filer2.FullPath(path), snapshot.startTsNs); err != nil {
+			return err
+		}
+		return snapshotMetaBackupDirectory(client, file, filer2.FullPath(path), snapshot.startTsNs, &count)
+	})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return snapshot, err
+	}
+
+	snapshot.doneTsNs = time.Now().UnixNano()
+	if err = os.Rename(tmpFileName, snapshot.snapshotFileName()); err != nil {
+		return snapshot, err
+	}
+	glog.V(0).Infof("saved %d entries to %s", count, snapshot.snapshotFileName())
+
+	return snapshot, nil
+}
+
+// snapshotMetaBackupParentEntries saves the entries of the path and its parents, which are not listed when walking the path.
+func snapshotMetaBackupParentEntries(client filer_pb.SeaweedFilerClient, w io.Writer, p filer2.FullPath, tsNs int64) error {
+	for p != "/" && p != "" {
+		dir, name := p.DirAndName()
+		resp, err := client.LookupDirectoryEntry(context.Background(), &filer_pb.LookupDirectoryEntryRequest{
+			Directory: dir,
+			Name:      name,
+		})
+		if err != nil {
+			return fmt.Errorf("lookup %s: %v", p, err)
+		}
+		resp.Entry.Name = string(p)
+		if err := filer2.WriteMetaLogRecord(w, &filer_pb.SubscribeMetadataResponse{
+			Directory:         dir,
+			EventNotification: &filer_pb.EventNotification{NewEntry: resp.Entry},
+			TsNs:              tsNs,
+		}); err != nil {
+			return err
+		}
+		p = filer2.FullPath(dir)
+	}
+	return nil
+}
+
+func snapshotMetaBackupDirectory(client filer_pb.SeaweedFilerClient, w io.Writer, dir filer2.FullPath, tsNs int64, count *int64) error {
+	lastFileName := ""
+	for {
+		resp, err := client.ListEntries(context.Background(), &filer_pb.ListEntriesRequest{
+			Directory:         string(dir),
+			StartFromFileName: lastFileName,
+			Limit:             1024,
+		})
+		if err != nil {
+			return fmt.Errorf("list %s: %v", dir, err)
+		}
+		for _, entry := range resp.Entries {
+			lastFileName = entry.Name
+			fullpath := filer2.NewFullPath(string(dir), entry.Name)
+			// same as in the metadata change events, the entry name is the full path
+			entry.Name = string(fullpath)
+			if err := filer2.WriteMetaLogRecord(w, &filer_pb.SubscribeMetadataResponse{
+				Directory:         string(dir),
+				EventNotification: &filer_pb.EventNotification{NewEntry: entry},
+				TsNs:              tsNs,
+			}); err != nil {
+				return err
+			}
+			*count++
+			if entry.IsDirectory {
+				if err := snapshotMetaBackupDirectory(client, w, fullpath, tsNs, count); err != nil {
+					return err
+				}
+			}
+		}
+		if len(resp.Entries) < 1024 {
+			return nil
+		}
+	}
+}
+
+// lastMetaBackupEventTsNs finds the last saved event, and drops a partially written record at the end.
+func lastMetaBackupEventTsNs(fileName string) (lastTsNs int64, err error) {
+	file, err := os.OpenFile(fileName, os.O_RDWR, 0644)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	size, err := filer2.ReadMetaLogRecords(file, func(resp *filer_pb.SubscribeMetadataResponse) error {
+		lastTsNs = resp.TsNs
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return lastTsNs, file.Truncate(size)
+}
@@ -0,0 +1,462 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/server"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	cmdFilerMetaMigrate.Run = runFilerMetaMigrate // break init cycle
+}
+
+var cmdFilerMetaMigrate = &Command{
+	UsageLine: "filer.meta.migrate -sourceStore=leveldb -targetStore=postgres",
+	Short:     "copy all filer meta data from one filer store to another",
+	Long: `Walk the file tree in the source store, and write every entry into the target store.
+
+	Both source and target store:
+        * should be a store name already specified in filer.toml
+        * do not need to be enabled state
+        * should be different stores
+
+	The filer should be stopped, or only serve reads, during the migration.
+	Then enable the target store in filer.toml and start the filer again.
+
+	Each fully copied directory is recorded in the checkpoint file.
+	If the migration is interrupted, run the same command again to resume.
+	Remove the checkpoint file to start over.
+
+	The filer system directories, which keep the hard link records, the quotas, the snapshot index and the versions,
+	are always copied, since the entries under -dir may refer to them.
+
+	After copying, the directories and files under -dir are counted in both stores to verify the migration.
+
+  `,
+}
+
+var (
+	migrateSourceStore = cmdFilerMetaMigrate.Flag.String("sourceStore", "", "the source store name in filer.toml, default to currently enabled store")
+	migrateTargetStore = cmdFilerMetaMigrate.Flag.String("targetStore", "", "the target store name in filer.toml")
+	migrateDir         = cmdFilerMetaMigrate.Flag.String("dir", "/", "only migrate entries under this directory")
+	migrateConcurrency = cmdFilerMetaMigrate.Flag.Int("concurrency", 8, "number of directories to migrate in parallel")
+	migrateCheckpoint  = cmdFilerMetaMigrate.Flag.String("checkpoint", "filer.meta.migrate.checkpoint", "file to record the migrated directories, for resuming. Disabled if empty")
+	migrateVerify      = cmdFilerMetaMigrate.Flag.Bool("verify", true, "count the entries in both stores after the migration")
+	migrateListLimit   = cmdFilerMetaMigrate.Flag.Int("dirListLimit", 1024, "number of entries to list in one batch")
+)
+
+type migrationStatistics struct {
+	directoryCount        int64
+	fileCount             int64
+	skippedDirectoryCount int64
+	errorCount            int64
+}
+
+func runFilerMetaMigrate(cmd *Command, args []string) bool {
+
+	weed_server.LoadConfiguration("filer", true)
+	config := viper.GetViper()
+
+	if *migrateTargetStore == "" || *migrateTargetStore == *migrateSourceStore {
+		glog.Errorf("target store should be set and different from the source store")
+		return false
+	}
+
+	sourceStore, err := loadFilerStore(config, *migrateSourceStore)
+	if err != nil {
+		glog.Errorf("source store: %v", err)
+		return false
+	}
+	targetStore, err := loadFilerStore(config, *migrateTargetStore)
+	if err != nil {
+		glog.Errorf("target store: %v", err)
+		return false
+	}
+	if sourceStore == targetStore {
+		glog.Errorf("source and target stores are both %s", sourceStore.GetName())
+		return false
+	}
+
+	dir := filer2.FullPath(*migrateDir)
+	if len(dir) > 1 {
+		dir = filer2.FullPath(strings.TrimSuffix(string(dir), "/"))
+	}
+
+	checkpoint, err := openMigrationCheckpoint(*migrateCheckpoint)
+	if err != nil {
+		glog.Errorf("checkpoint: %v", err)
+		return false
+	}
+	defer checkpoint.close()
+
+	glog.V(0).Infof("migrating %s from %s to %s", dir, sourceStore.GetName(), targetStore.GetName())
+
+	ctx := context.Background()
+	dirs, err := migrationDirectories(ctx, sourceStore, dir)
+	if err != nil {
+		glog.Errorf("find system directories: %v", err)
+		return false
+	}
+
+	stat := &migrationStatistics{}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(10 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				glog.V(0).Infof("migrated %d directories, %d files, skipped %d checkpointed directories, %d errors",
+					atomic.LoadInt64(&stat.directoryCount), atomic.LoadInt64(&stat.fileCount),
+					atomic.LoadInt64(&stat.skippedDirectoryCount), atomic.LoadInt64(&stat.errorCount))
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err = migrateFilerStore(ctx, sourceStore, targetStore, dirs, checkpoint, stat)
+	close(done)
+	if err != nil {
+		glog.Errorf("migrate %s: %v", dir, err)
+		return false
+	}
+
+	glog.V(0).Infof("migrated %d directories, %d files, skipped %d checkpointed directories, %d errors",
+		stat.directoryCount, stat.fileCount, stat.skippedDirectoryCount, stat.errorCount)
+
+	if stat.errorCount > 0 {
+		glog.Errorf("%d entries failed to migrate, run the command again to retry", stat.errorCount)
+		return false
+	}
+
+	if *migrateVerify {
+		sourceDirs, sourceFiles, err := countStoreDirectories(ctx, sourceStore, dirs)
+		if err != nil {
+			glog.Errorf("count source store: %v", err)
+			return false
+		}
+		targetDirs, targetFiles, err := countStoreDirectories(ctx, targetStore, dirs)
+		if err != nil {
+			glog.Errorf("count target store: %v", err)
+			return false
+		}
+		glog.V(0).Infof("source store %s: %d directories, %d files", sourceStore.GetName(), sourceDirs, sourceFiles)
+		glog.V(0).Infof("target store %s: %d directories, %d files", targetStore.GetName(), targetDirs, targetFiles)
+		if sourceDirs != targetDirs || sourceFiles != targetFiles {
+			glog.Errorf("verification failed: the entry counts are different")
+			return false
+		}
+	}
+
+	return true
+}
+
+func loadFilerStore(config *viper.Viper, name string) (filer2.FilerStore, error) {
+	for _, store := range filer2.Stores {
+		if store.GetName() == name || name == "" && config.GetBool(store.GetName()+".enabled") {
+			if err := store.Initialize(config.Sub(store.GetName())); err != nil {
+				return nil, fmt.Errorf("initialize %s: %v", store.GetName(), err)
+			}
+			return store, nil
+		}
+	}
+	var names []string
+	for _, store := range filer2.Stores {
+		names = append(names, store.GetName())
+	}
+	return nil, fmt.Errorf("store %q not found, supported filer stores are: %s", name, strings.Join(names, ", "))
+}
+
+// filerSystemDirectories are kept by the filer itself. The entries anywhere in the tree may refer to them,
+// like the hard links to their records, so they are migrated together with any directory.
+var filerSystemDirectories = []filer2.FullPath{
+	filer2.HardLinkDirectory,
+	filer2.QuotaDirectory,
+	filer2.SnapshotIndexDirectory,
+	filer2.VersionDirectory,
+}
+
+// migrationDirectories returns the directory to migrate, and the existing system directories outside of it.
+func migrationDirectories(ctx context.Context, sourceStore filer2.FilerStore, dir filer2.FullPath) ([]filer2.FullPath, error) {
+	dirs := []filer2.FullPath{dir}
+	if dir == "/" {
+		return dirs, nil
+	}
+	for _, systemDir := range filerSystemDirectories {
+		if systemDir == dir || strings.HasPrefix(string(dir), string(systemDir)+"/") || strings.HasPrefix(string(systemDir), string(dir)+"/") {
+			continue
+		}
+		if _, err := sourceStore.FindEntry(ctx, systemDir); err == filer2.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("find %s: %v", systemDir, err)
+		}
+		dirs = append(dirs, systemDir)
+	}
+	return dirs, nil
+}
+
+// migrateFilerStore copies the directories with their parents and everything under them.
+// The failed entries are counted in the statistics, and are retried by the next run.
+func migrateFilerStore(ctx context.Context, sourceStore, targetStore filer2.FilerStore, dirs []filer2.FullPath,
+	checkpoint *migrationCheckpoint, stat *migrationStatistics) error {
+
+	queue := newMigrationQueue()
+	for _, dir := range dirs {
+		if err := migrateParentEntries(ctx, sourceStore, targetStore, dir); err != nil {
+			return err
+		}
+		queue.push(dir)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < *migrateConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				dirPath, found := queue.pop()
+				if !found {
+					return
+				}
+				migrateDirectory(ctx, sourceStore, targetStore, dirPath, queue, checkpoint, stat)
+				queue.done()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// migrateParentEntries copies the entries of the directory and its parents, which are not listed when walking the directory.
+func migrateParentEntries(ctx context.Context, sourceStore, targetStore filer2.FilerStore, dir filer2.FullPath) error {
+	for p := dir; p != "/"; {
+		entry, err := sourceStore.FindEntry(ctx, p)
+		if err != nil {
+			return fmt.Errorf("find %s: %v", p, err)
+		}
+		if err = saveMigratedEntry(ctx, targetStore, entry); err != nil {
+			return err
+		}
+		parent, _ := p.DirAndName()
+		p = filer2.FullPath(parent)
+	}
+	return nil
+}
+
+func migrateDirectory(ctx context.Context, sourceStore, targetStore filer2.FilerStore, dirPath filer2.FullPath,
+	queue *migrationQueue, checkpoint *migrationCheckpoint, stat *migrationStatistics) {
+
+	// a checkpointed directory is still listed, to find its sub directories
+	isCheckpointed := checkpoint.contains(dirPath)
+	if isCheckpointed {
+		atomic.AddInt64(&stat.skippedDirectoryCount, 1)
+	}
+
+	hasError := false
+	lastFileName := ""
+	for {
+		entries, err := sourceStore.ListDirectoryEntries(ctx, dirPath, lastFileName, false, *migrateListLimit)
+		if err != nil {
+			glog.Errorf("list %s: %v", dirPath, err)
+			atomic.AddInt64(&stat.errorCount, 1)
+			return
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			if entry.IsDirectory() {
+				queue.push(entry.FullPath)
+			}
+			if isCheckpointed {
+				continue
+			}
+			if err := saveMigratedEntry(ctx, targetStore, entry); err != nil {
+				glog.Errorf("%v", err)
+				atomic.AddInt64(&stat.errorCount, 1)
+				hasError = true
+				continue
+			}
+			if entry.IsDirectory() {
+				atomic.AddInt64(&stat.directoryCount, 1)
+			} else {
+				atomic.AddInt64(&stat.fileCount, 1)
+			}
+		}
+		if len(entries) < *migrateListLimit {
+			break
+		}
+	}
+
+	if !isCheckpointed && !hasError {
+		if err := checkpoint.add(dirPath); err != nil {
+			glog.Errorf("checkpoint %s: %v", dirPath, err)
+		}
+	}
+}
+
+// saveMigratedEntry inserts the entry, or updates it if it was already copied before an interruption.
+func saveMigratedEntry(ctx context.Context, targetStore filer2.FilerStore, entry *filer2.Entry) error {
+	if insertErr := targetStore.InsertEntry(ctx, entry); insertErr != nil {
+		if updateErr := targetStore.UpdateEntry(ctx, entry); updateErr != nil {
+			return fmt.Errorf("save %s: %v, %v", entry.FullPath, insertErr, updateErr)
+		}
+	}
+	return nil
+}
+
+func countStoreDirectories(ctx context.Context, store filer2.FilerStore, dirs []filer2.FullPath) (dirCount, fileCount int64, err error) {
+	for _, dir := range dirs {
+		subDirCount, subFileCount, err := countStoreEntries(ctx, store, dir)
+		if err != nil {
+			return dirCount, fileCount, err
+		}
+		dirCount += subDirCount
+		fileCount += subFileCount
+	}
+	return dirCount, fileCount, nil
+}
+
+func countStoreEntries(ctx context.Context, store filer2.FilerStore, dirPath filer2.FullPath) (dirCount, fileCount int64, err error) {
+	lastFileName := ""
+	for {
+		entries, err := store.ListDirectoryEntries(ctx, dirPath, lastFileName, false, *migrateListLimit)
+		if err != nil {
+			return dirCount, fileCount, fmt.Errorf("list %s: %v", dirPath, err)
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			if !entry.IsDirectory() {
+				fileCount++
+				continue
+			}
+			dirCount++
+			subDirCount, subFileCount, err := countStoreEntries(ctx, store, entry.FullPath)
+			if err != nil {
+				return dirCount, fileCount, err
+			}
+			dirCount += subDirCount
+			fileCount += subFileCount
+		}
+		if len(entries) < *migrateListLimit {
+			return dirCount, fileCount, nil
+		}
+	}
+}
+
+// migrationQueue holds the directories to migrate.
+// pop waits until a directory is pushed, or until all directories are done.
+type migrationQueue struct {
+	sync.Mutex
+	cond    *sync.Cond
+	dirs    []filer2.FullPath
+	pending int
+}
+
+func newMigrationQueue() *migrationQueue {
+	q := &migrationQueue{}
+	q.cond = sync.NewCond(&q.Mutex)
+	return q
+}
+
+func (q *migrationQueue) push(dir filer2.FullPath) {
+	q.Lock()
+	q.dirs = append(q.dirs, dir)
+	q.pending++
+	q.Unlock()
+	q.cond.Signal()
+}
+
+func (q *migrationQueue) pop() (filer2.FullPath, bool) {
+	q.Lock()
+	defer q.Unlock()
+	for len(q.dirs) == 0 && q.pending > 0 {
+		q.cond.Wait()
+	}
+	if len(q.dirs) == 0 {
+		return "", false
+	}
+	dir := q.dirs[len(q.dirs)-1]
+	q.dirs = q.dirs[:len(q.dirs)-1]
+	return dir, true
+}
+
+func (q *migrationQueue) done() {
+	q.Lock()
+	q.pending--
+	if q.pending == 0 {
+		q.cond.Broadcast()
+	}
+	q.Unlock()
+}
+
+// migrationCheckpoint records the fully migrated directories, one per line.
+type migrationCheckpoint struct {
+	sync.Mutex
+	file *os.File
+	dirs map[filer2.FullPath]bool
+}
+
+func openMigrationCheckpoint(fileName string) (*migrationCheckpoint, error) {
+	c := &migrationCheckpoint{
+		dirs: make(map[filer2.FullPath]bool),
+	}
+	if fileName == "" {
+		return c, nil
+	}
+
+	file, err := os.OpenFile(fileName, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			c.dirs[filer2.FullPath(line)] = true
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("read %s: %v", fileName, err)
+	}
+	if len(c.dirs) > 0 {
+		glog.V(0).Infof("resuming with %d migrated directories in %s", len(c.dirs), fileName)
+	}
+	c.file = file
+
+	return c, nil
+}
+
+func (c *migrationCheckpoint) contains(dir filer2.FullPath) bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.dirs[dir]
+}
+
+func (c *migrationCheckpoint) add(dir filer2.FullPath) error {
+	c.Lock()
+	defer c.Unlock()
+	c.dirs[dir] = true
+	if c.file == nil {
+		return nil
+	}
+	_, err := c.file.WriteString(string(dir) + "\n")
+	return err
+}
+
+func (c *migrationCheckpoint) close() {
+	if c.file != nil {
+		c.file.Close()
+	}
+}
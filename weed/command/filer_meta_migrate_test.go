@@ -0,0 +1,93 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/filer2/memdb"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestMigrateFilerStoreSystemDirectories(t *testing.T) {
+	ctx := context.Background()
+
+	sourceStore := &memdb.MemDbStore{}
+	sourceStore.Initialize(nil)
+	filer := filer2.NewFiler(nil)
+	filer.SetStore(sourceStore)
+	filer.DisableDirectoryCache()
+
+	write := func(p filer2.FullPath, fileId string) {
+		if err := filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: p,
+			Attr:     filer2.Attr{Mode: 0644},
+			Chunks:   []*filer_pb.FileChunk{{FileId: fileId, Size: 10}},
+		}); err != nil {
+			t.Fatalf("write %s: %v", p, err)
+		}
+	}
+
+	write("/data/a.txt", "1,01")
+	write("/other/c.txt", "1,02")
+	if err := filer.CreateHardLink(ctx, "/data/a.txt", "/data/b.txt"); err != nil {
+		t.Fatalf("hard link: %v", err)
+	}
+	if err := filer.ConfigureDirectoryQuota(ctx, "/data", 1<<20, 100); err != nil {
+		t.Fatalf("quota: %v", err)
+	}
+	if err := filer.ConfigureVersioning(ctx, "/data", true, 2, 0); err != nil {
+		t.Fatalf("versioning: %v", err)
+	}
+	write("/data/a.txt", "1,03")
+	if err := filer.CreateSnapshot(ctx, "/data", "s1"); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	for _, dir := range []filer2.FullPath{"/", "/data"} {
+		targetStore := &memdb.MemDbStore{}
+		targetStore.Initialize(nil)
+
+		dirs, err := migrationDirectories(ctx, sourceStore, dir)
+		if err != nil {
+			t.Fatalf("migration directories of %s: %v", dir, err)
+		}
+		checkpoint, _ := openMigrationCheckpoint("")
+		stat := &migrationStatistics{}
+		if err := migrateFilerStore(ctx, sourceStore, targetStore, dirs, checkpoint, stat); err != nil || stat.errorCount > 0 {
+			t.Fatalf("migrate %s: %v, %d errors", dir, err, stat.errorCount)
+		}
+
+		for _, systemDir := range filerSystemDirectories {
+			sourceDirs, sourceFiles, err := countStoreEntries(ctx, sourceStore, systemDir)
+			if err != nil || sourceDirs+sourceFiles == 0 {
+				t.Fatalf("source %s has %d directories, %d files: %v", systemDir, sourceDirs, sourceFiles, err)
+			}
+			targetDirs, targetFiles, err := countStoreEntries(ctx, targetStore, systemDir)
+			if err != nil || targetDirs != sourceDirs || targetFiles != sourceFiles {
+				t.Fatalf("migrating %s copied %d directories, %d files of %s, expected %d, %d: %v",
+					dir, targetDirs, targetFiles, systemDir, sourceDirs, sourceFiles, err)
+			}
+			if _, err := targetStore.FindEntry(ctx, systemDir); err != nil {
+				t.Fatalf("migrating %s did not copy %s: %v", dir, systemDir, err)
+			}
+		}
+
+		// the hard link still resolves to its record in the target store
+		link, err := targetStore.FindEntry(ctx, "/data/b.txt")
+		if err != nil || link.HardLinkId == "" {
+			t.Fatalf("hard link %+v: %v", link, err)
+		}
+		if _, err := targetStore.FindEntry(ctx, filer2.HardLinkPath(link.HardLinkId)); err != nil {
+			t.Fatalf("hard link record: %v", err)
+		}
+
+		_, err = targetStore.FindEntry(ctx, "/other/c.txt")
+		if dir == "/" && err != nil {
+			t.Fatalf("migrating / did not copy /other/c.txt: %v", err)
+		}
+		if dir == "/data" && err != filer2.ErrNotFound {
+			t.Fatalf("migrating /data copied /other/c.txt: %v", err)
+		}
+	}
+}
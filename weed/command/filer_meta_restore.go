@@ -0,0 +1,192 @@
+package command
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/draleyva/seaweedfs/weed/server"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	cmdFilerMetaRestore.Run = runFilerMetaRestore // break init cycle
+}
+
+var cmdFilerMetaRestore = &Command{
+	UsageLine: "filer.meta.restore -dir=/path/to/backup -targetStore=leveldb [-time=2006-01-02T15:04:05Z]",
+	Short:     "rebuild a filer store from the filer.meta.backup files",
+	Long: `Load the latest snapshot completed before the restore time, and replay the metadata change events
+	until the restore time, from the events file of the snapshot and the events files of the later snapshots.
+
+	The target store:
+        * should be a store name already specified in filer.toml
+        * does not need to be enabled state
+        * should not be used by a running filer
+
+	Existing entries in the target store are overwritten, but not removed.
+	Usually the target store should be empty.
+
+  `,
+}
+
+var (
+	metaRestoreDir         = cmdFilerMetaRestore.Flag.String("dir", "", "the backup directory from filer.meta.backup")
+	metaRestoreTargetStore = cmdFilerMetaRestore.Flag.String("targetStore", "", "the target store name in filer.toml, default to currently enabled store")
+	metaRestoreTime        = cmdFilerMetaRestore.Flag.String("time", "", "restore to this time in RFC3339 format, default to the latest backed up event")
+)
+
+func runFilerMetaRestore(cmd *Command, args []string) bool {
+
+	if *metaRestoreDir == "" {
+		glog.Errorf("the backup directory -dir is required")
+		return false
+	}
+
+	untilNs := time.Now().UnixNano()
+	if *metaRestoreTime != "" {
+		restoreTime, err := time.Parse(time.RFC3339, *metaRestoreTime)
+		if err != nil {
+			glog.Errorf("parse -time %s: %v", *metaRestoreTime, err)
+			return false
+		}
+		untilNs = restoreTime.UnixNano()
+	}
+
+	snapshots, err := listMetaBackupSnapshots(*metaRestoreDir)
+	if err != nil {
+		glog.Errorf("%v", err)
+		return false
+	}
+	snapshotIndex := -1
+	for i := range snapshots {
+		if snapshots[i].doneTsNs <= untilNs {
+			snapshotIndex = i
+		}
+	}
+	if snapshotIndex < 0 {
+		glog.Errorf("no snapshot in %s is completed before %v", *metaRestoreDir, time.Unix(0, untilNs))
+		return false
+	}
+
+	weed_server.LoadConfiguration("filer", true)
+	targetStore, err := loadFilerStore(viper.GetViper(), *metaRestoreTargetStore)
+	if err != nil {
+		glog.Errorf("target store: %v", err)
+		return false
+	}
+
+	snapshot := snapshots[snapshotIndex]
+	ctx := context.Background()
+
+	glog.V(0).Infof("restoring %s into %s", snapshot.snapshotFileName(), targetStore.GetName())
+	entryCount, _, err := replayMetaBackupFile(ctx, targetStore, snapshot.snapshotFileName(), 0, untilNs)
+	if err != nil {
+		glog.Errorf("restore %s: %v", snapshot.snapshotFileName(), err)
+		return false
+	}
+	glog.V(0).Infof("restored %d entries", entryCount)
+
+	// a later backup may start with a new snapshot, and the events continue in its events file
+	var eventCount, lastTsNs int64
+	for _, s := range snapshots[snapshotIndex:] {
+		if s.startTsNs-int64(metaBackupClockSkew) > untilNs {
+			break
+		}
+		count, fileLastTsNs, err := replayMetaBackupFile(ctx, targetStore, s.eventsFileName(), lastTsNs, untilNs)
+		if err != nil {
+			glog.Errorf("replay %s: %v", s.eventsFileName(), err)
+			return false
+		}
+		eventCount += count
+		if fileLastTsNs > lastTsNs {
+			lastTsNs = fileLastTsNs
+		}
+	}
+	glog.V(0).Infof("replayed %d events until %v", eventCount, time.Unix(0, untilNs))
+
+	return true
+}
+
+// replayMetaBackupFile applies the events after sinceNs and up to untilNs to the store,
+// and returns the number of events applied and the time of the last one.
+func replayMetaBackupFile(ctx context.Context, store filer2.FilerStore, fileName string, sinceNs, untilNs int64) (count, lastTsNs int64, err error) {
+	file, err := os.Open(fileName)
+	if os.IsNotExist(err) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	_, err = filer2.ReadMetaLogRecords(file, func(resp *filer_pb.SubscribeMetadataResponse) error {
+		if resp.TsNs > untilNs || sinceNs > 0 && resp.TsNs <= sinceNs {
+			return nil
+		}
+		count++
+		lastTsNs = resp.TsNs
+		return applyMetaEvent(ctx, store, resp.EventNotification)
+	})
+	return count, lastTsNs, err
+}
+
+func applyMetaEvent(ctx context.Context, store filer2.FilerStore, event *filer_pb.EventNotification) error {
+	if event.OldEntry != nil && (event.NewEntry == nil || event.NewEntry.Name != event.OldEntry.Name) {
+		if err := store.DeleteEntry(ctx, filer2.FullPath(event.OldEntry.Name)); err != nil {
+			return err
+		}
+	}
+	if event.OldEntry != nil && event.OldEntry.HardLinkId != "" && (event.NewEntry == nil || event.NewEntry.HardLinkId != event.OldEntry.HardLinkId) {
+		if err := unlinkMetaBackupHardLink(ctx, store, event.OldEntry.HardLinkId); err != nil {
+			return err
+		}
+	}
+	if event.NewEntry == nil {
+		return nil
+	}
+	entry := &filer2.Entry{
+		FullPath:        filer2.FullPath(event.NewEntry.Name),
+		Attr:            filer2.PbToEntryAttribute(event.NewEntry.Attributes),
+		Extended:        event.NewEntry.Extended,
+		Chunks:          event.NewEntry.Chunks,
+		Content:         event.NewEntry.Content,
+		HardLinkId:      event.NewEntry.HardLinkId,
+		HardLinkCounter: event.NewEntry.HardLinkCounter,
+	}
+	if entry.HardLinkId != "" {
+		// the events carry the shared content of the hard linked files
+		if err := saveMigratedEntry(ctx, store, &filer2.Entry{
+			FullPath:        filer2.HardLinkPath(entry.HardLinkId),
+			Attr:            entry.Attr,
+			Extended:        entry.Extended,
+			Chunks:          entry.Chunks,
+			Content:         entry.Content,
+			HardLinkCounter: event.NewEntry.HardLinkCounter,
+		}); err != nil {
+			return err
+		}
+		entry.Extended, entry.Chunks, entry.Content, entry.HardLinkCounter = nil, nil, nil, 0
+	}
+	return saveMigratedEntry(ctx, store, entry)
+}
+
+// unlinkMetaBackupHardLink removes one link from the hard link record, and deletes the record with the last link.
+// The backups of the whole filer also log the record changes, which are replayed after this.
+func unlinkMetaBackupHardLink(ctx context.Context, store filer2.FilerStore, hardLinkId string) error {
+	record, err := store.FindEntry(ctx, filer2.HardLinkPath(hardLinkId))
+	if err == filer2.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if record.HardLinkCounter <= 1 {
+		return store.DeleteEntry(ctx, record.FullPath)
+	}
+	record.HardLinkCounter--
+	return store.UpdateEntry(ctx, record)
+}
@@ -0,0 +1,80 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	cmdFilerQuota.Run = runFilerQuota // break init cycle
+}
+
+var cmdFilerQuota = &Command{
+	UsageLine: "filer.quota -filer=localhost:8888 [-dir=/some/dir -maxBytes=0 -maxEntries=0]",
+	Short:     "set directory quotas on the filer, or report their usage",
+	Long: `Set the byte and entry count limits of a directory on the filer.
+
+	A zero limit means unlimited. If both limits are zero, the quota is removed.
+	Entries created, updated, or deleted under the directory are counted against the quota.
+	Exceeding a quota fails the change with "quota exceeded".
+
+	Without -dir, it reports the usage of all quota directories.
+
+  `,
+}
+
+var (
+	quotaFiler         = cmdFilerQuota.Flag.String("filer", "localhost:8888", "filer hostname:port")
+	quotaFilerGrpcPort = cmdFilerQuota.Flag.Int("filer.port.grpc", 0, "filer grpc server listen port, default to filer port + 10000")
+	quotaDir           = cmdFilerQuota.Flag.String("dir", "", "the directory to set the quota on")
+	quotaMaxBytes      = cmdFilerQuota.Flag.Uint64("maxBytes", 0, "maximum total file size in bytes under the directory, 0 means unlimited")
+	quotaMaxEntries    = cmdFilerQuota.Flag.Uint64("maxEntries", 0, "maximum number of files and directories under the directory, 0 means unlimited")
+)
+
+func runFilerQuota(cmd *Command, args []string) bool {
+
+	filerGrpcAddress, err := parseFilerGrpcAddress(*quotaFiler, *quotaFilerGrpcPort)
+	if err != nil {
+		glog.Errorf("%v", err)
+		return false
+	}
+
+	err = withFilerClient(filerGrpcAddress, func(client filer_pb.SeaweedFilerClient) error {
+
+		if *quotaDir != "" {
+			_, err := client.DirectoryQuotaConfigure(context.Background(), &filer_pb.DirectoryQuotaConfigureRequest{
+				Directory:     *quotaDir,
+				MaxBytes:      *quotaMaxBytes,
+				MaxEntryCount: *quotaMaxEntries,
+			})
+			return err
+		}
+
+		resp, err := client.DirectoryQuotaList(context.Background(), &filer_pb.DirectoryQuotaListRequest{})
+		if err != nil {
+			return err
+		}
+		for _, quota := range resp.Quotas {
+			fmt.Printf("%s\tbytes %d/%s\tentries %d/%s\n", quota.Directory,
+				quota.Bytes, quotaLimitString(quota.MaxBytes),
+				quota.EntryCount, quotaLimitString(quota.MaxEntryCount))
+		}
+		return nil
+	})
+	if err != nil {
+		glog.Errorf("filer quota on %s: %v", *quotaFiler, err)
+		return false
+	}
+
+	return true
+}
+
+func quotaLimitString(limit uint64) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+	return fmt.Sprintf("%d", limit)
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	cmdFilerSnapshot.Run = runFilerSnapshot // break init cycle
+}
+
+var cmdFilerSnapshot = &Command{
+	UsageLine: "filer.snapshot -filer=localhost:8888 [-dir=/some/dir -name=snapshotName [-delete]]",
+	Short:     "create or delete a read only snapshot of a filer directory, or list the snapshots",
+	Long: `Create a read only snapshot of a filer directory, or delete it.
+
+	The snapshot copies the metadata of all entries under the directory, and is browsable
+	at <dir>/.snapshots/<name> through http, grpc, and "weed mount".
+	The file chunks are shared with the live entries, and are kept until the last snapshot
+	referencing them is deleted. Deleting <dir>/.snapshots/<name> also deletes the snapshot.
+	A directory with snapshots can not be renamed or deleted.
+
+	Without -dir, it lists all snapshots.
+
+  `,
+}
+
+var (
+	snapshotFiler         = cmdFilerSnapshot.Flag.String("filer", "localhost:8888", "filer hostname:port")
+	snapshotFilerGrpcPort = cmdFilerSnapshot.Flag.Int("filer.port.grpc", 0, "filer grpc server listen port, default to filer port + 10000")
+	snapshotDir           = cmdFilerSnapshot.Flag.String("dir", "", "the directory to snapshot")
+	snapshotName          = cmdFilerSnapshot.Flag.String("name", "", "the snapshot name")
+	snapshotDelete        = cmdFilerSnapshot.Flag.Bool("delete", false, "delete the snapshot instead of creating it")
+)
+
+func runFilerSnapshot(cmd *Command, args []string) bool {
+
+	if *snapshotDir != "" && *snapshotName == "" {
+		glog.Errorf("the snapshot -name is required")
+		return false
+	}
+
+	filerGrpcAddress, err := parseFilerGrpcAddress(*snapshotFiler, *snapshotFilerGrpcPort)
+	if err != nil {
+		glog.Errorf("%v", err)
+		return false
+	}
+
+	err = withFilerClient(filerGrpcAddress, func(client filer_pb.SeaweedFilerClient) error {
+
+		if *snapshotDir != "" && *snapshotDelete {
+			_, err := client.SnapshotDelete(context.Background(), &filer_pb.SnapshotDeleteRequest{
+				Directory: *snapshotDir,
+				Name:      *snapshotName,
+			})
+			return err
+		}
+
+		if *snapshotDir != "" {
+			_, err := client.SnapshotCreate(context.Background(), &filer_pb.SnapshotCreateRequest{
+				Directory: *snapshotDir,
+				Name:      *snapshotName,
+			})
+			return err
+		}
+
+		resp, err := client.SnapshotList(context.Background(), &filer_pb.SnapshotListRequest{})
+		if err != nil {
+			return err
+		}
+		for _, snapshot := range resp.Snapshots {
+			fmt.Printf("%s\t%s\t%s\n", snapshot.Directory, snapshot.Name,
+				time.Unix(0, snapshot.CreatedTsNs).Format(time.RFC3339))
+		}
+		return nil
+	})
+	if err != nil {
+		glog.Errorf("filer snapshot on %s: %v", *snapshotFiler, err)
+		return false
+	}
+
+	return true
+}
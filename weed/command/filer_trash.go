@@ -0,0 +1,74 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	cmdFilerTrash.Run = runFilerTrash // break init cycle
+}
+
+var cmdFilerTrash = &Command{
+	UsageLine: "filer.trash -filer=localhost:8888 [-restore=/.trash/1000/1577836800000000000]",
+	Short:     "list the deleted entries in the filer trash, or restore one of them",
+	Long: `List the deleted entries kept in the filer trash, or restore one of them to its original path.
+
+	The trash is enabled by the filer -trash.retention option. A deleted entry is moved into
+	a trash item, /.trash/<uid>/<deletion time> for normal entries, or
+	<bucket>/.trash/<deletion time> for entries deleted inside a bucket.
+	The trash items are permanently deleted after the retention period.
+
+	Restoring fails if the original path exists again.
+
+  `,
+}
+
+var (
+	trashFiler         = cmdFilerTrash.Flag.String("filer", "localhost:8888", "filer hostname:port")
+	trashFilerGrpcPort = cmdFilerTrash.Flag.Int("filer.port.grpc", 0, "filer grpc server listen port, default to filer port + 10000")
+	trashRestore       = cmdFilerTrash.Flag.String("restore", "", "the trash item to restore")
+)
+
+func runFilerTrash(cmd *Command, args []string) bool {
+
+	filerGrpcAddress, err := parseFilerGrpcAddress(*trashFiler, *trashFilerGrpcPort)
+	if err != nil {
+		glog.Errorf("%v", err)
+		return false
+	}
+
+	err = withFilerClient(filerGrpcAddress, func(client filer_pb.SeaweedFilerClient) error {
+
+		if *trashRestore != "" {
+			_, err := client.TrashRestore(context.Background(), &filer_pb.TrashRestoreRequest{
+				TrashPath: *trashRestore,
+			})
+			return err
+		}
+
+		resp, err := client.TrashList(context.Background(), &filer_pb.TrashListRequest{})
+		if err != nil {
+			return err
+		}
+		for _, entry := range resp.Entries {
+			kind := "file"
+			if entry.IsDirectory {
+				kind = "dir"
+			}
+			fmt.Printf("%s\t%s\t%s\t%d\t%s\n", entry.TrashPath,
+				time.Unix(0, entry.DeletedTsNs).Format(time.RFC3339), kind, entry.FileSize, entry.OriginalPath)
+		}
+		return nil
+	})
+	if err != nil {
+		glog.Errorf("filer trash on %s: %v", *trashFiler, err)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func init() {
+	cmdFilerVersioning.Run = runFilerVersioning // break init cycle
+}
+
+var cmdFilerVersioning = &Command{
+	UsageLine: "filer.versioning -filer=localhost:8888 [-dir=/some/dir -enabled=true -maxVersions=0 -maxAge=0] [-file=/some/file [-restore=versionId]]",
+	Short:     "configure file versioning of a filer directory, list or restore the versions of a file",
+	Long: `Keep the previous versions of the files under a filer directory when they are overwritten or deleted.
+
+	With -dir, it enables or disables the versioning of the directory. The versions beyond
+	-maxVersions, or replaced longer than -maxAge ago, are expired. Zero means unlimited.
+	The existing versions are kept when the versioning is disabled.
+
+	With -file, it lists the previous versions of the file, or restores one of them with -restore.
+	A version can also be read by "http://<filer>/some/file?version=<versionId>",
+	and the versions are listed by "http://<filer>/some/file?versions".
+
+	Without -dir or -file, it lists the directories with versioning.
+
+  `,
+}
+
+var (
+	versioningFiler         = cmdFilerVersioning.Flag.String("filer", "localhost:8888", "filer hostname:port")
+	versioningFilerGrpcPort = cmdFilerVersioning.Flag.Int("filer.port.grpc", 0, "filer grpc server listen port, default to filer port + 10000")
+	versioningDir           = cmdFilerVersioning.Flag.String("dir", "", "the directory to configure the versioning")
+	versioningEnabled       = cmdFilerVersioning.Flag.Bool("enabled", true, "enable or disable the versioning of the directory")
+	versioningMaxVersions   = cmdFilerVersioning.Flag.Uint("maxVersions", 0, "the max number of previous versions to keep for each file, 0 means unlimited")
+	versioningMaxAge        = cmdFilerVersioning.Flag.Duration("maxAge", 0, "expire the versions replaced longer than this ago, 0 means unlimited")
+	versioningFile          = cmdFilerVersioning.Flag.String("file", "", "the file to list or restore the versions")
+	versioningRestore       = cmdFilerVersioning.Flag.String("restore", "", "the version id to restore the file to")
+)
+
+func runFilerVersioning(cmd *Command, args []string) bool {
+
+	filerGrpcAddress, err := parseFilerGrpcAddress(*versioningFiler, *versioningFilerGrpcPort)
+	if err != nil {
+		glog.Errorf("%v", err)
+		return false
+	}
+
+	err = withFilerClient(filerGrpcAddress, func(client filer_pb.SeaweedFilerClient) error {
+
+		if *versioningDir != "" {
+			_, err := client.VersioningConfigure(context.Background(), &filer_pb.VersioningConfigureRequest{
+				Directory:     *versioningDir,
+				Enabled:       *versioningEnabled,
+				MaxVersions:   uint32(*versioningMaxVersions),
+				MaxAgeSeconds: int64(*versioningMaxAge / time.Second),
+			})
+			return err
+		}
+
+		if *versioningFile != "" {
+			dir, name := filer2.FullPath(*versioningFile).DirAndName()
+			if *versioningRestore != "" {
+				_, err := client.VersionRestore(context.Background(), &filer_pb.VersionRestoreRequest{
+					Directory: dir,
+					Name:      name,
+					VersionId: *versioningRestore,
+				})
+				return err
+			}
+			resp, err := client.VersionList(context.Background(), &filer_pb.VersionListRequest{
+				Directory: dir,
+				Name:      name,
+			})
+			if err != nil {
+				return err
+			}
+			for _, version := range resp.Versions {
+				fmt.Printf("%s\t%s\t%d\n", version.VersionId,
+					time.Unix(0, version.ReplacedTsNs).Format(time.RFC3339), filer2.TotalSize(version.Entry.Chunks))
+			}
+			return nil
+		}
+
+		resp, err := client.VersioningList(context.Background(), &filer_pb.VersioningListRequest{})
+		if err != nil {
+			return err
+		}
+		for _, config := range resp.Configs {
+			fmt.Printf("%s\tmaxVersions %d\tmaxAge %v\n", config.Directory,
+				config.MaxVersions, time.Duration(config.MaxAgeSeconds)*time.Second)
+		}
+		return nil
+	})
+	if err != nil {
+		glog.Errorf("filer versioning on %s: %v", *versioningFiler, err)
+		return false
+	}
+
+	return true
+}
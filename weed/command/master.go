@@ -43,6 +43,10 @@ var (
 	// mTimeout                = cmdMaster.Flag.Int("idleTimeout", 30, "connection idle seconds")
 	mMaxCpu               = cmdMaster.Flag.Int("maxCpu", 0, "maximum number of CPUs. 0 means all available CPUs")
 	garbageThreshold      = cmdMaster.Flag.Float64("garbageThreshold", 0.3, "threshold to vacuum and reclaim spaces")
+	vacuumWindows         = cmdMaster.Flag.String("vacuum.windows", "", "comma separated daily time windows for scheduled vacuum, e.g. 01:00-05:00,22:00-23:30. Any time if empty.")
+	vacuumMaxPerNode      = cmdMaster.Flag.Int("vacuum.maxPerNode", 1, "maximum concurrent volume compactions per volume server. 0 means no limit")
+	vacuumMaxPerRack      = cmdMaster.Flag.Int("vacuum.maxPerRack", 0, "maximum concurrent volume compactions per rack. 0 means no limit")
+	vacuumInterval        = cmdMaster.Flag.Duration("vacuum.interval", 15*time.Minute, "how often to check the volumes for scheduled vacuum")
 	masterWhiteListOption = cmdMaster.Flag.String("whiteList", "", "comma separated Ip addresses having write permission. No limit if empty.")
 	masterSecureKey       = cmdMaster.Flag.String("secure.secret", "", "secret to encrypt Json Web Token(JWT)")
 	masterCpuProfile      = cmdMaster.Flag.String("cpuprofile", "", "cpu profile output file")
@@ -67,11 +71,15 @@ func runMaster(cmd *Command, args []string) bool {
 	if *volumeSizeLimitMB > 30*1000 {
 		glog.Fatalf("volumeSizeLimitMB should be smaller than 30000")
 	}
+	if *vacuumInterval <= 0 {
+		glog.Fatalf("vacuum.interval should be positive, but got %v", *vacuumInterval)
+	}
 
 	r := mux.NewRouter()
 	ms := weed_server.NewMasterServer(r, *mport, *metaFolder,
 		*volumeSizeLimitMB, *volumePreallocate,
 		*mpulse, *defaultReplicaPlacement, *garbageThreshold,
+		*vacuumWindows, *vacuumMaxPerNode, *vacuumMaxPerRack, *vacuumInterval,
 		masterWhiteList, *masterSecureKey,
 	)
 
@@ -64,6 +64,23 @@ enabled = false
 enabled = true
 dir = "."					# directory to store level db files
 
+[bolt]
+# local on disk, single-machine setup, one file with B+tree pages, good for read-heavy workloads
+enabled = false
+dir = "."					# directory to store the filer.bolt file
+
+[badger]
+# local on disk, single-machine setup, LSM tree with values in a separate log, lower write amplification
+enabled = false
+dir = "."					# directory to store badger files
+
+[sqlite]
+# local on disk, single-machine setup, the metadata can be inspected with SQL and backed up by copying the file
+# the filemeta table is created automatically
+enabled = false
+dbFile = "./filer.db"		# sqlite database file
+busy_timeout_ms = 5000		# how long to wait for a locked database
+
 ####################################################
 # multiple filers on shared storage, fairly scalable
 ####################################################
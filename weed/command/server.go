@@ -61,6 +61,10 @@ var (
 	serverPeers                   = cmdServer.Flag.String("master.peers", "", "all master nodes in comma separated ip:masterPort list")
 	serverSecureKey               = cmdServer.Flag.String("secure.secret", "", "secret to encrypt Json Web Token(JWT)")
 	serverGarbageThreshold        = cmdServer.Flag.Float64("garbageThreshold", 0.3, "threshold to vacuum and reclaim spaces")
+	serverVacuumWindows           = cmdServer.Flag.String("vacuum.windows", "", "comma separated daily time windows for scheduled vacuum, e.g. 01:00-05:00,22:00-23:30. Any time if empty.")
+	serverVacuumMaxPerNode        = cmdServer.Flag.Int("vacuum.maxPerNode", 1, "maximum concurrent volume compactions per volume server. 0 means no limit")
+	serverVacuumMaxPerRack        = cmdServer.Flag.Int("vacuum.maxPerRack", 0, "maximum concurrent volume compactions per rack. 0 means no limit")
+	serverVacuumInterval          = cmdServer.Flag.Duration("vacuum.interval", 15*time.Minute, "how often to check the volumes for scheduled vacuum")
 	masterPort                    = cmdServer.Flag.Int("master.port", 9333, "master server http listen port")
 	masterMetaFolder              = cmdServer.Flag.String("master.dir", "", "data directory to store meta data, default to same as -dir specified")
 	masterVolumeSizeLimitMB       = cmdServer.Flag.Uint("master.volumeSizeLimitMB", 30*1000, "Master stops directing writes to oversized volumes.")
@@ -85,6 +89,13 @@ func init() {
 	filerOptions.disableDirListing = cmdServer.Flag.Bool("filer.disableDirListing", false, "turn off directory listing")
 	filerOptions.maxMB = cmdServer.Flag.Int("filer.maxMB", 32, "split files larger than the limit")
 	filerOptions.dirListingLimit = cmdServer.Flag.Int("filer.dirListLimit", 1000, "limit sub dir listing size")
+	filerOptions.metaLogDir = cmdServer.Flag.String("filer.metaLog.dir", "", "directory to keep the metadata change log for subscribers, one for each filer, disabled if empty")
+	filerOptions.bucketsPath = cmdServer.Flag.String("filer.dir.buckets", "/buckets", "folder on filer to store all buckets, entries deleted in a bucket go to the .trash folder of the bucket")
+	filerOptions.trashRetention = cmdServer.Flag.Duration("filer.trash.retention", 0, "keep deleted entries in the trash for this long before deleting the file content, disabled if 0")
+	filerOptions.inlineMaxBytes = cmdServer.Flag.Int("filer.inline.maxBytes", 0, "keep the content of files up to this size in the filer store instead of the volume servers, disabled if 0")
+	filerOptions.appendCompactChunks = cmdServer.Flag.Int("filer.append.compactChunks", 0, "merge the small chunks of a file appended to once it has this many chunks, disabled if 0")
+	filerOptions.defragMinChunks = cmdServer.Flag.Int("filer.defrag.minChunks", 0, "periodically rewrite the files with at least this many chunks into fewer large chunks, disabled if 0")
+	filerOptions.chunkManifest = cmdServer.Flag.Bool("filer.chunk.manifest", false, "fold the chunk lists of huge files into manifest chunks, which older clients would read as file content")
 
 	serverOptions.v.port = cmdServer.Flag.Int("volume.port", 8080, "volume server http listen port")
 	serverOptions.v.publicPort = cmdServer.Flag.Int("volume.port.public", 0, "volume server public port")
@@ -137,6 +148,9 @@ func runServer(cmd *Command, args []string) bool {
 	if *masterVolumeSizeLimitMB > 30*1000 {
 		glog.Fatalf("masterVolumeSizeLimitMB should be less than 30000")
 	}
+	if *serverVacuumInterval <= 0 {
+		glog.Fatalf("vacuum.interval should be positive, but got %v", *serverVacuumInterval)
+	}
 
 	if *masterMetaFolder == "" {
 		*masterMetaFolder = folders[0]
@@ -169,6 +183,7 @@ func runServer(cmd *Command, args []string) bool {
 		ms := weed_server.NewMasterServer(r, *masterPort, *masterMetaFolder,
 			*masterVolumeSizeLimitMB, *masterVolumePreallocate,
 			*pulseSeconds, *masterDefaultReplicaPlacement, *serverGarbageThreshold,
+			*serverVacuumWindows, *serverVacuumMaxPerNode, *serverVacuumMaxPerRack, *serverVacuumInterval,
 			serverWhiteList, *serverSecureKey,
 		)
 
@@ -1,8 +1,11 @@
 package abstract_sql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/draleyva/seaweedfs/weed/filer2"
 	"github.com/draleyva/seaweedfs/weed/glog"
@@ -16,9 +19,52 @@ type AbstractSqlStore struct {
 	SqlDelete        string
 	SqlListExclusive string
 	SqlListInclusive string
+	// the listings with an extra upper bound of the names, as the parameter before the limit
+	SqlListExclusiveUntil string
+	SqlListInclusiveUntil string
 }
 
-func (store *AbstractSqlStore) InsertEntry(entry *filer2.Entry) (err error) {
+type sqlTransactionKey struct{}
+
+// executor is implemented by both *sql.DB and *sql.Tx
+type executor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func (store *AbstractSqlStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	tx, err := store.DB.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelReadCommitted,
+	})
+	if err != nil {
+		return ctx, fmt.Errorf("begin transaction: %v", err)
+	}
+	return context.WithValue(ctx, sqlTransactionKey{}, tx), nil
+}
+
+func (store *AbstractSqlStore) CommitTransaction(ctx context.Context) error {
+	if tx, ok := ctx.Value(sqlTransactionKey{}).(*sql.Tx); ok {
+		return tx.Commit()
+	}
+	return nil
+}
+
+func (store *AbstractSqlStore) RollbackTransaction(ctx context.Context) error {
+	if tx, ok := ctx.Value(sqlTransactionKey{}).(*sql.Tx); ok {
+		return tx.Rollback()
+	}
+	return nil
+}
+
+func (store *AbstractSqlStore) getTxOrDB(ctx context.Context) executor {
+	if tx, ok := ctx.Value(sqlTransactionKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return store.DB
+}
+
+func (store *AbstractSqlStore) InsertEntry(ctx context.Context, entry *filer2.Entry) (err error) {
 
 	dir, name := entry.FullPath.DirAndName()
 	meta, err := entry.EncodeAttributesAndChunks()
@@ -26,7 +72,7 @@ func (store *AbstractSqlStore) InsertEntry(entry *filer2.Entry) (err error) {
 		return fmt.Errorf("encode %s: %s", entry.FullPath, err)
 	}
 
-	res, err := store.DB.Exec(store.SqlInsert, hashToLong(dir), name, dir, meta)
+	res, err := store.getTxOrDB(ctx).Exec(store.SqlInsert, hashToLong(dir), name, dir, meta)
 	if err != nil {
 		return fmt.Errorf("insert %s: %s", entry.FullPath, err)
 	}
@@ -38,7 +84,7 @@ func (store *AbstractSqlStore) InsertEntry(entry *filer2.Entry) (err error) {
 	return nil
 }
 
-func (store *AbstractSqlStore) UpdateEntry(entry *filer2.Entry) (err error) {
+func (store *AbstractSqlStore) UpdateEntry(ctx context.Context, entry *filer2.Entry) (err error) {
 
 	dir, name := entry.FullPath.DirAndName()
 	meta, err := entry.EncodeAttributesAndChunks()
@@ -46,7 +92,7 @@ func (store *AbstractSqlStore) UpdateEntry(entry *filer2.Entry) (err error) {
 		return fmt.Errorf("encode %s: %s", entry.FullPath, err)
 	}
 
-	res, err := store.DB.Exec(store.SqlUpdate, meta, hashToLong(dir), name, dir)
+	res, err := store.getTxOrDB(ctx).Exec(store.SqlUpdate, meta, hashToLong(dir), name, dir)
 	if err != nil {
 		return fmt.Errorf("update %s: %s", entry.FullPath, err)
 	}
@@ -58,10 +104,10 @@ func (store *AbstractSqlStore) UpdateEntry(entry *filer2.Entry) (err error) {
 	return nil
 }
 
-func (store *AbstractSqlStore) FindEntry(fullpath filer2.FullPath) (*filer2.Entry, error) {
+func (store *AbstractSqlStore) FindEntry(ctx context.Context, fullpath filer2.FullPath) (*filer2.Entry, error) {
 
 	dir, name := fullpath.DirAndName()
-	row := store.DB.QueryRow(store.SqlFind, hashToLong(dir), name, dir)
+	row := store.getTxOrDB(ctx).QueryRow(store.SqlFind, hashToLong(dir), name, dir)
 	var data []byte
 	if err := row.Scan(&data); err != nil {
 		return nil, filer2.ErrNotFound
@@ -77,11 +123,11 @@ func (store *AbstractSqlStore) FindEntry(fullpath filer2.FullPath) (*filer2.Entr
 	return entry, nil
 }
 
-func (store *AbstractSqlStore) DeleteEntry(fullpath filer2.FullPath) error {
+func (store *AbstractSqlStore) DeleteEntry(ctx context.Context, fullpath filer2.FullPath) error {
 
 	dir, name := fullpath.DirAndName()
 
-	res, err := store.DB.Exec(store.SqlDelete, hashToLong(dir), name, dir)
+	res, err := store.getTxOrDB(ctx).Exec(store.SqlDelete, hashToLong(dir), name, dir)
 	if err != nil {
 		return fmt.Errorf("delete %s: %s", fullpath, err)
 	}
@@ -94,37 +140,98 @@ func (store *AbstractSqlStore) DeleteEntry(fullpath filer2.FullPath) error {
 	return nil
 }
 
-func (store *AbstractSqlStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool, limit int) (entries []*filer2.Entry, err error) {
+func (store *AbstractSqlStore) ListDirectoryEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool, limit int) (entries []*filer2.Entry, err error) {
+	return store.ListDirectoryPrefixedEntries(ctx, fullpath, startFileName, inclusive, limit, "")
+}
+
+// ListDirectoryPrefixedEntries limits the name range in the query to [prefix, prefixUpperBound(prefix)).
+// The database collation may still order other names in the range, e.g. ignoring the case,
+// so the names without the prefix are skipped, and the range is read on until the limit is reached.
+func (store *AbstractSqlStore) ListDirectoryPrefixedEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool, limit int, prefix string) (entries []*filer2.Entry, err error) {
 
-	sqlText := store.SqlListExclusive
-	if inclusive {
-		sqlText = store.SqlListInclusive
+	if startFileName < prefix {
+		startFileName, inclusive = prefix, true
+	}
+	upperBound := prefixUpperBound(prefix)
+
+	for len(entries) < limit {
+		rowCount, lastFileName, err := store.listRange(ctx, fullpath, startFileName, inclusive, upperBound, limit, func(name string, data []byte) error {
+			if !strings.HasPrefix(name, prefix) || len(entries) >= limit {
+				return nil
+			}
+			entry := &filer2.Entry{
+				FullPath: filer2.NewFullPath(string(fullpath), name),
+			}
+			if err := entry.DecodeAttributesAndChunks(data); err != nil {
+				glog.V(0).Infof("scan decode %s : %v", entry.FullPath, err)
+				return fmt.Errorf("scan decode %s : %v", entry.FullPath, err)
+			}
+			entries = append(entries, entry)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if rowCount < limit {
+			break
+		}
+		startFileName, inclusive = lastFileName, false
 	}
 
-	rows, err := store.DB.Query(sqlText, hashToLong(string(fullpath)), startFileName, string(fullpath), limit)
+	return entries, nil
+}
+
+// listRange queries up to limit rows from the start name, and below the upper bound if not empty.
+func (store *AbstractSqlStore) listRange(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool, upperBound string, limit int,
+	fn func(name string, data []byte) error) (rowCount int, lastFileName string, err error) {
+
+	var rows *sql.Rows
+	switch {
+	case upperBound == "" && inclusive:
+		rows, err = store.getTxOrDB(ctx).Query(store.SqlListInclusive, hashToLong(string(fullpath)), startFileName, string(fullpath), limit)
+	case upperBound == "":
+		rows, err = store.getTxOrDB(ctx).Query(store.SqlListExclusive, hashToLong(string(fullpath)), startFileName, string(fullpath), limit)
+	case inclusive:
+		rows, err = store.getTxOrDB(ctx).Query(store.SqlListInclusiveUntil, hashToLong(string(fullpath)), startFileName, string(fullpath), upperBound, limit)
+	default:
+		rows, err = store.getTxOrDB(ctx).Query(store.SqlListExclusiveUntil, hashToLong(string(fullpath)), startFileName, string(fullpath), upperBound, limit)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("list %s : %v", fullpath, err)
+		return 0, "", fmt.Errorf("list %s : %v", fullpath, err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var name string
 		var data []byte
-		if err = rows.Scan(&name, &data); err != nil {
+		if err = rows.Scan(&lastFileName, &data); err != nil {
 			glog.V(0).Infof("scan %s : %v", fullpath, err)
-			return nil, fmt.Errorf("scan %s: %v", fullpath, err)
+			return 0, "", fmt.Errorf("scan %s: %v", fullpath, err)
 		}
-
-		entry := &filer2.Entry{
-			FullPath: filer2.NewFullPath(string(fullpath), name),
-		}
-		if err = entry.DecodeAttributesAndChunks(data); err != nil {
-			glog.V(0).Infof("scan decode %s : %v", entry.FullPath, err)
-			return nil, fmt.Errorf("scan decode %s : %v", entry.FullPath, err)
+		rowCount++
+		if err = fn(lastFileName, data); err != nil {
+			return 0, "", err
 		}
-
-		entries = append(entries, entry)
 	}
 
-	return entries, nil
+	return rowCount, lastFileName, rows.Err()
+}
+
+// prefixUpperBound returns the smallest string greater than all strings with the prefix,
+// by incrementing its last rune, or "" if there is no such string.
+func prefixUpperBound(prefix string) string {
+	runes := []rune(prefix)
+	for len(runes) > 0 {
+		last := runes[len(runes)-1]
+		if last < utf8.MaxRune {
+			next := last + 1
+			if next >= 0xD800 && next <= 0xDFFF {
+				// skip the surrogates, which are not valid in UTF-8
+				next = 0xE000
+			}
+			runes[len(runes)-1] = next
+			return string(runes)
+		}
+		runes = runes[:len(runes)-1]
+	}
+	return ""
 }
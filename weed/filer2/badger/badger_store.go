@@ -0,0 +1,226 @@
+package badger
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dgraph-io/badger"
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	weed_util "github.com/draleyva/seaweedfs/weed/util"
+)
+
+const (
+	DIR_FILE_SEPARATOR = byte(0x00)
+)
+
+func init() {
+	filer2.Stores = append(filer2.Stores, &BadgerStore{})
+}
+
+type BadgerStore struct {
+	db *badger.DB
+}
+
+func (store *BadgerStore) GetName() string {
+	return "badger"
+}
+
+func (store *BadgerStore) Initialize(configuration weed_util.Configuration) (err error) {
+	dir := configuration.GetString("dir")
+	return store.initialize(dir)
+}
+
+func (store *BadgerStore) initialize(dir string) (err error) {
+	if err := weed_util.TestFolderWritable(dir); err != nil {
+		return fmt.Errorf("Check Badger Folder %s Writable: %s", dir, err)
+	}
+
+	if store.db, err = badger.Open(badger.DefaultOptions(dir)); err != nil {
+		return
+	}
+	return
+}
+
+type badgerTransactionKey struct{}
+
+// BeginTransaction starts a badger read-write transaction.
+// Conflicting concurrent transactions fail on commit, and a transaction
+// with too many changes fails with badger.ErrTxnTooBig.
+func (store *BadgerStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	return context.WithValue(ctx, badgerTransactionKey{}, store.db.NewTransaction(true)), nil
+}
+
+func (store *BadgerStore) CommitTransaction(ctx context.Context) error {
+	if txn, ok := ctx.Value(badgerTransactionKey{}).(*badger.Txn); ok {
+		return txn.Commit()
+	}
+	return nil
+}
+
+func (store *BadgerStore) RollbackTransaction(ctx context.Context) error {
+	if txn, ok := ctx.Value(badgerTransactionKey{}).(*badger.Txn); ok {
+		txn.Discard()
+	}
+	return nil
+}
+
+// update runs fn in the transaction from the context, or in a new read-write transaction.
+func (store *BadgerStore) update(ctx context.Context, fn func(txn *badger.Txn) error) error {
+	if txn, ok := ctx.Value(badgerTransactionKey{}).(*badger.Txn); ok {
+		return fn(txn)
+	}
+	return store.db.Update(fn)
+}
+
+// view runs fn in the transaction from the context, or in a new read-only transaction.
+func (store *BadgerStore) view(ctx context.Context, fn func(txn *badger.Txn) error) error {
+	if txn, ok := ctx.Value(badgerTransactionKey{}).(*badger.Txn); ok {
+		return fn(txn)
+	}
+	return store.db.View(fn)
+}
+
+func (store *BadgerStore) InsertEntry(ctx context.Context, entry *filer2.Entry) (err error) {
+	key := genKey(entry.DirAndName())
+
+	value, err := entry.EncodeAttributesAndChunks()
+	if err != nil {
+		return fmt.Errorf("encoding %s %+v: %v", entry.FullPath, entry.Attr, err)
+	}
+
+	err = store.update(ctx, func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+	if err != nil {
+		return fmt.Errorf("persisting %s : %v", entry.FullPath, err)
+	}
+
+	return nil
+}
+
+func (store *BadgerStore) UpdateEntry(ctx context.Context, entry *filer2.Entry) (err error) {
+
+	return store.InsertEntry(ctx, entry)
+}
+
+func (store *BadgerStore) FindEntry(ctx context.Context, fullpath filer2.FullPath) (entry *filer2.Entry, err error) {
+	key := genKey(fullpath.DirAndName())
+
+	var data []byte
+	err = store.view(ctx, func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+		data, err = item.ValueCopy(nil)
+		return err
+	})
+	if err == badger.ErrKeyNotFound {
+		return nil, filer2.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s : %v", fullpath, err)
+	}
+
+	entry = &filer2.Entry{
+		FullPath: fullpath,
+	}
+	err = entry.DecodeAttributesAndChunks(data)
+	if err != nil {
+		return entry, fmt.Errorf("decode %s : %v", entry.FullPath, err)
+	}
+
+	return entry, nil
+}
+
+func (store *BadgerStore) DeleteEntry(ctx context.Context, fullpath filer2.FullPath) (err error) {
+	key := genKey(fullpath.DirAndName())
+
+	err = store.update(ctx, func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+	if err != nil {
+		return fmt.Errorf("delete %s : %v", fullpath, err)
+	}
+
+	return nil
+}
+
+func (store *BadgerStore) ListDirectoryEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool,
+	limit int) (entries []*filer2.Entry, err error) {
+	return store.ListDirectoryPrefixedEntries(ctx, fullpath, startFileName, inclusive, limit, "")
+}
+
+func (store *BadgerStore) ListDirectoryPrefixedEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool,
+	limit int, prefix string) (entries []*filer2.Entry, err error) {
+
+	if startFileName < prefix {
+		startFileName, inclusive = prefix, true
+	}
+
+	directoryPrefix := genDirectoryKeyPrefix(fullpath, prefix)
+
+	err = store.view(ctx, func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = directoryPrefix
+		iter := txn.NewIterator(opts)
+		defer iter.Close()
+
+		for iter.Seek(genDirectoryKeyPrefix(fullpath, startFileName)); iter.ValidForPrefix(directoryPrefix); iter.Next() {
+			item := iter.Item()
+			fileName := getNameFromKey(item.Key())
+			if fileName == "" {
+				continue
+			}
+			if fileName == startFileName && !inclusive {
+				continue
+			}
+			limit--
+			if limit < 0 {
+				break
+			}
+			entry := &filer2.Entry{
+				FullPath: filer2.NewFullPath(string(fullpath), fileName),
+			}
+			decodeErr := item.Value(func(value []byte) error {
+				return entry.DecodeAttributesAndChunks(value)
+			})
+			if decodeErr != nil {
+				glog.V(0).Infof("list %s : %v", entry.FullPath, decodeErr)
+				return decodeErr
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+
+	return entries, err
+}
+
+func genKey(dirPath, fileName string) (key []byte) {
+	key = []byte(dirPath)
+	key = append(key, DIR_FILE_SEPARATOR)
+	key = append(key, []byte(fileName)...)
+	return key
+}
+
+func genDirectoryKeyPrefix(fullpath filer2.FullPath, startFileName string) (keyPrefix []byte) {
+	keyPrefix = []byte(string(fullpath))
+	keyPrefix = append(keyPrefix, DIR_FILE_SEPARATOR)
+	if len(startFileName) > 0 {
+		keyPrefix = append(keyPrefix, []byte(startFileName)...)
+	}
+	return keyPrefix
+}
+
+func getNameFromKey(key []byte) string {
+
+	sepIndex := len(key) - 1
+	for sepIndex >= 0 && key[sepIndex] != DIR_FILE_SEPARATOR {
+		sepIndex--
+	}
+
+	return string(key[sepIndex+1:])
+
+}
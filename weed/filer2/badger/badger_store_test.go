@@ -0,0 +1,19 @@
+package badger
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/filer2/storetest"
+)
+
+func TestFilerStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &BadgerStore{}
+	if err := store.initialize(dir); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	storetest.TestFilerStore(t, store)
+}
@@ -0,0 +1,285 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	weed_util "github.com/draleyva/seaweedfs/weed/util"
+)
+
+const (
+	DIR_FILE_SEPARATOR = byte(0x00)
+	BOLT_DB_FILE_NAME  = "filer.bolt"
+)
+
+var filemetaBucket = []byte("filemeta")
+
+// writeLockTimeout stops waiting for the writable transaction, which may be held by the caller itself,
+// e.g. an update without the transaction context inside a transaction.
+var writeLockTimeout = 10 * time.Second
+
+func init() {
+	filer2.Stores = append(filer2.Stores, &BoltStore{})
+}
+
+type BoltStore struct {
+	db *bolt.DB
+	// writer is held by the writable bolt transaction, since bolt blocks on a second one without a timeout
+	writer chan struct{}
+}
+
+func (store *BoltStore) GetName() string {
+	return "bolt"
+}
+
+func (store *BoltStore) Initialize(configuration weed_util.Configuration) (err error) {
+	dir := configuration.GetString("dir")
+	return store.initialize(dir)
+}
+
+func (store *BoltStore) initialize(dir string) (err error) {
+	if err := weed_util.TestFolderWritable(dir); err != nil {
+		return fmt.Errorf("Check Bolt Folder %s Writable: %s", dir, err)
+	}
+
+	if store.db, err = bolt.Open(filepath.Join(dir, BOLT_DB_FILE_NAME), 0644, nil); err != nil {
+		return
+	}
+	store.writer = make(chan struct{}, 1)
+
+	return store.db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(filemetaBucket)
+		return err
+	})
+}
+
+type boltTransactionKey struct{}
+
+type boltTransaction struct {
+	tx     *bolt.Tx
+	closed bool
+}
+
+func getTransaction(ctx context.Context) *boltTransaction {
+	tx, _ := ctx.Value(boltTransactionKey{}).(*boltTransaction)
+	return tx
+}
+
+// lockWriter waits for the other writable transaction to finish, up to writeLockTimeout.
+func (store *BoltStore) lockWriter(ctx context.Context) error {
+	timer := time.NewTimer(writeLockTimeout)
+	defer timer.Stop()
+	select {
+	case store.writer <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return fmt.Errorf("wait %v for the writable transaction, which may be held by the caller", writeLockTimeout)
+	}
+}
+
+func (store *BoltStore) unlockWriter() {
+	<-store.writer
+}
+
+// BeginTransaction starts a writable bolt transaction.
+// Bolt allows only one writable transaction at a time, so the other writers wait until it is done.
+func (store *BoltStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	if err := store.lockWriter(ctx); err != nil {
+		return ctx, fmt.Errorf("begin transaction: %v", err)
+	}
+	tx, err := store.db.Begin(true)
+	if err != nil {
+		store.unlockWriter()
+		return ctx, fmt.Errorf("begin transaction: %v", err)
+	}
+	return context.WithValue(ctx, boltTransactionKey{}, &boltTransaction{tx: tx}), nil
+}
+
+func (store *BoltStore) CommitTransaction(ctx context.Context) error {
+	if tx := getTransaction(ctx); tx != nil && !tx.closed {
+		// bolt rolls back the transaction if the commit fails
+		tx.closed = true
+		defer store.unlockWriter()
+		return tx.tx.Commit()
+	}
+	return nil
+}
+
+func (store *BoltStore) RollbackTransaction(ctx context.Context) error {
+	if tx := getTransaction(ctx); tx != nil && !tx.closed {
+		tx.closed = true
+		defer store.unlockWriter()
+		return tx.tx.Rollback()
+	}
+	return nil
+}
+
+// update runs fn in the transaction from the context, or in a new writable transaction.
+func (store *BoltStore) update(ctx context.Context, fn func(bucket *bolt.Bucket) error) error {
+	if tx := getTransaction(ctx); tx != nil {
+		return fn(tx.tx.Bucket(filemetaBucket))
+	}
+	if err := store.lockWriter(ctx); err != nil {
+		return err
+	}
+	defer store.unlockWriter()
+	return store.db.Update(func(tx *bolt.Tx) error {
+		return fn(tx.Bucket(filemetaBucket))
+	})
+}
+
+// view runs fn in the transaction from the context, or in a new read-only transaction.
+func (store *BoltStore) view(ctx context.Context, fn func(bucket *bolt.Bucket) error) error {
+	if tx := getTransaction(ctx); tx != nil {
+		return fn(tx.tx.Bucket(filemetaBucket))
+	}
+	return store.db.View(func(tx *bolt.Tx) error {
+		return fn(tx.Bucket(filemetaBucket))
+	})
+}
+
+func (store *BoltStore) InsertEntry(ctx context.Context, entry *filer2.Entry) (err error) {
+	key := genKey(entry.DirAndName())
+
+	value, err := entry.EncodeAttributesAndChunks()
+	if err != nil {
+		return fmt.Errorf("encoding %s %+v: %v", entry.FullPath, entry.Attr, err)
+	}
+
+	err = store.update(ctx, func(bucket *bolt.Bucket) error {
+		return bucket.Put(key, value)
+	})
+	if err != nil {
+		return fmt.Errorf("persisting %s : %v", entry.FullPath, err)
+	}
+
+	return nil
+}
+
+func (store *BoltStore) UpdateEntry(ctx context.Context, entry *filer2.Entry) (err error) {
+
+	return store.InsertEntry(ctx, entry)
+}
+
+func (store *BoltStore) FindEntry(ctx context.Context, fullpath filer2.FullPath) (entry *filer2.Entry, err error) {
+	key := genKey(fullpath.DirAndName())
+
+	var data []byte
+	err = store.view(ctx, func(bucket *bolt.Bucket) error {
+		// the value is only valid during the transaction
+		if value := bucket.Get(key); value != nil {
+			data = append([]byte{}, value...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get %s : %v", fullpath, err)
+	}
+	if data == nil {
+		return nil, filer2.ErrNotFound
+	}
+
+	entry = &filer2.Entry{
+		FullPath: fullpath,
+	}
+	err = entry.DecodeAttributesAndChunks(data)
+	if err != nil {
+		return entry, fmt.Errorf("decode %s : %v", entry.FullPath, err)
+	}
+
+	return entry, nil
+}
+
+func (store *BoltStore) DeleteEntry(ctx context.Context, fullpath filer2.FullPath) (err error) {
+	key := genKey(fullpath.DirAndName())
+
+	err = store.update(ctx, func(bucket *bolt.Bucket) error {
+		return bucket.Delete(key)
+	})
+	if err != nil {
+		return fmt.Errorf("delete %s : %v", fullpath, err)
+	}
+
+	return nil
+}
+
+func (store *BoltStore) ListDirectoryEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool,
+	limit int) (entries []*filer2.Entry, err error) {
+	return store.ListDirectoryPrefixedEntries(ctx, fullpath, startFileName, inclusive, limit, "")
+}
+
+func (store *BoltStore) ListDirectoryPrefixedEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool,
+	limit int, prefix string) (entries []*filer2.Entry, err error) {
+
+	if startFileName < prefix {
+		startFileName, inclusive = prefix, true
+	}
+
+	directoryPrefix := genDirectoryKeyPrefix(fullpath, prefix)
+
+	err = store.view(ctx, func(bucket *bolt.Bucket) error {
+		cursor := bucket.Cursor()
+		for key, value := cursor.Seek(genDirectoryKeyPrefix(fullpath, startFileName)); key != nil; key, value = cursor.Next() {
+			if !bytes.HasPrefix(key, directoryPrefix) {
+				break
+			}
+			fileName := getNameFromKey(key)
+			if fileName == "" {
+				continue
+			}
+			if fileName == startFileName && !inclusive {
+				continue
+			}
+			limit--
+			if limit < 0 {
+				break
+			}
+			entry := &filer2.Entry{
+				FullPath: filer2.NewFullPath(string(fullpath), fileName),
+			}
+			if decodeErr := entry.DecodeAttributesAndChunks(value); decodeErr != nil {
+				glog.V(0).Infof("list %s : %v", entry.FullPath, decodeErr)
+				return decodeErr
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+
+	return entries, err
+}
+
+func genKey(dirPath, fileName string) (key []byte) {
+	key = []byte(dirPath)
+	key = append(key, DIR_FILE_SEPARATOR)
+	key = append(key, []byte(fileName)...)
+	return key
+}
+
+func genDirectoryKeyPrefix(fullpath filer2.FullPath, startFileName string) (keyPrefix []byte) {
+	keyPrefix = []byte(string(fullpath))
+	keyPrefix = append(keyPrefix, DIR_FILE_SEPARATOR)
+	if len(startFileName) > 0 {
+		keyPrefix = append(keyPrefix, []byte(startFileName)...)
+	}
+	return keyPrefix
+}
+
+func getNameFromKey(key []byte) string {
+
+	sepIndex := len(key) - 1
+	for sepIndex >= 0 && key[sepIndex] != DIR_FILE_SEPARATOR {
+		sepIndex--
+	}
+
+	return string(key[sepIndex+1:])
+
+}
@@ -0,0 +1,50 @@
+package bolt
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/filer2/storetest"
+)
+
+func TestFilerStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &BoltStore{}
+	if err := store.initialize(dir); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	storetest.TestFilerStore(t, store)
+}
+
+func TestUpdateOutsideTransaction(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &BoltStore{}
+	if err := store.initialize(dir); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	defer func(timeout time.Duration) { writeLockTimeout = timeout }(writeLockTimeout)
+	writeLockTimeout = 100 * time.Millisecond
+
+	// an update without the transaction context fails instead of waiting for the transaction forever
+	txCtx, err := store.BeginTransaction(context.Background())
+	if err != nil {
+		t.Fatalf("begin transaction: %v", err)
+	}
+	if err := store.InsertEntry(context.Background(), &filer2.Entry{FullPath: "/home/a.txt"}); err == nil {
+		t.Fatalf("expected an error for the update outside the transaction")
+	}
+	if err := store.CommitTransaction(txCtx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	store.RollbackTransaction(txCtx)
+
+	if err := store.InsertEntry(context.Background(), &filer2.Entry{FullPath: "/home/a.txt"}); err != nil {
+		t.Fatalf("insert after the transaction: %v", err)
+	}
+}
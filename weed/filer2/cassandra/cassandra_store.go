@@ -1,11 +1,13 @@
 package cassandra
 
 import (
+	"context"
 	"fmt"
 	"github.com/draleyva/seaweedfs/weed/filer2"
 	"github.com/draleyva/seaweedfs/weed/glog"
 	"github.com/draleyva/seaweedfs/weed/util"
 	"github.com/gocql/gocql"
+	"strings"
 )
 
 func init() {
@@ -13,6 +15,7 @@ func init() {
 }
 
 type CassandraStore struct {
+	filer2.NoTransactions
 	cluster *gocql.ClusterConfig
 	session *gocql.Session
 }
@@ -39,7 +42,7 @@ func (store *CassandraStore) initialize(keyspace string, hosts []string) (err er
 	return
 }
 
-func (store *CassandraStore) InsertEntry(entry *filer2.Entry) (err error) {
+func (store *CassandraStore) InsertEntry(ctx context.Context, entry *filer2.Entry) (err error) {
 
 	dir, name := entry.FullPath.DirAndName()
 	meta, err := entry.EncodeAttributesAndChunks()
@@ -56,12 +59,12 @@ func (store *CassandraStore) InsertEntry(entry *filer2.Entry) (err error) {
 	return nil
 }
 
-func (store *CassandraStore) UpdateEntry(entry *filer2.Entry) (err error) {
+func (store *CassandraStore) UpdateEntry(ctx context.Context, entry *filer2.Entry) (err error) {
 
-	return store.InsertEntry(entry)
+	return store.InsertEntry(ctx, entry)
 }
 
-func (store *CassandraStore) FindEntry(fullpath filer2.FullPath) (entry *filer2.Entry, err error) {
+func (store *CassandraStore) FindEntry(ctx context.Context, fullpath filer2.FullPath) (entry *filer2.Entry, err error) {
 
 	dir, name := fullpath.DirAndName()
 	var data []byte
@@ -88,7 +91,7 @@ func (store *CassandraStore) FindEntry(fullpath filer2.FullPath) (entry *filer2.
 	return entry, nil
 }
 
-func (store *CassandraStore) DeleteEntry(fullpath filer2.FullPath) error {
+func (store *CassandraStore) DeleteEntry(ctx context.Context, fullpath filer2.FullPath) error {
 
 	dir, name := fullpath.DirAndName()
 
@@ -101,8 +104,18 @@ func (store *CassandraStore) DeleteEntry(fullpath filer2.FullPath) error {
 	return nil
 }
 
-func (store *CassandraStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool,
+func (store *CassandraStore) ListDirectoryEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool,
 	limit int) (entries []*filer2.Entry, err error) {
+	return store.ListDirectoryPrefixedEntries(ctx, fullpath, startFileName, inclusive, limit, "")
+}
+
+// ListDirectoryPrefixedEntries starts the name range at the prefix, and stops at the first name without it.
+func (store *CassandraStore) ListDirectoryPrefixedEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool,
+	limit int, prefix string) (entries []*filer2.Entry, err error) {
+
+	if startFileName < prefix {
+		startFileName, inclusive = prefix, true
+	}
 
 	cqlStr := "SELECT NAME, meta FROM filemeta WHERE directory=? AND name>? ORDER BY NAME ASC LIMIT ?"
 	if inclusive {
@@ -113,6 +126,9 @@ func (store *CassandraStore) ListDirectoryEntries(fullpath filer2.FullPath, star
 	var name string
 	iter := store.session.Query(cqlStr, string(fullpath), startFileName, limit).Iter()
 	for iter.Scan(&name, &data) {
+		if !strings.HasPrefix(name, prefix) {
+			break
+		}
 		entry := &filer2.Entry{
 			FullPath: filer2.NewFullPath(string(fullpath), name),
 		}
@@ -8,31 +8,49 @@ import (
 )
 
 type Attr struct {
-	Mtime       time.Time   // time of last modification
-	Crtime      time.Time   // time of creation (OS X only)
-	Mode        os.FileMode // file mode
-	Uid         uint32      // owner uid
-	Gid         uint32      // group gid
-	Mime        string      // mime type
-	Replication string      // replication
-	Collection  string      // collection name
-	TtlSec      int32       // ttl in seconds
+	Mtime         time.Time   // time of last modification
+	Crtime        time.Time   // time of creation (OS X only)
+	Mode          os.FileMode // file mode
+	Uid           uint32      // owner uid
+	Gid           uint32      // group gid
+	Mime          string      // mime type
+	Replication   string      // replication
+	Collection    string      // collection name
+	TtlSec        int32       // ttl in seconds
+	SymlinkTarget string      // target of a symbolic link
 }
 
 func (attr Attr) IsDirectory() bool {
 	return attr.Mode&os.ModeDir > 0
 }
 
+func (attr Attr) IsSymlink() bool {
+	return attr.Mode&os.ModeSymlink > 0
+}
+
 type Entry struct {
 	FullPath
 
 	Attr
 
+	// extended attributes, e.g. FUSE xattrs and S3 user metadata
+	Extended map[string][]byte `json:"extended,omitempty"`
+
 	// the following is for files
 	Chunks []*filer_pb.FileChunk `json:"chunks,omitempty"`
+
+	// small files keep the content in the entry instead of the chunks
+	Content []byte `json:"content,omitempty"`
+
+	// hard linked files share the content saved in the hard link record, see filer_hardlink.go
+	HardLinkId      string `json:"hardLinkId,omitempty"`
+	HardLinkCounter int32  `json:"hardLinkCounter,omitempty"`
 }
 
 func (entry *Entry) Size() uint64 {
+	if len(entry.Content) > 0 {
+		return uint64(len(entry.Content))
+	}
 	return TotalSize(entry.Chunks)
 }
 
@@ -49,9 +67,13 @@ func (entry *Entry) ToProtoEntry() *filer_pb.Entry {
 		return nil
 	}
 	return &filer_pb.Entry{
-		Name:        string(entry.FullPath),
-		IsDirectory: entry.IsDirectory(),
-		Attributes:  EntryAttributeToPb(entry),
-		Chunks:      entry.Chunks,
+		Name:            string(entry.FullPath),
+		IsDirectory:     entry.IsDirectory(),
+		Attributes:      EntryAttributeToPb(entry),
+		Chunks:          entry.Chunks,
+		Content:         entry.Content,
+		Extended:        entry.Extended,
+		HardLinkId:      entry.HardLinkId,
+		HardLinkCounter: entry.HardLinkCounter,
 	}
 }
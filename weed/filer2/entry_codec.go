@@ -1,6 +1,7 @@
 package filer2
 
 import (
+	"bytes"
 	"os"
 	"time"
 
@@ -11,8 +12,12 @@ import (
 
 func (entry *Entry) EncodeAttributesAndChunks() ([]byte, error) {
 	message := &filer_pb.Entry{
-		Attributes: EntryAttributeToPb(entry),
-		Chunks:     entry.Chunks,
+		Attributes:      EntryAttributeToPb(entry),
+		Chunks:          entry.Chunks,
+		Content:         entry.Content,
+		Extended:        entry.Extended,
+		HardLinkId:      entry.HardLinkId,
+		HardLinkCounter: entry.HardLinkCounter,
 	}
 	return proto.Marshal(message)
 }
@@ -27,23 +32,31 @@ func (entry *Entry) DecodeAttributesAndChunks(blob []byte) error {
 
 	entry.Attr = PbToEntryAttribute(message.Attributes)
 
+	entry.Extended = message.Extended
+
 	entry.Chunks = message.Chunks
 
+	entry.Content = message.Content
+
+	entry.HardLinkId = message.HardLinkId
+	entry.HardLinkCounter = message.HardLinkCounter
+
 	return nil
 }
 
 func EntryAttributeToPb(entry *Entry) *filer_pb.FuseAttributes {
 
 	return &filer_pb.FuseAttributes{
-		Crtime:      entry.Attr.Crtime.Unix(),
-		Mtime:       entry.Attr.Mtime.Unix(),
-		FileMode:    uint32(entry.Attr.Mode),
-		Uid:         entry.Uid,
-		Gid:         entry.Gid,
-		Mime:        entry.Mime,
-		Collection:  entry.Attr.Collection,
-		Replication: entry.Attr.Replication,
-		TtlSec:      entry.Attr.TtlSec,
+		Crtime:        entry.Attr.Crtime.Unix(),
+		Mtime:         entry.Attr.Mtime.Unix(),
+		FileMode:      uint32(entry.Attr.Mode),
+		Uid:           entry.Uid,
+		Gid:           entry.Gid,
+		Mime:          entry.Mime,
+		Collection:    entry.Attr.Collection,
+		Replication:   entry.Attr.Replication,
+		TtlSec:        entry.Attr.TtlSec,
+		SymlinkTarget: entry.Attr.SymlinkTarget,
 	}
 }
 
@@ -60,6 +73,7 @@ func PbToEntryAttribute(attr *filer_pb.FuseAttributes) Attr {
 	t.Collection = attr.Collection
 	t.Replication = attr.Replication
 	t.TtlSec = attr.TtlSec
+	t.SymlinkTarget = attr.SymlinkTarget
 
 	return t
 }
@@ -74,6 +88,15 @@ func EqualEntry(a, b *Entry) bool {
 	if !proto.Equal(EntryAttributeToPb(a), EntryAttributeToPb(b)) {
 		return false
 	}
+	if !EqualExtended(a.Extended, b.Extended) {
+		return false
+	}
+	if a.HardLinkId != b.HardLinkId || a.HardLinkCounter != b.HardLinkCounter {
+		return false
+	}
+	if !bytes.Equal(a.Content, b.Content) {
+		return false
+	}
 	if len(a.Chunks) != len(b.Chunks) {
 		return false
 	}
@@ -85,3 +108,15 @@ func EqualEntry(a, b *Entry) bool {
 	}
 	return true
 }
+
+func EqualExtended(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, found := b[k]; !found || !bytes.Equal(v, bv) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package filer2_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+)
+
+func TestInlineContent(t *testing.T) {
+	ctx := context.Background()
+	filer := newTestFiler()
+
+	write := func(content string) {
+		if err := filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: "/docs/a.txt",
+			Attr:     filer2.Attr{Mode: 0644},
+			Content:  []byte(content),
+		}); err != nil {
+			t.Fatalf("write %s: %v", content, err)
+		}
+	}
+
+	write("hello")
+	if err := filer.ConfigureVersioning(ctx, "/docs", true, 0, 0); err != nil {
+		t.Fatalf("configure versioning: %v", err)
+	}
+	write("world!")
+
+	entry, err := filer.FindEntry(ctx, "/docs/a.txt")
+	if err != nil || string(entry.Content) != "world!" || entry.Size() != 6 {
+		t.Fatalf("unexpected entry %+v: %v", entry, err)
+	}
+
+	blob, err := entry.EncodeAttributesAndChunks()
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	decoded := &filer2.Entry{FullPath: entry.FullPath}
+	if err := decoded.DecodeAttributesAndChunks(blob); err != nil || !filer2.EqualEntry(entry, decoded) {
+		t.Fatalf("decoded %+v: %v", decoded, err)
+	}
+
+	// the replaced content is kept as a version
+	versions, err := filer.ListVersions(ctx, "/docs/a.txt")
+	if err != nil || len(versions) != 1 || string(versions[0].Entry.Content) != "hello" {
+		t.Fatalf("unexpected versions %+v: %v", versions, err)
+	}
+
+	// the content follows the hard links and renames
+	if err := filer.CreateHardLink(ctx, "/docs/a.txt", "/docs/b.txt"); err != nil {
+		t.Fatalf("link: %v", err)
+	}
+	if err := filer.AtomicRenameEntry(ctx, "/docs/b.txt", "/c.txt"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if entry, err := filer.FindEntry(ctx, "/c.txt"); err != nil || string(entry.Content) != "world!" {
+		t.Fatalf("unexpected linked entry %+v: %v", entry, err)
+	}
+}
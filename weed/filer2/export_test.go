@@ -0,0 +1,65 @@
+package filer2
+
+import (
+	"context"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+// the unexported functions used by the tests in the filer2_test package
+
+var CountDataChunks = countDataChunks
+
+func (f *Filer) RewriteChunk(entry *Entry, start, stop int64) (*filer_pb.FileChunk, error) {
+	return f.rewriteChunk(entry, start, stop)
+}
+
+func (f *Filer) ReplaceChunks(ctx context.Context, entry *Entry, chunks []*filer_pb.FileChunk) error {
+	return f.replaceChunks(ctx, entry, chunks)
+}
+
+func (f *Filer) UnusedReplacedChunks(ctx context.Context, p FullPath, chunks []*filer_pb.FileChunk) ([]*filer_pb.FileChunk, error) {
+	return f.unusedReplacedChunks(ctx, p, chunks)
+}
+
+// PendingReplacedChunks returns the replaced chunks recorded for deletion.
+func (f *Filer) PendingReplacedChunks(ctx context.Context) (chunks []*filer_pb.FileChunk, err error) {
+	err = f.eachEntry(ctx, replacedChunksDirectory, func(record *Entry) error {
+		chunks = append(chunks, record.Chunks...)
+		return nil
+	})
+	return
+}
+
+func SetHardLinkLockTimeout(timeout time.Duration) {
+	hardLinkLockTimeout = timeout
+}
+
+// SetDirectoryUsageCacheTtl sets the ttl of the cached directory usages, and returns a function restoring it.
+func SetDirectoryUsageCacheTtl(ttl time.Duration) func() {
+	previous := directoryUsageCacheTtl
+	directoryUsageCacheTtl = ttl
+	return func() { directoryUsageCacheTtl = previous }
+}
+
+// SetSearchScanLimit sets the scan limit of the searches, and returns a function restoring it.
+func SetSearchScanLimit(limit int) func() {
+	previous := searchScanLimit
+	searchScanLimit = limit
+	return func() { searchScanLimit = previous }
+}
+
+func (f *Filer) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return f.withTransaction(ctx, fn)
+}
+
+func (f *Filer) LockHardLink(ctx context.Context, hardLinkId string) error {
+	return f.lockHardLink(ctx, hardLinkId)
+}
+
+var SmallChunkRuns = smallChunkRuns
+
+func (f *Filer) CompactPendingAppends() {
+	f.compactPendingAppends()
+}
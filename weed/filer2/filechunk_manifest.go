@@ -0,0 +1,205 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/draleyva/seaweedfs/weed/util"
+	"github.com/golang/protobuf/proto"
+)
+
+// ManifestBatch is the number of chunks folded into one manifest chunk.
+// The manifest chunks are folded again once there are this many of them.
+const ManifestBatch = 1000
+
+// LookupFileIdFunctionType returns the url to read the file id.
+type LookupFileIdFunctionType func(fileId string) (fileUrl string, err error)
+
+// SaveDataAsChunkFunctionType saves the data as a new chunk, and returns the chunk with its file id.
+type SaveDataAsChunkFunctionType func(data []byte) (chunk *filer_pb.FileChunk, err error)
+
+// LookupFileIdWithFiler returns a lookup function asking the filer for the volume locations.
+func LookupFileIdWithFiler(withFilerClient func(fn func(filer_pb.SeaweedFilerClient) error) error) LookupFileIdFunctionType {
+	return func(fileId string) (fileUrl string, err error) {
+		vid := volumeId(fileId)
+		err = withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+			resp, err := client.LookupVolume(context.Background(), &filer_pb.LookupVolumeRequest{
+				VolumeIds: []string{vid},
+			})
+			if err != nil {
+				return err
+			}
+			locations := resp.LocationsMap[vid]
+			if locations == nil || len(locations.Locations) == 0 {
+				return fmt.Errorf("volume %s not found", vid)
+			}
+			fileUrl = fmt.Sprintf("http://%s/%s", locations.Locations[0].Url, fileId)
+			return nil
+		})
+		if err != nil {
+			return "", fmt.Errorf("lookup %s: %v", fileId, err)
+		}
+		return fileUrl, nil
+	}
+}
+
+func HasChunkManifest(chunks []*filer_pb.FileChunk) bool {
+	for _, chunk := range chunks {
+		if chunk.IsChunkManifest {
+			return true
+		}
+	}
+	return false
+}
+
+func SeparateManifestChunks(chunks []*filer_pb.FileChunk) (manifestChunks, dataChunks []*filer_pb.FileChunk) {
+	for _, chunk := range chunks {
+		if chunk.IsChunkManifest {
+			manifestChunks = append(manifestChunks, chunk)
+		} else {
+			dataChunks = append(dataChunks, chunk)
+		}
+	}
+	return
+}
+
+// ResolveChunkManifest returns the data chunks, with the chunks folded in the manifests,
+// and the manifest chunks themselves, at all levels.
+func ResolveChunkManifest(lookupFileIdFn LookupFileIdFunctionType, chunks []*filer_pb.FileChunk) (dataChunks, manifestChunks []*filer_pb.FileChunk, err error) {
+	return resolveChunksInRange(lookupFileIdFn, chunks, 0, math.MaxInt64)
+}
+
+// resolveChunksInRange only reads the manifests overlapping [start, stop).
+// The data chunks of the other manifests can not be visible in the range.
+func resolveChunksInRange(lookupFileIdFn LookupFileIdFunctionType, chunks []*filer_pb.FileChunk, start, stop int64) (dataChunks, manifestChunks []*filer_pb.FileChunk, err error) {
+	for _, chunk := range chunks {
+		if !chunk.IsChunkManifest {
+			dataChunks = append(dataChunks, chunk)
+			continue
+		}
+		if stop <= chunk.Offset || chunk.Offset+int64(chunk.Size) <= start {
+			continue
+		}
+		manifestChunks = append(manifestChunks, chunk)
+		folded, err := ResolveOneChunkManifest(lookupFileIdFn, chunk)
+		if err != nil {
+			return nil, nil, err
+		}
+		subDataChunks, subManifestChunks, err := resolveChunksInRange(lookupFileIdFn, folded, start, stop)
+		if err != nil {
+			return nil, nil, err
+		}
+		dataChunks = append(dataChunks, subDataChunks...)
+		manifestChunks = append(manifestChunks, subManifestChunks...)
+	}
+	return
+}
+
+// ResolveOneChunkManifest reads the chunks folded in the manifest chunk, which may be manifests again.
+func ResolveOneChunkManifest(lookupFileIdFn LookupFileIdFunctionType, manifestChunk *filer_pb.FileChunk) ([]*filer_pb.FileChunk, error) {
+	if lookupFileIdFn == nil {
+		return nil, fmt.Errorf("no lookup to read chunk manifest %s", manifestChunk.FileId)
+	}
+	fileUrl, err := lookupFileIdFn(manifestChunk.FileId)
+	if err != nil {
+		return nil, fmt.Errorf("lookup chunk manifest %s: %v", manifestChunk.FileId, err)
+	}
+	data, err := util.Get(fileUrl)
+	if err != nil {
+		return nil, fmt.Errorf("read chunk manifest %s: %v", manifestChunk.FileId, err)
+	}
+	manifest := &filer_pb.FileChunkManifest{}
+	if err := proto.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("decode chunk manifest %s: %v", manifestChunk.FileId, err)
+	}
+	return manifest.Chunks, nil
+}
+
+// MaybeManifestize folds the data chunks into manifest chunks by ManifestBatch,
+// and then the manifest chunks, until there are fewer than ManifestBatch of either.
+func MaybeManifestize(saveFn SaveDataAsChunkFunctionType, chunks []*filer_pb.FileChunk) ([]*filer_pb.FileChunk, error) {
+	manifestChunks, dataChunks := SeparateManifestChunks(chunks)
+	if len(dataChunks) < ManifestBatch && len(manifestChunks) < ManifestBatch {
+		return chunks, nil
+	}
+
+	dataChunks, folded, err := foldChunks(saveFn, dataChunks)
+	if err != nil {
+		return nil, err
+	}
+	manifestChunks = append(manifestChunks, folded...)
+
+	for len(manifestChunks) >= ManifestBatch {
+		var remaining []*filer_pb.FileChunk
+		if remaining, folded, err = foldChunks(saveFn, manifestChunks); err != nil {
+			return nil, err
+		}
+		manifestChunks = append(remaining, folded...)
+	}
+
+	return append(manifestChunks, dataChunks...), nil
+}
+
+// foldChunks saves each full batch of the chunks, ordered by offset, as one manifest chunk,
+// and returns the chunks left over.
+func foldChunks(saveFn SaveDataAsChunkFunctionType, chunks []*filer_pb.FileChunk) (remaining, manifestChunks []*filer_pb.FileChunk, err error) {
+	sorted := make([]*filer_pb.FileChunk, len(chunks))
+	copy(sorted, chunks)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+
+	for len(sorted) >= ManifestBatch {
+		manifestChunk, err := saveChunkManifest(saveFn, sorted[:ManifestBatch])
+		if err != nil {
+			return nil, nil, err
+		}
+		manifestChunks = append(manifestChunks, manifestChunk)
+		sorted = sorted[ManifestBatch:]
+	}
+
+	return sorted, manifestChunks, nil
+}
+
+// saveChunkManifest saves the chunks as a manifest chunk covering all of them.
+// The manifest takes the latest mtime of its chunks.
+func saveChunkManifest(saveFn SaveDataAsChunkFunctionType, chunks []*filer_pb.FileChunk) (*filer_pb.FileChunk, error) {
+	data, err := proto.Marshal(&filer_pb.FileChunkManifest{Chunks: chunks})
+	if err != nil {
+		return nil, fmt.Errorf("encode chunk manifest: %v", err)
+	}
+	manifestChunk, err := saveFn(data)
+	if err != nil {
+		return nil, fmt.Errorf("save chunk manifest: %v", err)
+	}
+
+	minOffset, maxStop := int64(math.MaxInt64), int64(0)
+	for _, chunk := range chunks {
+		if chunk.Offset < minOffset {
+			minOffset = chunk.Offset
+		}
+		if stop := chunk.Offset + int64(chunk.Size); stop > maxStop {
+			maxStop = stop
+		}
+		if chunk.Mtime > manifestChunk.Mtime {
+			manifestChunk.Mtime = chunk.Mtime
+		}
+	}
+	manifestChunk.Offset = minOffset
+	manifestChunk.Size = uint64(maxStop - minOffset)
+	manifestChunk.IsChunkManifest = true
+
+	return manifestChunk, nil
+}
+
+func volumeId(fileId string) string {
+	lastCommaIndex := strings.LastIndex(fileId, ",")
+	if lastCommaIndex > 0 {
+		return fileId[:lastCommaIndex]
+	}
+	return fileId
+}
@@ -0,0 +1,154 @@
+package filer2
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+// fakeVolume keeps the saved manifests in memory, and counts the manifest reads.
+type fakeVolume struct {
+	sync.Mutex
+	server *httptest.Server
+	data   map[string][]byte
+	reads  int
+}
+
+func newFakeVolume() *fakeVolume {
+	v := &fakeVolume{data: make(map[string][]byte)}
+	v.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v.Lock()
+		defer v.Unlock()
+		data, found := v.data[strings.TrimPrefix(r.URL.Path, "/")]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		v.reads++
+		w.Write(data)
+	}))
+	return v
+}
+
+func (v *fakeVolume) save(data []byte) (*filer_pb.FileChunk, error) {
+	v.Lock()
+	defer v.Unlock()
+	fileId := fmt.Sprintf("9,%x", len(v.data)+1)
+	v.data[fileId] = data
+	return &filer_pb.FileChunk{FileId: fileId}, nil
+}
+
+func (v *fakeVolume) lookup(fileId string) (string, error) {
+	return v.server.URL + "/" + fileId, nil
+}
+
+func TestChunkManifest(t *testing.T) {
+	v := newFakeVolume()
+	defer v.server.Close()
+
+	// 2500 chunks of 10 bytes, and a later chunk overwriting [10, 20)
+	var chunks []*filer_pb.FileChunk
+	for i := 0; i < 2500; i++ {
+		chunks = append(chunks, &filer_pb.FileChunk{FileId: fmt.Sprintf("1,%x", i), Offset: int64(i * 10), Size: 10, Mtime: int64(i)})
+	}
+	chunks = append(chunks, &filer_pb.FileChunk{FileId: "2,1", Offset: 10, Size: 10, Mtime: 3000})
+
+	folded, err := MaybeManifestize(v.save, chunks)
+	if err != nil {
+		t.Fatalf("manifestize: %v", err)
+	}
+	manifestChunks, dataChunks := SeparateManifestChunks(folded)
+	if len(manifestChunks) != 2 || len(dataChunks) != 501 {
+		t.Fatalf("folded into %d manifests and %d chunks", len(manifestChunks), len(dataChunks))
+	}
+	// the chunks are folded by offset, so the later chunk is in the first manifest
+	if manifestChunks[0].Offset != 0 || manifestChunks[0].Size != 9990 || manifestChunks[0].Mtime != 3000 {
+		t.Fatalf("unexpected manifest chunk %+v", manifestChunks[0])
+	}
+	if TotalSize(folded) != 25000 {
+		t.Fatalf("unexpected total size %d", TotalSize(folded))
+	}
+
+	resolved, resolvedManifests, err := ResolveChunkManifest(v.lookup, folded)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if len(resolved) != 2501 || len(resolvedManifests) != 2 {
+		t.Fatalf("resolved %d chunks and %d manifests", len(resolved), len(resolvedManifests))
+	}
+
+	// the later chunk is visible over the manifest chunk, which is not compacted
+	compacted, garbage := CompactFileChunks(folded)
+	if len(compacted) != len(folded) || len(garbage) != 0 {
+		t.Fatalf("compacted %d chunks into %d with %d garbage", len(folded), len(compacted), len(garbage))
+	}
+
+	// reading through the manifests is the same as reading the chunks
+	v.reads = 0
+	views, err := ViewFromChunks(v.lookup, folded, 5, 30)
+	if err != nil {
+		t.Fatalf("view: %v", err)
+	}
+	expected, _ := ViewFromChunks(nil, chunks, 5, 30)
+	if len(views) == 0 || len(views) != len(expected) {
+		t.Fatalf("expected %d views, but got %d", len(expected), len(views))
+	}
+	for i, view := range views {
+		if *view != *expected[i] {
+			t.Fatalf("view %d: expected %+v, but got %+v", i, expected[i], view)
+		}
+	}
+	if v.reads != 1 {
+		t.Fatalf("expected to read only the overlapping manifest, but read %d", v.reads)
+	}
+
+	if _, err := ViewFromChunks(nil, folded, 0, 10); err == nil {
+		t.Fatalf("expected an error to read the manifests without a lookup")
+	}
+}
+
+func TestNestedChunkManifest(t *testing.T) {
+	v := newFakeVolume()
+	defer v.server.Close()
+
+	// manifests of one chunk each, so the manifests are folded again
+	var chunks []*filer_pb.FileChunk
+	for i := 0; i < ManifestBatch; i++ {
+		manifestChunk, err := saveChunkManifest(v.save, []*filer_pb.FileChunk{
+			{FileId: fmt.Sprintf("1,%x", i), Offset: int64(i * 10), Size: 10, Mtime: int64(i)},
+		})
+		if err != nil {
+			t.Fatalf("save manifest: %v", err)
+		}
+		chunks = append(chunks, manifestChunk)
+	}
+
+	folded, err := MaybeManifestize(v.save, chunks)
+	if err != nil {
+		t.Fatalf("manifestize: %v", err)
+	}
+	if len(folded) != 1 || !folded[0].IsChunkManifest || folded[0].Size != 10*ManifestBatch {
+		t.Fatalf("unexpected folded chunks %+v", folded)
+	}
+
+	views, err := ViewFromChunks(v.lookup, folded, 9995, 10)
+	if err != nil {
+		t.Fatalf("view: %v", err)
+	}
+	if len(views) != 1 || views[0].FileId != "1,3e7" || views[0].Offset != 5 || views[0].Size != 5 {
+		t.Fatalf("unexpected views %+v", views)
+	}
+
+	resolved, resolvedManifests, err := ResolveChunkManifest(v.lookup, folded)
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if len(resolved) != ManifestBatch || len(resolvedManifests) != ManifestBatch+1 {
+		t.Fatalf("resolved %d chunks and %d manifests", len(resolved), len(resolvedManifests))
+	}
+}
@@ -1,6 +1,7 @@
 package filer2
 
 import (
+	"crypto/md5"
 	"fmt"
 	"hash/fnv"
 	"sort"
@@ -31,15 +32,40 @@ func ETag(chunks []*filer_pb.FileChunk) (etag string) {
 	return fmt.Sprintf("%x", h.Sum32())
 }
 
+// ContentETag is the md5 of the content kept in the entry, same as the S3 ETag of a single part upload.
+func ContentETag(content []byte) string {
+	return fmt.Sprintf("%x", md5.Sum(content))
+}
+
+// FileSize returns the file size from the content kept in the entry, or from the chunks.
+func FileSize(entry *filer_pb.Entry) uint64 {
+	if len(entry.Content) > 0 {
+		return uint64(len(entry.Content))
+	}
+	return TotalSize(entry.Chunks)
+}
+
+// FileETag returns the ETag from the content kept in the entry, or from the chunks.
+func FileETag(entry *filer_pb.Entry) string {
+	if len(entry.Content) > 0 {
+		return ContentETag(entry.Content)
+	}
+	return ETag(entry.Chunks)
+}
+
+// CompactFileChunks separates the data chunks hidden by later chunks as garbage.
+// The manifest chunks are always kept, and do not hide any chunks, since their own chunks may be older.
 func CompactFileChunks(chunks []*filer_pb.FileChunk) (compacted, garbage []*filer_pb.FileChunk) {
 
-	visibles := nonOverlappingVisibleIntervals(chunks)
+	compacted, dataChunks := SeparateManifestChunks(chunks)
+
+	visibles := nonOverlappingVisibleIntervals(dataChunks)
 
 	fileIds := make(map[string]bool)
 	for _, interval := range visibles {
 		fileIds[interval.fileId] = true
 	}
-	for _, chunk := range chunks {
+	for _, chunk := range dataChunks {
 		if found := fileIds[chunk.FileId]; found {
 			compacted = append(compacted, chunk)
 		} else {
@@ -72,12 +98,19 @@ type ChunkView struct {
 	LogicOffset int64
 }
 
-func ViewFromChunks(chunks []*filer_pb.FileChunk, offset int64, size int) (views []*ChunkView) {
-
-	visibles := nonOverlappingVisibleIntervals(chunks)
+// ViewFromChunks returns the visible parts of the data chunks in [offset, offset+size).
+// Only the manifest chunks overlapping the range are read with lookupFileIdFn.
+func ViewFromChunks(lookupFileIdFn LookupFileIdFunctionType, chunks []*filer_pb.FileChunk, offset int64, size int) (views []*ChunkView, err error) {
 
 	stop := offset + int64(size)
 
+	dataChunks, _, err := resolveChunksInRange(lookupFileIdFn, chunks, offset, stop)
+	if err != nil {
+		return nil, err
+	}
+
+	visibles := nonOverlappingVisibleIntervals(dataChunks)
+
 	for _, chunk := range visibles {
 		if chunk.start <= offset && offset < chunk.stop && offset < stop {
 			views = append(views, &ChunkView{
@@ -90,7 +123,7 @@ func ViewFromChunks(chunks []*filer_pb.FileChunk, offset int64, size int) (views
 		}
 	}
 
-	return views
+	return views, nil
 
 }
 
@@ -319,7 +319,10 @@ func TestChunksReading(t *testing.T) {
 
 	for i, testcase := range testcases {
 		log.Printf("++++++++++ read test case %d ++++++++++++++++++++", i)
-		chunks := ViewFromChunks(testcase.Chunks, testcase.Offset, testcase.Size)
+		chunks, err := ViewFromChunks(nil, testcase.Chunks, testcase.Offset, testcase.Size)
+		if err != nil {
+			t.Fatalf("failed on read case %d: %v", i, err)
+		}
 		for x, chunk := range chunks {
 			log.Printf("read case %d, chunk %d, offset=%d, size=%d, fileId=%s",
 				i, x, chunk.Offset, chunk.Size, chunk.FileId)
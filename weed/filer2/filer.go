@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
 	"github.com/draleyva/seaweedfs/weed/wdclient"
 	"github.com/karlseguin/ccache"
 )
@@ -19,6 +20,14 @@ type Filer struct {
 	directoryCache     *ccache.Cache
 	MasterClient       *wdclient.MasterClient
 	fileIdDeletionChan chan string
+	MetaLog            *MetaLog
+	trash              *trashOption
+	appends            appendLocks
+	appendCompaction   *appendCompactionOption
+	defrag             *defragOption
+	usages             directoryUsages
+	chunkManifest      bool
+	records            recordLocks
 }
 
 func NewFiler(masters []string) *Filer {
@@ -26,6 +35,7 @@ func NewFiler(masters []string) *Filer {
 		directoryCache:     ccache.New(ccache.Configure().MaxSize(1000).ItemsToPrune(100)),
 		MasterClient:       wdclient.NewMasterClient(context.Background(), "filer", masters),
 		fileIdDeletionChan: make(chan string, 4096),
+		usages:             directoryUsages{cache: ccache.New(ccache.Configure().MaxSize(10000).ItemsToPrune(1000))},
 	}
 
 	go f.loopProcessingDeletion()
@@ -34,7 +44,7 @@ func NewFiler(masters []string) *Filer {
 }
 
 func (f *Filer) SetStore(store FilerStore) {
-	f.store = store
+	f.store = &systemEntryLoggingStore{FilerStore: store, filer: f}
 }
 
 func (f *Filer) DisableDirectoryCache() {
@@ -49,7 +59,33 @@ func (fs *Filer) KeepConnectedToMaster() {
 	fs.MasterClient.KeepConnectedToMaster()
 }
 
-func (f *Filer) CreateEntry(entry *Entry) error {
+func (f *Filer) CreateEntry(ctx context.Context, entry *Entry) error {
+	return f.createEntry(ctx, entry, nil)
+}
+
+// createEntry checks and saves the entry like CreateEntry, running beforeCreate first in the same transaction.
+func (f *Filer) createEntry(ctx context.Context, entry *Entry, beforeCreate func(ctx context.Context) error) error {
+	if err := f.checkSnapshotPath(ctx, entry.FullPath); err != nil {
+		return fmt.Errorf("create %s: %v", entry.FullPath, err)
+	}
+	if err := f.maybeManifestize(entry); err != nil {
+		return err
+	}
+	if !entry.IsDirectory() {
+		// serialized with the appends to the file
+		defer f.lockAppend(entry.FullPath)()
+	}
+	return f.withTransaction(ctx, func(ctx context.Context) error {
+		if beforeCreate != nil {
+			if err := beforeCreate(ctx); err != nil {
+				return err
+			}
+		}
+		return f.doCreateEntry(ctx, entry)
+	})
+}
+
+func (f *Filer) doCreateEntry(ctx context.Context, entry *Entry) error {
 
 	dirParts := strings.Split(string(entry.FullPath), "/")
 
@@ -67,7 +103,7 @@ func (f *Filer) CreateEntry(entry *Entry) error {
 		// not found, check the store directly
 		if dirEntry == nil {
 			glog.V(4).Infof("find uncached directory: %s", dirPath)
-			dirEntry, _ = f.FindEntry(FullPath(dirPath))
+			dirEntry, _ = f.FindEntry(ctx, FullPath(dirPath))
 		} else {
 			glog.V(4).Infof("found cached directory: %s", dirPath)
 		}
@@ -89,23 +125,36 @@ func (f *Filer) CreateEntry(entry *Entry) error {
 				},
 			}
 
+			if err := f.updateQuotaUsage(ctx, dirEntry.FullPath, nil, dirEntry); err != nil {
+				return err
+			}
+
+			if err := f.preserveForSnapshots(ctx, dirEntry.FullPath); err != nil {
+				return err
+			}
+
 			glog.V(2).Infof("create directory: %s %v", dirPath, dirEntry.Mode)
-			mkdirErr := f.store.InsertEntry(dirEntry)
+			mkdirErr := f.store.InsertEntry(ctx, dirEntry)
 			if mkdirErr != nil {
-				if _, err := f.FindEntry(FullPath(dirPath)); err == ErrNotFound {
+				if _, err := f.FindEntry(ctx, FullPath(dirPath)); err == ErrNotFound {
 					return fmt.Errorf("mkdir %s: %v", dirPath, mkdirErr)
 				}
 			} else {
-				f.NotifyUpdateEvent(nil, dirEntry, false)
+				createdDirEntry, level := dirEntry, i
+				f.afterCommit(ctx, func() {
+					f.NotifyUpdateEvent(nil, createdDirEntry, false)
+					// only cache the new directory once it is committed
+					f.cacheSetDirectory(dirPath, createdDirEntry, level)
+				})
 			}
 
 		} else if !dirEntry.IsDirectory() {
 			return fmt.Errorf("%s is a file", dirPath)
+		} else {
+			// cache the directory entry
+			f.cacheSetDirectory(dirPath, dirEntry, i)
 		}
 
-		// cache the directory entry
-		f.cacheSetDirectory(dirPath, dirEntry, i)
-
 		// remember the direct parent directory entry
 		if i == len(dirParts)-1 {
 			lastDirectoryEntry = dirEntry
@@ -125,35 +174,124 @@ func (f *Filer) CreateEntry(entry *Entry) error {
 		}
 	*/
 
-	oldEntry, _ := f.FindEntry(entry.FullPath)
+	oldEntry, _ := f.FindEntry(ctx, entry.FullPath)
+
+	if err := f.updateQuotaUsage(ctx, entry.FullPath, oldEntry, entry); err != nil {
+		return err
+	}
+
+	// replacing a hard link only removes this link, and keeps the content if still linked elsewhere
+	oldContent := oldEntry
+	if oldEntry != nil && oldEntry.HardLinkId != "" && oldEntry.HardLinkId != entry.HardLinkId {
+		isLastLink, err := f.unlinkHardLink(ctx, oldEntry)
+		if err != nil {
+			return fmt.Errorf("replace entry %s: %v", entry.FullPath, err)
+		}
+		if !isLastLink {
+			oldContent = nil
+		}
+	}
+
+	if err := f.keepOrDeleteReplacedChunks(ctx, oldContent, entry); err != nil {
+		return fmt.Errorf("replace entry %s: %v", entry.FullPath, err)
+	}
 
 	if oldEntry == nil {
-		if err := f.store.InsertEntry(entry); err != nil {
+		if err := f.storeEntry(ctx, entry, true); err != nil {
 			return fmt.Errorf("insert entry %s: %v", entry.FullPath, err)
 		}
 	} else {
-		if err := f.store.UpdateEntry(entry); err != nil {
+		if err := f.storeEntry(ctx, entry, false); err != nil {
 			return fmt.Errorf("update entry %s: %v", entry.FullPath, err)
 		}
 	}
 
-	f.NotifyUpdateEvent(oldEntry, entry, true)
-
-	f.deleteChunksIfNotNew(oldEntry, entry)
+	f.afterCommit(ctx, func() {
+		f.NotifyUpdateEvent(oldEntry, entry, true)
+	})
 
 	return nil
 }
 
-func (f *Filer) UpdateEntry(entry *Entry) (err error) {
-	return f.store.UpdateEntry(entry)
+func (f *Filer) UpdateEntry(ctx context.Context, entry *Entry) (err error) {
+	if err := f.checkSnapshotPath(ctx, entry.FullPath); err != nil {
+		return fmt.Errorf("update %s: %v", entry.FullPath, err)
+	}
+	if err := f.maybeManifestize(entry); err != nil {
+		return err
+	}
+	// serialized with the appends to the file
+	defer f.lockAppend(entry.FullPath)()
+	return f.withTransaction(ctx, func(ctx context.Context) error {
+		return f.doUpdateEntry(ctx, entry)
+	})
 }
 
-func (f *Filer) FindEntry(p FullPath) (entry *Entry, err error) {
-	return f.store.FindEntry(p)
+func (f *Filer) doUpdateEntry(ctx context.Context, entry *Entry) error {
+	oldEntry, err := f.FindEntry(ctx, entry.FullPath)
+	if err != nil {
+		return err
+	}
+	if err := f.updateQuotaUsage(ctx, entry.FullPath, oldEntry, entry); err != nil {
+		return err
+	}
+	if err := f.keepOrDeleteReplacedChunks(ctx, oldEntry, entry); err != nil {
+		return fmt.Errorf("update entry %s: %v", entry.FullPath, err)
+	}
+	return f.storeEntry(ctx, entry, false)
+}
+
+func (f *Filer) FindEntry(ctx context.Context, p FullPath) (entry *Entry, err error) {
+	if inSnapshot, err := f.isInSnapshot(ctx, p); err != nil {
+		return nil, err
+	} else if inSnapshot {
+		return f.findInSnapshot(ctx, p)
+	}
+	entry, err = f.store.FindEntry(ctx, p)
+	if err == nil && entry.HardLinkId != "" {
+		return f.resolveHardLink(ctx, entry)
+	}
+	return entry, err
 }
 
-func (f *Filer) DeleteEntryMetaAndData(p FullPath, isRecursive bool, shouldDeleteChunks bool) (err error) {
-	entry, err := f.FindEntry(p)
+func (f *Filer) DeleteEntryMetaAndData(ctx context.Context, p FullPath, isRecursive bool, shouldDeleteChunks bool) (err error) {
+	if IsHardLinkRecord(p) {
+		return fmt.Errorf("can not delete %s, the hard link records are managed by the filer", p)
+	}
+	if IsSnapshotIndex(p) {
+		return fmt.Errorf("can not delete %s, the snapshot records are managed by the filer", p)
+	}
+	if IsVersionPath(p) {
+		return fmt.Errorf("can not delete %s, the file versions are managed by the filer", p)
+	}
+	if dir, name, ok := parseSnapshotPath(p); ok {
+		if _, err := f.findSnapshotRecord(ctx, dir, name); err == nil {
+			// deleting the snapshot root deletes the whole snapshot
+			return f.DeleteSnapshot(ctx, dir, name)
+		}
+	}
+	if inSnapshot, err := f.isInSnapshot(ctx, p); err != nil {
+		return fmt.Errorf("delete %s: %v", p, err)
+	} else if inSnapshot {
+		return fmt.Errorf("can not delete %s in a read only snapshot", p)
+	}
+	if hasSnapshot, err := f.hasSnapshotUnder(ctx, p); err != nil {
+		return fmt.Errorf("delete %s: %v", p, err)
+	} else if hasSnapshot {
+		return fmt.Errorf("can not delete %s with snapshots, delete the snapshots first", p)
+	}
+	if f.shouldMoveToTrash(p, shouldDeleteChunks) {
+		return f.withTransaction(ctx, func(ctx context.Context) error {
+			return f.doMoveToTrash(ctx, p, isRecursive)
+		})
+	}
+	return f.withTransaction(ctx, func(ctx context.Context) error {
+		return f.doDeleteEntryMetaAndData(ctx, p, isRecursive, shouldDeleteChunks)
+	})
+}
+
+func (f *Filer) doDeleteEntryMetaAndData(ctx context.Context, p FullPath, isRecursive bool, shouldDeleteChunks bool) (err error) {
+	entry, err := f.FindEntry(ctx, p)
 	if err != nil {
 		return err
 	}
@@ -163,13 +301,19 @@ func (f *Filer) DeleteEntryMetaAndData(p FullPath, isRecursive bool, shouldDelet
 		if isRecursive {
 			limit = math.MaxInt32
 		}
-		entries, err := f.ListDirectoryEntries(p, "", false, limit)
+		entries, err := f.ListDirectoryEntries(ctx, p, "", false, limit)
 		if err != nil {
 			return fmt.Errorf("list folder %s: %v", p, err)
 		}
 		if isRecursive {
 			for _, sub := range entries {
-				f.DeleteEntryMetaAndData(sub.FullPath, isRecursive, shouldDeleteChunks)
+				if IsHardLinkRecord(sub.FullPath) {
+					// the records are removed along with their last links
+					continue
+				}
+				if err := f.doDeleteEntryMetaAndData(ctx, sub.FullPath, isRecursive, shouldDeleteChunks); err != nil {
+					return err
+				}
 			}
 		} else {
 			if len(entries) > 0 {
@@ -179,25 +323,79 @@ func (f *Filer) DeleteEntryMetaAndData(p FullPath, isRecursive bool, shouldDelet
 		f.cacheDelDirectory(string(p))
 	}
 
-	if shouldDeleteChunks {
-		f.DeleteChunks(entry.Chunks)
-	}
-
 	if p == "/" {
 		return nil
 	}
 	glog.V(3).Infof("deleting entry %v", p)
 
-	f.NotifyUpdateEvent(entry, nil, shouldDeleteChunks)
+	if err := f.updateQuotaUsage(ctx, p, entry, nil); err != nil {
+		return err
+	}
+
+	if err := f.preserveForSnapshots(ctx, p); err != nil {
+		return err
+	}
+	if err := f.store.DeleteEntry(ctx, p); err != nil {
+		return err
+	}
+
+	if entry.IsDirectory() {
+		if err := f.removeDeletedDirectoryQuota(ctx, p); err != nil {
+			return err
+		}
+	}
+
+	if entry.HardLinkId != "" {
+		isLastLink, err := f.unlinkHardLink(ctx, entry)
+		if err != nil {
+			return err
+		}
+		shouldDeleteChunks = shouldDeleteChunks && isLastLink
+	}
+
+	var chunksToDelete []*filer_pb.FileChunk
+	if shouldDeleteChunks {
+		if chunksToDelete, err = f.deletedFileChunks(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	f.afterCommit(ctx, func() {
+		f.DeleteChunks(chunksToDelete)
+		f.NotifyUpdateEvent(entry, nil, shouldDeleteChunks)
+	})
 
-	return f.store.DeleteEntry(p)
+	return nil
+}
+
+func (f *Filer) ListDirectoryEntries(ctx context.Context, p FullPath, startFileName string, inclusive bool, limit int) ([]*Entry, error) {
+	return f.ListDirectoryPrefixedEntries(ctx, p, startFileName, inclusive, limit, "")
 }
 
-func (f *Filer) ListDirectoryEntries(p FullPath, startFileName string, inclusive bool, limit int) ([]*Entry, error) {
+func (f *Filer) ListDirectoryPrefixedEntries(ctx context.Context, p FullPath, startFileName string, inclusive bool, limit int, prefix string) ([]*Entry, error) {
 	if strings.HasSuffix(string(p), "/") && len(p) > 1 {
 		p = p[0 : len(p)-1]
 	}
-	return f.store.ListDirectoryEntries(p, startFileName, inclusive, limit)
+	if inSnapshot, err := f.isInSnapshot(ctx, p); err != nil {
+		return nil, err
+	} else if inSnapshot {
+		return f.listInSnapshot(ctx, p, startFileName, inclusive, limit, prefix)
+	}
+	entries, err := f.store.ListDirectoryPrefixedEntries(ctx, p, startFileName, inclusive, limit, prefix)
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range entries {
+		if entry.HardLinkId == "" {
+			continue
+		}
+		if resolved, resolveErr := f.resolveHardLink(ctx, entry); resolveErr != nil {
+			glog.V(0).Infof("list %s: %v", p, resolveErr)
+		} else {
+			entries[i] = resolved
+		}
+	}
+	return entries, nil
 }
 
 func (f *Filer) cacheDelDirectory(dirpath string) {
@@ -0,0 +1,273 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+// appendLocks serializes the appends to each file with the other changes of the file through this filer,
+// including the compaction of the appended chunks. The changes through other filers sharing the store
+// are not serialized, so the appends to a file should go through one filer.
+type appendLocks struct {
+	sync.Mutex
+	locks map[FullPath]*appendLock
+}
+
+type appendLock struct {
+	sync.Mutex
+	waiters int
+}
+
+// appendCompactionInterval is how often the files appended to are compacted.
+const appendCompactionInterval = time.Minute
+
+type appendCompactionOption struct {
+	maxChunks      int
+	chunkSizeLimit int64
+
+	sync.Mutex
+	pending map[FullPath]bool
+}
+
+func (f *Filer) lockAppend(p FullPath) (unlock func()) {
+	f.appends.Lock()
+	if f.appends.locks == nil {
+		f.appends.locks = make(map[FullPath]*appendLock)
+	}
+	lock, found := f.appends.locks[p]
+	if !found {
+		lock = &appendLock{}
+		f.appends.locks[p] = lock
+	}
+	lock.waiters++
+	f.appends.Unlock()
+
+	lock.Lock()
+
+	return func() {
+		lock.Unlock()
+		f.appends.Lock()
+		lock.waiters--
+		if lock.waiters == 0 {
+			delete(f.appends.locks, p)
+		}
+		f.appends.Unlock()
+	}
+}
+
+// EnableAppendCompaction periodically merges the small chunks of the files appended to with maxChunks data chunks.
+// The merged chunks are up to chunkSizeLimit bytes.
+func (f *Filer) EnableAppendCompaction(maxChunks int, chunkSizeLimit int64) {
+	f.appendCompaction = &appendCompactionOption{
+		maxChunks:      maxChunks,
+		chunkSizeLimit: chunkSizeLimit,
+		pending:        make(map[FullPath]bool),
+	}
+	go f.loopCompactingAppends()
+}
+
+func (f *Filer) loopCompactingAppends() {
+	for {
+		time.Sleep(appendCompactionInterval)
+		f.compactPendingAppends()
+	}
+}
+
+// compactPendingAppends compacts the files with enough chunks appended since the last run.
+func (f *Filer) compactPendingAppends() {
+	f.appendCompaction.Lock()
+	pending := f.appendCompaction.pending
+	f.appendCompaction.pending = make(map[FullPath]bool)
+	f.appendCompaction.Unlock()
+
+	for p := range pending {
+		if err := f.compactAppendedChunks(context.Background(), p); err != nil {
+			glog.Errorf("compact appended chunks of %s: %v", p, err)
+		}
+	}
+}
+
+// AppendToEntry adds the chunks of the entry, with offsets relative to the appended data, at the end of the file,
+// and returns the offset the data is appended at. The file is created with the entry attributes if not found.
+// The appends to the same file through this filer are serialized, and each append is atomic.
+// The chunks are deleted if the append fails.
+func (f *Filer) AppendToEntry(ctx context.Context, entry *Entry) (offset int64, err error) {
+	if err := f.checkSnapshotPath(ctx, entry.FullPath); err != nil {
+		return 0, fmt.Errorf("append to %s: %v", entry.FullPath, err)
+	}
+
+	unlock := f.lockAppend(entry.FullPath)
+	defer unlock()
+
+	var updated *Entry
+	err = f.withTransaction(ctx, func(ctx context.Context) error {
+		existing, err := f.FindEntry(ctx, entry.FullPath)
+		if err != nil && err != ErrNotFound {
+			return err
+		}
+		if existing != nil && existing.IsDirectory() {
+			return fmt.Errorf("%s is a directory", entry.FullPath)
+		}
+
+		if existing == nil {
+			updated = &Entry{
+				FullPath: entry.FullPath,
+				Attr:     entry.Attr,
+				Extended: entry.Extended,
+			}
+		} else {
+			copied := *existing
+			updated = &copied
+			updated.Chunks = append([]*filer_pb.FileChunk(nil), existing.Chunks...)
+			updated.Mtime = time.Now()
+			offset = int64(existing.Size())
+		}
+
+		mtime := time.Now().UnixNano()
+
+		// the content kept in the entry can not be followed by chunks, so it becomes the first chunk
+		if len(updated.Content) > 0 {
+			chunk, err := f.saveDataAsChunk(updated, updated.Content)
+			if err != nil {
+				return fmt.Errorf("move content of %s to chunk: %v", entry.FullPath, err)
+			}
+			chunk.Size = uint64(len(updated.Content))
+			chunk.Mtime = mtime
+			updated.Chunks = append(updated.Chunks, chunk)
+			updated.Content = nil
+			f.onRollback(ctx, func() {
+				f.DeleteChunks([]*filer_pb.FileChunk{chunk})
+			})
+		}
+
+		for _, chunk := range entry.Chunks {
+			updated.Chunks = append(updated.Chunks, &filer_pb.FileChunk{
+				FileId: chunk.FileId,
+				Offset: offset + chunk.Offset,
+				Size:   chunk.Size,
+				Mtime:  mtime,
+				ETag:   chunk.ETag,
+			})
+		}
+
+		if err := f.maybeManifestize(updated); err != nil {
+			return err
+		}
+
+		if existing == nil {
+			return f.doCreateEntry(ctx, updated)
+		}
+		if err := f.doUpdateEntry(ctx, updated); err != nil {
+			return err
+		}
+		f.afterCommit(ctx, func() {
+			f.NotifyUpdateEvent(existing, updated, true)
+		})
+		return nil
+	})
+	if err != nil {
+		f.DeleteChunks(entry.Chunks)
+		return 0, err
+	}
+
+	glog.V(3).Infof("appended %d chunks to %s at %d", len(entry.Chunks), entry.FullPath, offset)
+
+	if f.appendCompaction != nil && f.appendCompaction.maxChunks > 0 {
+		if _, dataChunks := SeparateManifestChunks(updated.Chunks); len(dataChunks) >= f.appendCompaction.maxChunks {
+			f.appendCompaction.Lock()
+			f.appendCompaction.pending[entry.FullPath] = true
+			f.appendCompaction.Unlock()
+		}
+	}
+
+	return offset, nil
+}
+
+// compactAppendedChunks merges each run of adjacent small data chunks into one chunk.
+// The merged chunk has the visible content of its range, and is newer than the chunks it replaces.
+// The compaction is abandoned if the file is changed meanwhile. The replaced chunks are deleted
+// later, as the defragmentation does, for the clients still holding them.
+func (f *Filer) compactAppendedChunks(ctx context.Context, p FullPath) error {
+
+	unlock := f.lockAppend(p)
+	defer unlock()
+
+	entry, err := f.FindEntry(ctx, p)
+	if err != nil {
+		return err
+	}
+	_, dataChunks := SeparateManifestChunks(entry.Chunks)
+	if len(dataChunks) < f.appendCompaction.maxChunks {
+		return nil
+	}
+
+	runs := smallChunkRuns(dataChunks, f.appendCompaction.chunkSizeLimit)
+	if len(runs) == 0 {
+		return nil
+	}
+
+	var merged []*filer_pb.FileChunk
+	replaced := make(map[string]bool)
+	for _, run := range runs {
+		last := run[len(run)-1]
+		chunk, err := f.rewriteChunk(entry, run[0].Offset, last.Offset+int64(last.Size))
+		if err != nil {
+			f.DeleteChunks(merged)
+			return err
+		}
+		merged = append(merged, chunk)
+		for _, c := range run {
+			replaced[c.FileId] = true
+		}
+	}
+
+	var chunks []*filer_pb.FileChunk
+	for _, chunk := range entry.Chunks {
+		if !replaced[chunk.FileId] {
+			chunks = append(chunks, chunk)
+		}
+	}
+	if err := f.replaceChunks(ctx, entry, append(chunks, merged...)); err != nil {
+		f.DeleteChunks(merged)
+		return err
+	}
+
+	glog.V(2).Infof("compacted %d appended chunks of %s into %d", len(replaced), p, len(merged))
+
+	return nil
+}
+
+// smallChunkRuns returns the runs of at least 2 adjacent chunks smaller than the limit, up to the limit in total.
+func smallChunkRuns(chunks []*filer_pb.FileChunk, limit int64) (runs [][]*filer_pb.FileChunk) {
+	sorted := make([]*filer_pb.FileChunk, len(chunks))
+	copy(sorted, chunks)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+
+	var run []*filer_pb.FileChunk
+	var runSize int64
+	for _, chunk := range sorted {
+		adjacent := len(run) > 0 && run[len(run)-1].Offset+int64(run[len(run)-1].Size) == chunk.Offset
+		if int64(chunk.Size) >= limit || !adjacent || runSize+int64(chunk.Size) > limit {
+			if len(run) > 1 {
+				runs = append(runs, run)
+			}
+			run, runSize = nil, 0
+		}
+		if int64(chunk.Size) < limit {
+			run = append(run, chunk)
+			runSize += int64(chunk.Size)
+		}
+	}
+	if len(run) > 1 {
+		runs = append(runs, run)
+	}
+	return
+}
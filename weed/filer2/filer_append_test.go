@@ -0,0 +1,169 @@
+package filer2_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestAppendToEntry(t *testing.T) {
+	ctx := context.Background()
+	filer := newTestFiler()
+
+	appendChunk := func(fileId string, size uint64) int64 {
+		offset, err := filer.AppendToEntry(ctx, &filer2.Entry{
+			FullPath: "/logs/a.log",
+			Attr:     filer2.Attr{Mode: 0644},
+			Chunks:   []*filer_pb.FileChunk{{FileId: fileId, Size: size}},
+		})
+		if err != nil {
+			t.Fatalf("append %s: %v", fileId, err)
+		}
+		return offset
+	}
+
+	// the file is created by the first append
+	if offset := appendChunk("1,1", 10); offset != 0 {
+		t.Fatalf("appended at %d", offset)
+	}
+	if offset := appendChunk("1,2", 5); offset != 10 {
+		t.Fatalf("appended at %d", offset)
+	}
+
+	// the concurrent appends do not overlap
+	var wg sync.WaitGroup
+	offsets := make(chan int64, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			offsets <- appendChunk(fmt.Sprintf("2,%x", i), 100)
+		}(i)
+	}
+	wg.Wait()
+	close(offsets)
+	seen := make(map[int64]bool)
+	for offset := range offsets {
+		if (offset-15)%100 != 0 || seen[offset] {
+			t.Fatalf("unexpected offset %d", offset)
+		}
+		seen[offset] = true
+	}
+
+	entry, err := filer.FindEntry(ctx, "/logs/a.log")
+	if err != nil || len(entry.Chunks) != 12 || entry.Size() != 1015 {
+		t.Fatalf("unexpected entry %+v: %v", entry, err)
+	}
+
+	if _, err := filer.AppendToEntry(ctx, &filer2.Entry{FullPath: "/logs"}); err == nil {
+		t.Fatalf("expected an error to append to a directory")
+	}
+}
+
+func TestAppendToInlineContent(t *testing.T) {
+	filer, volume, cleanup := newTestFilerWithVolume(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/logs/a.log", Attr: filer2.Attr{Mode: 0644}, Content: []byte("hello ")}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	volume.put("1,0187654321", []byte("world"))
+	offset, err := filer.AppendToEntry(ctx, &filer2.Entry{
+		FullPath: "/logs/a.log",
+		Chunks:   []*filer_pb.FileChunk{{FileId: "1,0187654321", Size: 5}},
+	})
+	if err != nil || offset != 6 {
+		t.Fatalf("append at %d: %v", offset, err)
+	}
+
+	// the kept content becomes the first chunk, followed by the appended chunk
+	entry, err := filer.FindEntry(ctx, "/logs/a.log")
+	if err != nil || len(entry.Content) != 0 || len(entry.Chunks) != 2 {
+		t.Fatalf("appended entry %+v: %v", entry, err)
+	}
+	if entry.Chunks[0].Offset != 0 || entry.Chunks[1].Offset != 6 {
+		t.Errorf("chunk offsets %d and %d", entry.Chunks[0].Offset, entry.Chunks[1].Offset)
+	}
+	if content := readChunks(volume, entry.Chunks); content != "hello world" {
+		t.Errorf("content %q", content)
+	}
+}
+
+func TestSmallChunkRuns(t *testing.T) {
+	chunk := func(offset int64, size uint64) *filer_pb.FileChunk {
+		return &filer_pb.FileChunk{FileId: fmt.Sprintf("1,%x", offset), Offset: offset, Size: size}
+	}
+	// unsorted, with a large chunk, a gap, and a run over the limit
+	chunks := []*filer_pb.FileChunk{
+		chunk(3, 3), chunk(0, 3), chunk(6, 10),
+		chunk(16, 2), chunk(18, 2), chunk(20, 2), chunk(22, 3),
+		chunk(30, 1),
+	}
+
+	runs := filer2.SmallChunkRuns(chunks, 8)
+	var got []string
+	for _, run := range runs {
+		var offsets []int64
+		for _, c := range run {
+			offsets = append(offsets, c.Offset)
+		}
+		got = append(got, fmt.Sprint(offsets))
+	}
+	if fmt.Sprint(got) != "[[0 3] [16 18 20]]" {
+		t.Errorf("runs %v", got)
+	}
+}
+
+func TestCompactAppendedChunks(t *testing.T) {
+	filer, volume, cleanup := newTestFilerWithVolume(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	filer.EnableAppendCompaction(4, 6)
+
+	var appended []*filer_pb.FileChunk
+	for i, part := range []string{"abc", "def", "ghi", "jkl"} {
+		fileId := fmt.Sprintf("1,%02x87654321", i+1)
+		volume.put(fileId, []byte(part))
+		chunk := &filer_pb.FileChunk{FileId: fileId, Size: 3}
+		if _, err := filer.AppendToEntry(ctx, &filer2.Entry{
+			FullPath: "/logs/a.log",
+			Attr:     filer2.Attr{Mode: 0644, Mtime: time.Now()},
+			Chunks:   []*filer_pb.FileChunk{chunk},
+		}); err != nil {
+			t.Fatalf("append %s: %v", part, err)
+		}
+		appended = append(appended, chunk)
+	}
+
+	// the compaction runs periodically, not on the append
+	if entry, err := filer.FindEntry(ctx, "/logs/a.log"); err != nil || len(entry.Chunks) != 4 {
+		t.Fatalf("chunks before compaction: %+v, %v", entry, err)
+	}
+
+	filer.CompactPendingAppends()
+
+	entry, err := filer.FindEntry(ctx, "/logs/a.log")
+	if err != nil || len(entry.Chunks) != 2 {
+		t.Fatalf("chunks after compaction: %+v, %v", entry, err)
+	}
+	if content := readChunks(volume, entry.Chunks); content != "abcdefghijkl" {
+		t.Errorf("compacted content %q", content)
+	}
+	// the replaced chunks are kept for the clients still holding them
+	if content := readChunks(volume, appended); content != "abcdefghijkl" {
+		t.Errorf("replaced chunks are deleted, left %q", content)
+	}
+
+	// nothing is pending after the compaction
+	filer.CompactPendingAppends()
+	if entry, err := filer.FindEntry(ctx, "/logs/a.log"); err != nil || len(entry.Chunks) != 2 {
+		t.Errorf("chunks after another run: %+v, %v", entry, err)
+	}
+}
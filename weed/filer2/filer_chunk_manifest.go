@@ -0,0 +1,126 @@
+package filer2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/operation"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+// EnableChunkManifest folds the huge chunk lists into manifest chunks. The clients without manifest
+// support read the manifest chunks as file content, so it should be enabled after upgrading all of them.
+func (f *Filer) EnableChunkManifest() {
+	f.chunkManifest = true
+}
+
+// maybeManifestize folds the huge chunk list of the entry into manifest chunks, before the entry is saved.
+func (f *Filer) maybeManifestize(entry *Entry) (err error) {
+	if !f.chunkManifest || entry.IsDirectory() || len(entry.Chunks) < ManifestBatch {
+		return nil
+	}
+	entry.Chunks, err = MaybeManifestize(func(data []byte) (*filer_pb.FileChunk, error) {
+		return f.saveDataAsChunk(entry, data)
+	}, entry.Chunks)
+	if err != nil {
+		return fmt.Errorf("fold chunks of %s: %v", entry.FullPath, err)
+	}
+	return nil
+}
+
+// saveDataAsChunk uploads the data as a new chunk, with the replication, collection and ttl of the entry.
+func (f *Filer) saveDataAsChunk(entry *Entry, data []byte) (*filer_pb.FileChunk, error) {
+	ttlStr := ""
+	if entry.TtlSec > 0 {
+		ttlStr = strconv.Itoa(int(entry.TtlSec))
+	}
+	assignResult, err := operation.Assign(f.GetMaster(), &operation.VolumeAssignRequest{
+		Count:       1,
+		Replication: entry.Replication,
+		Collection:  entry.Collection,
+		Ttl:         ttlStr,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("assign volume: %v", err)
+	}
+	if assignResult.Error != "" {
+		return nil, fmt.Errorf("assign volume result: %v", assignResult.Error)
+	}
+
+	uploadUrl := "http://" + assignResult.Url + "/" + assignResult.Fid
+	uploadResult, err := operation.Upload(uploadUrl, "", bytes.NewReader(data), false, "application/octet-stream", nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("upload to %s: %v", uploadUrl, err)
+	}
+	if uploadResult.Error != "" {
+		return nil, fmt.Errorf("upload to %s: %v", uploadUrl, uploadResult.Error)
+	}
+
+	return &filer_pb.FileChunk{
+		FileId: assignResult.Fid,
+		Mtime:  time.Now().UnixNano(),
+		ETag:   uploadResult.ETag,
+	}, nil
+}
+
+// resolveChunks returns the chunks with the data chunks and the manifest chunks folded in the manifests.
+func (f *Filer) resolveChunks(chunks []*filer_pb.FileChunk) ([]*filer_pb.FileChunk, error) {
+	if !HasChunkManifest(chunks) {
+		return chunks, nil
+	}
+	dataChunks, manifestChunks, err := ResolveChunkManifest(f.MasterClient.LookupFileId, chunks)
+	if err != nil {
+		return nil, err
+	}
+	var resolved []*filer_pb.FileChunk
+	fileIds := make(map[string]bool)
+	for _, chunk := range append(manifestChunks, dataChunks...) {
+		if !fileIds[chunk.FileId] {
+			fileIds[chunk.FileId] = true
+			resolved = append(resolved, chunk)
+		}
+	}
+	return resolved, nil
+}
+
+// FindUnusedChunks returns the chunks of oldChunks, including the chunks folded in the manifests,
+// which are not referenced by newChunks, or the chunks folded in its manifests.
+func (f *Filer) FindUnusedChunks(oldChunks, newChunks []*filer_pb.FileChunk) ([]*filer_pb.FileChunk, error) {
+	unused := FindUnusedFileChunks(oldChunks, newChunks)
+	if len(unused) == 0 || !HasChunkManifest(unused) && !HasChunkManifest(newChunks) {
+		return unused, nil
+	}
+	unused, err := f.resolveChunks(unused)
+	if err != nil {
+		return nil, err
+	}
+	resolvedNewChunks, err := f.resolveChunks(newChunks)
+	if err != nil {
+		return nil, err
+	}
+	return FindUnusedFileChunks(unused, resolvedNewChunks), nil
+}
+
+// DeleteEntryKeepingData deletes the file and its chunk manifests, but not the data chunks folded in them,
+// for the files whose data chunks are moved into another file.
+func (f *Filer) DeleteEntryKeepingData(ctx context.Context, p FullPath) error {
+	entry, err := f.FindEntry(ctx, p)
+	if err != nil {
+		return err
+	}
+	if entry.IsDirectory() {
+		return fmt.Errorf("%s is a directory", p)
+	}
+	_, manifestChunks, err := ResolveChunkManifest(f.MasterClient.LookupFileId, entry.Chunks)
+	if err != nil {
+		return fmt.Errorf("resolve chunk manifests of %s: %v", p, err)
+	}
+	if err := f.DeleteEntryMetaAndData(ctx, p, false, false); err != nil {
+		return err
+	}
+	f.DeleteChunks(manifestChunks)
+	return nil
+}
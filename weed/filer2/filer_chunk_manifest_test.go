@@ -0,0 +1,53 @@
+package filer2_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestChunkManifestOption(t *testing.T) {
+	filer, _, cleanup := newTestFilerWithVolume(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	newHugeFile := func(p string) *filer2.Entry {
+		entry := &filer2.Entry{FullPath: filer2.FullPath(p), Attr: filer2.Attr{Mode: 0660}}
+		for i := 0; i < filer2.ManifestBatch; i++ {
+			entry.Chunks = append(entry.Chunks, &filer_pb.FileChunk{
+				FileId: fmt.Sprintf("1,%x87654321", i+1),
+				Offset: int64(i),
+				Size:   1,
+			})
+		}
+		return entry
+	}
+
+	// the chunk lists are not folded by default, for the clients without manifest support
+	if err := filer.CreateEntry(ctx, newHugeFile("/data/a")); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if entry, err := filer.FindEntry(ctx, "/data/a"); err != nil || filer2.HasChunkManifest(entry.Chunks) {
+		t.Fatalf("chunks are folded without the option: %v", err)
+	}
+
+	filer.EnableChunkManifest()
+	if err := filer.CreateEntry(ctx, newHugeFile("/data/b")); err != nil {
+		t.Fatalf("create with manifests: %v", err)
+	}
+	entry, err := filer.FindEntry(ctx, "/data/b")
+	if err != nil || !filer2.HasChunkManifest(entry.Chunks) {
+		t.Fatalf("chunks are not folded: %v", err)
+	}
+
+	// the file is deleted, but not the data chunks folded in its manifests
+	if err := filer.DeleteEntryKeepingData(ctx, "/data/b"); err != nil {
+		t.Fatalf("delete keeping data: %v", err)
+	}
+	if _, err := filer.FindEntry(ctx, "/data/b"); err != filer2.ErrNotFound {
+		t.Errorf("find the deleted file: %v", err)
+	}
+}
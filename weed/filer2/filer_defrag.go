@@ -0,0 +1,287 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/draleyva/seaweedfs/weed/util"
+)
+
+const defragInterval = time.Hour
+
+// defragMinAge skips the files changed recently when defragmenting a directory, since they are likely still being written.
+const defragMinAge = time.Hour
+
+// replacedChunkDeletionDelay keeps the chunks replaced by defragmentation or compaction for a while,
+// since the clients holding the old chunk list, like the open files of the mounts, may still save it back.
+const replacedChunkDeletionDelay = 10 * time.Minute
+
+const replacedChunkCheckInterval = time.Minute
+
+// replacedChunksDirectory keeps the postponed deletions of the replaced chunks, so they survive the filer restarts.
+// Each record is named by its due time and the escaped file path, and has the file path and the replaced chunks.
+const (
+	replacedChunksDirectory = SnapshotIndexDirectory + "/replaced"
+	replacedPathExtendedKey = "replaced.path"
+)
+
+func replacedChunksPath(p FullPath, due time.Time) FullPath {
+	return NewFullPath(string(replacedChunksDirectory), fmt.Sprintf("%019d@%s", due.UnixNano(), url.QueryEscape(string(p))))
+}
+
+type defragOption struct {
+	minChunks int
+	chunkSize int64
+}
+
+// EnableDefragmentation periodically rewrites the files with at least minChunks data chunks,
+// if they can be stored in fewer chunks of chunkSize.
+func (f *Filer) EnableDefragmentation(minChunks int, chunkSize int64) {
+	f.defrag = &defragOption{
+		minChunks: minChunks,
+		chunkSize: chunkSize,
+	}
+	go f.loopDefragmenting()
+}
+
+// DefragmentEntry rewrites the visible content of the file into non-overlapping chunks of chunkSize,
+// and swaps them in if the file is not changed meanwhile. It returns the number of data chunks
+// before and after, which are the same if the file does not need fewer chunks.
+func (f *Filer) DefragmentEntry(ctx context.Context, p FullPath, chunkSize int64) (before, after int, err error) {
+	if err := f.checkSnapshotPath(ctx, p); err != nil {
+		return 0, 0, fmt.Errorf("defragment %s: %v", p, err)
+	}
+
+	entry, err := f.FindEntry(ctx, p)
+	if err != nil {
+		return 0, 0, err
+	}
+	if entry.IsDirectory() {
+		return 0, 0, fmt.Errorf("%s is a directory", p)
+	}
+	if len(entry.Content) > 0 {
+		return 0, 0, nil
+	}
+
+	dataChunks, _, err := ResolveChunkManifest(f.MasterClient.LookupFileId, entry.Chunks)
+	if err != nil {
+		return 0, 0, err
+	}
+	before = len(dataChunks)
+	size := int64(entry.Size())
+	if int64(before) <= (size+chunkSize-1)/chunkSize {
+		return before, before, nil
+	}
+
+	var chunks []*filer_pb.FileChunk
+	for start := int64(0); start < size; start += chunkSize {
+		chunk, err := f.rewriteChunk(entry, start, min(start+chunkSize, size))
+		if err != nil {
+			f.DeleteChunks(chunks)
+			return before, before, err
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	defragmented := &Entry{
+		FullPath: entry.FullPath,
+		Attr:     entry.Attr,
+		Chunks:   chunks,
+	}
+	if err = f.maybeManifestize(defragmented); err == nil {
+		err = f.replaceChunks(ctx, entry, defragmented.Chunks)
+	}
+	if err != nil {
+		manifestChunks, _ := SeparateManifestChunks(defragmented.Chunks)
+		f.DeleteChunks(append(manifestChunks, chunks...))
+		return before, before, err
+	}
+
+	glog.V(2).Infof("defragmented %s from %d chunks into %d", p, before, len(chunks))
+
+	return before, len(chunks), nil
+}
+
+// DefragmentDirectory defragments the files under the directory with at least minChunks data chunks.
+// The snapshots, the trash, the files changed within defragMinAge, and the files changed during
+// their defragmentation are skipped.
+func (f *Filer) DefragmentDirectory(ctx context.Context, dir FullPath, minChunks int, chunkSize int64) (files, before, after int, err error) {
+	err = f.walkEntries(ctx, dir, func(entry *Entry) error {
+		if f.IsInTrash(entry.FullPath) {
+			return errSkipDirectory
+		}
+		if entry.IsDirectory() || countDataChunks(entry.Chunks) < minChunks || time.Since(entry.Mtime) < defragMinAge {
+			return nil
+		}
+		fileBefore, fileAfter, err := f.DefragmentEntry(ctx, entry.FullPath, chunkSize)
+		if err != nil {
+			glog.Warningf("defragment %s: %v", entry.FullPath, err)
+			return nil
+		}
+		if fileAfter < fileBefore {
+			files, before, after = files+1, before+fileBefore, after+fileAfter
+		}
+		return nil
+	})
+	return
+}
+
+func (f *Filer) loopDefragmenting() {
+	for {
+		time.Sleep(defragInterval)
+		files, before, after, err := f.DefragmentDirectory(context.Background(), "/", f.defrag.minChunks, f.defrag.chunkSize)
+		if err != nil {
+			glog.Errorf("defragment: %v", err)
+		}
+		if files > 0 {
+			glog.V(0).Infof("defragmented %d files from %d chunks into %d", files, before, after)
+		}
+	}
+}
+
+// countDataChunks counts each manifest chunk as ManifestBatch data chunks, without reading it.
+func countDataChunks(chunks []*filer_pb.FileChunk) int {
+	manifestChunks, dataChunks := SeparateManifestChunks(chunks)
+	return len(dataChunks) + len(manifestChunks)*ManifestBatch
+}
+
+// rewriteChunk reads the visible content of the file in [start, stop), and saves it as one chunk.
+func (f *Filer) rewriteChunk(entry *Entry, start, stop int64) (*filer_pb.FileChunk, error) {
+
+	views, err := ViewFromChunks(f.MasterClient.LookupFileId, entry.Chunks, start, int(stop-start))
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, stop-start)
+	for _, view := range views {
+		fileUrl, err := f.MasterClient.LookupFileId(view.FileId)
+		if err != nil {
+			return nil, fmt.Errorf("lookup %s: %v", view.FileId, err)
+		}
+		if _, err := util.ReadUrl(fileUrl, view.Offset, int(view.Size), data[view.LogicOffset-start:view.LogicOffset-start+int64(view.Size)]); err != nil {
+			return nil, fmt.Errorf("read %s: %v", fileUrl, err)
+		}
+	}
+
+	chunk, err := f.saveDataAsChunk(entry, data)
+	if err != nil {
+		return nil, err
+	}
+	chunk.Offset = start
+	chunk.Size = uint64(len(data))
+	chunk.Mtime = time.Now().UnixNano()
+	return chunk, nil
+}
+
+// replaceChunks swaps the chunks of the entry for new chunks with the same content, unless the entry
+// is changed meanwhile. The check only covers the changes through this filer, or through the other
+// filers if the store transactions are serializable. The replaced chunks are recorded in the same
+// transaction, and deleted after replacedChunkDeletionDelay, unless the file or its versions use them again by then.
+// The file content is not modified, so the mtime and the quota usage stay, and no version is kept.
+func (f *Filer) replaceChunks(ctx context.Context, entry *Entry, chunks []*filer_pb.FileChunk) error {
+	return f.withTransaction(ctx, func(ctx context.Context) error {
+		current, err := f.FindEntry(ctx, entry.FullPath)
+		if err != nil {
+			return err
+		}
+		if !EqualEntry(current, entry) {
+			return fmt.Errorf("%s is changed", entry.FullPath)
+		}
+
+		replaced := *current
+		replaced.Chunks = chunks
+		unused, err := f.FindUnusedChunks(current.Chunks, chunks)
+		if err != nil {
+			return err
+		}
+		if err := f.storeEntry(ctx, &replaced, false); err != nil {
+			return fmt.Errorf("update entry %s: %v", entry.FullPath, err)
+		}
+		if err := f.recordReplacedChunks(ctx, entry.FullPath, unused); err != nil {
+			return err
+		}
+
+		f.afterCommit(ctx, func() {
+			f.NotifyUpdateEvent(current, &replaced, true)
+		})
+		return nil
+	})
+}
+
+func (f *Filer) recordReplacedChunks(ctx context.Context, p FullPath, chunks []*filer_pb.FileChunk) error {
+	if len(chunks) == 0 {
+		return nil
+	}
+	if err := f.ensureSystemDirectory(ctx, replacedChunksDirectory); err != nil {
+		return err
+	}
+	now := time.Now()
+	record := &Entry{
+		FullPath: replacedChunksPath(p, now.Add(replacedChunkDeletionDelay)),
+		Attr:     Attr{Mtime: now, Crtime: now, Mode: 0600},
+		Extended: map[string][]byte{replacedPathExtendedKey: []byte(p)},
+		Chunks:   chunks,
+	}
+	if err := f.store.InsertEntry(ctx, record); err != nil {
+		return fmt.Errorf("record replaced chunks of %s: %v", p, err)
+	}
+	return nil
+}
+
+// KeepDeletingReplacedChunks periodically deletes the replaced chunks due for deletion,
+// starting with the ones left by the previous run of the filer.
+func (f *Filer) KeepDeletingReplacedChunks() {
+	for {
+		if err := f.DeleteReplacedChunks(context.Background(), time.Now()); err != nil {
+			glog.Errorf("delete replaced chunks: %v", err)
+		}
+		time.Sleep(replacedChunkCheckInterval)
+	}
+}
+
+// DeleteReplacedChunks deletes the replaced chunks due by now, except the ones used again
+// by the file or its versions, and removes their records.
+func (f *Filer) DeleteReplacedChunks(ctx context.Context, now time.Time) error {
+	lastFileName := ""
+	for {
+		records, err := f.store.ListDirectoryEntries(ctx, replacedChunksDirectory, lastFileName, false, 1024)
+		if err != nil {
+			return fmt.Errorf("list %s: %v", replacedChunksDirectory, err)
+		}
+		for _, record := range records {
+			lastFileName = record.Name()
+			// the records are sorted by the due time
+			if record.Name() > fmt.Sprintf("%019d", now.UnixNano()) {
+				return nil
+			}
+			p := FullPath(record.Extended[replacedPathExtendedKey])
+			unused, err := f.unusedReplacedChunks(ctx, p, record.Chunks)
+			if err != nil {
+				return fmt.Errorf("replaced chunks of %s: %v", p, err)
+			}
+			f.DeleteChunks(unused)
+			if err := f.store.DeleteEntry(ctx, record.FullPath); err != nil {
+				return fmt.Errorf("delete replaced chunks record %s: %v", record.FullPath, err)
+			}
+		}
+		if len(records) < 1024 {
+			return nil
+		}
+	}
+}
+
+func (f *Filer) unusedReplacedChunks(ctx context.Context, p FullPath, chunks []*filer_pb.FileChunk) ([]*filer_pb.FileChunk, error) {
+	entry, err := f.FindEntry(ctx, p)
+	if err == nil {
+		if chunks, err = f.FindUnusedChunks(chunks, entry.Chunks); err != nil {
+			return nil, err
+		}
+	} else if err != ErrNotFound {
+		return nil, err
+	}
+	return f.unusedByVersions(ctx, p, chunks)
+}
@@ -0,0 +1,217 @@
+package filer2_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/filer2/memdb"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+// createFragmentedFile saves the parts as adjacent chunks on the test volume server.
+func createFragmentedFile(t *testing.T, filer *filer2.Filer, volume *testVolumeServer, p string, mtime time.Time, parts ...string) *filer2.Entry {
+	entry := &filer2.Entry{
+		FullPath: filer2.FullPath(p),
+		Attr:     filer2.Attr{Mtime: mtime, Crtime: mtime, Mode: 0660},
+	}
+	offset := int64(0)
+	for _, part := range parts {
+		volume.Lock()
+		fileId := fmt.Sprintf("1,%02x87654321", len(volume.needles)+1)
+		volume.Unlock()
+		volume.put(fileId, []byte(part))
+		entry.Chunks = append(entry.Chunks, &filer_pb.FileChunk{
+			FileId: fileId,
+			Offset: offset,
+			Size:   uint64(len(part)),
+			Mtime:  mtime.UnixNano(),
+		})
+		offset += int64(len(part))
+	}
+	if err := filer.CreateEntry(context.Background(), entry); err != nil {
+		t.Fatalf("create %s: %v", p, err)
+	}
+	return entry
+}
+
+// readChunks concatenates the content of the adjacent chunks from the test volume server.
+func readChunks(volume *testVolumeServer, chunks []*filer_pb.FileChunk) string {
+	var content string
+	for _, chunk := range chunks {
+		data, _ := volume.get(chunk.FileId)
+		content += string(data)
+	}
+	return content
+}
+
+func TestCountDataChunks(t *testing.T) {
+	chunks := []*filer_pb.FileChunk{
+		{FileId: "1,01"},
+		{FileId: "1,02"},
+		{FileId: "1,03", IsChunkManifest: true},
+	}
+	if count := filer2.CountDataChunks(chunks); count != 2+filer2.ManifestBatch {
+		t.Errorf("counted %d data chunks", count)
+	}
+}
+
+func TestRewriteChunk(t *testing.T) {
+	filer, volume, cleanup := newTestFilerWithVolume(t)
+	defer cleanup()
+
+	entry := createFragmentedFile(t, filer, volume, "/data/f", time.Now(), "abc", "def", "ghi")
+
+	chunk, err := filer.RewriteChunk(entry, 2, 7)
+	if err != nil {
+		t.Fatalf("rewrite chunk: %v", err)
+	}
+	if chunk.Offset != 2 || chunk.Size != 5 {
+		t.Errorf("rewritten chunk at %d of %d bytes", chunk.Offset, chunk.Size)
+	}
+	if data, _ := volume.get(chunk.FileId); string(data) != "cdefg" {
+		t.Errorf("rewritten chunk content %q", data)
+	}
+}
+
+func TestDefragmentEntry(t *testing.T) {
+	filer, volume, cleanup := newTestFilerWithVolume(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	original := createFragmentedFile(t, filer, volume, "/data/f", time.Now(), "abc", "def", "ghi", "jkl")
+
+	before, after, err := filer.DefragmentEntry(ctx, "/data/f", 6)
+	if err != nil || before != 4 || after != 2 {
+		t.Fatalf("defragment: %d => %d chunks, %v", before, after, err)
+	}
+	entry, err := filer.FindEntry(ctx, "/data/f")
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if content := readChunks(volume, entry.Chunks); content != "abcdefghijkl" {
+		t.Errorf("defragmented content %q", content)
+	}
+	if !entry.Mtime.Equal(original.Mtime) {
+		t.Errorf("mtime changed from %v to %v", original.Mtime, entry.Mtime)
+	}
+
+	// the replaced chunks are kept for the clients still holding them
+	if content := readChunks(volume, original.Chunks); content != "abcdefghijkl" {
+		t.Errorf("replaced chunks are deleted, left %q", content)
+	}
+
+	// a file with as few chunks as possible is not rewritten
+	if before, after, err := filer.DefragmentEntry(ctx, "/data/f", 6); err != nil || before != 2 || after != 2 {
+		t.Errorf("defragment again: %d => %d chunks, %v", before, after, err)
+	}
+}
+
+func TestReplaceChunks(t *testing.T) {
+	filer, volume, cleanup := newTestFilerWithVolume(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	original := createFragmentedFile(t, filer, volume, "/data/f", time.Now(), "abc", "def")
+	merged, err := filer.RewriteChunk(original, 0, 6)
+	if err != nil {
+		t.Fatalf("rewrite chunk: %v", err)
+	}
+
+	// a changed file is not replaced
+	changed := *original
+	changed.Mode = 0600
+	if err := filer.UpdateEntry(ctx, &changed); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := filer.ReplaceChunks(ctx, original, []*filer_pb.FileChunk{merged}); err == nil {
+		t.Fatalf("replaced the chunks of a changed file")
+	}
+
+	current, _ := filer.FindEntry(ctx, "/data/f")
+	if err := filer.ReplaceChunks(ctx, current, []*filer_pb.FileChunk{merged}); err != nil {
+		t.Fatalf("replace chunks: %v", err)
+	}
+	if unused, err := filer.UnusedReplacedChunks(ctx, "/data/f", original.Chunks); err != nil || len(unused) != 2 {
+		t.Fatalf("unused replaced chunks: %v, %v", unused, err)
+	}
+
+	// a stale client saves the replaced chunks back, so they are not deleted
+	replaced, _ := filer.FindEntry(ctx, "/data/f")
+	stale := *replaced
+	stale.Chunks = original.Chunks
+	if err := filer.UpdateEntry(ctx, &stale); err != nil {
+		t.Fatalf("update with the replaced chunks: %v", err)
+	}
+	if unused, err := filer.UnusedReplacedChunks(ctx, "/data/f", original.Chunks); err != nil || len(unused) != 0 {
+		t.Errorf("unused replaced chunks after saved back: %v, %v", unused, err)
+	}
+}
+
+func TestReplacedChunksDeletedAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	store := &memdb.MemDbStore{}
+	store.Initialize(nil)
+	filer := newTestFilerOn(store)
+
+	if err := filer.CreateEntry(ctx, &filer2.Entry{
+		FullPath: "/data/f",
+		Attr:     filer2.Attr{Mode: 0660},
+		Chunks: []*filer_pb.FileChunk{
+			{FileId: "1,01", Offset: 0, Size: 3},
+			{FileId: "1,02", Offset: 3, Size: 3},
+		},
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	current, _ := filer.FindEntry(ctx, "/data/f")
+	if err := filer.ReplaceChunks(ctx, current, []*filer_pb.FileChunk{{FileId: "1,03", Offset: 0, Size: 6}}); err != nil {
+		t.Fatalf("replace chunks: %v", err)
+	}
+
+	// the pending deletions are kept in the store, and processed by the restarted filer when due
+	restarted := newTestFilerOn(store)
+	expectPending := func(count int) {
+		chunks, err := restarted.PendingReplacedChunks(ctx)
+		if err != nil || len(chunks) != count {
+			t.Fatalf("expected %d pending replaced chunks, but got %v, %v", count, chunks, err)
+		}
+	}
+	expectPending(2)
+	if err := restarted.DeleteReplacedChunks(ctx, time.Now()); err != nil {
+		t.Fatalf("delete replaced chunks: %v", err)
+	}
+	expectPending(2)
+	if err := restarted.DeleteReplacedChunks(ctx, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("delete due replaced chunks: %v", err)
+	}
+	expectPending(0)
+}
+
+func TestDefragmentDirectory(t *testing.T) {
+	filer, volume, cleanup := newTestFilerWithVolume(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	old := time.Now().Add(-2 * time.Hour)
+	createFragmentedFile(t, filer, volume, "/data/old", old, "abc", "def", "ghi")
+	createFragmentedFile(t, filer, volume, "/data/recent", time.Now(), "abc", "def", "ghi")
+	createFragmentedFile(t, filer, volume, "/.trash/0/1/old", old, "abc", "def", "ghi")
+	if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/data/.snapshots", Attr: filer2.Attr{Mode: os.ModeDir | 0770}}); err == nil {
+		t.Fatalf("created a .snapshots directory")
+	}
+
+	files, before, after, err := filer.DefragmentDirectory(ctx, "/", 3, 9)
+	if err != nil || files != 1 || before != 3 || after != 1 {
+		t.Fatalf("defragment directory: %d files %d => %d chunks, %v", files, before, after, err)
+	}
+	for p, count := range map[string]int{"/data/old": 1, "/data/recent": 3, "/.trash/0/1/old": 3} {
+		entry, err := filer.FindEntry(ctx, filer2.FullPath(p))
+		if err != nil || len(entry.Chunks) != count {
+			t.Errorf("%s has %+v, %v", p, entry, err)
+		}
+	}
+}
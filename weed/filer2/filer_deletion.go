@@ -52,37 +52,17 @@ func (f *Filer) loopProcessingDeletion() {
 }
 
 func (f *Filer) DeleteChunks(chunks []*filer_pb.FileChunk) {
+	var fileIds []string
 	for _, chunk := range chunks {
-		f.fileIdDeletionChan <- chunk.FileId
+		fileIds = append(fileIds, chunk.FileId)
+	}
+	for _, fileId := range f.filterPinnedFileIds(fileIds) {
+		f.fileIdDeletionChan <- fileId
 	}
 }
 
 func (f *Filer) DeleteFileByFileId(fileId string) {
-	f.fileIdDeletionChan <- fileId
-}
-
-func (f *Filer) deleteChunksIfNotNew(oldEntry, newEntry *Entry) {
-
-	if oldEntry == nil {
-		return
-	}
-	if newEntry == nil {
-		f.DeleteChunks(oldEntry.Chunks)
-	}
-
-	var toDelete []*filer_pb.FileChunk
-
-	for _, oldChunk := range oldEntry.Chunks {
-		found := false
-		for _, newChunk := range newEntry.Chunks {
-			if oldChunk.FileId == newChunk.FileId {
-				found = true
-				break
-			}
-		}
-		if !found {
-			toDelete = append(toDelete, oldChunk)
-		}
+	for _, fileId := range f.filterPinnedFileIds([]string{fileId}) {
+		f.fileIdDeletionChan <- fileId
 	}
-	f.DeleteChunks(toDelete)
 }
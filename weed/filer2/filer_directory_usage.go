@@ -0,0 +1,129 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/karlseguin/ccache"
+)
+
+// directoryUsageCacheTtl expires the cached usages, which miss the changes made by the other filers on the same store.
+var directoryUsageCacheTtl = time.Minute
+
+// directoryUsageTimeout stops counting a large tree, e.g. the whole filer.
+var directoryUsageTimeout = 5 * time.Minute
+
+// DirectoryUsage is the aggregated usage of a directory tree, not counting the directory itself.
+type DirectoryUsage struct {
+	Bytes          uint64
+	FileCount      uint64
+	DirectoryCount uint64
+}
+
+func (usage *DirectoryUsage) add(other *DirectoryUsage) {
+	usage.Bytes += other.Bytes
+	usage.FileCount += other.FileCount
+	usage.DirectoryCount += other.DirectoryCount
+}
+
+// directoryUsages caches the usages of the directories, which are computed on demand, in a bounded lru cache.
+// Each entry change on this filer drops the cached usages of its parent directories and of the entry itself.
+// Deleting or moving a directory changes each entry under it, so the usages of its sub directories are dropped too.
+// The generation is bumped by each entry change, so the usages counted during a change are not cached.
+type directoryUsages struct {
+	sync.Mutex
+	generation uint64
+	cache      *ccache.Cache
+}
+
+// DirectoryUsage returns the total bytes, file count and directory count under the directory,
+// skipping the snapshots and the filer system directories.
+// The counting fails if it takes longer than directoryUsageTimeout.
+func (f *Filer) DirectoryUsage(ctx context.Context, dir FullPath) (*DirectoryUsage, error) {
+	ctx, cancel := context.WithTimeout(ctx, directoryUsageTimeout)
+	defer cancel()
+
+	if dir != "/" {
+		entry, err := f.FindEntry(ctx, dir)
+		if err != nil {
+			return nil, err
+		}
+		if !entry.IsDirectory() {
+			return nil, fmt.Errorf("%s is not a directory", dir)
+		}
+	}
+	return f.directoryUsage(ctx, dir)
+}
+
+func (f *Filer) directoryUsage(ctx context.Context, dir FullPath) (*DirectoryUsage, error) {
+
+	f.usages.Lock()
+	generation := f.usages.generation
+	f.usages.Unlock()
+	if item := f.usages.cache.Get(string(dir)); item != nil {
+		if !item.Expired() {
+			copied := *item.Value().(*DirectoryUsage)
+			return &copied, nil
+		}
+		f.usages.cache.Delete(string(dir))
+	}
+
+	usage := &DirectoryUsage{}
+	err := f.eachEntry(ctx, dir, func(entry *Entry) error {
+		if ctx.Err() != nil {
+			return fmt.Errorf("usage of %s: %v", dir, ctx.Err())
+		}
+		if isSkippedByWalk(entry.FullPath) {
+			return nil
+		}
+		if !entry.IsDirectory() {
+			usage.Bytes += entry.Size()
+			usage.FileCount++
+			return nil
+		}
+		subUsage, err := f.directoryUsage(ctx, entry.FullPath)
+		if err != nil {
+			return err
+		}
+		usage.add(subUsage)
+		usage.DirectoryCount++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f.usages.Lock()
+	if f.usages.generation == generation {
+		copied := *usage
+		f.usages.cache.Set(string(dir), &copied, directoryUsageCacheTtl)
+	}
+	f.usages.Unlock()
+
+	return usage, nil
+}
+
+// invalidateDirectoryUsage drops the cached usages changed by replacing the old entry with the new entry.
+func (f *Filer) invalidateDirectoryUsage(oldEntry, newEntry *Entry) {
+
+	f.usages.Lock()
+	defer f.usages.Unlock()
+
+	f.usages.generation++
+
+	for _, entry := range []*Entry{oldEntry, newEntry} {
+		if entry == nil {
+			continue
+		}
+		for p := entry.FullPath; p != "/"; {
+			parent, _ := p.DirAndName()
+			p = FullPath(parent)
+			f.usages.cache.Delete(string(p))
+		}
+		if entry.IsDirectory() {
+			f.usages.cache.Delete(string(entry.FullPath))
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package filer2_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/filer2/memdb"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestDirectoryUsage(t *testing.T) {
+	ctx := context.Background()
+	filer := newTestFiler()
+
+	write := func(p string, size uint64) {
+		if err := filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr:     filer2.Attr{Mode: 0644},
+			Chunks:   []*filer_pb.FileChunk{{FileId: p, Size: size}},
+		}); err != nil {
+			t.Fatalf("write %s: %v", p, err)
+		}
+	}
+	expectUsage := func(dir string, bytes, files, dirs uint64) {
+		usage, err := filer.DirectoryUsage(ctx, filer2.FullPath(dir))
+		if err != nil {
+			t.Fatalf("usage of %s: %v", dir, err)
+		}
+		if usage.Bytes != bytes || usage.FileCount != files || usage.DirectoryCount != dirs {
+			t.Fatalf("unexpected usage of %s: %+v", dir, usage)
+		}
+	}
+
+	write("/home/a/1.txt", 10)
+	write("/home/a/b/2.txt", 20)
+	write("/home/c/3.txt", 30)
+	expectUsage("/home", 60, 3, 3)
+	expectUsage("/home/a", 30, 2, 1)
+
+	// the cached usages are dropped by the changes under the directory
+	write("/home/a/b/4.txt", 40)
+	expectUsage("/home", 100, 4, 3)
+	if err := filer.DeleteEntryMetaAndData(ctx, "/home/a", true, false); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	expectUsage("/home", 30, 1, 1)
+	write("/home/a/5.txt", 50)
+	expectUsage("/home/a", 50, 1, 0)
+
+	// moving a directory drops the cached usages of its sub directories
+	write("/home/a/d/6.txt", 60)
+	write("/new/d/7.txt", 70)
+	expectUsage("/home/a", 110, 2, 1)
+	if err := filer.AtomicRenameEntry(ctx, "/home/a", "/old"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if err := filer.AtomicRenameEntry(ctx, "/new", "/home/a"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	expectUsage("/home/a", 70, 1, 1)
+	expectUsage("/old", 110, 2, 1)
+	if err := filer.AtomicRenameEntry(ctx, "/old", "/home/a/old"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	expectUsage("/home/a", 180, 3, 3)
+	if err := filer.DeleteEntryMetaAndData(ctx, "/home/a/old", true, false); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	expectUsage("/home/a", 70, 1, 1)
+
+	// the snapshots are not counted
+	if err := filer.CreateSnapshot(ctx, "/home", "s1"); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	expectUsage("/home", 100, 2, 3)
+
+	if _, err := filer.DirectoryUsage(ctx, "/home/a/5.txt"); err == nil {
+		t.Fatalf("expected an error for the usage of a file")
+	}
+}
+
+func TestDirectoryUsageCacheTtl(t *testing.T) {
+	ctx := context.Background()
+	store := &memdb.MemDbStore{}
+	store.Initialize(nil)
+	filer := newTestFilerOn(store)
+
+	write := func(p string) {
+		if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: filer2.FullPath(p), Attr: filer2.Attr{Mode: 0644}}); err != nil {
+			t.Fatalf("write %s: %v", p, err)
+		}
+	}
+	expectFileCount := func(files uint64) {
+		usage, err := filer.DirectoryUsage(ctx, "/home")
+		if err != nil || usage.FileCount != files {
+			t.Fatalf("expected %d files, but got %+v, %v", files, usage, err)
+		}
+	}
+
+	defer filer2.SetDirectoryUsageCacheTtl(50 * time.Millisecond)()
+	write("/home/1.txt")
+	expectFileCount(1)
+
+	// a change by another filer on the same store is not notified, and is counted after the ttl
+	if err := store.InsertEntry(ctx, &filer2.Entry{FullPath: "/home/2.txt", Attr: filer2.Attr{Mode: 0644}}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	expectFileCount(2)
+}
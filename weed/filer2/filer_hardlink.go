@@ -0,0 +1,231 @@
+package filer2
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+)
+
+// HardLinkDirectory keeps one hard link record for each group of hard linked files,
+// named by the hard link id. The record has the shared attributes, extended attributes,
+// chunks, and the number of links. Each hard linked entry only saves the hard link id.
+// The records are normal entries, so every filer store supports hard links,
+// and the records are also copied when migrating or backing up the filer store.
+const HardLinkDirectory = FullPath("/.hardlinks")
+
+func HardLinkPath(hardLinkId string) FullPath {
+	return NewFullPath(string(HardLinkDirectory), hardLinkId)
+}
+
+func IsHardLinkRecord(p FullPath) bool {
+	return p == HardLinkDirectory || strings.HasPrefix(string(p), string(HardLinkDirectory)+"/")
+}
+
+func newHardLinkId() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+var ErrHardLinkConflict = errors.New("the hard link is being changed by another request")
+
+// hardLinkLockTimeout is how long to wait for another transaction changing the same hard link record.
+var hardLinkLockTimeout = 10 * time.Second
+
+// lockHardLink locks the hard link record for the current transaction, or fails with
+// ErrHardLinkConflict if another transaction does not release it in time.
+func (f *Filer) lockHardLink(ctx context.Context, hardLinkId string) error {
+	if err := f.lockRecord(ctx, HardLinkPath(hardLinkId), hardLinkLockTimeout); err == errRecordLockTimeout {
+		return fmt.Errorf("hard link %s: %v", hardLinkId, ErrHardLinkConflict)
+	} else if err != nil {
+		return fmt.Errorf("hard link %s: %v", hardLinkId, err)
+	}
+	return nil
+}
+
+// CreateHardLink adds the new path as another link to the file at the old path.
+func (f *Filer) CreateHardLink(ctx context.Context, oldPath, newPath FullPath) error {
+
+	if IsHardLinkRecord(oldPath) || IsHardLinkRecord(newPath) || IsSnapshotIndex(newPath) {
+		return fmt.Errorf("can not link %s to %s", oldPath, newPath)
+	}
+	if err := f.checkSnapshotPath(ctx, newPath); err != nil {
+		return fmt.Errorf("link to %s: %v", newPath, err)
+	}
+
+	return f.withTransaction(ctx, func(ctx context.Context) error {
+
+		oldEntry, err := f.FindEntry(ctx, oldPath)
+		if err != nil {
+			return fmt.Errorf("link %s: %v", oldPath, err)
+		}
+		if oldEntry.IsDirectory() {
+			return fmt.Errorf("can not link directory %s", oldPath)
+		}
+
+		if _, err := f.FindEntry(ctx, newPath); err == nil {
+			return fmt.Errorf("link to %s: already exists", newPath)
+		} else if err != ErrNotFound {
+			return fmt.Errorf("link to %s: %v", newPath, err)
+		}
+
+		if err := f.ensureParentDirectory(ctx, newPath, oldEntry); err != nil {
+			return fmt.Errorf("link to %s: %v", newPath, err)
+		}
+
+		if err := f.updateQuotaUsage(ctx, newPath, nil, oldEntry); err != nil {
+			return err
+		}
+
+		if oldEntry.HardLinkId == "" {
+			// the first hard link moves the file content to a new hard link record
+			oldEntry.HardLinkId = newHardLinkId()
+			oldEntry.HardLinkCounter = 2
+			if err := f.ensureParentDirectory(ctx, HardLinkPath(oldEntry.HardLinkId), oldEntry); err != nil {
+				return err
+			}
+			if err := f.storeEntry(ctx, oldEntry, false); err != nil {
+				return fmt.Errorf("link %s: %v", oldPath, err)
+			}
+		} else {
+			if oldEntry.HardLinkCounter, err = f.addHardLinkCounter(ctx, oldEntry.HardLinkId, 1); err != nil {
+				return fmt.Errorf("link %s: %v", oldPath, err)
+			}
+		}
+
+		newEntry := &Entry{
+			FullPath:        newPath,
+			Attr:            oldEntry.Attr,
+			Extended:        oldEntry.Extended,
+			Chunks:          oldEntry.Chunks,
+			Content:         oldEntry.Content,
+			HardLinkId:      oldEntry.HardLinkId,
+			HardLinkCounter: oldEntry.HardLinkCounter,
+		}
+		if err := f.storeEntry(ctx, newEntry, true); err != nil {
+			return fmt.Errorf("link to %s: %v", newPath, err)
+		}
+
+		f.afterCommit(ctx, func() {
+			f.NotifyUpdateEvent(nil, newEntry, false)
+		})
+
+		return nil
+	})
+}
+
+// storeEntry saves the entry to the filer store.
+// For a hard linked entry, the content goes to the hard link record, keeping its link counter,
+// and the entry itself only keeps the attributes and the hard link id.
+func (f *Filer) storeEntry(ctx context.Context, entry *Entry, isNew bool) error {
+
+	if err := f.preserveForSnapshots(ctx, entry.FullPath); err != nil {
+		return err
+	}
+
+	if entry.HardLinkId == "" {
+		if isNew {
+			return f.store.InsertEntry(ctx, entry)
+		}
+		return f.store.UpdateEntry(ctx, entry)
+	}
+
+	record := &Entry{
+		FullPath:        HardLinkPath(entry.HardLinkId),
+		Attr:            entry.Attr,
+		Extended:        entry.Extended,
+		Chunks:          entry.Chunks,
+		Content:         entry.Content,
+		HardLinkCounter: entry.HardLinkCounter,
+	}
+	if err := f.lockHardLink(ctx, entry.HardLinkId); err != nil {
+		return err
+	}
+	existingRecord, err := f.store.FindEntry(ctx, record.FullPath)
+	if err == ErrNotFound {
+		if record.HardLinkCounter < 1 {
+			record.HardLinkCounter = 1
+		}
+		err = f.store.InsertEntry(ctx, record)
+	} else if err == nil {
+		record.HardLinkCounter = existingRecord.HardLinkCounter
+		err = f.store.UpdateEntry(ctx, record)
+	}
+	if err != nil {
+		return fmt.Errorf("save hard link %s: %v", entry.HardLinkId, err)
+	}
+
+	link := &Entry{
+		FullPath:   entry.FullPath,
+		Attr:       entry.Attr,
+		HardLinkId: entry.HardLinkId,
+	}
+	if isNew {
+		return f.store.InsertEntry(ctx, link)
+	}
+	return f.store.UpdateEntry(ctx, link)
+}
+
+// resolveHardLink returns a copy of the hard linked entry, with the content from the hard link record.
+func (f *Filer) resolveHardLink(ctx context.Context, entry *Entry) (*Entry, error) {
+
+	record, err := f.store.FindEntry(ctx, HardLinkPath(entry.HardLinkId))
+	if err != nil {
+		return nil, fmt.Errorf("hard link %s of %s: %v", entry.HardLinkId, entry.FullPath, err)
+	}
+
+	return &Entry{
+		FullPath:        entry.FullPath,
+		Attr:            record.Attr,
+		Extended:        record.Extended,
+		Chunks:          record.Chunks,
+		Content:         record.Content,
+		HardLinkId:      entry.HardLinkId,
+		HardLinkCounter: record.HardLinkCounter,
+	}, nil
+}
+
+// addHardLinkCounter changes the number of links in the hard link record.
+func (f *Filer) addHardLinkCounter(ctx context.Context, hardLinkId string, delta int32) (counter int32, err error) {
+
+	if err := f.lockHardLink(ctx, hardLinkId); err != nil {
+		return 0, err
+	}
+
+	record, err := f.store.FindEntry(ctx, HardLinkPath(hardLinkId))
+	if err != nil {
+		return 0, fmt.Errorf("hard link %s: %v", hardLinkId, err)
+	}
+
+	// copy before changing it, in case the store returns the saved entry
+	updated := *record
+	updated.HardLinkCounter += delta
+
+	return updated.HardLinkCounter, f.store.UpdateEntry(ctx, &updated)
+}
+
+// unlinkHardLink removes one link from the hard link record, and
+// deletes the record when the last link is gone.
+func (f *Filer) unlinkHardLink(ctx context.Context, entry *Entry) (isLastLink bool, err error) {
+
+	counter, err := f.addHardLinkCounter(ctx, entry.HardLinkId, -1)
+	if err != nil {
+		return false, err
+	}
+	if counter > 0 {
+		return false, nil
+	}
+
+	glog.V(3).Infof("deleting hard link record %s of %s", entry.HardLinkId, entry.FullPath)
+	if err := f.store.DeleteEntry(ctx, HardLinkPath(entry.HardLinkId)); err != nil {
+		return false, fmt.Errorf("delete hard link %s: %v", entry.HardLinkId, err)
+	}
+
+	return true, nil
+}
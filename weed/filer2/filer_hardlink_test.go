@@ -0,0 +1,163 @@
+package filer2_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestHardLink(t *testing.T) {
+	ctx := context.Background()
+	filer := newTestFiler()
+
+	entry1 := &filer2.Entry{
+		FullPath: filer2.FullPath("/home/chris/file1.jpg"),
+		Attr: filer2.Attr{
+			Mode: 0440,
+			Uid:  1234,
+			Gid:  5678,
+		},
+		Extended: map[string][]byte{"user.tag": []byte("a")},
+	}
+	if err := filer.CreateEntry(ctx, entry1); err != nil {
+		t.Fatalf("create entry %v: %v", entry1.FullPath, err)
+	}
+
+	if err := filer.CreateHardLink(ctx, "/home/chris/file1.jpg", "/home/other/file2.jpg"); err != nil {
+		t.Fatalf("link: %v", err)
+	}
+
+	entry2, err := filer.FindEntry(ctx, "/home/other/file2.jpg")
+	if err != nil {
+		t.Fatalf("find link: %v", err)
+	}
+	if entry2.HardLinkCounter != 2 || string(entry2.Extended["user.tag"]) != "a" {
+		t.Fatalf("unexpected link %+v", entry2)
+	}
+
+	// a change through one link is visible through the other
+	entry2.Extended = map[string][]byte{"user.tag": []byte("b")}
+	if err := filer.UpdateEntry(ctx, entry2); err != nil {
+		t.Fatalf("update link: %v", err)
+	}
+	entry1, err = filer.FindEntry(ctx, "/home/chris/file1.jpg")
+	if err != nil {
+		t.Fatalf("find entry: %v", err)
+	}
+	if string(entry1.Extended["user.tag"]) != "b" {
+		t.Fatalf("change not shared: %+v", entry1)
+	}
+
+	if err := filer.DeleteEntryMetaAndData(ctx, "/home/chris/file1.jpg", false, false); err != nil {
+		t.Fatalf("delete first link: %v", err)
+	}
+	entry2, err = filer.FindEntry(ctx, "/home/other/file2.jpg")
+	if err != nil || entry2.HardLinkCounter != 1 {
+		t.Fatalf("remaining link %+v: %v", entry2, err)
+	}
+
+	if err := filer.DeleteEntryMetaAndData(ctx, "/home/other/file2.jpg", false, false); err != nil {
+		t.Fatalf("delete last link: %v", err)
+	}
+	if _, err := filer.FindEntry(ctx, filer2.HardLinkPath(entry2.HardLinkId)); err != filer2.ErrNotFound {
+		t.Fatalf("hard link record is not deleted: %v", err)
+	}
+}
+
+func TestHardLinkLock(t *testing.T) {
+	ctx := context.Background()
+	filer := newTestFiler()
+	filer2.SetHardLinkLockTimeout(50 * time.Millisecond)
+	defer filer2.SetHardLinkLockTimeout(10 * time.Second)
+
+	if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/data/file", Attr: filer2.Attr{Mode: 0440}}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := filer.CreateHardLink(ctx, "/data/file", "/data/link1"); err != nil {
+		t.Fatalf("link: %v", err)
+	}
+	entry, _ := filer.FindEntry(ctx, "/data/file")
+
+	err := filer.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := filer.LockHardLink(ctx, entry.HardLinkId); err != nil {
+			return err
+		}
+		// the same transaction can take the lock again
+		if err := filer.LockHardLink(ctx, entry.HardLinkId); err != nil {
+			return err
+		}
+		// another transaction changing the link counter fails
+		done := make(chan error)
+		go func() {
+			done <- filer.CreateHardLink(context.Background(), "/data/file", "/data/link2")
+		}()
+		if err := <-done; !strings.Contains(fmt.Sprint(err), filer2.ErrHardLinkConflict.Error()) {
+			t.Errorf("expected a conflict, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("transaction: %v", err)
+	}
+
+	// the lock is released with the transaction
+	if err := filer.CreateHardLink(ctx, "/data/file", "/data/link2"); err != nil {
+		t.Fatalf("link after the transaction: %v", err)
+	}
+	if entry, err := filer.FindEntry(ctx, "/data/file"); err != nil || entry.HardLinkCounter != 3 {
+		t.Errorf("links counted: %+v, %v", entry, err)
+	}
+}
+
+func TestHardLinkMetaLog(t *testing.T) {
+	ctx := context.Background()
+	filer := newTestFiler()
+	dir, _ := ioutil.TempDir("", "seaweedfs_meta_log_test")
+	defer os.RemoveAll(dir)
+	metaLog, err := filer2.NewMetaLog(dir)
+	if err != nil {
+		t.Fatalf("create meta log: %v", err)
+	}
+	filer.MetaLog = metaLog
+
+	if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/home/file1.jpg", Attr: filer2.Attr{Mode: 0440}}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := filer.CreateHardLink(ctx, "/home/file1.jpg", "/home/file2.jpg"); err != nil {
+		t.Fatalf("link: %v", err)
+	}
+	if err := filer.DeleteEntryMetaAndData(ctx, "/home/file2.jpg", false, false); err != nil {
+		t.Fatalf("delete link: %v", err)
+	}
+
+	var events []*filer_pb.EventNotification
+	subscribeCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	metaLog.Subscribe(subscribeCtx, 0, func(resp *filer_pb.SubscribeMetadataResponse) error {
+		events = append(events, resp.EventNotification)
+		return nil
+	})
+
+	// the hard link record changes are logged after the entry events of the same change
+	deletedAt, lastRecord := -1, -1
+	var lastRecordCounter int32
+	for i, event := range events {
+		if event.OldEntry != nil && event.NewEntry == nil && event.OldEntry.Name == "/home/file2.jpg" {
+			deletedAt = i
+		}
+		if event.NewEntry != nil && filer2.IsHardLinkRecord(filer2.FullPath(event.NewEntry.Name)) {
+			lastRecord, lastRecordCounter = i, event.NewEntry.HardLinkCounter
+		}
+	}
+	if deletedAt < 0 || lastRecord < deletedAt || lastRecordCounter != 1 {
+		t.Fatalf("expected the record with 1 link after deleting the link, but got the record %d with %d links, the delete %d",
+			lastRecord, lastRecordCounter, deletedAt)
+	}
+}
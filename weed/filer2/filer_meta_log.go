@@ -0,0 +1,287 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/draleyva/seaweedfs/weed/util"
+	"github.com/golang/protobuf/proto"
+)
+
+const (
+	metaLogSegmentMaxSize = 64 * 1024 * 1024
+	metaLogSegmentMaxAge  = time.Hour
+	metaLogRetention      = 7 * 24 * time.Hour
+	metaLogSegmentSuffix  = ".log"
+)
+
+// MetaLog keeps the metadata change events in local append-only segment files.
+// Each segment is named after the timestamp of its first event, and each record
+// is a 4-byte length followed by a marshalled SubscribeMetadataResponse.
+type MetaLog struct {
+	sync.Mutex
+	dir         string
+	segment     *os.File
+	segmentTsNs int64
+	segmentSize int64
+	lastTsNs    int64
+	updated     chan struct{}
+}
+
+func NewMetaLog(dir string) (*MetaLog, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create metadata log dir %s: %v", dir, err)
+	}
+	l := &MetaLog{
+		dir:     dir,
+		updated: make(chan struct{}),
+	}
+
+	segments, err := l.listSegments()
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) > 0 {
+		last := segments[len(segments)-1]
+		if _, l.lastTsNs, err = l.readSegment(last, 0, last-1, func(*filer_pb.SubscribeMetadataResponse) error {
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return l, nil
+}
+
+// AppendEntry persists one event and wakes up the subscribers.
+func (l *MetaLog) AppendEntry(dir string, event *filer_pb.EventNotification) error {
+	l.Lock()
+	defer l.Unlock()
+
+	tsNs := time.Now().UnixNano()
+	if tsNs <= l.lastTsNs {
+		tsNs = l.lastTsNs + 1
+	}
+
+	data, err := proto.Marshal(&filer_pb.SubscribeMetadataResponse{
+		Directory:         dir,
+		EventNotification: event,
+		TsNs:              tsNs,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal metadata event: %v", err)
+	}
+
+	if err = l.maybeRollover(tsNs); err != nil {
+		return err
+	}
+
+	n, err := writeMetaLogRecord(l.segment, data)
+	if err != nil {
+		return fmt.Errorf("write metadata log %s: %v", l.segment.Name(), err)
+	}
+	l.segmentSize += int64(n)
+	l.lastTsNs = tsNs
+
+	close(l.updated)
+	l.updated = make(chan struct{})
+
+	return nil
+}
+
+// Subscribe calls fn for each event after sinceNs, first for the events already in the log,
+// and then for new events as they are appended, until fn returns an error or ctx is done.
+func (l *MetaLog) Subscribe(ctx context.Context, sinceNs int64, fn func(*filer_pb.SubscribeMetadataResponse) error) error {
+
+	segmentTsNs, offset, lastTsNs := int64(-1), int64(0), sinceNs
+
+	for {
+		l.Lock()
+		updated := l.updated
+		l.Unlock()
+
+		segments, err := l.listSegments()
+		if err != nil {
+			return err
+		}
+
+		for i, segment := range segments {
+			if segment < segmentTsNs {
+				continue
+			}
+			if segmentTsNs < 0 && i+1 < len(segments) && segments[i+1] <= sinceNs {
+				// all events in this segment are before sinceNs
+				continue
+			}
+			if segment > segmentTsNs {
+				segmentTsNs, offset = segment, 0
+			}
+			if offset, lastTsNs, err = l.readSegment(segment, offset, lastTsNs, fn); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// readSegment calls fn for the events after lastTsNs, starting from the offset in the segment.
+// It stops at the end of the segment or at a partially written record,
+// and returns the offset to continue from.
+func (l *MetaLog) readSegment(segmentTsNs, offset, lastTsNs int64, fn func(*filer_pb.SubscribeMetadataResponse) error) (int64, int64, error) {
+
+	file, err := os.Open(l.segmentFileName(segmentTsNs))
+	if os.IsNotExist(err) {
+		// removed after the retention period
+		return offset, lastTsNs, nil
+	}
+	if err != nil {
+		return offset, lastTsNs, err
+	}
+	defer file.Close()
+
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		return offset, lastTsNs, err
+	}
+
+	header := make([]byte, 4)
+	for {
+		if _, err = io.ReadFull(file, header); err != nil {
+			return offset, lastTsNs, nil
+		}
+		data := make([]byte, util.BytesToUint32(header))
+		if _, err = io.ReadFull(file, data); err != nil {
+			return offset, lastTsNs, nil
+		}
+		resp := &filer_pb.SubscribeMetadataResponse{}
+		if err = proto.Unmarshal(data, resp); err != nil {
+			return offset, lastTsNs, fmt.Errorf("unmarshal metadata log %s at %d: %v", file.Name(), offset, err)
+		}
+		offset += int64(4 + len(data))
+		if resp.TsNs <= lastTsNs {
+			continue
+		}
+		if err = fn(resp); err != nil {
+			return offset, lastTsNs, err
+		}
+		lastTsNs = resp.TsNs
+	}
+}
+
+// WriteMetaLogRecord writes one event in the metadata log record format.
+func WriteMetaLogRecord(w io.Writer, resp *filer_pb.SubscribeMetadataResponse) error {
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("marshal metadata event: %v", err)
+	}
+	_, err = writeMetaLogRecord(w, data)
+	return err
+}
+
+// ReadMetaLogRecords calls fn for each event in the metadata log record format,
+// and returns the size of the complete records. A partially written record at the end is ignored.
+func ReadMetaLogRecords(r io.Reader, fn func(*filer_pb.SubscribeMetadataResponse) error) (size int64, err error) {
+	header := make([]byte, 4)
+	for {
+		if _, err = io.ReadFull(r, header); err != nil {
+			return size, nil
+		}
+		data := make([]byte, util.BytesToUint32(header))
+		if _, err = io.ReadFull(r, data); err != nil {
+			return size, nil
+		}
+		resp := &filer_pb.SubscribeMetadataResponse{}
+		if err = proto.Unmarshal(data, resp); err != nil {
+			return size, fmt.Errorf("unmarshal metadata event at %d: %v", size, err)
+		}
+		if err = fn(resp); err != nil {
+			return size, err
+		}
+		size += int64(4 + len(data))
+	}
+}
+
+// writeMetaLogRecord writes the length and the data with one write, so concurrent readers
+// of the file see either a partial record at the end, or the complete record.
+func writeMetaLogRecord(w io.Writer, data []byte) (int, error) {
+	record := make([]byte, 4+len(data))
+	util.Uint32toBytes(record[0:4], uint32(len(data)))
+	copy(record[4:], data)
+	return w.Write(record)
+}
+
+func (l *MetaLog) maybeRollover(tsNs int64) error {
+	if l.segment != nil && l.segmentSize < metaLogSegmentMaxSize && tsNs-l.segmentTsNs < int64(metaLogSegmentMaxAge) {
+		return nil
+	}
+	if l.segment != nil {
+		l.segment.Close()
+		l.segment = nil
+	}
+
+	segment, err := os.OpenFile(l.segmentFileName(tsNs), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("create metadata log segment: %v", err)
+	}
+	l.segment, l.segmentTsNs, l.segmentSize = segment, tsNs, 0
+
+	l.removeExpiredSegments(tsNs - int64(metaLogRetention))
+
+	return nil
+}
+
+// removeExpiredSegments removes the segments whose events are all before the cutoff time.
+func (l *MetaLog) removeExpiredSegments(cutoffTsNs int64) {
+	segments, err := l.listSegments()
+	if err != nil {
+		glog.Errorf("list metadata log segments: %v", err)
+		return
+	}
+	for i := 0; i+1 < len(segments) && segments[i+1] < cutoffTsNs; i++ {
+		glog.V(1).Infof("remove expired metadata log segment %s", l.segmentFileName(segments[i]))
+		if err := os.Remove(l.segmentFileName(segments[i])); err != nil {
+			glog.Errorf("remove metadata log segment: %v", err)
+		}
+	}
+}
+
+func (l *MetaLog) listSegments() (segments []int64, err error) {
+	fileInfos, err := ioutil.ReadDir(l.dir)
+	if err != nil {
+		return nil, fmt.Errorf("list metadata log dir %s: %v", l.dir, err)
+	}
+	for _, fileInfo := range fileInfos {
+		name := fileInfo.Name()
+		if fileInfo.IsDir() || !strings.HasSuffix(name, metaLogSegmentSuffix) {
+			continue
+		}
+		tsNs, parseErr := strconv.ParseInt(strings.TrimSuffix(name, metaLogSegmentSuffix), 10, 64)
+		if parseErr != nil {
+			continue
+		}
+		segments = append(segments, tsNs)
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i] < segments[j]
+	})
+	return segments, nil
+}
+
+func (l *MetaLog) segmentFileName(tsNs int64) string {
+	return filepath.Join(l.dir, fmt.Sprintf("%019d%s", tsNs, metaLogSegmentSuffix))
+}
@@ -0,0 +1,74 @@
+package filer2
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestMetaLogSubscribe(t *testing.T) {
+
+	dir, _ := ioutil.TempDir("", "seaweedfs_meta_log_test")
+	defer os.RemoveAll(dir)
+
+	metaLog, err := NewMetaLog(dir)
+	if err != nil {
+		t.Fatalf("create meta log: %v", err)
+	}
+
+	for _, name := range []string{"/a/1", "/a/2", "/b/3"} {
+		if err := metaLog.AppendEntry("/", &filer_pb.EventNotification{
+			NewEntry: &filer_pb.Entry{Name: name},
+		}); err != nil {
+			t.Fatalf("append %s: %v", name, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var names []string
+	var secondTsNs int64
+	metaLog.Subscribe(ctx, 0, func(resp *filer_pb.SubscribeMetadataResponse) error {
+		names = append(names, resp.EventNotification.NewEntry.Name)
+		if len(names) == 2 {
+			secondTsNs = resp.TsNs
+		}
+		if len(names) == 3 {
+			return context.Canceled
+		}
+		return nil
+	})
+	if len(names) != 3 || names[0] != "/a/1" || names[2] != "/b/3" {
+		t.Fatalf("unexpected events: %v", names)
+	}
+
+	// reopen the log, and resume after the second event
+	metaLog, err = NewMetaLog(dir)
+	if err != nil {
+		t.Fatalf("reopen meta log: %v", err)
+	}
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		metaLog.AppendEntry("/", &filer_pb.EventNotification{
+			NewEntry: &filer_pb.Entry{Name: "/c/4"},
+		})
+	}()
+
+	names = nil
+	metaLog.Subscribe(ctx, secondTsNs, func(resp *filer_pb.SubscribeMetadataResponse) error {
+		names = append(names, resp.EventNotification.NewEntry.Name)
+		if len(names) == 2 {
+			return context.Canceled
+		}
+		return nil
+	})
+	if len(names) != 2 || names[0] != "/b/3" || names[1] != "/c/4" {
+		t.Fatalf("unexpected resumed events: %v", names)
+	}
+
+}
@@ -1,12 +1,16 @@
 package filer2
 
 import (
+	"context"
+
 	"github.com/draleyva/seaweedfs/weed/glog"
 	"github.com/draleyva/seaweedfs/weed/notification"
 	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
 )
 
 func (f *Filer) NotifyUpdateEvent(oldEntry, newEntry *Entry, deleteChunks bool) {
+	f.invalidateDirectoryUsage(oldEntry, newEntry)
+
 	var key string
 	if oldEntry != nil {
 		key = string(oldEntry.FullPath)
@@ -16,18 +20,96 @@ func (f *Filer) NotifyUpdateEvent(oldEntry, newEntry *Entry, deleteChunks bool)
 		return
 	}
 
+	eventNotification := &filer_pb.EventNotification{
+		OldEntry:     oldEntry.ToProtoEntry(),
+		NewEntry:     newEntry.ToProtoEntry(),
+		DeleteChunks: deleteChunks,
+	}
+
 	if notification.Queue != nil {
 
 		glog.V(3).Infof("notifying entry update %v", key)
 
-		notification.Queue.SendMessage(
-			key,
-			&filer_pb.EventNotification{
-				OldEntry:     oldEntry.ToProtoEntry(),
-				NewEntry:     newEntry.ToProtoEntry(),
-				DeleteChunks: deleteChunks,
-			},
-		)
+		notification.Queue.SendMessage(key, eventNotification)
+
+	}
+
+	if f.MetaLog != nil {
+		dir, _ := FullPath(key).DirAndName()
+		if err := f.MetaLog.AppendEntry(dir, eventNotification); err != nil {
+			glog.Errorf("append metadata log for %s: %v", key, err)
+		}
+	}
+}
 
+// isSystemEntry checks whether the path is kept by the filer itself, and changed without the update events:
+// the hard link records, the quotas, the snapshot index and contents, and the versions.
+func isSystemEntry(p FullPath) bool {
+	return IsHardLinkRecord(p) || IsQuotaRecord(p) || IsSnapshotIndex(p) || IsSnapshotPath(p) || IsVersionPath(p)
+}
+
+// systemEntryLoggingStore logs the changes of the system entries to the metadata log, so the metadata backups
+// have them. They are not sent to the notification queue, which is for the files and directories.
+type systemEntryLoggingStore struct {
+	FilerStore
+	filer *Filer
+}
+
+func (store *systemEntryLoggingStore) InsertEntry(ctx context.Context, entry *Entry) error {
+	if err := store.FilerStore.InsertEntry(ctx, entry); err != nil {
+		return err
+	}
+	store.filer.addSystemEntryEvent(ctx, nil, entry)
+	return nil
+}
+
+func (store *systemEntryLoggingStore) UpdateEntry(ctx context.Context, entry *Entry) error {
+	if err := store.FilerStore.UpdateEntry(ctx, entry); err != nil {
+		return err
+	}
+	store.filer.addSystemEntryEvent(ctx, nil, entry)
+	return nil
+}
+
+func (store *systemEntryLoggingStore) DeleteEntry(ctx context.Context, p FullPath) error {
+	if err := store.FilerStore.DeleteEntry(ctx, p); err != nil {
+		return err
+	}
+	store.filer.addSystemEntryEvent(ctx, &Entry{FullPath: p}, nil)
+	return nil
+}
+
+// addSystemEntryEvent logs the change of a system entry after the transaction is committed,
+// following the events of the transaction, so replaying the log ends with the saved system entries.
+func (f *Filer) addSystemEntryEvent(ctx context.Context, oldEntry, newEntry *Entry) {
+	if f.MetaLog == nil {
+		return
+	}
+	p := newEntry
+	if p == nil {
+		p = oldEntry
+	}
+	if !isSystemEntry(p.FullPath) {
+		return
+	}
+	event := &filer_pb.EventNotification{
+		OldEntry: oldEntry.ToProtoEntry(),
+		NewEntry: newEntry.ToProtoEntry(),
+	}
+	if tx, found := ctx.Value(filerTransactionKey{}).(*filerTransaction); found {
+		tx.systemEvents = append(tx.systemEvents, event)
+		return
+	}
+	f.logSystemEntryEvent(event)
+}
+
+func (f *Filer) logSystemEntryEvent(event *filer_pb.EventNotification) {
+	key := event.NewEntry
+	if key == nil {
+		key = event.OldEntry
+	}
+	dir, _ := FullPath(key.Name).DirAndName()
+	if err := f.MetaLog.AppendEntry(dir, event); err != nil {
+		glog.Errorf("append metadata log for %s: %v", key.Name, err)
 	}
 }
@@ -0,0 +1,339 @@
+package filer2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/draleyva/seaweedfs/weed/util"
+	"github.com/gogo/protobuf/proto"
+)
+
+// QuotaDirectory keeps one quota record for each directory with a quota, named by
+// the escaped directory path. The record saves the limits and the current usage,
+// which is updated in the same transaction as the entry changes.
+const QuotaDirectory = FullPath("/.quotas")
+
+const quotaExtendedKey = "quota"
+
+var ErrQuotaConflict = errors.New("the quota is being changed by another request")
+
+// quotaLockTimeout is how long to wait for another transaction changing the same quota record.
+var quotaLockTimeout = 10 * time.Second
+
+func quotaPath(dir FullPath) FullPath {
+	return NewFullPath(string(QuotaDirectory), url.QueryEscape(string(dir)))
+}
+
+func IsQuotaRecord(p FullPath) bool {
+	return p == QuotaDirectory || strings.HasPrefix(string(p), string(QuotaDirectory)+"/")
+}
+
+func decodeQuota(entry *Entry) (*filer_pb.DirectoryQuota, error) {
+	quota := &filer_pb.DirectoryQuota{}
+	if err := proto.Unmarshal(entry.Extended[quotaExtendedKey], quota); err != nil {
+		return nil, fmt.Errorf("decode quota %s: %v", entry.FullPath, err)
+	}
+	return quota, nil
+}
+
+// lockQuota locks the quota record of the directory for the current transaction, so the usage
+// is not read by another transaction before this one commits the change.
+func (f *Filer) lockQuota(ctx context.Context, dir FullPath) error {
+	if err := f.lockRecord(ctx, quotaPath(dir), quotaLockTimeout); err == errRecordLockTimeout {
+		return fmt.Errorf("directory %s: %v", dir, ErrQuotaConflict)
+	} else if err != nil {
+		return fmt.Errorf("directory %s: %v", dir, err)
+	}
+	return nil
+}
+
+// findQuota reads the quota of the directory from the store, or returns nil if it has no quota.
+func (f *Filer) findQuota(ctx context.Context, dir FullPath) (*filer_pb.DirectoryQuota, error) {
+	record, err := f.store.FindEntry(ctx, quotaPath(dir))
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find quota of %s: %v", dir, err)
+	}
+	return decodeQuota(record)
+}
+
+// listQuotas reads all quota records from the store.
+func (f *Filer) listQuotas(ctx context.Context) ([]*filer_pb.DirectoryQuota, error) {
+	var quotas []*filer_pb.DirectoryQuota
+	lastFileName := ""
+	for {
+		entries, err := f.store.ListDirectoryEntries(ctx, QuotaDirectory, lastFileName, false, 1024)
+		if err != nil {
+			return nil, fmt.Errorf("list %s: %v", QuotaDirectory, err)
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			quota, err := decodeQuota(entry)
+			if err != nil {
+				return nil, err
+			}
+			quotas = append(quotas, quota)
+		}
+		if len(entries) < 1024 {
+			return quotas, nil
+		}
+	}
+}
+
+func (f *Filer) saveQuota(ctx context.Context, quota *filer_pb.DirectoryQuota, isNew bool) error {
+	data, err := proto.Marshal(quota)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	record := &Entry{
+		FullPath: quotaPath(FullPath(quota.Directory)),
+		Attr: Attr{
+			Mtime:  now,
+			Crtime: now,
+			Mode:   0600,
+		},
+		Extended: map[string][]byte{quotaExtendedKey: data},
+	}
+	if isNew {
+		return f.store.InsertEntry(ctx, record)
+	}
+	return f.store.UpdateEntry(ctx, record)
+}
+
+// ConfigureDirectoryQuota sets the byte and entry count limits of the directory.
+// Zero limits mean unlimited, and the quota is removed if both limits are zero.
+// The usage of a new quota is counted by walking the directory.
+func (f *Filer) ConfigureDirectoryQuota(ctx context.Context, dir FullPath, maxBytes, maxEntryCount uint64) error {
+
+	if IsHardLinkRecord(dir) || IsQuotaRecord(dir) {
+		return fmt.Errorf("can not set quota on %s", dir)
+	}
+
+	dirEntry, err := f.FindEntry(ctx, dir)
+	if err != nil && dir != "/" {
+		return fmt.Errorf("quota on %s: %v", dir, err)
+	}
+	if dirEntry != nil && !dirEntry.IsDirectory() {
+		return fmt.Errorf("quota on %s: not a directory", dir)
+	}
+
+	quota, err := f.findQuota(ctx, dir)
+	if err != nil {
+		return err
+	}
+	found := quota != nil
+
+	if !found && (maxBytes != 0 || maxEntryCount != 0) {
+		// count before saving the quota, the changes during the walk are not counted
+		quota = &filer_pb.DirectoryQuota{Directory: string(dir)}
+		if err := f.countDirectoryUsage(ctx, dir, quota); err != nil {
+			return fmt.Errorf("count usage of %s: %v", dir, err)
+		}
+	}
+
+	return f.withTransaction(ctx, func(ctx context.Context) error {
+
+		if err := f.lockQuota(ctx, dir); err != nil {
+			return err
+		}
+
+		if maxBytes == 0 && maxEntryCount == 0 {
+			if !found {
+				return nil
+			}
+			if err := f.store.DeleteEntry(ctx, quotaPath(dir)); err != nil {
+				return fmt.Errorf("remove quota on %s: %v", dir, err)
+			}
+			return nil
+		}
+
+		if found {
+			// keep the usage updated since it was read
+			current, err := f.findQuota(ctx, dir)
+			if err != nil {
+				return err
+			}
+			if current != nil {
+				quota = current
+			}
+		} else if err := f.ensureSystemDirectory(ctx, QuotaDirectory); err != nil {
+			return err
+		}
+
+		quota.MaxBytes, quota.MaxEntryCount = maxBytes, maxEntryCount
+		if err := f.saveQuota(ctx, quota, !found); err != nil {
+			return fmt.Errorf("save quota on %s: %v", dir, err)
+		}
+		return nil
+	})
+}
+
+// ListDirectoryQuotas returns the quotas and usages, sorted by directory.
+func (f *Filer) ListDirectoryQuotas(ctx context.Context) ([]*filer_pb.DirectoryQuota, error) {
+
+	quotas, err := f.listQuotas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(quotas, func(i, j int) bool {
+		return quotas[i].Directory < quotas[j].Directory
+	})
+
+	return quotas, nil
+}
+
+func (f *Filer) countDirectoryUsage(ctx context.Context, dir FullPath, quota *filer_pb.DirectoryQuota) error {
+	lastFileName := ""
+	for {
+		entries, err := f.ListDirectoryEntries(ctx, dir, lastFileName, false, 1024)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			if IsHardLinkRecord(entry.FullPath) || IsQuotaRecord(entry.FullPath) ||
+				IsSnapshotPath(entry.FullPath) || IsSnapshotIndex(entry.FullPath) || IsVersionPath(entry.FullPath) {
+				continue
+			}
+			quota.EntryCount++
+			quota.Bytes += entry.Size()
+			if entry.IsDirectory() {
+				if err := f.countDirectoryUsage(ctx, entry.FullPath, quota); err != nil {
+					return err
+				}
+			}
+		}
+		if len(entries) < 1024 {
+			return nil
+		}
+	}
+}
+
+// updateQuotaUsage adds the changes of replacing the old entry with the new entry at the path
+// to the quotas of its parent directories. Either entry can be nil for creating or deleting.
+// It fails if any quota would be exceeded by the increase.
+// The usage is read and written in the store within the current transaction,
+// so it is shared by all filers and is rolled back together with the entry changes.
+// The quota records are locked until the transaction ends, so the concurrent changes do not overwrite each other.
+func (f *Filer) updateQuotaUsage(ctx context.Context, p FullPath, oldEntry, newEntry *Entry) error {
+
+	deltaBytes, deltaEntryCount := entryUsageDelta(oldEntry, newEntry)
+	if deltaBytes == 0 && deltaEntryCount == 0 {
+		return nil
+	}
+	if IsHardLinkRecord(p) || IsQuotaRecord(p) || IsSnapshotPath(p) || IsSnapshotIndex(p) || IsVersionPath(p) {
+		return nil
+	}
+
+	if _, err := f.store.FindEntry(ctx, QuotaDirectory); err == ErrNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("find %s: %v", QuotaDirectory, err)
+	}
+
+	var quotas []*filer_pb.DirectoryQuota
+	for dir := p; dir != "/"; {
+		parent, _ := dir.DirAndName()
+		dir = FullPath(parent)
+		quota, err := f.findQuota(ctx, dir)
+		if err != nil {
+			return err
+		}
+		if quota == nil {
+			continue
+		}
+		// read the usage again after locking, another transaction may have just changed it
+		if err = f.lockQuota(ctx, dir); err != nil {
+			return err
+		}
+		if quota, err = f.findQuota(ctx, dir); err != nil {
+			return err
+		}
+		if quota != nil {
+			quotas = append(quotas, quota)
+		}
+	}
+
+	for _, quota := range quotas {
+		if deltaBytes > 0 && quota.MaxBytes > 0 && quota.Bytes+uint64(deltaBytes) > quota.MaxBytes {
+			return fmt.Errorf("directory %s: %v, %d + %d bytes over %d bytes",
+				quota.Directory, util.ErrQuotaExceeded, quota.Bytes, deltaBytes, quota.MaxBytes)
+		}
+		if deltaEntryCount > 0 && quota.MaxEntryCount > 0 && quota.EntryCount+uint64(deltaEntryCount) > quota.MaxEntryCount {
+			return fmt.Errorf("directory %s: %v, %d + %d entries over %d entries",
+				quota.Directory, util.ErrQuotaExceeded, quota.EntryCount, deltaEntryCount, quota.MaxEntryCount)
+		}
+	}
+
+	for _, quota := range quotas {
+		quota.Bytes = addUsage(quota.Bytes, deltaBytes)
+		quota.EntryCount = addUsage(quota.EntryCount, deltaEntryCount)
+		if err := f.saveQuota(ctx, quota, false); err != nil {
+			return fmt.Errorf("update quota of %s: %v", quota.Directory, err)
+		}
+	}
+
+	return nil
+}
+
+// quotasUnder returns the directory and its sub directories which have quotas.
+func (f *Filer) quotasUnder(ctx context.Context, dir FullPath) ([]FullPath, error) {
+
+	quotas, err := f.listQuotas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var quotaDirs []FullPath
+	for _, quota := range quotas {
+		quotaDir := FullPath(quota.Directory)
+		if quotaDir == dir || strings.HasPrefix(string(quotaDir), string(dir)+"/") {
+			quotaDirs = append(quotaDirs, quotaDir)
+		}
+	}
+	return quotaDirs, nil
+}
+
+// removeDeletedDirectoryQuota removes the quota of a deleted directory.
+func (f *Filer) removeDeletedDirectoryQuota(ctx context.Context, dir FullPath) error {
+
+	if _, err := f.store.FindEntry(ctx, quotaPath(dir)); err == ErrNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("find quota of %s: %v", dir, err)
+	}
+	if err := f.store.DeleteEntry(ctx, quotaPath(dir)); err != nil {
+		return fmt.Errorf("remove quota on %s: %v", dir, err)
+	}
+
+	return nil
+}
+
+func addUsage(usage uint64, delta int64) uint64 {
+	if delta < 0 && uint64(-delta) > usage {
+		return 0
+	}
+	return uint64(int64(usage) + delta)
+}
+
+// entryUsageDelta returns the bytes and entry count changed by replacing the old entry with the new entry.
+func entryUsageDelta(oldEntry, newEntry *Entry) (deltaBytes, deltaEntryCount int64) {
+	if oldEntry != nil {
+		deltaBytes -= int64(oldEntry.Size())
+		deltaEntryCount--
+	}
+	if newEntry != nil {
+		deltaBytes += int64(newEntry.Size())
+		deltaEntryCount++
+	}
+	return
+}
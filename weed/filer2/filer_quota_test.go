@@ -0,0 +1,137 @@
+package filer2_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/filer2/memdb"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/draleyva/seaweedfs/weed/util"
+)
+
+func TestDirectoryQuota(t *testing.T) {
+	ctx := context.Background()
+	filer := newTestFiler()
+
+	newFile := func(p string, size uint64) *filer2.Entry {
+		return &filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr:     filer2.Attr{Mode: 0440},
+			Chunks:   []*filer_pb.FileChunk{{FileId: p, Size: size}},
+		}
+	}
+	usage := func() (uint64, uint64) {
+		quotas, err := filer.ListDirectoryQuotas(ctx)
+		if err != nil || len(quotas) != 1 {
+			t.Fatalf("list quotas %v: %v", quotas, err)
+		}
+		return quotas[0].Bytes, quotas[0].EntryCount
+	}
+
+	if err := filer.CreateEntry(ctx, newFile("/team/a", 100)); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := filer.ConfigureDirectoryQuota(ctx, "/team", 150, 10); err != nil {
+		t.Fatalf("configure quota: %v", err)
+	}
+	if bytes, count := usage(); bytes != 100 || count != 1 {
+		t.Fatalf("counted usage %d bytes %d entries", bytes, count)
+	}
+
+	// the failed change does not leave its usage behind
+	if err := filer.CreateEntry(ctx, newFile("/team/sub/b", 100)); !util.IsQuotaExceeded(err) {
+		t.Fatalf("expected quota exceeded, got %v", err)
+	}
+	if bytes, count := usage(); bytes != 100 || count != 1 {
+		t.Fatalf("usage after failure %d bytes %d entries", bytes, count)
+	}
+	if _, err := filer.FindEntry(ctx, "/team/sub"); err != filer2.ErrNotFound {
+		t.Fatalf("parent directory of the failed entry: %v", err)
+	}
+
+	if err := filer.DeleteEntryMetaAndData(ctx, "/team/a", false, false); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if bytes, count := usage(); bytes != 0 || count != 0 {
+		t.Fatalf("usage after delete %d bytes %d entries", bytes, count)
+	}
+
+	if err := filer.CreateEntry(ctx, newFile("/team/sub/b", 100)); err != nil {
+		t.Fatalf("create after delete: %v", err)
+	}
+	if bytes, count := usage(); bytes != 100 || count != 2 {
+		t.Fatalf("usage after create %d bytes %d entries", bytes, count)
+	}
+}
+
+func TestDirectoryQuotaSharedStore(t *testing.T) {
+	ctx := context.Background()
+	store := &memdb.MemDbStore{}
+	store.Initialize(nil)
+	filer, other := newTestFilerOn(store), newTestFilerOn(store)
+
+	if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/team", Attr: filer2.Attr{Mode: os.ModeDir | 0770}}); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := filer.ConfigureDirectoryQuota(ctx, "/team", 0, 1); err != nil {
+		t.Fatalf("configure quota: %v", err)
+	}
+	// the other filer loads nothing before the quota is set, and still sees it
+	if err := other.CreateEntry(ctx, &filer2.Entry{FullPath: "/team/a", Attr: filer2.Attr{Mode: 0440}}); err != nil {
+		t.Fatalf("create on the other filer: %v", err)
+	}
+	if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/team/b", Attr: filer2.Attr{Mode: 0440}}); !util.IsQuotaExceeded(err) {
+		t.Fatalf("expected quota exceeded with the usage of the other filer, got %v", err)
+	}
+	quotas, err := other.ListDirectoryQuotas(ctx)
+	if err != nil || len(quotas) != 1 || quotas[0].EntryCount != 1 {
+		t.Fatalf("list quotas on the other filer: %+v, %v", quotas, err)
+	}
+}
+
+func TestDirectoryQuotaConcurrentTransactions(t *testing.T) {
+	ctx := context.Background()
+	filer := newTestFilerOn(newUncommittedStore(20 * time.Millisecond))
+
+	if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/team", Attr: filer2.Attr{Mode: os.ModeDir | 0770}}); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := filer.ConfigureDirectoryQuota(ctx, "/team", 0, 1000); err != nil {
+		t.Fatalf("configure quota: %v", err)
+	}
+
+	// each transaction reads the usage before the others commit, unless the quota is locked
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- filer.CreateEntry(ctx, &filer2.Entry{
+				FullPath: filer2.FullPath(fmt.Sprintf("/team/f%d", i)),
+				Attr:     filer2.Attr{Mode: 0644},
+				Chunks:   []*filer_pb.FileChunk{{FileId: fmt.Sprintf("1,%02d", i), Size: 100}},
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	quotas, err := filer.ListDirectoryQuotas(ctx)
+	if err != nil || len(quotas) != 1 {
+		t.Fatalf("list quotas %+v: %v", quotas, err)
+	}
+	if quotas[0].EntryCount != 8 || quotas[0].Bytes != 800 {
+		t.Fatalf("usage %d entries %d bytes, expected 8 entries 800 bytes", quotas[0].EntryCount, quotas[0].Bytes)
+	}
+}
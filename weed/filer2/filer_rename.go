@@ -0,0 +1,224 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+const renameListingBatchSize = 1024
+
+// AtomicRenameEntry moves an entry, and all its sub entries for a directory, to the new path.
+// An existing file at the new path is replaced. An existing directory at the new path
+// is replaced only if it is empty. Missing parent directories of the new path are created.
+func (f *Filer) AtomicRenameEntry(ctx context.Context, oldPath, newPath FullPath) error {
+
+	if oldPath == "/" || newPath == "/" || IsHardLinkRecord(oldPath) || IsHardLinkRecord(newPath) {
+		return fmt.Errorf("can not rename %s to %s", oldPath, newPath)
+	}
+	if IsSnapshotIndex(oldPath) || IsSnapshotIndex(newPath) {
+		return fmt.Errorf("can not rename %s to %s", oldPath, newPath)
+	}
+	if err := f.checkSnapshotPath(ctx, oldPath); err != nil {
+		return fmt.Errorf("rename %s: %v", oldPath, err)
+	}
+	if err := f.checkSnapshotPath(ctx, newPath); err != nil {
+		return fmt.Errorf("rename to %s: %v", newPath, err)
+	}
+	if oldPath == newPath {
+		return nil
+	}
+
+	oldEntry, err := f.FindEntry(ctx, oldPath)
+	if err != nil {
+		return fmt.Errorf("rename %s: %v", oldPath, err)
+	}
+
+	if oldEntry.IsDirectory() && strings.HasPrefix(string(newPath), string(oldPath)+"/") {
+		return fmt.Errorf("can not move directory %s into its own sub directory %s", oldPath, newPath)
+	}
+
+	if oldEntry.IsDirectory() {
+		if quotaDirs, err := f.quotasUnder(ctx, oldPath); err != nil {
+			return fmt.Errorf("rename %s: %v", oldPath, err)
+		} else if len(quotaDirs) > 0 {
+			return fmt.Errorf("can not rename %s with directory quotas, remove the quotas first", oldPath)
+		}
+		if hasSnapshot, err := f.hasSnapshotUnder(ctx, oldPath); err != nil {
+			return fmt.Errorf("rename %s: %v", oldPath, err)
+		} else if hasSnapshot {
+			return fmt.Errorf("can not rename %s with snapshots, delete the snapshots first", oldPath)
+		}
+	}
+
+	return f.withTransaction(ctx, func(ctx context.Context) error {
+		return f.doRenameEntry(ctx, oldEntry, newPath)
+	})
+}
+
+func (f *Filer) doRenameEntry(ctx context.Context, oldEntry *Entry, newPath FullPath) error {
+
+	oldPath := oldEntry.FullPath
+
+	if err := f.ensureParentDirectory(ctx, newPath, oldEntry); err != nil {
+		return fmt.Errorf("rename to %s: %v", newPath, err)
+	}
+
+	targetEntry, err := f.FindEntry(ctx, newPath)
+	if err != nil && err != ErrNotFound {
+		return fmt.Errorf("rename to %s: %v", newPath, err)
+	}
+	if targetEntry != nil {
+		if targetEntry.IsDirectory() != oldEntry.IsDirectory() {
+			return fmt.Errorf("rename %s to %s: can not replace a file with a directory or the other way", oldPath, newPath)
+		}
+		if targetEntry.IsDirectory() {
+			entries, err := f.ListDirectoryEntries(ctx, newPath, "", false, 1)
+			if err != nil {
+				return fmt.Errorf("list %s: %v", newPath, err)
+			}
+			if len(entries) > 0 {
+				return fmt.Errorf("rename %s to %s: directory not empty", oldPath, newPath)
+			}
+		}
+	}
+
+	glog.V(2).Infof("rename %s => %s", oldPath, newPath)
+
+	if targetEntry != nil && targetEntry.HardLinkId != "" && targetEntry.HardLinkId == oldEntry.HardLinkId {
+		// same as rename(2), nothing to do if both are links to the same file
+		return nil
+	}
+
+	if targetEntry != nil {
+		if err := f.updateQuotaUsage(ctx, newPath, targetEntry, nil); err != nil {
+			return err
+		}
+		if err := f.preserveForSnapshots(ctx, newPath); err != nil {
+			return err
+		}
+		if err := f.store.DeleteEntry(ctx, newPath); err != nil {
+			return fmt.Errorf("replace %s: %v", newPath, err)
+		}
+		isLastLink := true
+		if targetEntry.HardLinkId != "" {
+			if isLastLink, err = f.unlinkHardLink(ctx, targetEntry); err != nil {
+				return fmt.Errorf("replace %s: %v", newPath, err)
+			}
+		}
+		var chunksToDelete []*filer_pb.FileChunk
+		if isLastLink {
+			if chunksToDelete, err = f.deletedFileChunks(ctx, targetEntry); err != nil {
+				return fmt.Errorf("replace %s: %v", newPath, err)
+			}
+		}
+		f.afterCommit(ctx, func() {
+			f.NotifyUpdateEvent(targetEntry, nil, isLastLink)
+			f.DeleteChunks(chunksToDelete)
+		})
+	}
+
+	return f.moveEntry(ctx, oldEntry, newPath)
+}
+
+// moveEntry creates the new entry first, then moves the sub entries, and deletes the old entry last,
+// so an interrupted move never loses an entry even if the store has no transaction support.
+func (f *Filer) moveEntry(ctx context.Context, oldEntry *Entry, newPath FullPath) error {
+
+	oldPath := oldEntry.FullPath
+
+	newEntry := &Entry{
+		FullPath:        newPath,
+		Attr:            oldEntry.Attr,
+		Extended:        oldEntry.Extended,
+		Chunks:          oldEntry.Chunks,
+		Content:         oldEntry.Content,
+		HardLinkId:      oldEntry.HardLinkId,
+		HardLinkCounter: oldEntry.HardLinkCounter,
+	}
+
+	// remove the usage first, in case both paths are under the same quota
+	if err := f.updateQuotaUsage(ctx, oldPath, oldEntry, nil); err != nil {
+		return err
+	}
+	if err := f.updateQuotaUsage(ctx, newPath, nil, newEntry); err != nil {
+		return err
+	}
+
+	if err := f.storeEntry(ctx, newEntry, true); err != nil {
+		return fmt.Errorf("insert entry %s: %v", newPath, err)
+	}
+
+	if !oldEntry.IsDirectory() {
+		if err := f.moveVersions(ctx, oldPath, newPath); err != nil {
+			return fmt.Errorf("move versions of %s: %v", oldPath, err)
+		}
+	}
+
+	if oldEntry.IsDirectory() {
+		f.cacheDelDirectory(string(oldPath))
+		lastFileName := ""
+		for {
+			entries, err := f.ListDirectoryEntries(ctx, oldPath, lastFileName, false, renameListingBatchSize)
+			if err != nil {
+				return fmt.Errorf("list %s: %v", oldPath, err)
+			}
+			if len(entries) == 0 {
+				break
+			}
+			for _, sub := range entries {
+				if err := f.moveEntry(ctx, sub, NewFullPath(string(newPath), sub.Name())); err != nil {
+					return err
+				}
+				lastFileName = sub.Name()
+			}
+		}
+	}
+
+	if err := f.preserveForSnapshots(ctx, oldPath); err != nil {
+		return err
+	}
+	if err := f.store.DeleteEntry(ctx, oldPath); err != nil {
+		return fmt.Errorf("delete entry %s: %v", oldPath, err)
+	}
+
+	f.afterCommit(ctx, func() {
+		f.NotifyUpdateEvent(oldEntry, nil, false)
+		f.NotifyUpdateEvent(nil, newEntry, false)
+	})
+
+	return nil
+}
+
+func (f *Filer) ensureParentDirectory(ctx context.Context, p FullPath, entry *Entry) error {
+	dir, _ := p.DirAndName()
+	if dir == "/" {
+		return nil
+	}
+	dirEntry, err := f.FindEntry(ctx, FullPath(dir))
+	if err == ErrNotFound {
+		now := time.Now()
+		return f.CreateEntry(ctx, &Entry{
+			FullPath: FullPath(dir),
+			Attr: Attr{
+				Mtime:  now,
+				Crtime: now,
+				Mode:   os.ModeDir | 0770,
+				Uid:    entry.Uid,
+				Gid:    entry.Gid,
+			},
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if !dirEntry.IsDirectory() {
+		return fmt.Errorf("%s is a file", dir)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package filer2_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+)
+
+func TestAtomicRename(t *testing.T) {
+	ctx := context.Background()
+	filer := newTestFiler()
+
+	for _, p := range []string{"/home/chris/a/file1.jpg", "/home/chris/a/b/file2.jpg", "/home/chris/c.jpg"} {
+		entry := &filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr: filer2.Attr{
+				Mode: 0440,
+			},
+		}
+		if err := filer.CreateEntry(ctx, entry); err != nil {
+			t.Fatalf("create entry %v: %v", entry.FullPath, err)
+		}
+	}
+
+	if err := filer.AtomicRenameEntry(ctx, "/home/chris/a", "/home/chris/a/b/a"); err == nil {
+		t.Errorf("moving a directory into itself should fail")
+	}
+
+	if err := filer.AtomicRenameEntry(ctx, "/home/chris/a", "/home/other/x"); err != nil {
+		t.Fatalf("rename directory: %v", err)
+	}
+	for _, p := range []string{"/home/other/x/file1.jpg", "/home/other/x/b/file2.jpg"} {
+		if _, err := filer.FindEntry(ctx, filer2.FullPath(p)); err != nil {
+			t.Errorf("find moved entry %s: %v", p, err)
+		}
+	}
+	for _, p := range []string{"/home/chris/a", "/home/chris/a/b/file2.jpg"} {
+		if _, err := filer.FindEntry(ctx, filer2.FullPath(p)); err != filer2.ErrNotFound {
+			t.Errorf("old entry %s should be gone: %v", p, err)
+		}
+	}
+
+	if err := filer.AtomicRenameEntry(ctx, "/home/chris/c.jpg", "/home/other/x/file1.jpg"); err != nil {
+		t.Fatalf("rename file over an existing file: %v", err)
+	}
+	if err := filer.AtomicRenameEntry(ctx, "/home/other/x/file1.jpg", "/home/other/x/b"); err == nil {
+		t.Errorf("replacing a directory with a file should fail")
+	}
+
+}
@@ -0,0 +1,157 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+const searchListingBatchSize = 1024
+
+// searchScanLimit caps the entries read by one search, so a selective filter over a large tree
+// returns a page early instead of reading the whole tree.
+var searchScanLimit = 100000
+
+// ListOptions filters the entries listed by SearchDirectoryEntries. The zero values do not filter.
+type ListOptions struct {
+	// Prefix of the entry names directly under the directory, which the store lists by key range.
+	Prefix string
+	// NamePattern is a shell glob of the entry names, as in path.Match.
+	NamePattern string
+	MinMtime    time.Time
+	MaxMtime    time.Time
+	MinSize     uint64
+	MaxSize     uint64
+	// Recursive lists the entries under each sub directory right after the sub directory.
+	// The snapshots and the filer system directories are listed but not descended into.
+	Recursive bool
+}
+
+// SearchDirectoryEntries lists up to limit entries under the directory matching the options, ordered by name.
+// The listing starts after startFrom, the name of the last entry of the previous page, or for a recursive
+// listing, its path relative to the directory.
+// If the scan limit is reached first, lastScanned is the name or the relative path of the last entry read,
+// where the next page starts, and is empty otherwise.
+func (f *Filer) SearchDirectoryEntries(ctx context.Context, dir FullPath, startFrom string, inclusive bool, limit int,
+	options *ListOptions) (entries []*Entry, lastScanned string, err error) {
+
+	if _, err := path.Match(options.NamePattern, ""); err != nil {
+		return nil, "", fmt.Errorf("name pattern %q: %v", options.NamePattern, err)
+	}
+	if limit <= 0 {
+		return nil, "", nil
+	}
+
+	var after []string
+	if startFrom != "" && options.Recursive {
+		after = strings.Split(startFrom, "/")
+	} else if startFrom != "" {
+		after = []string{startFrom}
+	}
+
+	scanned, scanLimitReached := 0, false
+	var lastScannedEntry *Entry
+	search := &entrySearch{
+		options: options,
+		scan: func(entry *Entry) bool {
+			if scanned >= searchScanLimit {
+				scanLimitReached = true
+				return false
+			}
+			scanned++
+			lastScannedEntry = entry
+			return true
+		},
+		fn: func(entry *Entry) bool {
+			entries = append(entries, entry)
+			return len(entries) < limit
+		},
+	}
+	_, err = f.searchEntries(ctx, dir, options.Prefix, after, inclusive, search)
+
+	if scanLimitReached && lastScannedEntry != nil {
+		lastScanned = lastScannedEntry.Name()
+		if options.Recursive {
+			lastScanned = lastScannedEntry.FullPath.RelativeTo(dir)
+		}
+	}
+
+	return entries, lastScanned, err
+}
+
+type entrySearch struct {
+	options *ListOptions
+	// scan is called on each entry read, and stops the search if it returns false
+	scan func(entry *Entry) bool
+	// fn is called on each matching entry, and stops the search if it returns false
+	fn func(entry *Entry) bool
+}
+
+// searchEntries visits the matching entries under the directory in order, starting after the path
+// of the after components, until the search stops.
+func (f *Filer) searchEntries(ctx context.Context, dir FullPath, prefix string, after []string, inclusive bool,
+	search *entrySearch) (stopped bool, err error) {
+
+	options := search.options
+
+	lastFileName, includeLastFile := "", false
+	if len(after) > 0 {
+		// the sub directories of the last entry may not be listed yet
+		lastFileName, includeLastFile = after[0], true
+	}
+
+	for {
+		entries, err := f.ListDirectoryPrefixedEntries(ctx, dir, lastFileName, includeLastFile, searchListingBatchSize, prefix)
+		if err != nil {
+			return false, err
+		}
+		includeLastFile = false
+
+		for _, entry := range entries {
+			resumed := len(after) > 0 && entry.Name() == after[0]
+			if !resumed && !search.scan(entry) {
+				return true, nil
+			}
+			lastFileName = entry.Name()
+
+			if (!resumed || len(after) == 1 && inclusive) && options.matches(entry) && !search.fn(entry) {
+				return true, nil
+			}
+
+			if options.Recursive && entry.IsDirectory() && !isSkippedByWalk(entry.FullPath) {
+				var subAfter []string
+				if resumed {
+					subAfter = after[1:]
+				}
+				if stopped, err := f.searchEntries(ctx, entry.FullPath, "", subAfter, inclusive, search); stopped || err != nil {
+					return stopped, err
+				}
+			}
+		}
+
+		if len(entries) < searchListingBatchSize {
+			return false, nil
+		}
+	}
+}
+
+func (options *ListOptions) matches(entry *Entry) bool {
+	if options.NamePattern != "" {
+		if matched, _ := path.Match(options.NamePattern, entry.Name()); !matched {
+			return false
+		}
+	}
+	if !options.MinMtime.IsZero() && entry.Mtime.Before(options.MinMtime) {
+		return false
+	}
+	if !options.MaxMtime.IsZero() && entry.Mtime.After(options.MaxMtime) {
+		return false
+	}
+	size := entry.Size()
+	if size < options.MinSize || options.MaxSize > 0 && size > options.MaxSize {
+		return false
+	}
+	return true
+}
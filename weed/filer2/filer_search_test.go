@@ -0,0 +1,70 @@
+package filer2_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestSearchDirectoryEntries(t *testing.T) {
+	ctx := context.Background()
+	filer := newTestFiler()
+
+	for i, p := range []string{"/data/a.log", "/data/a/b.txt", "/data/a/c/d.txt", "/data/b.txt", "/data/ba.txt", "/data/c.log"} {
+		if err := filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr:     filer2.Attr{Mode: 0644, Mtime: time.Unix(int64(1000+i), 0)},
+			Chunks:   []*filer_pb.FileChunk{{FileId: p, Size: uint64(10 * (i + 1))}},
+		}); err != nil {
+			t.Fatalf("create %s: %v", p, err)
+		}
+	}
+
+	search := func(startFrom string, limit int, options *filer2.ListOptions) (names []string) {
+		entries, _, err := filer.SearchDirectoryEntries(ctx, "/data", startFrom, false, limit, options)
+		if err != nil {
+			t.Fatalf("search %+v: %v", options, err)
+		}
+		for _, entry := range entries {
+			names = append(names, entry.FullPath.RelativeTo("/data"))
+		}
+		return
+	}
+	expect := func(names []string, expected ...string) {
+		if strings.Join(names, ",") != strings.Join(expected, ",") {
+			t.Fatalf("expected %v, but got %v", expected, names)
+		}
+	}
+
+	expect(search("", 10, &filer2.ListOptions{Prefix: "b"}), "b.txt", "ba.txt")
+	expect(search("b.txt", 10, &filer2.ListOptions{Prefix: "b"}), "ba.txt")
+	expect(search("", 10, &filer2.ListOptions{NamePattern: "*.log"}), "a.log", "c.log")
+	expect(search("", 10, &filer2.ListOptions{MinSize: 40, MaxSize: 50}), "b.txt", "ba.txt")
+	expect(search("", 10, &filer2.ListOptions{MinMtime: time.Unix(1003, 0), MaxMtime: time.Unix(1004, 0)}), "b.txt", "ba.txt")
+
+	// the recursive listing continues from the relative path of the last entry
+	options := &filer2.ListOptions{Recursive: true}
+	expect(search("", 3, options), "a", "a/b.txt", "a/c")
+	expect(search("a/c", 3, options), "a/c/d.txt", "a.log", "b.txt")
+	expect(search("", 10, &filer2.ListOptions{Recursive: true, NamePattern: "*.txt"}), "a/b.txt", "a/c/d.txt", "b.txt", "ba.txt")
+	expect(search("a/c/d.txt", 10, &filer2.ListOptions{Recursive: true, Prefix: "a"}), "a.log")
+
+	if _, _, err := filer.SearchDirectoryEntries(ctx, "/data", "", false, 10, &filer2.ListOptions{NamePattern: "["}); err == nil {
+		t.Fatalf("expected an error for a bad name pattern")
+	}
+
+	// the scan limit ends the page early, and the next page starts after the last scanned entry
+	defer filer2.SetSearchScanLimit(3)()
+	entries, lastScanned, err := filer.SearchDirectoryEntries(ctx, "/data", "", false, 10, &filer2.ListOptions{Recursive: true, NamePattern: "*.txt"})
+	if err != nil || len(entries) != 1 || lastScanned != "a/c" {
+		t.Fatalf("search with the scan limit: %+v, %q, %v", entries, lastScanned, err)
+	}
+	entries, lastScanned, err = filer.SearchDirectoryEntries(ctx, "/data", lastScanned, false, 10, &filer2.ListOptions{Recursive: true, NamePattern: "*.txt"})
+	if err != nil || len(entries) != 2 || lastScanned != "b.txt" {
+		t.Fatalf("search the next page with the scan limit: %+v, %q, %v", entries, lastScanned, err)
+	}
+}
@@ -0,0 +1,779 @@
+package filer2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/gogo/protobuf/proto"
+)
+
+// A snapshot is a read only view of a directory tree at <dir>/.snapshots/<name>, as of its creation.
+// Snapshots are copy on write at the metadata level: creating a snapshot only saves its record.
+// Before an entry under the directory is changed for the first time after the snapshot, the old entry
+// is copied into the snapshot along with its parent directories, or marked absent there if it did not
+// exist yet. The entries not copied are read from the live tree, so a hard linked file changed through
+// a link outside the directory is not preserved. The chunks of the copied entries are pinned with one
+// record for each chunk and snapshot, and their deletions are postponed until no snapshot pins them.
+// SnapshotIndexDirectory keeps the snapshot records, the pins, and the postponed chunk deletions.
+// They are always read from the store, so all filers on the same store see the same snapshots.
+const SnapshotIndexDirectory = FullPath("/.snapshotindex")
+
+const (
+	snapshotDirectoryName    = ".snapshots"
+	snapshotRecordsDirectory = SnapshotIndexDirectory + "/snapshots"
+	pinnedChunksDirectory    = SnapshotIndexDirectory + "/pins"
+	releasedChunksDirectory  = SnapshotIndexDirectory + "/released"
+	snapshotExtendedKey      = "snapshot"
+	snapshotAbsentKey        = "snapshot.absent"
+)
+
+func IsSnapshotIndex(p FullPath) bool {
+	return p == SnapshotIndexDirectory || strings.HasPrefix(string(p), string(SnapshotIndexDirectory)+"/")
+}
+
+// IsSnapshotPath checks whether the path is a .snapshots directory or inside one. It is only a snapshot
+// if the directory has snapshots, since directories named .snapshots may be created before the name is reserved.
+func IsSnapshotPath(p FullPath) bool {
+	return strings.Contains(string(p)+"/", "/"+snapshotDirectoryName+"/")
+}
+
+// SnapshotPath returns the path of the named snapshot of the directory.
+func SnapshotPath(dir FullPath, name string) FullPath {
+	return NewFullPath(string(snapshotsDirectory(dir)), name)
+}
+
+func snapshotsDirectory(dir FullPath) FullPath {
+	return NewFullPath(string(dir), snapshotDirectoryName)
+}
+
+// parseSnapshotPath splits the path of a snapshot root into the directory and the snapshot name.
+func parseSnapshotPath(p FullPath) (dir FullPath, name string, ok bool) {
+	dir, name, rel, ok := splitSnapshotPath(p)
+	if !ok || name == "" || rel != "" {
+		return "", "", false
+	}
+	return dir, name, true
+}
+
+// splitSnapshotPath splits a path in a .snapshots directory into the directory, the snapshot name,
+// and the path relative to the snapshot root. The name is empty for the .snapshots directory itself.
+func splitSnapshotPath(p FullPath) (dir FullPath, name, rel string, ok bool) {
+	s := string(p)
+	i := strings.Index(s+"/", "/"+snapshotDirectoryName+"/")
+	if i < 0 {
+		return "", "", "", false
+	}
+	dir = FullPath(s[:i])
+	if dir == "" {
+		dir = "/"
+	}
+	rest := strings.TrimPrefix(s[i+len(snapshotDirectoryName)+1:], "/")
+	if rest == "" {
+		return dir, "", "", true
+	}
+	parts := strings.SplitN(rest, "/", 2)
+	if len(parts) > 1 {
+		rel = parts[1]
+	}
+	return dir, parts[0], rel, true
+}
+
+// snapshotEntryPath returns the path of the entry in the snapshot, by its path relative to the snapshot root.
+func snapshotEntryPath(dir FullPath, name, rel string) FullPath {
+	if rel == "" {
+		return SnapshotPath(dir, name)
+	}
+	return NewFullPath(string(SnapshotPath(dir, name)), rel)
+}
+
+func liveEntryPath(dir FullPath, rel string) FullPath {
+	if rel == "" {
+		return dir
+	}
+	return NewFullPath(string(dir), rel)
+}
+
+func parentRelativePath(rel string) string {
+	if i := strings.LastIndex(rel, "/"); i >= 0 {
+		return rel[:i]
+	}
+	return ""
+}
+
+func snapshotRecordPath(dir FullPath, name string) FullPath {
+	return NewFullPath(string(snapshotRecordsDirectory), url.QueryEscape(string(SnapshotPath(dir, name))))
+}
+
+func releasedChunkPath(fileId string) FullPath {
+	return NewFullPath(string(releasedChunksDirectory), url.QueryEscape(fileId))
+}
+
+// pinnedChunkPath names the pin by the file id first, so the pins of a file id can be listed by prefix.
+func pinnedChunkPath(fileId string, snapshotPath FullPath) FullPath {
+	return NewFullPath(string(pinnedChunksDirectory), pinnedChunkPrefix(fileId)+url.QueryEscape(string(snapshotPath)))
+}
+
+func pinnedChunkPrefix(fileId string) string {
+	return url.QueryEscape(fileId) + "@"
+}
+
+func isAbsentInSnapshot(entry *Entry) bool {
+	_, found := entry.Extended[snapshotAbsentKey]
+	return found
+}
+
+// snapshotCopy copies the entry to the path in the snapshot. Hard links are copied as plain files.
+func snapshotCopy(entry *Entry, p FullPath) *Entry {
+	return &Entry{
+		FullPath: p,
+		Attr:     entry.Attr,
+		Extended: entry.Extended,
+		Chunks:   entry.Chunks,
+		Content:  entry.Content,
+	}
+}
+
+// hasSnapshots checks whether the directory has any snapshot.
+func (f *Filer) hasSnapshots(ctx context.Context, dir FullPath) (bool, error) {
+	records, err := f.store.ListDirectoryPrefixedEntries(ctx, snapshotRecordsDirectory, "", false, 1,
+		url.QueryEscape(string(snapshotsDirectory(dir))+"/"))
+	if err != nil {
+		return false, fmt.Errorf("list snapshots of %s: %v", dir, err)
+	}
+	return len(records) > 0, nil
+}
+
+// isInSnapshot checks whether the path is in a .snapshots directory of a directory with snapshots.
+func (f *Filer) isInSnapshot(ctx context.Context, p FullPath) (bool, error) {
+	dir, _, _, ok := splitSnapshotPath(p)
+	if !ok {
+		return false, nil
+	}
+	return f.hasSnapshots(ctx, dir)
+}
+
+// checkSnapshotPath rejects changing the path if it is in a snapshot, or if it would create a new
+// directory named .snapshots, which is reserved for the snapshots.
+func (f *Filer) checkSnapshotPath(ctx context.Context, p FullPath) error {
+	dir, _, _, ok := splitSnapshotPath(p)
+	if !ok {
+		return nil
+	}
+	if inSnapshot, err := f.hasSnapshots(ctx, dir); err != nil {
+		return err
+	} else if inSnapshot {
+		return fmt.Errorf("in a read only snapshot")
+	}
+	if _, err := f.store.FindEntry(ctx, snapshotsDirectory(dir)); err == ErrNotFound {
+		return fmt.Errorf("the name %s is reserved for snapshots", snapshotDirectoryName)
+	} else if err != nil {
+		return err
+	}
+	// a directory named .snapshots created before the name was reserved
+	return nil
+}
+
+func (f *Filer) findSnapshotRecord(ctx context.Context, dir FullPath, name string) (*filer_pb.Snapshot, error) {
+	record, err := f.store.FindEntry(ctx, snapshotRecordPath(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	return decodeSnapshotRecord(record)
+}
+
+func decodeSnapshotRecord(record *Entry) (*filer_pb.Snapshot, error) {
+	snapshot := &filer_pb.Snapshot{}
+	if err := proto.Unmarshal(record.Extended[snapshotExtendedKey], snapshot); err != nil {
+		return nil, fmt.Errorf("decode snapshot %s: %v", record.FullPath, err)
+	}
+	return snapshot, nil
+}
+
+// CreateSnapshot saves the record of the named snapshot of the directory. The entries are only copied
+// into the snapshot when they are changed later.
+func (f *Filer) CreateSnapshot(ctx context.Context, dir FullPath, name string) error {
+
+	if name == "" || strings.Contains(name, "/") || name == "." || name == ".." {
+		return fmt.Errorf("invalid snapshot name %q", name)
+	}
+	if IsSnapshotPath(dir) || IsHardLinkRecord(dir) || IsQuotaRecord(dir) || IsSnapshotIndex(dir) || IsVersionPath(dir) {
+		return fmt.Errorf("can not snapshot %s", dir)
+	}
+
+	return f.withTransaction(ctx, func(ctx context.Context) error {
+
+		dirEntry, err := f.FindEntry(ctx, dir)
+		if err != nil && dir != "/" {
+			return fmt.Errorf("snapshot %s: %v", dir, err)
+		}
+		if dirEntry != nil && !dirEntry.IsDirectory() {
+			return fmt.Errorf("snapshot %s: not a directory", dir)
+		}
+
+		snapshotPath := SnapshotPath(dir, name)
+		if _, err := f.store.FindEntry(ctx, snapshotRecordPath(dir, name)); err == nil {
+			return fmt.Errorf("snapshot %s already exists", snapshotPath)
+		}
+
+		now := time.Now()
+		snapshotsDir := snapshotsDirectory(dir)
+		if _, err := f.store.FindEntry(ctx, snapshotsDir); err == ErrNotFound {
+			if err := f.store.InsertEntry(ctx, &Entry{
+				FullPath: snapshotsDir,
+				Attr:     Attr{Mtime: now, Crtime: now, Mode: os.ModeDir | 0555},
+			}); err != nil {
+				return fmt.Errorf("create %s: %v", snapshotsDir, err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("find %s: %v", snapshotsDir, err)
+		} else if hasSnapshots, err := f.hasSnapshots(ctx, dir); err != nil {
+			return err
+		} else if !hasSnapshots {
+			return fmt.Errorf("can not snapshot %s, %s is not a snapshot directory", dir, snapshotsDir)
+		}
+
+		rootAttr := Attr{Mtime: now, Crtime: now, Mode: os.ModeDir | 0555}
+		if dirEntry != nil {
+			rootAttr = dirEntry.Attr
+		}
+		if err := f.store.InsertEntry(ctx, &Entry{FullPath: snapshotPath, Attr: rootAttr}); err != nil {
+			return fmt.Errorf("create %s: %v", snapshotPath, err)
+		}
+
+		if err := f.saveSnapshotRecord(ctx, &filer_pb.Snapshot{
+			Directory:   string(dir),
+			Name:        name,
+			CreatedTsNs: now.UnixNano(),
+		}); err != nil {
+			return err
+		}
+
+		glog.V(0).Infof("created snapshot %s", snapshotPath)
+
+		return nil
+	})
+}
+
+func (f *Filer) saveSnapshotRecord(ctx context.Context, snapshot *filer_pb.Snapshot) error {
+	data, err := proto.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	record := &Entry{
+		FullPath: snapshotRecordPath(FullPath(snapshot.Directory), snapshot.Name),
+		Attr: Attr{
+			Mtime:  time.Unix(0, snapshot.CreatedTsNs),
+			Crtime: time.Unix(0, snapshot.CreatedTsNs),
+			Mode:   0600,
+		},
+		Extended: map[string][]byte{snapshotExtendedKey: data},
+	}
+	if err := f.ensureSystemDirectory(ctx, snapshotRecordsDirectory); err != nil {
+		return err
+	}
+	if err := f.store.InsertEntry(ctx, record); err != nil {
+		return fmt.Errorf("save snapshot record %s: %v", record.FullPath, err)
+	}
+	return nil
+}
+
+// preserveForSnapshots copies the entry into the snapshots covering it, before it is changed for
+// the first time after each snapshot. A missing entry is marked absent in the snapshots instead,
+// unless its parent directory is absent from the snapshot too.
+func (f *Filer) preserveForSnapshots(ctx context.Context, p FullPath) error {
+
+	if IsSnapshotPath(p) || IsHardLinkRecord(p) || IsQuotaRecord(p) || IsSnapshotIndex(p) || IsVersionPath(p) {
+		return nil
+	}
+
+	if _, err := f.store.FindEntry(ctx, snapshotRecordsDirectory); err == ErrNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("find %s: %v", snapshotRecordsDirectory, err)
+	}
+
+	var live *Entry
+	liveLoaded := false
+	for dir := p; dir != "/"; {
+		parent, _ := dir.DirAndName()
+		dir = FullPath(parent)
+		snapshots, err := f.listSnapshotsOf(ctx, dir)
+		if err != nil {
+			return err
+		}
+		for _, snapshot := range snapshots {
+			rel := p.RelativeTo(dir)
+			snapshotEntry := snapshotEntryPath(dir, snapshot.Name, rel)
+
+			if _, err := f.store.FindEntry(ctx, snapshotEntry); err == nil {
+				continue
+			} else if err != ErrNotFound {
+				return err
+			}
+			if parent, err := f.findSnapshotEntry(ctx, dir, snapshot.Name, parentRelativePath(rel)); err == ErrNotFound {
+				continue
+			} else if err != nil {
+				return err
+			} else if !parent.IsDirectory() {
+				continue
+			}
+			if err := f.saveSnapshotDirectories(ctx, dir, snapshot.Name, parentRelativePath(rel)); err != nil {
+				return err
+			}
+
+			if !liveLoaded {
+				if live, err = f.FindEntry(ctx, p); err == ErrNotFound {
+					live = nil
+				} else if err != nil {
+					return err
+				}
+				liveLoaded = true
+			}
+
+			preserved := &Entry{
+				FullPath: snapshotEntry,
+				Extended: map[string][]byte{snapshotAbsentKey: nil},
+			}
+			if live != nil {
+				preserved = snapshotCopy(live, snapshotEntry)
+				if err := f.pinChunks(ctx, SnapshotPath(dir, snapshot.Name), live.Chunks); err != nil {
+					return fmt.Errorf("pin %s: %v", p, err)
+				}
+			}
+			if err := f.store.InsertEntry(ctx, preserved); err != nil {
+				return fmt.Errorf("preserve %s in snapshot %s: %v", p, snapshot.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// saveSnapshotDirectories copies the unchanged parent directories into the snapshot, so the entries
+// saved in the snapshot always form a tree.
+func (f *Filer) saveSnapshotDirectories(ctx context.Context, dir FullPath, name, rel string) error {
+	if rel == "" {
+		return nil
+	}
+	p := snapshotEntryPath(dir, name, rel)
+	if _, err := f.store.FindEntry(ctx, p); err == nil {
+		return nil
+	} else if err != ErrNotFound {
+		return err
+	}
+	if err := f.saveSnapshotDirectories(ctx, dir, name, parentRelativePath(rel)); err != nil {
+		return err
+	}
+	live, err := f.FindEntry(ctx, liveEntryPath(dir, rel))
+	if err != nil {
+		return fmt.Errorf("preserve %s in snapshot %s: %v", liveEntryPath(dir, rel), name, err)
+	}
+	return f.store.InsertEntry(ctx, snapshotCopy(live, p))
+}
+
+// pinChunks records the chunks as used by the snapshot.
+func (f *Filer) pinChunks(ctx context.Context, snapshotPath FullPath, chunks []*filer_pb.FileChunk) error {
+	if len(chunks) == 0 {
+		return nil
+	}
+	resolved, err := f.resolveChunks(chunks)
+	if err != nil {
+		return err
+	}
+	if err := f.ensureSystemDirectory(ctx, pinnedChunksDirectory); err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, chunk := range resolved {
+		pinPath := pinnedChunkPath(chunk.FileId, snapshotPath)
+		if _, err := f.store.FindEntry(ctx, pinPath); err == nil {
+			continue
+		} else if err != ErrNotFound {
+			return err
+		}
+		if err := f.store.InsertEntry(ctx, &Entry{
+			FullPath: pinPath,
+			Attr:     Attr{Mtime: now, Crtime: now, Mode: 0600},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Filer) isChunkPinned(ctx context.Context, fileId string) (bool, error) {
+	pins, err := f.store.ListDirectoryPrefixedEntries(ctx, pinnedChunksDirectory, "", false, 1, pinnedChunkPrefix(fileId))
+	if err != nil {
+		return false, err
+	}
+	return len(pins) > 0, nil
+}
+
+// findSnapshotEntry finds the entry as of the snapshot, either copied into the snapshot,
+// or unchanged in the live tree since the snapshot.
+func (f *Filer) findSnapshotEntry(ctx context.Context, dir FullPath, name, rel string) (*Entry, error) {
+
+	p := snapshotEntryPath(dir, name, rel)
+	preserved, err := f.store.FindEntry(ctx, p)
+	if err == nil {
+		if isAbsentInSnapshot(preserved) {
+			return nil, ErrNotFound
+		}
+		return preserved, nil
+	}
+	if err != ErrNotFound || rel == "" {
+		return nil, err
+	}
+	if IsSnapshotPath(FullPath("/" + rel)) {
+		// the snapshots of the sub directories are not part of the snapshot
+		return nil, ErrNotFound
+	}
+
+	parent, err := f.findSnapshotEntry(ctx, dir, name, parentRelativePath(rel))
+	if err != nil {
+		return nil, err
+	}
+	if !parent.IsDirectory() {
+		return nil, ErrNotFound
+	}
+
+	live, err := f.FindEntry(ctx, liveEntryPath(dir, rel))
+	if err != nil {
+		return nil, err
+	}
+	return snapshotCopy(live, p), nil
+}
+
+// findInSnapshot finds the entry of a path in a .snapshots directory.
+func (f *Filer) findInSnapshot(ctx context.Context, p FullPath) (*Entry, error) {
+	dir, name, rel, _ := splitSnapshotPath(p)
+	if name == "" {
+		return f.store.FindEntry(ctx, p)
+	}
+	return f.findSnapshotEntry(ctx, dir, name, rel)
+}
+
+// listInSnapshot lists the directory in a .snapshots directory, merging the entries copied into
+// the snapshot with the unchanged live entries.
+func (f *Filer) listInSnapshot(ctx context.Context, p FullPath, startFileName string, inclusive bool, limit int, prefix string) ([]*Entry, error) {
+
+	dir, name, rel, _ := splitSnapshotPath(p)
+	if name == "" {
+		return f.store.ListDirectoryPrefixedEntries(ctx, p, startFileName, inclusive, limit, prefix)
+	}
+	dirEntry, err := f.findSnapshotEntry(ctx, dir, name, rel)
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !dirEntry.IsDirectory() {
+		return nil, nil
+	}
+
+	var entries []*Entry
+	for len(entries) < limit {
+		preserved, err := f.store.ListDirectoryPrefixedEntries(ctx, p, startFileName, inclusive, limit, prefix)
+		if err != nil {
+			return nil, err
+		}
+		live, err := f.ListDirectoryPrefixedEntries(ctx, liveEntryPath(dir, rel), startFileName, inclusive, limit, prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		// a full batch may have more entries after its last name, so only the names
+		// up to the smaller last name of the full batches are merged in this round
+		hasMore, lastFileName := false, ""
+		if len(preserved) == limit {
+			hasMore, lastFileName = true, preserved[len(preserved)-1].Name()
+		}
+		if len(live) == limit && (!hasMore || live[len(live)-1].Name() < lastFileName) {
+			hasMore, lastFileName = true, live[len(live)-1].Name()
+		}
+
+		i, j := 0, 0
+		for (i < len(preserved) || j < len(live)) && len(entries) < limit {
+			var entry *Entry
+			if j >= len(live) || i < len(preserved) && preserved[i].Name() <= live[j].Name() {
+				entry = preserved[i]
+				if j < len(live) && live[j].Name() == entry.Name() {
+					j++
+				}
+				i++
+			} else {
+				entry = snapshotCopy(live[j], NewFullPath(string(p), live[j].Name()))
+				j++
+			}
+			if hasMore && entry.Name() > lastFileName {
+				break
+			}
+			if isAbsentInSnapshot(entry) || entry.Name() == snapshotDirectoryName {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+
+		if !hasMore {
+			break
+		}
+		startFileName, inclusive = lastFileName, false
+	}
+
+	return entries, nil
+}
+
+// DeleteSnapshot removes the named snapshot, and deletes the chunks no longer referenced
+// by the live entries or other snapshots.
+func (f *Filer) DeleteSnapshot(ctx context.Context, dir FullPath, name string) error {
+
+	recordPath := snapshotRecordPath(dir, name)
+	if _, err := f.store.FindEntry(ctx, recordPath); err != nil {
+		return fmt.Errorf("snapshot %s: %v", SnapshotPath(dir, name), err)
+	}
+
+	snapshotPath := SnapshotPath(dir, name)
+	unpinned := make(map[string]bool)
+	err := f.withTransaction(ctx, func(ctx context.Context) error {
+
+		if err := f.deleteSnapshotEntries(ctx, snapshotPath, unpinned); err != nil {
+			return err
+		}
+		for fileId := range unpinned {
+			if err := f.store.DeleteEntry(ctx, pinnedChunkPath(fileId, snapshotPath)); err != nil {
+				return fmt.Errorf("unpin %s: %v", fileId, err)
+			}
+		}
+		if err := f.store.DeleteEntry(ctx, snapshotPath); err != nil {
+			return fmt.Errorf("delete %s: %v", snapshotPath, err)
+		}
+		if err := f.store.DeleteEntry(ctx, recordPath); err != nil {
+			return fmt.Errorf("delete snapshot record %s: %v", recordPath, err)
+		}
+
+		snapshotsDir := snapshotsDirectory(dir)
+		if entries, err := f.store.ListDirectoryEntries(ctx, snapshotsDir, "", false, 1); err != nil {
+			return fmt.Errorf("list %s: %v", snapshotsDir, err)
+		} else if len(entries) == 0 {
+			if err := f.store.DeleteEntry(ctx, snapshotsDir); err != nil {
+				return fmt.Errorf("delete %s: %v", snapshotsDir, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var toDelete []string
+	for fileId := range unpinned {
+		if _, err := f.store.FindEntry(ctx, releasedChunkPath(fileId)); err != nil {
+			continue
+		}
+		if pinned, err := f.isChunkPinned(ctx, fileId); err != nil || pinned {
+			continue
+		}
+		if err := f.store.DeleteEntry(ctx, releasedChunkPath(fileId)); err != nil {
+			glog.Errorf("delete released chunk record %s: %v", fileId, err)
+			continue
+		}
+		toDelete = append(toDelete, fileId)
+	}
+
+	glog.V(0).Infof("deleted snapshot %s, releasing %d chunks", snapshotPath, len(toDelete))
+
+	for _, fileId := range toDelete {
+		f.fileIdDeletionChan <- fileId
+	}
+
+	return nil
+}
+
+// deleteSnapshotEntries deletes the entries copied into the snapshot, and collects their chunks.
+func (f *Filer) deleteSnapshotEntries(ctx context.Context, dir FullPath, unpinned map[string]bool) error {
+	lastFileName := ""
+	for {
+		entries, err := f.store.ListDirectoryEntries(ctx, dir, lastFileName, false, 1024)
+		if err != nil {
+			return fmt.Errorf("list %s: %v", dir, err)
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			if entry.IsDirectory() {
+				if err := f.deleteSnapshotEntries(ctx, entry.FullPath, unpinned); err != nil {
+					return err
+				}
+			}
+			chunks, err := f.resolveChunks(entry.Chunks)
+			if err != nil {
+				return fmt.Errorf("unpin %s: %v", entry.FullPath, err)
+			}
+			for _, chunk := range chunks {
+				unpinned[chunk.FileId] = true
+			}
+			if err := f.store.DeleteEntry(ctx, entry.FullPath); err != nil {
+				return fmt.Errorf("delete %s: %v", entry.FullPath, err)
+			}
+		}
+		if len(entries) < 1024 {
+			return nil
+		}
+	}
+}
+
+// ListSnapshots returns all snapshots, sorted by the directory and the name.
+func (f *Filer) ListSnapshots(ctx context.Context) ([]*filer_pb.Snapshot, error) {
+
+	var snapshots []*filer_pb.Snapshot
+	if err := f.eachEntry(ctx, snapshotRecordsDirectory, func(record *Entry) error {
+		snapshot, err := decodeSnapshotRecord(record)
+		if err != nil {
+			return err
+		}
+		snapshots = append(snapshots, snapshot)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		if snapshots[i].Directory != snapshots[j].Directory {
+			return snapshots[i].Directory < snapshots[j].Directory
+		}
+		return snapshots[i].Name < snapshots[j].Name
+	})
+
+	return snapshots, nil
+}
+
+// hasSnapshotUnder checks whether the directory or any of its sub directories has snapshots.
+// The records are named by the escaped snapshot paths, so one prefix lookup finds them.
+func (f *Filer) hasSnapshotUnder(ctx context.Context, dir FullPath) (bool, error) {
+	prefix := url.QueryEscape(string(dir) + "/")
+	if dir == "/" {
+		prefix = url.QueryEscape("/")
+	}
+	records, err := f.store.ListDirectoryPrefixedEntries(ctx, snapshotRecordsDirectory, "", false, 1, prefix)
+	if err != nil {
+		return false, fmt.Errorf("list snapshots under %s: %v", dir, err)
+	}
+	return len(records) > 0, nil
+}
+
+// listSnapshotsOf returns the snapshots of the directory, only listing its own records by prefix.
+func (f *Filer) listSnapshotsOf(ctx context.Context, dir FullPath) ([]*filer_pb.Snapshot, error) {
+	var snapshots []*filer_pb.Snapshot
+	prefix := url.QueryEscape(string(snapshotsDirectory(dir)) + "/")
+	lastFileName := ""
+	for {
+		records, err := f.store.ListDirectoryPrefixedEntries(ctx, snapshotRecordsDirectory, lastFileName, false, 1024, prefix)
+		if err != nil {
+			return nil, fmt.Errorf("list snapshots of %s: %v", dir, err)
+		}
+		for _, record := range records {
+			lastFileName = record.Name()
+			snapshot, err := decodeSnapshotRecord(record)
+			if err != nil {
+				return nil, err
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+		if len(records) < 1024 {
+			return snapshots, nil
+		}
+	}
+}
+
+// filterPinnedFileIds returns the file ids not pinned by any snapshot.
+// The pinned ones are recorded to be deleted along with the last snapshot pinning them.
+func (f *Filer) filterPinnedFileIds(fileIds []string) []string {
+
+	ctx := context.Background()
+	var unpinned []string
+	for _, fileId := range fileIds {
+		pinned, err := f.isChunkPinned(ctx, fileId)
+		if err != nil {
+			// keep the chunk, in case it is pinned by snapshots
+			glog.Errorf("skip deleting chunk %s: %v", fileId, err)
+			continue
+		}
+		if !pinned {
+			unpinned = append(unpinned, fileId)
+			continue
+		}
+		glog.V(3).Infof("postpone deleting chunk %s pinned by snapshots", fileId)
+		if _, err := f.store.FindEntry(ctx, releasedChunkPath(fileId)); err == nil {
+			continue
+		}
+		if err := f.ensureSystemDirectory(ctx, releasedChunksDirectory); err != nil {
+			glog.Errorf("record released chunk %s: %v", fileId, err)
+			continue
+		}
+		now := time.Now()
+		if err := f.store.InsertEntry(ctx, &Entry{
+			FullPath: releasedChunkPath(fileId),
+			Attr:     Attr{Mtime: now, Crtime: now, Mode: 0600},
+		}); err != nil {
+			glog.Errorf("record released chunk %s: %v", fileId, err)
+		}
+	}
+
+	return unpinned
+}
+
+// errSkipDirectory is returned by the walkEntries function to skip the entries under the directory.
+var errSkipDirectory = errors.New("skip this directory")
+
+// walkEntries visits all entries under the directory, skipping the snapshots and the filer system directories.
+func (f *Filer) walkEntries(ctx context.Context, dir FullPath, fn func(entry *Entry) error) error {
+	return f.eachEntry(ctx, dir, func(entry *Entry) error {
+		if isSkippedByWalk(entry.FullPath) {
+			return nil
+		}
+		if err := fn(entry); err == errSkipDirectory {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if entry.IsDirectory() {
+			return f.walkEntries(ctx, entry.FullPath, fn)
+		}
+		return nil
+	})
+}
+
+// isSkippedByWalk checks whether the path is a .snapshots directory or a filer system directory.
+func isSkippedByWalk(p FullPath) bool {
+	return p.Name() == snapshotDirectoryName || IsHardLinkRecord(p) || IsQuotaRecord(p) || IsSnapshotIndex(p) || IsVersionPath(p)
+}
+
+// ensureSystemDirectory creates the filer system directory and its parents, bypassing the quotas and snapshots.
+func (f *Filer) ensureSystemDirectory(ctx context.Context, dir FullPath) error {
+	if dir == "/" {
+		return nil
+	}
+	if _, err := f.store.FindEntry(ctx, dir); err == nil {
+		return nil
+	} else if err != ErrNotFound {
+		return err
+	}
+	parent, _ := dir.DirAndName()
+	if err := f.ensureSystemDirectory(ctx, FullPath(parent)); err != nil {
+		return err
+	}
+	now := time.Now()
+	return f.store.InsertEntry(ctx, &Entry{
+		FullPath: dir,
+		Attr:     Attr{Mtime: now, Crtime: now, Mode: os.ModeDir | 0700},
+	})
+}
@@ -0,0 +1,219 @@
+package filer2_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/filer2/memdb"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestSnapshot(t *testing.T) {
+	ctx := context.Background()
+	store := &memdb.MemDbStore{}
+	store.Initialize(nil)
+	filer := newTestFilerOn(store)
+
+	write := func(p, fileId string) {
+		if err := filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr:     filer2.Attr{Mode: 0440},
+			Chunks:   []*filer_pb.FileChunk{{FileId: fileId, Size: 100}},
+		}); err != nil {
+			t.Fatalf("write %s: %v", p, err)
+		}
+	}
+	list := func(dir string) string {
+		entries, err := filer.ListDirectoryEntries(ctx, filer2.FullPath(dir), "", false, 100)
+		if err != nil {
+			t.Fatalf("list %s: %v", dir, err)
+		}
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		return strings.Join(names, ",")
+	}
+
+	write("/data/set/file1.jpg", "1,01")
+	write("/data/set/file2.jpg", "1,02")
+
+	if err := filer.CreateSnapshot(ctx, "/data", "v1"); err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	// the live tree changes after the snapshot, and the snapshot keeps the old view
+	if err := filer.DeleteEntryMetaAndData(ctx, "/data/set/file1.jpg", false, true); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	write("/data/set/file2.jpg", "1,03")
+	write("/data/set/file3.jpg", "1,04")
+	write("/data/new/file4.jpg", "1,05")
+
+	snapshotEntry, err := filer.FindEntry(ctx, "/data/.snapshots/v1/set/file1.jpg")
+	if err != nil || len(snapshotEntry.Chunks) != 1 || snapshotEntry.Chunks[0].FileId != "1,01" {
+		t.Fatalf("find deleted file in snapshot %+v: %v", snapshotEntry, err)
+	}
+	if snapshotEntry, err = filer.FindEntry(ctx, "/data/.snapshots/v1/set/file2.jpg"); err != nil || snapshotEntry.Chunks[0].FileId != "1,02" {
+		t.Fatalf("find replaced file in snapshot %+v: %v", snapshotEntry, err)
+	}
+	for _, p := range []string{"/data/.snapshots/v1/set/file3.jpg", "/data/.snapshots/v1/new", "/data/.snapshots/v1/new/file4.jpg"} {
+		if _, err := filer.FindEntry(ctx, filer2.FullPath(p)); err != filer2.ErrNotFound {
+			t.Fatalf("new entry %s in snapshot: %v", p, err)
+		}
+	}
+	if names := list("/data/.snapshots/v1/set"); names != "file1.jpg,file2.jpg" {
+		t.Fatalf("list snapshot: %s", names)
+	}
+	if names := list("/data/.snapshots/v1"); names != "set" {
+		t.Fatalf("list snapshot root: %s", names)
+	}
+	if names := list("/data/set"); names != "file2.jpg,file3.jpg" {
+		t.Fatalf("list live directory: %s", names)
+	}
+
+	// another filer on the same store sees the snapshot, and postpones deleting its chunks
+	other := newTestFilerOn(store)
+	if err := other.DeleteEntryMetaAndData(ctx, "/data/set/file2.jpg", false, true); err != nil {
+		t.Fatalf("delete on another filer: %v", err)
+	}
+	if snapshotEntry, err = other.FindEntry(ctx, "/data/.snapshots/v1/set/file2.jpg"); err != nil || snapshotEntry.Chunks[0].FileId != "1,02" {
+		t.Fatalf("find in snapshot on another filer %+v: %v", snapshotEntry, err)
+	}
+	filer.DeleteChunks([]*filer_pb.FileChunk{{FileId: "1,02"}})
+	if _, err := store.FindEntry(ctx, "/.snapshotindex/released/1%2C02"); err != nil {
+		t.Fatalf("deleting a pinned chunk is not postponed: %v", err)
+	}
+
+	// snapshots are read only
+	if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/data/.snapshots/v1/new.jpg"}); err == nil {
+		t.Fatalf("created an entry in the snapshot")
+	}
+	if err := filer.DeleteEntryMetaAndData(ctx, "/data/.snapshots/v1/set/file1.jpg", false, true); err == nil {
+		t.Fatalf("deleted an entry in the snapshot")
+	}
+	if err := filer.DeleteEntryMetaAndData(ctx, "/data", true, true); err == nil {
+		t.Fatalf("deleted a directory with snapshots")
+	}
+
+	snapshots, err := filer.ListSnapshots(ctx)
+	if err != nil || len(snapshots) != 1 || snapshots[0].Directory != "/data" {
+		t.Fatalf("list snapshots %v: %v", snapshots, err)
+	}
+
+	if err := filer.DeleteEntryMetaAndData(ctx, "/data/.snapshots/v1", true, true); err != nil {
+		t.Fatalf("delete snapshot: %v", err)
+	}
+	if _, err := filer.FindEntry(ctx, "/data/.snapshots"); err != filer2.ErrNotFound {
+		t.Fatalf("snapshots directory after deleting the last snapshot: %v", err)
+	}
+	if _, err := store.FindEntry(ctx, "/.snapshotindex/released/1%2C02"); err != filer2.ErrNotFound {
+		t.Fatalf("released chunk after deleting the snapshot: %v", err)
+	}
+	if snapshots, err = filer.ListSnapshots(ctx); err != nil || len(snapshots) != 0 {
+		t.Fatalf("list snapshots after delete %v: %v", snapshots, err)
+	}
+	if err := filer.DeleteEntryMetaAndData(ctx, "/data", true, true); err != nil {
+		t.Fatalf("delete directory: %v", err)
+	}
+}
+
+func TestSnapshotDirectoryName(t *testing.T) {
+	ctx := context.Background()
+	store := &memdb.MemDbStore{}
+	store.Initialize(nil)
+	filer := newTestFilerOn(store)
+
+	// the name is reserved for the new directories
+	if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/home/.snapshots/a.txt", Attr: filer2.Attr{Mode: 0644}}); err == nil {
+		t.Fatalf("created a directory named .snapshots")
+	}
+
+	// a directory named .snapshots created before is a normal directory
+	store.InsertEntry(ctx, &filer2.Entry{FullPath: "/home", Attr: filer2.Attr{Mode: os.ModeDir | 0755}})
+	store.InsertEntry(ctx, &filer2.Entry{FullPath: "/home/.snapshots", Attr: filer2.Attr{Mode: os.ModeDir | 0755}})
+	if err := filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/home/.snapshots/a.txt", Attr: filer2.Attr{Mode: 0644}}); err != nil {
+		t.Fatalf("create in an old directory named .snapshots: %v", err)
+	}
+	if _, err := filer.FindEntry(ctx, "/home/.snapshots/a.txt"); err != nil {
+		t.Fatalf("find in an old directory named .snapshots: %v", err)
+	}
+	if err := filer.CreateSnapshot(ctx, "/home", "v1"); err == nil {
+		t.Fatalf("snapshot into an old directory named .snapshots")
+	}
+	if err := filer.DeleteEntryMetaAndData(ctx, "/home/.snapshots", true, false); err != nil {
+		t.Fatalf("delete an old directory named .snapshots: %v", err)
+	}
+}
+
+// snapshotRecordCountingStore counts the snapshot records listed from the store.
+type snapshotRecordCountingStore struct {
+	*memdb.MemDbStore
+	listed int
+}
+
+func (s *snapshotRecordCountingStore) ListDirectoryEntries(ctx context.Context, dirPath filer2.FullPath, startFileName string, includeStartFile bool, limit int) ([]*filer2.Entry, error) {
+	return s.ListDirectoryPrefixedEntries(ctx, dirPath, startFileName, includeStartFile, limit, "")
+}
+
+func (s *snapshotRecordCountingStore) ListDirectoryPrefixedEntries(ctx context.Context, dirPath filer2.FullPath, startFileName string, includeStartFile bool, limit int, prefix string) ([]*filer2.Entry, error) {
+	entries, err := s.MemDbStore.ListDirectoryPrefixedEntries(ctx, dirPath, startFileName, includeStartFile, limit, prefix)
+	if dirPath == filer2.SnapshotIndexDirectory+"/snapshots" {
+		s.listed += len(entries)
+	}
+	return entries, err
+}
+
+func TestSnapshotLookupOnAncestors(t *testing.T) {
+	ctx := context.Background()
+	store := &snapshotRecordCountingStore{MemDbStore: &memdb.MemDbStore{}}
+	store.Initialize(nil)
+	filer := newTestFilerOn(store)
+
+	write := func(p, fileId string) {
+		if err := filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr:     filer2.Attr{Mode: 0640},
+			Chunks:   []*filer_pb.FileChunk{{FileId: fileId, Size: 100}},
+		}); err != nil {
+			t.Fatalf("write %s: %v", p, err)
+		}
+	}
+
+	write("/a/x.txt", "1,01")
+	write("/b/y.txt", "1,02")
+	if err := filer.CreateSnapshot(ctx, "/a", "s"); err != nil {
+		t.Fatalf("snapshot /a: %v", err)
+	}
+	for _, name := range []string{"s1", "s2", "s3", "s4", "s5"} {
+		if err := filer.CreateSnapshot(ctx, "/b", name); err != nil {
+			t.Fatalf("snapshot /b: %v", err)
+		}
+	}
+
+	// changing a file only reads the snapshots of its parent directories
+	store.listed = 0
+	write("/a/x.txt", "1,03")
+	if store.listed != 1 {
+		t.Fatalf("read %d snapshot records to change /a/x.txt", store.listed)
+	}
+	if entry, err := filer.FindEntry(ctx, filer2.SnapshotPath("/a", "s")+"/x.txt"); err != nil || entry.Chunks[0].FileId != "1,01" {
+		t.Fatalf("preserved entry %+v: %v", entry, err)
+	}
+
+	// renaming a directory only checks whether it has snapshots under it
+	store.listed = 0
+	if err := filer.AtomicRenameEntry(ctx, "/b", "/c"); err == nil {
+		t.Fatalf("renamed a directory with snapshots")
+	}
+	if store.listed != 1 {
+		t.Fatalf("read %d snapshot records to rename /b", store.listed)
+	}
+	write("/d/z.txt", "1,04")
+	if err := filer.AtomicRenameEntry(ctx, "/d", "/e"); err != nil {
+		t.Fatalf("rename a directory without snapshots: %v", err)
+	}
+}
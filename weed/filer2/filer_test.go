@@ -0,0 +1,263 @@
+package filer2_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/filer2/memdb"
+	"github.com/draleyva/seaweedfs/weed/operation"
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
+	"github.com/draleyva/seaweedfs/weed/util"
+)
+
+// newTestFiler returns a filer on an empty in memory store, without the directory cache.
+func newTestFiler() *filer2.Filer {
+	store := &memdb.MemDbStore{}
+	store.Initialize(nil)
+	return newTestFilerOn(store)
+}
+
+// newTestFilerOn returns another filer on the same store, to test the filers sharing a store.
+func newTestFilerOn(store filer2.FilerStore) *filer2.Filer {
+	filer := filer2.NewFiler(nil)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+	return filer
+}
+
+// uncommittedStore keeps the changes of each transaction until it is committed, and does not isolate
+// the concurrent transactions, like the leveldb write batches or the sql read committed level.
+// Each commit waits for commitDelay first, so the concurrent transactions overlap.
+type uncommittedStore struct {
+	sync.Mutex
+	store       *memdb.MemDbStore
+	commitDelay time.Duration
+}
+
+type uncommittedChangesKey struct{}
+
+// uncommittedChanges maps the changed paths to the new entries, or nil for the deleted entries.
+type uncommittedChanges map[filer2.FullPath]*filer2.Entry
+
+func newUncommittedStore(commitDelay time.Duration) *uncommittedStore {
+	store := &memdb.MemDbStore{}
+	store.Initialize(nil)
+	return &uncommittedStore{store: store, commitDelay: commitDelay}
+}
+
+func (s *uncommittedStore) GetName() string {
+	return "uncommitted"
+}
+
+func (s *uncommittedStore) Initialize(configuration util.Configuration) error {
+	return nil
+}
+
+func (s *uncommittedStore) changes(ctx context.Context) (uncommittedChanges, bool) {
+	changes, found := ctx.Value(uncommittedChangesKey{}).(uncommittedChanges)
+	return changes, found
+}
+
+func (s *uncommittedStore) InsertEntry(ctx context.Context, entry *filer2.Entry) error {
+	if changes, found := s.changes(ctx); found {
+		changes[entry.FullPath] = entry
+		return nil
+	}
+	s.Lock()
+	defer s.Unlock()
+	return s.store.InsertEntry(ctx, entry)
+}
+
+func (s *uncommittedStore) UpdateEntry(ctx context.Context, entry *filer2.Entry) error {
+	if changes, found := s.changes(ctx); found {
+		changes[entry.FullPath] = entry
+		return nil
+	}
+	s.Lock()
+	defer s.Unlock()
+	return s.store.UpdateEntry(ctx, entry)
+}
+
+func (s *uncommittedStore) FindEntry(ctx context.Context, p filer2.FullPath) (*filer2.Entry, error) {
+	if changes, found := s.changes(ctx); found {
+		if entry, changed := changes[p]; changed {
+			if entry == nil {
+				return nil, filer2.ErrNotFound
+			}
+			return entry, nil
+		}
+	}
+	s.Lock()
+	defer s.Unlock()
+	return s.store.FindEntry(ctx, p)
+}
+
+func (s *uncommittedStore) DeleteEntry(ctx context.Context, p filer2.FullPath) error {
+	if changes, found := s.changes(ctx); found {
+		changes[p] = nil
+		return nil
+	}
+	s.Lock()
+	defer s.Unlock()
+	return s.store.DeleteEntry(ctx, p)
+}
+
+// ListDirectoryEntries only lists the committed entries.
+func (s *uncommittedStore) ListDirectoryEntries(ctx context.Context, dirPath filer2.FullPath, startFileName string, includeStartFile bool, limit int) ([]*filer2.Entry, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.store.ListDirectoryEntries(ctx, dirPath, startFileName, includeStartFile, limit)
+}
+
+func (s *uncommittedStore) ListDirectoryPrefixedEntries(ctx context.Context, dirPath filer2.FullPath, startFileName string, includeStartFile bool, limit int, prefix string) ([]*filer2.Entry, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.store.ListDirectoryPrefixedEntries(ctx, dirPath, startFileName, includeStartFile, limit, prefix)
+}
+
+func (s *uncommittedStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	return context.WithValue(ctx, uncommittedChangesKey{}, uncommittedChanges{}), nil
+}
+
+func (s *uncommittedStore) CommitTransaction(ctx context.Context) error {
+	changes, _ := s.changes(ctx)
+	time.Sleep(s.commitDelay)
+	s.Lock()
+	defer s.Unlock()
+	for p, entry := range changes {
+		if entry == nil {
+			s.store.DeleteEntry(context.Background(), p)
+		} else {
+			s.store.InsertEntry(context.Background(), entry)
+		}
+	}
+	return nil
+}
+
+func (s *uncommittedStore) RollbackTransaction(ctx context.Context) error {
+	return nil
+}
+
+// testMaster assigns file ids on volume 1, served by testVolumeServer.
+type testMaster struct {
+	master_pb.SeaweedServer
+	volumeServer string
+
+	sync.Mutex
+	lastKey int
+}
+
+func (m *testMaster) KeepConnected(stream master_pb.Seaweed_KeepConnectedServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	if err := stream.Send(&master_pb.VolumeLocation{Url: m.volumeServer, PublicUrl: m.volumeServer, NewVids: []uint32{1}}); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+func (m *testMaster) Assign(ctx context.Context, req *master_pb.AssignRequest) (*master_pb.AssignResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.lastKey++
+	return &master_pb.AssignResponse{
+		Fid:       fmt.Sprintf("1,%02x12345678", m.lastKey),
+		Url:       m.volumeServer,
+		PublicUrl: m.volumeServer,
+		Count:     1,
+	}, nil
+}
+
+// testVolumeServer keeps the uploaded files in memory, by file id.
+type testVolumeServer struct {
+	sync.Mutex
+	needles map[string][]byte
+}
+
+func (v *testVolumeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fileId := strings.TrimPrefix(r.URL.Path, "/")
+	switch r.Method {
+	case "POST", "PUT":
+		reader, err := r.MultipartReader()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		part, err := reader.NextPart()
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, _ := ioutil.ReadAll(part)
+		v.put(fileId, data)
+		json.NewEncoder(w).Encode(operation.UploadResult{Name: fileId, Size: uint32(len(data))})
+	case "GET":
+		data, found := v.get(fileId)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	}
+}
+
+func (v *testVolumeServer) put(fileId string, data []byte) {
+	v.Lock()
+	defer v.Unlock()
+	v.needles[fileId] = data
+}
+
+func (v *testVolumeServer) get(fileId string) ([]byte, bool) {
+	v.Lock()
+	defer v.Unlock()
+	data, found := v.needles[fileId]
+	return data, found
+}
+
+// newTestFilerWithVolume returns a filer on an empty in memory store, connected to a test master
+// assigning the file ids on the test volume server.
+func newTestFilerWithVolume(t *testing.T) (filer *filer2.Filer, volume *testVolumeServer, cleanup func()) {
+
+	volume = &testVolumeServer{needles: make(map[string][]byte)}
+	volumeServer := httptest.NewServer(volume)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcS := util.NewGrpcServer()
+	master_pb.RegisterSeaweedServer(grpcS, &testMaster{volumeServer: strings.TrimPrefix(volumeServer.URL, "http://")})
+	go grpcS.Serve(listener)
+
+	store := &memdb.MemDbStore{}
+	store.Initialize(nil)
+	filer = filer2.NewFiler([]string{listener.Addr().String()})
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+	go filer.KeepConnectedToMaster()
+	filer.MasterClient.WaitUntilConnected()
+	for {
+		if _, err := filer.MasterClient.LookupFileId("1,0112345678"); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return filer, volume, func() {
+		grpcS.Stop()
+		volumeServer.Close()
+	}
+}
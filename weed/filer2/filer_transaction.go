@@ -0,0 +1,150 @@
+package filer2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+type filerTransactionKey struct{}
+
+// filerTransaction holds the actions to run only after the store transaction is committed,
+// e.g. sending notifications and deleting chunks, and the actions to undo in-memory changes
+// if the transaction fails. It also remembers the system records locked by it,
+// and the system entry changes to log after the other events.
+type filerTransaction struct {
+	afterCommit  []func()
+	onRollback   []func()
+	records      map[FullPath]bool
+	systemEvents []*filer_pb.EventNotification
+}
+
+// withTransaction runs fn in one store transaction. Nested calls join the outer transaction.
+func (f *Filer) withTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, found := ctx.Value(filerTransactionKey{}).(*filerTransaction); found {
+		return fn(ctx)
+	}
+
+	tx := &filerTransaction{}
+	txCtx, err := f.store.BeginTransaction(context.WithValue(ctx, filerTransactionKey{}, tx))
+	if err != nil {
+		return err
+	}
+
+	if err := fn(txCtx); err != nil {
+		if rollbackErr := f.store.RollbackTransaction(txCtx); rollbackErr != nil {
+			glog.Errorf("rollback transaction: %v", rollbackErr)
+		}
+		tx.rollback()
+		return err
+	}
+
+	if err := f.store.CommitTransaction(txCtx); err != nil {
+		tx.rollback()
+		return err
+	}
+
+	for _, action := range tx.afterCommit {
+		action()
+	}
+	for _, event := range tx.systemEvents {
+		f.logSystemEntryEvent(event)
+	}
+	return nil
+}
+
+// afterCommit runs the action after the current transaction is committed,
+// or right away if there is no transaction.
+func (f *Filer) afterCommit(ctx context.Context, action func()) {
+	if tx, found := ctx.Value(filerTransactionKey{}).(*filerTransaction); found {
+		tx.afterCommit = append(tx.afterCommit, action)
+		return
+	}
+	action()
+}
+
+// onRollback runs the action if the current transaction fails.
+func (f *Filer) onRollback(ctx context.Context, action func()) {
+	if tx, found := ctx.Value(filerTransactionKey{}).(*filerTransaction); found {
+		tx.onRollback = append(tx.onRollback, action)
+	}
+}
+
+func (tx *filerTransaction) rollback() {
+	for i := len(tx.onRollback) - 1; i >= 0; i-- {
+		tx.onRollback[i]()
+	}
+}
+
+var errRecordLockTimeout = errors.New("record lock timeout")
+
+// recordLocks serializes the read-modify-write of each system record, like the hard link
+// and quota records, within this filer. A lock is held until the transaction taking it
+// is committed or rolled back, since the stores may not isolate the concurrent transactions.
+type recordLocks struct {
+	sync.Mutex
+	locks map[FullPath]*recordLock
+}
+
+type recordLock struct {
+	held chan struct{}
+	refs int
+}
+
+// lockRecord locks the record for the current transaction, or fails with
+// errRecordLockTimeout if another transaction does not release it in time.
+func (f *Filer) lockRecord(ctx context.Context, record FullPath, timeout time.Duration) error {
+
+	tx, found := ctx.Value(filerTransactionKey{}).(*filerTransaction)
+	if !found {
+		return fmt.Errorf("%s is changed outside of a transaction", record)
+	}
+	if tx.records[record] {
+		return nil
+	}
+
+	f.records.Lock()
+	if f.records.locks == nil {
+		f.records.locks = make(map[FullPath]*recordLock)
+	}
+	lock, found := f.records.locks[record]
+	if !found {
+		lock = &recordLock{held: make(chan struct{}, 1)}
+		f.records.locks[record] = lock
+	}
+	lock.refs++
+	f.records.Unlock()
+
+	release := func() {
+		f.records.Lock()
+		defer f.records.Unlock()
+		if lock.refs--; lock.refs == 0 {
+			delete(f.records.locks, record)
+		}
+	}
+
+	select {
+	case lock.held <- struct{}{}:
+	case <-time.After(timeout):
+		release()
+		return errRecordLockTimeout
+	}
+
+	if tx.records == nil {
+		tx.records = make(map[FullPath]bool)
+	}
+	tx.records[record] = true
+	unlock := func() {
+		<-lock.held
+		release()
+	}
+	f.afterCommit(ctx, unlock)
+	f.onRollback(ctx, unlock)
+
+	return nil
+}
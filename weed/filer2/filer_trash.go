@@ -0,0 +1,286 @@
+package filer2
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+// TrashDirectory keeps the deleted entries of each user, in a sub directory named by the uid.
+// Entries deleted inside a bucket go to the .trash directory of the bucket instead.
+// Each deleted entry is moved into its own trash item directory, named by the deletion time,
+// which records the original path and the deletion time in its extended attributes.
+// Deleting an entry already in the trash deletes it permanently.
+const TrashDirectory = FullPath("/.trash")
+
+const (
+	// TrashDirectoryName is the name of the trash directory in each bucket
+	TrashDirectoryName   = ".trash"
+	trashOriginalPathKey = "trash.path"
+	trashDeletedTsNsKey  = "trash.deleted"
+	trashPurgeInterval   = 10 * time.Minute
+)
+
+type trashOption struct {
+	bucketsPath FullPath
+	retention   time.Duration
+}
+
+// EnableTrash keeps the deleted entries in the trash, and purges them after the retention period.
+func (f *Filer) EnableTrash(bucketsPath string, retention time.Duration) {
+	f.trash = &trashOption{
+		bucketsPath: FullPath(strings.TrimSuffix(bucketsPath, "/")),
+		retention:   retention,
+	}
+	go f.loopPurgingTrash()
+}
+
+// trashRoot returns the trash directory of the bucket containing the path, or of the entry owner.
+func (f *Filer) trashRoot(p FullPath, uid uint32) FullPath {
+	if bucket, rest := f.splitBucketPath(p); bucket != "" && rest != "" {
+		return NewFullPath(string(bucket), TrashDirectoryName)
+	}
+	return NewFullPath(string(TrashDirectory), strconv.FormatUint(uint64(uid), 10))
+}
+
+// splitBucketPath returns the bucket directory of the path, and the remaining path inside the bucket.
+func (f *Filer) splitBucketPath(p FullPath) (bucket FullPath, rest string) {
+	if f.trash == nil || f.trash.bucketsPath == "" || !strings.HasPrefix(string(p), string(f.trash.bucketsPath)+"/") {
+		return "", ""
+	}
+	parts := strings.SplitN(string(p)[len(f.trash.bucketsPath)+1:], "/", 2)
+	if len(parts) < 2 {
+		return NewFullPath(string(f.trash.bucketsPath), parts[0]), ""
+	}
+	return NewFullPath(string(f.trash.bucketsPath), parts[0]), parts[1]
+}
+
+// IsInTrash checks whether the path is a trash directory or inside one.
+func (f *Filer) IsInTrash(p FullPath) bool {
+	if p == TrashDirectory || strings.HasPrefix(string(p), string(TrashDirectory)+"/") {
+		return true
+	}
+	_, rest := f.splitBucketPath(p)
+	return rest == TrashDirectoryName || strings.HasPrefix(rest, TrashDirectoryName+"/")
+}
+
+func (f *Filer) shouldMoveToTrash(p FullPath, shouldDeleteChunks bool) bool {
+	return f.trash != nil && shouldDeleteChunks && p != "/" &&
+		!f.IsInTrash(p) && !IsHardLinkRecord(p) && !IsQuotaRecord(p)
+}
+
+func (f *Filer) doMoveToTrash(ctx context.Context, p FullPath, isRecursive bool) error {
+
+	entry, err := f.FindEntry(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	if entry.IsDirectory() {
+		if !isRecursive {
+			entries, err := f.ListDirectoryEntries(ctx, p, "", false, 1)
+			if err != nil {
+				return fmt.Errorf("list folder %s: %v", p, err)
+			}
+			if len(entries) > 0 {
+				return fmt.Errorf("folder %s is not empty", p)
+			}
+		}
+		quotaDirs, err := f.quotasUnder(ctx, p)
+		if err != nil {
+			return err
+		}
+		for _, quotaDir := range quotaDirs {
+			if err := f.removeDeletedDirectoryQuota(ctx, quotaDir); err != nil {
+				return err
+			}
+		}
+	}
+
+	root := f.trashRoot(p, entry.Uid)
+	now := time.Now()
+	tsNs := now.UnixNano()
+	for {
+		if _, err := f.FindEntry(ctx, NewFullPath(string(root), strconv.FormatInt(tsNs, 10))); err == ErrNotFound {
+			break
+		} else if err != nil {
+			return err
+		}
+		tsNs++
+	}
+
+	item := &Entry{
+		FullPath: NewFullPath(string(root), strconv.FormatInt(tsNs, 10)),
+		Attr: Attr{
+			Mtime:  now,
+			Crtime: now,
+			Mode:   os.ModeDir | 0700,
+			Uid:    entry.Uid,
+			Gid:    entry.Gid,
+		},
+		Extended: map[string][]byte{
+			trashOriginalPathKey: []byte(p),
+			trashDeletedTsNsKey:  []byte(strconv.FormatInt(tsNs, 10)),
+		},
+	}
+	if err := f.doCreateEntry(ctx, item); err != nil {
+		return fmt.Errorf("create trash item %s: %v", item.FullPath, err)
+	}
+
+	glog.V(2).Infof("move %s to trash %s", p, item.FullPath)
+
+	return f.moveEntry(ctx, entry, NewFullPath(string(item.FullPath), entry.Name()))
+}
+
+// RestoreFromTrash moves the entry in the trash item back to its original path,
+// which must not exist, and removes the trash item.
+func (f *Filer) RestoreFromTrash(ctx context.Context, itemPath FullPath) error {
+
+	item, err := f.FindEntry(ctx, itemPath)
+	if err != nil {
+		return fmt.Errorf("restore %s: %v", itemPath, err)
+	}
+	originalPath := FullPath(item.Extended[trashOriginalPathKey])
+	if !f.IsInTrash(itemPath) || originalPath == "" {
+		return fmt.Errorf("restore %s: not a trash item", itemPath)
+	}
+
+	return f.withTransaction(ctx, func(ctx context.Context) error {
+
+		_, name := originalPath.DirAndName()
+		entry, err := f.FindEntry(ctx, NewFullPath(string(itemPath), name))
+		if err != nil {
+			return fmt.Errorf("restore %s: %v", itemPath, err)
+		}
+
+		if _, err := f.FindEntry(ctx, originalPath); err == nil {
+			return fmt.Errorf("restore to %s: already exists", originalPath)
+		} else if err != ErrNotFound {
+			return fmt.Errorf("restore to %s: %v", originalPath, err)
+		}
+
+		if err := f.ensureParentDirectory(ctx, originalPath, entry); err != nil {
+			return fmt.Errorf("restore to %s: %v", originalPath, err)
+		}
+
+		if err := f.moveEntry(ctx, entry, originalPath); err != nil {
+			return err
+		}
+
+		glog.V(2).Infof("restore %s from trash %s", originalPath, itemPath)
+
+		return f.doDeleteEntryMetaAndData(ctx, itemPath, false, false)
+	})
+}
+
+// ListTrash returns the trash items of all users and buckets, sorted by the deletion time.
+func (f *Filer) ListTrash(ctx context.Context) ([]*filer_pb.TrashEntry, error) {
+
+	var roots []FullPath
+	if err := f.eachEntry(ctx, TrashDirectory, func(entry *Entry) error {
+		roots = append(roots, entry.FullPath)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if f.trash != nil && f.trash.bucketsPath != "" {
+		if err := f.eachEntry(ctx, f.trash.bucketsPath, func(entry *Entry) error {
+			if entry.IsDirectory() {
+				roots = append(roots, NewFullPath(string(entry.FullPath), TrashDirectoryName))
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	var trashEntries []*filer_pb.TrashEntry
+	for _, root := range roots {
+		if err := f.eachEntry(ctx, root, func(item *Entry) error {
+			originalPath := FullPath(item.Extended[trashOriginalPathKey])
+			if originalPath == "" {
+				return nil
+			}
+			deletedTsNs, err := strconv.ParseInt(string(item.Extended[trashDeletedTsNsKey]), 10, 64)
+			if err != nil {
+				deletedTsNs = item.Crtime.UnixNano()
+			}
+			trashEntry := &filer_pb.TrashEntry{
+				TrashPath:    string(item.FullPath),
+				OriginalPath: string(originalPath),
+				DeletedTsNs:  deletedTsNs,
+			}
+			_, name := originalPath.DirAndName()
+			if entry, err := f.FindEntry(ctx, NewFullPath(string(item.FullPath), name)); err == nil {
+				trashEntry.IsDirectory = entry.IsDirectory()
+				trashEntry.FileSize = entry.Size()
+			}
+			trashEntries = append(trashEntries, trashEntry)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(trashEntries, func(i, j int) bool {
+		return trashEntries[i].DeletedTsNs < trashEntries[j].DeletedTsNs
+	})
+
+	return trashEntries, nil
+}
+
+// PurgeTrash permanently deletes the trash items deleted before the time, and their file chunks.
+func (f *Filer) PurgeTrash(ctx context.Context, before time.Time) error {
+
+	trashEntries, err := f.ListTrash(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, trashEntry := range trashEntries {
+		if trashEntry.DeletedTsNs >= before.UnixNano() {
+			break
+		}
+		glog.V(1).Infof("purge trash %s of %s", trashEntry.TrashPath, trashEntry.OriginalPath)
+		if err := f.DeleteEntryMetaAndData(ctx, FullPath(trashEntry.TrashPath), true, true); err != nil && err != ErrNotFound {
+			return fmt.Errorf("purge %s: %v", trashEntry.TrashPath, err)
+		}
+	}
+
+	return nil
+}
+
+func (f *Filer) loopPurgingTrash() {
+	for {
+		time.Sleep(trashPurgeInterval)
+		if err := f.PurgeTrash(context.Background(), time.Now().Add(-f.trash.retention)); err != nil {
+			glog.Errorf("purge trash: %v", err)
+		}
+	}
+}
+
+func (f *Filer) eachEntry(ctx context.Context, dir FullPath, fn func(entry *Entry) error) error {
+	lastFileName := ""
+	for {
+		entries, err := f.ListDirectoryEntries(ctx, dir, lastFileName, false, 1024)
+		if err != nil {
+			return fmt.Errorf("list %s: %v", dir, err)
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+		if len(entries) < 1024 {
+			return nil
+		}
+	}
+}
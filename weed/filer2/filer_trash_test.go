@@ -0,0 +1,66 @@
+package filer2_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestTrash(t *testing.T) {
+	ctx := context.Background()
+	filer := newTestFiler()
+	filer.EnableTrash("/buckets", time.Hour)
+
+	for _, p := range []string{"/home/chris/dir/file1.jpg", "/buckets/b1/photos/file2.jpg"} {
+		if err := filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: filer2.FullPath(p),
+			Attr:     filer2.Attr{Mode: 0440, Uid: 1234},
+			Chunks:   []*filer_pb.FileChunk{{FileId: p, Size: 100}},
+		}); err != nil {
+			t.Fatalf("create %s: %v", p, err)
+		}
+	}
+
+	if err := filer.DeleteEntryMetaAndData(ctx, "/home/chris/dir", true, true); err != nil {
+		t.Fatalf("delete dir: %v", err)
+	}
+	if err := filer.DeleteEntryMetaAndData(ctx, "/buckets/b1/photos/file2.jpg", false, true); err != nil {
+		t.Fatalf("delete file: %v", err)
+	}
+
+	trashEntries, err := filer.ListTrash(ctx)
+	if err != nil || len(trashEntries) != 2 {
+		t.Fatalf("list trash %v: %v", trashEntries, err)
+	}
+	dirItem, fileItem := trashEntries[0], trashEntries[1]
+	if dirItem.OriginalPath != "/home/chris/dir" || !dirItem.IsDirectory || !strings.HasPrefix(dirItem.TrashPath, "/.trash/1234/") {
+		t.Fatalf("unexpected trash entry %+v", dirItem)
+	}
+	if fileItem.OriginalPath != "/buckets/b1/photos/file2.jpg" || fileItem.FileSize != 100 || !strings.HasPrefix(fileItem.TrashPath, "/buckets/b1/.trash/") {
+		t.Fatalf("unexpected trash entry %+v", fileItem)
+	}
+	if _, err := filer.FindEntry(ctx, filer2.FullPath(dirItem.TrashPath+"/dir/file1.jpg")); err != nil {
+		t.Fatalf("find in trash: %v", err)
+	}
+
+	if err := filer.RestoreFromTrash(ctx, filer2.FullPath(dirItem.TrashPath)); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if _, err := filer.FindEntry(ctx, "/home/chris/dir/file1.jpg"); err != nil {
+		t.Fatalf("find restored: %v", err)
+	}
+	if _, err := filer.FindEntry(ctx, filer2.FullPath(dirItem.TrashPath)); err != filer2.ErrNotFound {
+		t.Fatalf("restored trash item: %v", err)
+	}
+
+	if err := filer.PurgeTrash(ctx, time.Now()); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if trashEntries, err = filer.ListTrash(ctx); err != nil || len(trashEntries) != 0 {
+		t.Fatalf("list trash after purge %v: %v", trashEntries, err)
+	}
+}
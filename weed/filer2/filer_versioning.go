@@ -0,0 +1,555 @@
+package filer2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/gogo/protobuf/proto"
+)
+
+// VersionDirectory keeps the versioning configurations of the directories, and the previous versions
+// of the files under them. The previous versions of a file are saved in a directory named by the
+// escaped file path, each version named by the time it was replaced, with the old attributes,
+// extended attributes, and chunks. The versions follow the file when it is renamed, and are
+// deleted along with the file when it is deleted from a directory without versioning.
+// The chunks shared between the live file and its versions are only deleted when none references them.
+const VersionDirectory = FullPath("/.versions")
+
+const (
+	versioningConfigDirectory = VersionDirectory + "/config"
+	versionDataDirectory      = VersionDirectory + "/data"
+	versioningExtendedKey     = "versioning"
+	versionExpirationInterval = 10 * time.Minute
+	versioningLockTimeout     = 10 * time.Second
+)
+
+func IsVersionPath(p FullPath) bool {
+	return p == VersionDirectory || strings.HasPrefix(string(p), string(VersionDirectory)+"/")
+}
+
+func versioningConfigPath(dir FullPath) FullPath {
+	return NewFullPath(string(versioningConfigDirectory), url.QueryEscape(string(dir)))
+}
+
+func fileVersionsDirectory(p FullPath) FullPath {
+	return NewFullPath(string(versionDataDirectory), url.QueryEscape(string(p)))
+}
+
+// findVersioningConfig reads the versioning configuration of the directory from the store, or returns nil if it has none.
+func (f *Filer) findVersioningConfig(ctx context.Context, dir FullPath) (*filer_pb.VersioningConfig, error) {
+	record, err := f.store.FindEntry(ctx, versioningConfigPath(dir))
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find versioning of %s: %v", dir, err)
+	}
+	return decodeVersioningConfig(record)
+}
+
+func decodeVersioningConfig(record *Entry) (*filer_pb.VersioningConfig, error) {
+	config := &filer_pb.VersioningConfig{}
+	if err := proto.Unmarshal(record.Extended[versioningExtendedKey], config); err != nil {
+		return nil, fmt.Errorf("decode versioning %s: %v", record.FullPath, err)
+	}
+	return config, nil
+}
+
+// ConfigureVersioning enables or disables keeping the previous versions of the files under the directory.
+// The versions beyond the max number of versions, or replaced longer than the max age ago, are expired.
+// The existing versions are kept when the versioning is disabled.
+func (f *Filer) ConfigureVersioning(ctx context.Context, dir FullPath, enabled bool, maxVersions uint32, maxAge time.Duration) error {
+
+	if IsVersionPath(dir) || IsSnapshotPath(dir) {
+		return fmt.Errorf("can not configure versioning on %s", dir)
+	}
+	dirEntry, err := f.FindEntry(ctx, dir)
+	if err != nil && dir != "/" {
+		return fmt.Errorf("versioning on %s: %v", dir, err)
+	}
+	if dirEntry != nil && !dirEntry.IsDirectory() {
+		return fmt.Errorf("versioning on %s: not a directory", dir)
+	}
+
+	return f.withTransaction(ctx, func(ctx context.Context) error {
+
+		if err := f.lockRecord(ctx, versioningConfigPath(dir), versioningLockTimeout); err != nil {
+			return fmt.Errorf("versioning on %s: %v", dir, err)
+		}
+
+		existing, err := f.findVersioningConfig(ctx, dir)
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			if existing == nil {
+				return nil
+			}
+			if err := f.store.DeleteEntry(ctx, versioningConfigPath(dir)); err != nil {
+				return fmt.Errorf("disable versioning on %s: %v", dir, err)
+			}
+			return nil
+		}
+
+		data, err := proto.Marshal(&filer_pb.VersioningConfig{
+			Directory:     string(dir),
+			MaxVersions:   maxVersions,
+			MaxAgeSeconds: int64(maxAge / time.Second),
+		})
+		if err != nil {
+			return err
+		}
+		if err := f.ensureSystemDirectory(ctx, versioningConfigDirectory); err != nil {
+			return err
+		}
+		now := time.Now()
+		record := &Entry{
+			FullPath: versioningConfigPath(dir),
+			Attr:     Attr{Mtime: now, Crtime: now, Mode: 0600},
+			Extended: map[string][]byte{versioningExtendedKey: data},
+		}
+		if existing != nil {
+			err = f.store.UpdateEntry(ctx, record)
+		} else {
+			err = f.store.InsertEntry(ctx, record)
+		}
+		if err != nil {
+			return fmt.Errorf("enable versioning on %s: %v", dir, err)
+		}
+		return nil
+	})
+}
+
+// ListVersioningConfigs returns the versioning configurations, sorted by directory.
+func (f *Filer) ListVersioningConfigs(ctx context.Context) ([]*filer_pb.VersioningConfig, error) {
+
+	var configs []*filer_pb.VersioningConfig
+	if err := f.eachEntry(ctx, versioningConfigDirectory, func(record *Entry) error {
+		config, err := decodeVersioningConfig(record)
+		if err != nil {
+			return err
+		}
+		configs = append(configs, config)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].Directory < configs[j].Directory
+	})
+
+	return configs, nil
+}
+
+// versioningConfig returns the configuration of the closest parent directory with versioning, or nil.
+// The configurations are read from the store, so the changes made through other filers are seen at once.
+func (f *Filer) versioningConfig(ctx context.Context, p FullPath) (*filer_pb.VersioningConfig, error) {
+
+	if IsVersionPath(p) || IsSnapshotPath(p) || IsHardLinkRecord(p) || IsQuotaRecord(p) || f.IsInTrash(p) {
+		return nil, nil
+	}
+
+	if _, err := f.store.FindEntry(ctx, versioningConfigDirectory); err == ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("find %s: %v", versioningConfigDirectory, err)
+	}
+
+	for dir := p; dir != "/"; {
+		parent, _ := dir.DirAndName()
+		dir = FullPath(parent)
+		config, err := f.findVersioningConfig(ctx, dir)
+		if err != nil || config != nil {
+			return config, err
+		}
+	}
+	return nil, nil
+}
+
+// saveVersion keeps the replaced or deleted file as a version, and expires the versions over the limits.
+func (f *Filer) saveVersion(ctx context.Context, oldEntry *Entry, liveChunks []*filer_pb.FileChunk, config *filer_pb.VersioningConfig) error {
+
+	versionsDir := fileVersionsDirectory(oldEntry.FullPath)
+	if err := f.ensureSystemDirectory(ctx, versionsDir); err != nil {
+		return err
+	}
+
+	tsNs := time.Now().UnixNano()
+	for {
+		if _, err := f.store.FindEntry(ctx, NewFullPath(string(versionsDir), versionId(tsNs))); err == ErrNotFound {
+			break
+		} else if err != nil {
+			return err
+		}
+		tsNs++
+	}
+
+	version := &Entry{
+		FullPath: NewFullPath(string(versionsDir), versionId(tsNs)),
+		Attr:     oldEntry.Attr,
+		Extended: oldEntry.Extended,
+		Chunks:   oldEntry.Chunks,
+		Content:  oldEntry.Content,
+	}
+	if err := f.store.InsertEntry(ctx, version); err != nil {
+		return fmt.Errorf("save version of %s: %v", oldEntry.FullPath, err)
+	}
+
+	glog.V(2).Infof("saved version %s of %s", version.Name(), oldEntry.FullPath)
+
+	return f.expireVersions(ctx, oldEntry.FullPath, liveChunks, config, time.Now())
+}
+
+// version ids are the replacing time, zero padded to be sorted by the time
+func versionId(tsNs int64) string {
+	return fmt.Sprintf("%019d", tsNs)
+}
+
+func (f *Filer) listVersionEntries(ctx context.Context, p FullPath) (versions []*Entry, err error) {
+	err = f.eachEntry(ctx, fileVersionsDirectory(p), func(entry *Entry) error {
+		versions = append(versions, entry)
+		return nil
+	})
+	return
+}
+
+// ListVersions returns the previous versions of the file, the oldest first.
+func (f *Filer) ListVersions(ctx context.Context, p FullPath) ([]*filer_pb.FileVersion, error) {
+
+	versions, err := f.listVersionEntries(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	var fileVersions []*filer_pb.FileVersion
+	for _, version := range versions {
+		tsNs, _ := strconv.ParseInt(version.Name(), 10, 64)
+		entry := version.ToProtoEntry()
+		entry.Name = p.Name()
+		fileVersions = append(fileVersions, &filer_pb.FileVersion{
+			VersionId:    version.Name(),
+			ReplacedTsNs: tsNs,
+			Entry:        entry,
+		})
+	}
+
+	return fileVersions, nil
+}
+
+// FindVersion returns the previous version of the file, with the path of the file.
+func (f *Filer) FindVersion(ctx context.Context, p FullPath, versionId string) (*Entry, error) {
+
+	if versionId == "" || strings.Contains(versionId, "/") {
+		return nil, ErrNotFound
+	}
+
+	version, err := f.store.FindEntry(ctx, NewFullPath(string(fileVersionsDirectory(p)), versionId))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Entry{
+		FullPath: p,
+		Attr:     version.Attr,
+		Extended: version.Extended,
+		Chunks:   version.Chunks,
+		Content:  version.Content,
+	}, nil
+}
+
+// RestoreVersion replaces the file with the previous version. The current file is kept as a new
+// version if its directory still has versioning.
+func (f *Filer) RestoreVersion(ctx context.Context, p FullPath, versionId string) error {
+
+	restored, err := f.FindVersion(ctx, p, versionId)
+	if err != nil {
+		return fmt.Errorf("version %s of %s: %v", versionId, p, err)
+	}
+
+	versionPath := NewFullPath(string(fileVersionsDirectory(p)), versionId)
+	if err := f.createEntry(ctx, restored, func(ctx context.Context) error {
+		// remove the version first, so its chunks are not referenced twice
+		if _, err := f.store.FindEntry(ctx, versionPath); err != nil {
+			return fmt.Errorf("version %s: %v", versionId, err)
+		}
+		if err := f.store.DeleteEntry(ctx, versionPath); err != nil {
+			return fmt.Errorf("delete version %s: %v", versionId, err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("restore %s: %v", p, err)
+	}
+
+	glog.V(2).Infof("restored version %s of %s", versionId, p)
+
+	return nil
+}
+
+// expireVersions deletes the versions over the max number of versions or the max age,
+// and their chunks no longer referenced by the live file or the remaining versions.
+func (f *Filer) expireVersions(ctx context.Context, p FullPath, liveChunks []*filer_pb.FileChunk, config *filer_pb.VersioningConfig, now time.Time) error {
+
+	if config.MaxVersions == 0 && config.MaxAgeSeconds == 0 {
+		return nil
+	}
+
+	versions, err := f.listVersionEntries(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	var expired, remaining []*Entry
+	for i, version := range versions {
+		tsNs, _ := strconv.ParseInt(version.Name(), 10, 64)
+		tooMany := config.MaxVersions > 0 && len(versions)-i > int(config.MaxVersions)
+		tooOld := config.MaxAgeSeconds > 0 && now.Sub(time.Unix(0, tsNs)) > time.Duration(config.MaxAgeSeconds)*time.Second
+		if tooMany || tooOld {
+			expired = append(expired, version)
+		} else {
+			remaining = append(remaining, version)
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	referenced := make(map[string]bool)
+	liveChunks, err = f.resolveChunks(liveChunks)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range liveChunks {
+		referenced[chunk.FileId] = true
+	}
+	for _, version := range remaining {
+		versionChunks, err := f.resolveChunks(version.Chunks)
+		if err != nil {
+			return err
+		}
+		for _, chunk := range versionChunks {
+			referenced[chunk.FileId] = true
+		}
+	}
+
+	var toDelete []*filer_pb.FileChunk
+	for _, version := range expired {
+		glog.V(2).Infof("expire version %s of %s", version.Name(), p)
+		versionChunks, err := f.resolveChunks(version.Chunks)
+		if err != nil {
+			return err
+		}
+		if err := f.store.DeleteEntry(ctx, version.FullPath); err != nil {
+			return fmt.Errorf("expire version %s of %s: %v", version.Name(), p, err)
+		}
+		for _, chunk := range versionChunks {
+			if !referenced[chunk.FileId] {
+				referenced[chunk.FileId] = true
+				toDelete = append(toDelete, chunk)
+			}
+		}
+	}
+	if len(remaining) == 0 {
+		if err := f.store.DeleteEntry(ctx, fileVersionsDirectory(p)); err != nil {
+			return fmt.Errorf("delete versions of %s: %v", p, err)
+		}
+	}
+
+	f.afterCommit(ctx, func() {
+		f.DeleteChunks(toDelete)
+	})
+
+	return nil
+}
+
+// deleteVersions removes all versions of the deleted file, and returns their chunks.
+func (f *Filer) deleteVersions(ctx context.Context, p FullPath) (chunks []*filer_pb.FileChunk, err error) {
+
+	versions, err := f.listVersionEntries(ctx, p)
+	if err != nil || len(versions) == 0 {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		if err := f.store.DeleteEntry(ctx, version.FullPath); err != nil {
+			return nil, fmt.Errorf("delete version %s of %s: %v", version.Name(), p, err)
+		}
+		chunks = append(chunks, version.Chunks...)
+	}
+	if err := f.store.DeleteEntry(ctx, fileVersionsDirectory(p)); err != nil {
+		return nil, fmt.Errorf("delete versions of %s: %v", p, err)
+	}
+
+	return chunks, nil
+}
+
+// moveVersions moves the versions along with the renamed file.
+func (f *Filer) moveVersions(ctx context.Context, oldPath, newPath FullPath) error {
+
+	versions, err := f.listVersionEntries(ctx, oldPath)
+	if err != nil || len(versions) == 0 {
+		return err
+	}
+
+	newVersionsDir := fileVersionsDirectory(newPath)
+	if err := f.ensureSystemDirectory(ctx, newVersionsDir); err != nil {
+		return err
+	}
+	for _, version := range versions {
+		moved := *version
+		moved.FullPath = NewFullPath(string(newVersionsDir), version.Name())
+		if err := f.store.InsertEntry(ctx, &moved); err != nil {
+			return fmt.Errorf("move version %s of %s: %v", version.Name(), oldPath, err)
+		}
+		if err := f.store.DeleteEntry(ctx, version.FullPath); err != nil {
+			return fmt.Errorf("move version %s of %s: %v", version.Name(), oldPath, err)
+		}
+	}
+
+	return f.store.DeleteEntry(ctx, fileVersionsDirectory(oldPath))
+}
+
+// keepOrDeleteReplacedChunks handles the chunks of the old file no longer used by the new file.
+// They are kept as a new version if the directory has versioning, or deleted unless referenced by existing versions.
+func (f *Filer) keepOrDeleteReplacedChunks(ctx context.Context, oldEntry, newEntry *Entry) error {
+
+	if oldEntry == nil || oldEntry.IsDirectory() {
+		return nil
+	}
+	unused, err := f.FindUnusedChunks(oldEntry.Chunks, newEntry.Chunks)
+	if err != nil {
+		return err
+	}
+	contentReplaced := len(oldEntry.Content) > 0 && !bytes.Equal(oldEntry.Content, newEntry.Content)
+	if len(unused) == 0 && !contentReplaced {
+		return nil
+	}
+
+	config, err := f.versioningConfig(ctx, newEntry.FullPath)
+	if err != nil {
+		return err
+	}
+	if config != nil {
+		return f.saveVersion(ctx, oldEntry, newEntry.Chunks, config)
+	}
+
+	return f.deleteReplacedChunks(ctx, oldEntry, unused)
+}
+
+// deleteReplacedChunks deletes the chunks no longer used by the file, unless its versions still use them.
+func (f *Filer) deleteReplacedChunks(ctx context.Context, oldEntry *Entry, unused []*filer_pb.FileChunk) error {
+
+	unused, err := f.unusedByVersions(ctx, oldEntry.FullPath, unused)
+	if err != nil {
+		return err
+	}
+
+	f.afterCommit(ctx, func() {
+		f.DeleteChunks(unused)
+	})
+
+	return nil
+}
+
+// unusedByVersions returns the chunks not used by any version of the file.
+func (f *Filer) unusedByVersions(ctx context.Context, p FullPath, chunks []*filer_pb.FileChunk) ([]*filer_pb.FileChunk, error) {
+	versions, err := f.listVersionEntries(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	for _, version := range versions {
+		if chunks, err = f.FindUnusedChunks(chunks, version.Chunks); err != nil {
+			return nil, err
+		}
+	}
+	return chunks, nil
+}
+
+// deletedFileChunks returns the chunks to delete for the deleted file. The file is kept as a version
+// if its directory has versioning. Otherwise, its versions are deleted too.
+func (f *Filer) deletedFileChunks(ctx context.Context, entry *Entry) ([]*filer_pb.FileChunk, error) {
+
+	if entry.IsDirectory() {
+		return entry.Chunks, nil
+	}
+
+	config, err := f.versioningConfig(ctx, entry.FullPath)
+	if err != nil {
+		return nil, err
+	}
+	if config != nil {
+		return nil, f.saveVersion(ctx, entry, nil, config)
+	}
+
+	chunks, err := f.resolveChunks(entry.Chunks)
+	if err != nil {
+		return nil, err
+	}
+
+	versionChunks, err := f.deleteVersions(ctx, entry.FullPath)
+	if err != nil {
+		return nil, err
+	}
+	unusedVersionChunks, err := f.FindUnusedChunks(versionChunks, entry.Chunks)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(chunks, unusedVersionChunks...), nil
+}
+
+// KeepExpiringVersions periodically expires the versions over the age limits.
+func (f *Filer) KeepExpiringVersions() {
+	for {
+		time.Sleep(versionExpirationInterval)
+		if err := f.ExpireVersions(context.Background(), time.Now()); err != nil {
+			glog.Errorf("expire versions: %v", err)
+		}
+	}
+}
+
+// ExpireVersions checks the versions of all files for the limits of their directories.
+func (f *Filer) ExpireVersions(ctx context.Context, now time.Time) error {
+
+	var paths []FullPath
+	if err := f.eachEntry(ctx, versionDataDirectory, func(entry *Entry) error {
+		p, err := url.QueryUnescape(entry.Name())
+		if err != nil {
+			return fmt.Errorf("decode versions %s: %v", entry.FullPath, err)
+		}
+		paths = append(paths, FullPath(p))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, p := range paths {
+		config, err := f.versioningConfig(ctx, p)
+		if err != nil {
+			return err
+		}
+		if config == nil {
+			continue
+		}
+		if err := f.withTransaction(ctx, func(ctx context.Context) error {
+			var liveChunks []*filer_pb.FileChunk
+			if entry, err := f.FindEntry(ctx, p); err == nil {
+				liveChunks = entry.Chunks
+			} else if err != ErrNotFound {
+				return err
+			}
+			return f.expireVersions(ctx, p, liveChunks, config, now)
+		}); err != nil {
+			return fmt.Errorf("expire versions of %s: %v", p, err)
+		}
+	}
+
+	return nil
+}
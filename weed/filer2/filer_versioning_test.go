@@ -0,0 +1,175 @@
+package filer2_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/filer2/memdb"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestVersioning(t *testing.T) {
+	ctx := context.Background()
+	filer := newTestFiler()
+
+	write := func(fileId string) {
+		if err := filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: "/docs/a.txt",
+			Attr:     filer2.Attr{Mode: 0644},
+			Chunks:   []*filer_pb.FileChunk{{FileId: fileId, Size: 10}},
+		}); err != nil {
+			t.Fatalf("write %s: %v", fileId, err)
+		}
+	}
+	versionFileIds := func(p filer2.FullPath) (fileIds []string) {
+		versions, err := filer.ListVersions(ctx, p)
+		if err != nil {
+			t.Fatalf("list versions: %v", err)
+		}
+		for _, version := range versions {
+			fileIds = append(fileIds, version.Entry.Chunks[0].FileId)
+		}
+		return
+	}
+
+	write("1,01")
+	if err := filer.ConfigureVersioning(ctx, "/docs", true, 2, 0); err != nil {
+		t.Fatalf("configure versioning: %v", err)
+	}
+	write("1,02")
+	write("1,03")
+	write("1,04")
+
+	// only the latest 2 versions are kept
+	if fileIds := versionFileIds("/docs/a.txt"); len(fileIds) != 2 || fileIds[0] != "1,02" || fileIds[1] != "1,03" {
+		t.Fatalf("unexpected versions %v", fileIds)
+	}
+
+	versions, _ := filer.ListVersions(ctx, "/docs/a.txt")
+	version, err := filer.FindVersion(ctx, "/docs/a.txt", versions[0].VersionId)
+	if err != nil || version.Chunks[0].FileId != "1,02" {
+		t.Fatalf("find version %+v: %v", version, err)
+	}
+
+	// restoring keeps the current content as a new version
+	if err := filer.RestoreVersion(ctx, "/docs/a.txt", versions[0].VersionId); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	entry, err := filer.FindEntry(ctx, "/docs/a.txt")
+	if err != nil || entry.Chunks[0].FileId != "1,02" {
+		t.Fatalf("restored entry %+v: %v", entry, err)
+	}
+	if fileIds := versionFileIds("/docs/a.txt"); len(fileIds) != 2 || fileIds[0] != "1,03" || fileIds[1] != "1,04" {
+		t.Fatalf("versions after restore %v", fileIds)
+	}
+
+	// the versions follow the renamed file, and a deleted file is kept as a version
+	if err := filer.AtomicRenameEntry(ctx, "/docs/a.txt", "/docs/b.txt"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if err := filer.DeleteEntryMetaAndData(ctx, "/docs/b.txt", false, true); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if fileIds := versionFileIds("/docs/a.txt"); len(fileIds) != 0 {
+		t.Fatalf("versions left behind %v", fileIds)
+	}
+	if fileIds := versionFileIds("/docs/b.txt"); len(fileIds) != 2 || fileIds[1] != "1,02" {
+		t.Fatalf("versions after rename and delete %v", fileIds)
+	}
+}
+
+func TestVersioningConfiguredByAnotherFiler(t *testing.T) {
+	ctx := context.Background()
+	store := &memdb.MemDbStore{}
+	store.Initialize(nil)
+	filer, other := newTestFilerOn(store), newTestFilerOn(store)
+
+	write := func(fileId string) {
+		if err := filer.CreateEntry(ctx, &filer2.Entry{
+			FullPath: "/docs/a.txt",
+			Attr:     filer2.Attr{Mode: 0644},
+			Chunks:   []*filer_pb.FileChunk{{FileId: fileId, Size: 10}},
+		}); err != nil {
+			t.Fatalf("write %s: %v", fileId, err)
+		}
+	}
+	versionCount := func() int {
+		versions, err := filer.ListVersions(ctx, "/docs/a.txt")
+		if err != nil {
+			t.Fatalf("list versions: %v", err)
+		}
+		return len(versions)
+	}
+
+	write("1,01")
+	write("1,02")
+	if count := versionCount(); count != 0 {
+		t.Fatalf("%d versions kept without versioning", count)
+	}
+
+	if err := other.ConfigureVersioning(ctx, "/docs", true, 0, 0); err != nil {
+		t.Fatalf("configure versioning: %v", err)
+	}
+	write("1,03")
+	if count := versionCount(); count != 1 {
+		t.Fatalf("%d versions kept after another filer enabled versioning", count)
+	}
+	if configs, err := filer.ListVersioningConfigs(ctx); err != nil || len(configs) != 1 || configs[0].Directory != "/docs" {
+		t.Fatalf("versioning configs %v: %v", configs, err)
+	}
+
+	if err := other.ConfigureVersioning(ctx, "/docs", false, 0, 0); err != nil {
+		t.Fatalf("disable versioning: %v", err)
+	}
+	write("1,04")
+	if count := versionCount(); count != 1 {
+		t.Fatalf("%d versions kept after another filer disabled versioning", count)
+	}
+}
+
+func TestRestoreVersionFoldsChunks(t *testing.T) {
+	filer, _, cleanup := newTestFilerWithVolume(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	huge := &filer2.Entry{FullPath: "/docs/a.txt", Attr: filer2.Attr{Mode: 0644}}
+	for i := 0; i < filer2.ManifestBatch; i++ {
+		huge.Chunks = append(huge.Chunks, &filer_pb.FileChunk{
+			FileId: fmt.Sprintf("1,%x87654321", i+1),
+			Offset: int64(i),
+			Size:   1,
+		})
+	}
+	if err := filer.CreateEntry(ctx, huge); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := filer.ConfigureVersioning(ctx, "/docs", true, 0, 0); err != nil {
+		t.Fatalf("configure versioning: %v", err)
+	}
+	if err := filer.CreateEntry(ctx, &filer2.Entry{
+		FullPath: "/docs/a.txt",
+		Attr:     filer2.Attr{Mode: 0644},
+		Chunks:   []*filer_pb.FileChunk{{FileId: "1,ff87654321", Size: 1}},
+	}); err != nil {
+		t.Fatalf("overwrite: %v", err)
+	}
+
+	// the restored file is saved like a new file, with its chunks folded into manifests
+	filer.EnableChunkManifest()
+	versions, err := filer.ListVersions(ctx, "/docs/a.txt")
+	if err != nil || len(versions) != 1 {
+		t.Fatalf("versions %v: %v", versions, err)
+	}
+	if err := filer.RestoreVersion(ctx, "/docs/a.txt", versions[0].VersionId); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	entry, err := filer.FindEntry(ctx, "/docs/a.txt")
+	if err != nil || !filer2.HasChunkManifest(entry.Chunks) {
+		t.Fatalf("restored chunks are not folded: %v", err)
+	}
+	if err := filer.RestoreVersion(ctx, "/docs/a.txt", versions[0].VersionId); err == nil {
+		t.Fatalf("restored a version twice")
+	}
+}
@@ -1,7 +1,9 @@
 package filer2
 
 import (
+	"context"
 	"errors"
+
 	"github.com/draleyva/seaweedfs/weed/util"
 )
 
@@ -10,12 +12,36 @@ type FilerStore interface {
 	GetName() string
 	// Initialize initializes the file store
 	Initialize(configuration util.Configuration) error
-	InsertEntry(*Entry) error
-	UpdateEntry(*Entry) (err error)
+	InsertEntry(ctx context.Context, entry *Entry) error
+	UpdateEntry(ctx context.Context, entry *Entry) (err error)
 	// err == filer2.ErrNotFound if not found
-	FindEntry(FullPath) (entry *Entry, err error)
-	DeleteEntry(FullPath) (err error)
-	ListDirectoryEntries(dirPath FullPath, startFileName string, includeStartFile bool, limit int) ([]*Entry, error)
+	FindEntry(ctx context.Context, p FullPath) (entry *Entry, err error)
+	DeleteEntry(ctx context.Context, p FullPath) (err error)
+	ListDirectoryEntries(ctx context.Context, dirPath FullPath, startFileName string, includeStartFile bool, limit int) ([]*Entry, error)
+	// ListDirectoryPrefixedEntries only lists the entries whose names start with the prefix,
+	// scanning the key range of the prefix if the store supports it.
+	ListDirectoryPrefixedEntries(ctx context.Context, dirPath FullPath, startFileName string, includeStartFile bool, limit int, prefix string) ([]*Entry, error)
+
+	// BeginTransaction returns a context carrying the transaction, which should be passed
+	// to the other store methods. Stores without transaction support return the same context,
+	// e.g. by embedding NoTransactions.
+	BeginTransaction(ctx context.Context) (context.Context, error)
+	CommitTransaction(ctx context.Context) error
+	RollbackTransaction(ctx context.Context) error
 }
 
 var ErrNotFound = errors.New("filer: no entry is found in filer store")
+
+// NoTransactions implements the transaction methods of a FilerStore without transaction support,
+// to be embedded in the store.
+type NoTransactions struct{}
+
+func (NoTransactions) BeginTransaction(ctx context.Context) (context.Context, error) {
+	return ctx, nil
+}
+func (NoTransactions) CommitTransaction(ctx context.Context) error {
+	return nil
+}
+func (NoTransactions) RollbackTransaction(ctx context.Context) error {
+	return nil
+}
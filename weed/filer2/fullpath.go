@@ -29,3 +29,8 @@ func (fp FullPath) Name() string {
 	_, name := filepath.Split(string(fp))
 	return name
 }
+
+// RelativeTo returns the path relative to the directory containing it.
+func (fp FullPath) RelativeTo(dir FullPath) string {
+	return strings.TrimPrefix(string(fp), strings.TrimSuffix(string(dir), "/")+"/")
+}
@@ -2,11 +2,14 @@ package leveldb
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/draleyva/seaweedfs/weed/filer2"
 	"github.com/draleyva/seaweedfs/weed/glog"
 	weed_util "github.com/draleyva/seaweedfs/weed/util"
+	"github.com/google/btree"
 	"github.com/syndtr/goleveldb/leveldb"
 	leveldb_util "github.com/syndtr/goleveldb/leveldb/util"
 )
@@ -19,8 +22,16 @@ func init() {
 	filer2.Stores = append(filer2.Stores, &LevelDBStore{})
 }
 
+// transactionLockTimeout stops waiting for the other transaction, which may be held by the caller itself,
+// instead of blocking forever.
+var transactionLockTimeout = 10 * time.Second
+
 type LevelDBStore struct {
 	db *leveldb.DB
+	// writer is held from the beginning to the end of each transaction. A write batch does not
+	// isolate its reads from the other batches, so the concurrent transactions would overwrite
+	// each other's changes. The single writes outside of the transactions do not wait for it.
+	writer chan struct{}
 }
 
 func (store *LevelDBStore) GetName() string {
@@ -40,10 +51,84 @@ func (store *LevelDBStore) initialize(dir string) (err error) {
 	if store.db, err = leveldb.OpenFile(dir, nil); err != nil {
 		return
 	}
+	store.writer = make(chan struct{}, 1)
 	return
 }
 
-func (store *LevelDBStore) InsertEntry(entry *filer2.Entry) (err error) {
+type leveldbTransactionKey struct{}
+
+// leveldbTransaction collects the changes in a write batch.
+// The pending changes are kept sorted by key, and merged into the reads in the same transaction.
+type leveldbTransaction struct {
+	batch   *leveldb.Batch
+	pending *btree.BTree
+	closed  bool
+}
+
+type leveldbPendingValue struct {
+	key     []byte
+	value   []byte
+	deleted bool
+}
+
+func (a *leveldbPendingValue) Less(b btree.Item) bool {
+	return bytes.Compare(a.key, b.(*leveldbPendingValue).key) < 0
+}
+
+func getTransaction(ctx context.Context) *leveldbTransaction {
+	tx, _ := ctx.Value(leveldbTransactionKey{}).(*leveldbTransaction)
+	return tx
+}
+
+// lockWriter waits for the other transaction to finish, up to transactionLockTimeout.
+func (store *LevelDBStore) lockWriter(ctx context.Context) error {
+	timer := time.NewTimer(transactionLockTimeout)
+	defer timer.Stop()
+	select {
+	case store.writer <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return fmt.Errorf("wait %v for the other transaction, which may be held by the caller", transactionLockTimeout)
+	}
+}
+
+func (store *LevelDBStore) unlockWriter() {
+	<-store.writer
+}
+
+// BeginTransaction waits until the other transaction is committed or rolled back.
+func (store *LevelDBStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	if err := store.lockWriter(ctx); err != nil {
+		return ctx, fmt.Errorf("begin transaction: %v", err)
+	}
+	return context.WithValue(ctx, leveldbTransactionKey{}, &leveldbTransaction{
+		batch:   new(leveldb.Batch),
+		pending: btree.New(8),
+	}), nil
+}
+
+func (store *LevelDBStore) CommitTransaction(ctx context.Context) error {
+	if tx := getTransaction(ctx); tx != nil && !tx.closed {
+		tx.closed = true
+		defer store.unlockWriter()
+		return store.db.Write(tx.batch, nil)
+	}
+	return nil
+}
+
+func (store *LevelDBStore) RollbackTransaction(ctx context.Context) error {
+	if tx := getTransaction(ctx); tx != nil && !tx.closed {
+		tx.closed = true
+		defer store.unlockWriter()
+		tx.batch.Reset()
+		tx.pending = btree.New(8)
+	}
+	return nil
+}
+
+func (store *LevelDBStore) InsertEntry(ctx context.Context, entry *filer2.Entry) (err error) {
 	key := genKey(entry.DirAndName())
 
 	value, err := entry.EncodeAttributesAndChunks()
@@ -51,6 +136,12 @@ func (store *LevelDBStore) InsertEntry(entry *filer2.Entry) (err error) {
 		return fmt.Errorf("encoding %s %+v: %v", entry.FullPath, entry.Attr, err)
 	}
 
+	if tx := getTransaction(ctx); tx != nil {
+		tx.batch.Put(key, value)
+		tx.pending.ReplaceOrInsert(&leveldbPendingValue{key: key, value: value})
+		return nil
+	}
+
 	err = store.db.Put(key, value, nil)
 
 	if err != nil {
@@ -62,15 +153,23 @@ func (store *LevelDBStore) InsertEntry(entry *filer2.Entry) (err error) {
 	return nil
 }
 
-func (store *LevelDBStore) UpdateEntry(entry *filer2.Entry) (err error) {
+func (store *LevelDBStore) UpdateEntry(ctx context.Context, entry *filer2.Entry) (err error) {
 
-	return store.InsertEntry(entry)
+	return store.InsertEntry(ctx, entry)
 }
 
-func (store *LevelDBStore) FindEntry(fullpath filer2.FullPath) (entry *filer2.Entry, err error) {
+func (store *LevelDBStore) FindEntry(ctx context.Context, fullpath filer2.FullPath) (entry *filer2.Entry, err error) {
 	key := genKey(fullpath.DirAndName())
 
-	data, err := store.db.Get(key, nil)
+	var data []byte
+	if pending, found := getTransaction(ctx).getPending(key); found {
+		if pending.deleted {
+			return nil, filer2.ErrNotFound
+		}
+		data = pending.value
+	} else {
+		data, err = store.db.Get(key, nil)
+	}
 
 	if err == leveldb.ErrNotFound {
 		return nil, filer2.ErrNotFound
@@ -92,9 +191,15 @@ func (store *LevelDBStore) FindEntry(fullpath filer2.FullPath) (entry *filer2.En
 	return entry, nil
 }
 
-func (store *LevelDBStore) DeleteEntry(fullpath filer2.FullPath) (err error) {
+func (store *LevelDBStore) DeleteEntry(ctx context.Context, fullpath filer2.FullPath) (err error) {
 	key := genKey(fullpath.DirAndName())
 
+	if tx := getTransaction(ctx); tx != nil {
+		tx.batch.Delete(key)
+		tx.pending.ReplaceOrInsert(&leveldbPendingValue{key: key, deleted: true})
+		return nil
+	}
+
 	err = store.db.Delete(key, nil)
 	if err != nil {
 		return fmt.Errorf("delete %s : %v", fullpath, err)
@@ -103,17 +208,54 @@ func (store *LevelDBStore) DeleteEntry(fullpath filer2.FullPath) (err error) {
 	return nil
 }
 
-func (store *LevelDBStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool,
+func (store *LevelDBStore) ListDirectoryEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool,
 	limit int) (entries []*filer2.Entry, err error) {
+	return store.ListDirectoryPrefixedEntries(ctx, fullpath, startFileName, inclusive, limit, "")
+}
+
+func (store *LevelDBStore) ListDirectoryPrefixedEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool,
+	limit int, prefix string) (entries []*filer2.Entry, err error) {
+
+	if startFileName < prefix {
+		startFileName, inclusive = prefix, true
+	}
+
+	directoryPrefix := genDirectoryKeyPrefix(fullpath, prefix)
+	startKey := genDirectoryKeyPrefix(fullpath, startFileName)
 
-	directoryPrefix := genDirectoryKeyPrefix(fullpath, "")
+	iter := store.db.NewIterator(&leveldb_util.Range{Start: startKey}, nil)
+	defer iter.Release()
+	tx := getTransaction(ctx)
+	pending := tx.pendingFrom(startKey, directoryPrefix)
 
-	iter := store.db.NewIterator(&leveldb_util.Range{Start: genDirectoryKeyPrefix(fullpath, startFileName)}, nil)
-	for iter.Next() {
-		key := iter.Key()
-		if !bytes.HasPrefix(key, directoryPrefix) {
+	hasStored := iter.Next()
+	for {
+		if hasStored && !bytes.HasPrefix(iter.Key(), directoryPrefix) {
+			hasStored = false
+		}
+		if !hasStored && pending == nil {
 			break
 		}
+
+		// the pending changes replace the stored values of the same keys
+		var key, value []byte
+		if pending != nil && (!hasStored || bytes.Compare(pending.key, iter.Key()) <= 0) {
+			current := pending
+			key = current.key
+			pending = tx.pendingFrom(append(append([]byte(nil), key...), 0), directoryPrefix)
+			if hasStored && bytes.Equal(key, iter.Key()) {
+				hasStored = iter.Next()
+			}
+			if current.deleted {
+				continue
+			}
+			value = current.value
+		} else {
+			// the iterator reuses its buffers on the next call
+			key, value = append([]byte(nil), iter.Key()...), append([]byte(nil), iter.Value()...)
+			hasStored = iter.Next()
+		}
+
 		fileName := getNameFromKey(key)
 		if fileName == "" {
 			continue
@@ -128,18 +270,42 @@ func (store *LevelDBStore) ListDirectoryEntries(fullpath filer2.FullPath, startF
 		entry := &filer2.Entry{
 			FullPath: filer2.NewFullPath(string(fullpath), fileName),
 		}
-		if decodeErr := entry.DecodeAttributesAndChunks(iter.Value()); decodeErr != nil {
+		if decodeErr := entry.DecodeAttributesAndChunks(value); decodeErr != nil {
 			err = decodeErr
 			glog.V(0).Infof("list %s : %v", entry.FullPath, err)
 			break
 		}
 		entries = append(entries, entry)
 	}
-	iter.Release()
 
 	return entries, err
 }
 
+// pendingFrom returns the first pending change at or after the key with the key prefix, or nil.
+func (tx *leveldbTransaction) pendingFrom(key, keyPrefix []byte) (found *leveldbPendingValue) {
+	if tx == nil {
+		return nil
+	}
+	tx.pending.AscendGreaterOrEqual(&leveldbPendingValue{key: key}, func(item btree.Item) bool {
+		if pending := item.(*leveldbPendingValue); bytes.HasPrefix(pending.key, keyPrefix) {
+			found = pending
+		}
+		return false
+	})
+	return found
+}
+
+func (tx *leveldbTransaction) getPending(key []byte) (*leveldbPendingValue, bool) {
+	if tx == nil {
+		return nil, false
+	}
+	item := tx.pending.Get(&leveldbPendingValue{key: key})
+	if item == nil {
+		return nil, false
+	}
+	return item.(*leveldbPendingValue), true
+}
+
 func genKey(dirPath, fileName string) (key []byte) {
 	key = []byte(dirPath)
 	key = append(key, DIR_FILE_SEPARATOR)
@@ -1,13 +1,18 @@
 package leveldb
 
 import (
+	"context"
 	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/filer2/storetest"
 	"io/ioutil"
 	"os"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestCreateAndFind(t *testing.T) {
+	ctx := context.Background()
 	filer := filer2.NewFiler(nil)
 	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
 	defer os.RemoveAll(dir)
@@ -27,12 +32,12 @@ func TestCreateAndFind(t *testing.T) {
 		},
 	}
 
-	if err := filer.CreateEntry(entry1); err != nil {
+	if err := filer.CreateEntry(ctx, entry1); err != nil {
 		t.Errorf("create entry %v: %v", entry1.FullPath, err)
 		return
 	}
 
-	entry, err := filer.FindEntry(fullpath)
+	entry, err := filer.FindEntry(ctx, fullpath)
 
 	if err != nil {
 		t.Errorf("find entry: %v", err)
@@ -45,17 +50,175 @@ func TestCreateAndFind(t *testing.T) {
 	}
 
 	// checking one upper directory
-	entries, _ := filer.ListDirectoryEntries(filer2.FullPath("/home/chris/this/is/one"), "", false, 100)
+	entries, _ := filer.ListDirectoryEntries(ctx, filer2.FullPath("/home/chris/this/is/one"), "", false, 100)
 	if len(entries) != 1 {
 		t.Errorf("list entries count: %v", len(entries))
 		return
 	}
 
 	// checking one upper directory
-	entries, _ = filer.ListDirectoryEntries(filer2.FullPath("/"), "", false, 100)
+	entries, _ = filer.ListDirectoryEntries(ctx, filer2.FullPath("/"), "", false, 100)
 	if len(entries) != 1 {
 		t.Errorf("list entries count: %v", len(entries))
 		return
 	}
 
 }
+
+func TestTransaction(t *testing.T) {
+	filer := filer2.NewFiler(nil)
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &LevelDBStore{}
+	store.initialize(dir)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	ctx, _ := store.BeginTransaction(context.Background())
+	store.InsertEntry(ctx, &filer2.Entry{FullPath: "/home/chris/a", Attr: filer2.Attr{Mode: os.ModeDir | 0770}})
+	store.InsertEntry(ctx, &filer2.Entry{FullPath: "/home/chris/a/file1.jpg", Attr: filer2.Attr{Mode: 0440}})
+	if _, err := store.FindEntry(ctx, "/home/chris/a/file1.jpg"); err != nil {
+		t.Errorf("pending entry should be visible in the transaction: %v", err)
+	}
+	if _, err := store.FindEntry(context.Background(), "/home/chris/a/file1.jpg"); err != filer2.ErrNotFound {
+		t.Errorf("pending entry should not be visible outside the transaction: %v", err)
+	}
+	if entries, _ := store.ListDirectoryEntries(ctx, "/home/chris/a", "", false, 100); len(entries) != 1 || entries[0].Name() != "file1.jpg" {
+		t.Errorf("pending entry should be listed in the transaction: %+v", entries)
+	}
+	if err := store.CommitTransaction(ctx); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	// the pending changes replace the stored entries in the listing
+	ctx, _ = store.BeginTransaction(context.Background())
+	store.InsertEntry(ctx, &filer2.Entry{FullPath: "/home/chris/a/file0.jpg", Attr: filer2.Attr{Mode: 0440}})
+	store.UpdateEntry(ctx, &filer2.Entry{FullPath: "/home/chris/a/file1.jpg", Attr: filer2.Attr{Mode: 0400}})
+	store.InsertEntry(ctx, &filer2.Entry{FullPath: "/home/chris/a/file2.jpg", Attr: filer2.Attr{Mode: 0440}})
+	store.DeleteEntry(ctx, "/home/chris/a/file2.jpg")
+	entries, _ := store.ListDirectoryEntries(ctx, "/home/chris/a", "", false, 100)
+	if len(entries) != 2 || entries[0].Name() != "file0.jpg" || entries[1].Name() != "file1.jpg" || entries[1].Mode != 0400 {
+		t.Errorf("list in the transaction: %+v", entries)
+	}
+	if entries, _ := store.ListDirectoryEntries(ctx, "/home/chris/a", "file0.jpg", false, 1); len(entries) != 1 || entries[0].Name() != "file1.jpg" {
+		t.Errorf("list after file0.jpg in the transaction: %+v", entries)
+	}
+	store.RollbackTransaction(ctx)
+
+	if err := filer.AtomicRenameEntry(context.Background(), "/home/chris/a", "/home/chris/b"); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if _, err := filer.FindEntry(context.Background(), "/home/chris/b/file1.jpg"); err != nil {
+		t.Errorf("find renamed entry: %v", err)
+	}
+	if _, err := filer.FindEntry(context.Background(), "/home/chris/a/file1.jpg"); err != filer2.ErrNotFound {
+		t.Errorf("old entry should be gone: %v", err)
+	}
+
+}
+
+func TestListDirectoryPrefixedEntries(t *testing.T) {
+	ctx := context.Background()
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &LevelDBStore{}
+	store.initialize(dir)
+
+	for _, name := range []string{"a", "b1", "b2", "b3", "c"} {
+		if err := store.InsertEntry(ctx, &filer2.Entry{FullPath: filer2.NewFullPath("/home", name)}); err != nil {
+			t.Fatalf("insert %s: %v", name, err)
+		}
+	}
+
+	for _, test := range []struct {
+		startFileName string
+		limit         int
+		expected      int
+	}{
+		{"", 10, 3},
+		{"", 2, 2},
+		{"b1", 10, 2},
+		{"a", 10, 3},
+		{"b3", 10, 0},
+	} {
+		entries, err := store.ListDirectoryPrefixedEntries(ctx, "/home", test.startFileName, false, test.limit, "b")
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		if len(entries) != test.expected {
+			t.Fatalf("list after %q: expected %d entries, but got %d", test.startFileName, test.expected, len(entries))
+		}
+		for _, entry := range entries {
+			if entry.Name()[0] != 'b' {
+				t.Fatalf("unexpected entry %s", entry.FullPath)
+			}
+		}
+	}
+}
+
+func TestFilerStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &LevelDBStore{}
+	if err := store.initialize(dir); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	storetest.TestFilerStore(t, store)
+}
+
+func TestConcurrentTransactions(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &LevelDBStore{}
+	store.initialize(dir)
+
+	if err := store.InsertEntry(context.Background(), &filer2.Entry{FullPath: "/counter", Attr: filer2.Attr{Mode: 0440}}); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	// each transaction reads the counter and writes it back increased, without losing the other changes
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, err := store.BeginTransaction(context.Background())
+			if err != nil {
+				t.Errorf("begin: %v", err)
+				return
+			}
+			entry, err := store.FindEntry(ctx, "/counter")
+			if err != nil {
+				t.Errorf("find: %v", err)
+				store.RollbackTransaction(ctx)
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+			entry.Uid++
+			store.UpdateEntry(ctx, entry)
+			if err := store.CommitTransaction(ctx); err != nil {
+				t.Errorf("commit: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if entry, err := store.FindEntry(context.Background(), "/counter"); err != nil || entry.Uid != 8 {
+		t.Fatalf("counter %+v: %v", entry, err)
+	}
+
+	// a second transaction waits for the first one, and gives up after the timeout
+	transactionLockTimeout = 50 * time.Millisecond
+	defer func() { transactionLockTimeout = 10 * time.Second }()
+	ctx, _ := store.BeginTransaction(context.Background())
+	if _, err := store.BeginTransaction(context.Background()); err == nil {
+		t.Fatalf("began a second transaction")
+	}
+	store.RollbackTransaction(ctx)
+	store.RollbackTransaction(ctx)
+	other, err := store.BeginTransaction(context.Background())
+	if err != nil {
+		t.Fatalf("begin after rollback: %v", err)
+	}
+	store.CommitTransaction(other)
+}
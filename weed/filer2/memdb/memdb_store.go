@@ -1,6 +1,7 @@
 package memdb
 
 import (
+	"context"
 	"fmt"
 	"github.com/draleyva/seaweedfs/weed/filer2"
 	"github.com/draleyva/seaweedfs/weed/util"
@@ -33,21 +34,64 @@ func (store *MemDbStore) Initialize(configuration util.Configuration) (err error
 	return nil
 }
 
-func (store *MemDbStore) InsertEntry(entry *filer2.Entry) (err error) {
+type memdbTransactionKey struct{}
+
+// memdbTransaction applies changes right away, and remembers how to undo them.
+type memdbTransaction struct {
+	undo []func()
+}
+
+func (store *MemDbStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	return context.WithValue(ctx, memdbTransactionKey{}, &memdbTransaction{}), nil
+}
+
+func (store *MemDbStore) CommitTransaction(ctx context.Context) error {
+	if tx, ok := ctx.Value(memdbTransactionKey{}).(*memdbTransaction); ok {
+		tx.undo = nil
+	}
+	return nil
+}
+
+func (store *MemDbStore) RollbackTransaction(ctx context.Context) error {
+	if tx, ok := ctx.Value(memdbTransactionKey{}).(*memdbTransaction); ok {
+		for i := len(tx.undo) - 1; i >= 0; i-- {
+			tx.undo[i]()
+		}
+		tx.undo = nil
+	}
+	return nil
+}
+
+func (store *MemDbStore) rememberForUndo(ctx context.Context, fullpath filer2.FullPath) {
+	tx, ok := ctx.Value(memdbTransactionKey{}).(*memdbTransaction)
+	if !ok {
+		return
+	}
+	key := entryItem{&filer2.Entry{FullPath: fullpath}}
+	if item := store.tree.Get(key); item != nil {
+		tx.undo = append(tx.undo, func() { store.tree.ReplaceOrInsert(item) })
+	} else {
+		tx.undo = append(tx.undo, func() { store.tree.Delete(key) })
+	}
+}
+
+func (store *MemDbStore) InsertEntry(ctx context.Context, entry *filer2.Entry) (err error) {
 	// println("inserting", entry.FullPath)
+	store.rememberForUndo(ctx, entry.FullPath)
 	store.tree.ReplaceOrInsert(entryItem{entry})
 	return nil
 }
 
-func (store *MemDbStore) UpdateEntry(entry *filer2.Entry) (err error) {
-	if _, err = store.FindEntry(entry.FullPath); err != nil {
+func (store *MemDbStore) UpdateEntry(ctx context.Context, entry *filer2.Entry) (err error) {
+	if _, err = store.FindEntry(ctx, entry.FullPath); err != nil {
 		return fmt.Errorf("no such file %s : %v", entry.FullPath, err)
 	}
+	store.rememberForUndo(ctx, entry.FullPath)
 	store.tree.ReplaceOrInsert(entryItem{entry})
 	return nil
 }
 
-func (store *MemDbStore) FindEntry(fullpath filer2.FullPath) (entry *filer2.Entry, err error) {
+func (store *MemDbStore) FindEntry(ctx context.Context, fullpath filer2.FullPath) (entry *filer2.Entry, err error) {
 	item := store.tree.Get(entryItem{&filer2.Entry{FullPath: fullpath}})
 	if item == nil {
 		return nil, filer2.ErrNotFound
@@ -56,12 +100,21 @@ func (store *MemDbStore) FindEntry(fullpath filer2.FullPath) (entry *filer2.Entr
 	return entry, nil
 }
 
-func (store *MemDbStore) DeleteEntry(fullpath filer2.FullPath) (err error) {
+func (store *MemDbStore) DeleteEntry(ctx context.Context, fullpath filer2.FullPath) (err error) {
+	store.rememberForUndo(ctx, fullpath)
 	store.tree.Delete(entryItem{&filer2.Entry{FullPath: fullpath}})
 	return nil
 }
 
-func (store *MemDbStore) ListDirectoryEntries(fullpath filer2.FullPath, startFileName string, inclusive bool, limit int) (entries []*filer2.Entry, err error) {
+func (store *MemDbStore) ListDirectoryEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool, limit int) (entries []*filer2.Entry, err error) {
+	return store.ListDirectoryPrefixedEntries(ctx, fullpath, startFileName, inclusive, limit, "")
+}
+
+func (store *MemDbStore) ListDirectoryPrefixedEntries(ctx context.Context, fullpath filer2.FullPath, startFileName string, inclusive bool, limit int, prefix string) (entries []*filer2.Entry, err error) {
+
+	if startFileName < prefix {
+		startFileName, inclusive = prefix, true
+	}
 
 	startFrom := string(fullpath)
 	if startFileName != "" {
@@ -102,6 +155,10 @@ func (store *MemDbStore) ListDirectoryEntries(fullpath filer2.FullPath, startFil
 				// println("skipping deeper folder", entry.FullPath)
 				return true
 			}
+			if !strings.HasPrefix(name, prefix) {
+				// the names after the prefix range
+				return name < prefix
+			}
 			// now process the directory items
 			// println("adding entry", entry.FullPath)
 			limit--
@@ -1,11 +1,15 @@
 package memdb
 
 import (
-	"github.com/draleyva/seaweedfs/weed/filer2"
+	"context"
 	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/filer2/storetest"
 )
 
 func TestCreateAndFind(t *testing.T) {
+	ctx := context.Background()
 	filer := filer2.NewFiler(nil)
 	store := &MemDbStore{}
 	store.Initialize(nil)
@@ -23,12 +27,12 @@ func TestCreateAndFind(t *testing.T) {
 		},
 	}
 
-	if err := filer.CreateEntry(entry1); err != nil {
+	if err := filer.CreateEntry(ctx, entry1); err != nil {
 		t.Errorf("create entry %v: %v", entry1.FullPath, err)
 		return
 	}
 
-	entry, err := filer.FindEntry(fullpath)
+	entry, err := filer.FindEntry(ctx, fullpath)
 
 	if err != nil {
 		t.Errorf("find entry: %v", err)
@@ -43,6 +47,7 @@ func TestCreateAndFind(t *testing.T) {
 }
 
 func TestCreateFileAndList(t *testing.T) {
+	ctx := context.Background()
 	filer := filer2.NewFiler(nil)
 	store := &MemDbStore{}
 	store.Initialize(nil)
@@ -67,11 +72,11 @@ func TestCreateFileAndList(t *testing.T) {
 		},
 	}
 
-	filer.CreateEntry(entry1)
-	filer.CreateEntry(entry2)
+	filer.CreateEntry(ctx, entry1)
+	filer.CreateEntry(ctx, entry2)
 
 	// checking the 2 files
-	entries, err := filer.ListDirectoryEntries(filer2.FullPath("/home/chris/this/is/one/"), "", false, 100)
+	entries, err := filer.ListDirectoryEntries(ctx, filer2.FullPath("/home/chris/this/is/one/"), "", false, 100)
 
 	if err != nil {
 		t.Errorf("list entries: %v", err)
@@ -94,21 +99,21 @@ func TestCreateFileAndList(t *testing.T) {
 	}
 
 	// checking the offset
-	entries, err = filer.ListDirectoryEntries(filer2.FullPath("/home/chris/this/is/one/"), "file1.jpg", false, 100)
+	entries, err = filer.ListDirectoryEntries(ctx, filer2.FullPath("/home/chris/this/is/one/"), "file1.jpg", false, 100)
 	if len(entries) != 1 {
 		t.Errorf("list entries count: %v", len(entries))
 		return
 	}
 
 	// checking one upper directory
-	entries, _ = filer.ListDirectoryEntries(filer2.FullPath("/home/chris/this/is"), "", false, 100)
+	entries, _ = filer.ListDirectoryEntries(ctx, filer2.FullPath("/home/chris/this/is"), "", false, 100)
 	if len(entries) != 1 {
 		t.Errorf("list entries count: %v", len(entries))
 		return
 	}
 
 	// checking root directory
-	entries, _ = filer.ListDirectoryEntries(filer2.FullPath("/"), "", false, 100)
+	entries, _ = filer.ListDirectoryEntries(ctx, filer2.FullPath("/"), "", false, 100)
 	if len(entries) != 1 {
 		t.Errorf("list entries count: %v", len(entries))
 		return
@@ -124,21 +129,51 @@ func TestCreateFileAndList(t *testing.T) {
 			Gid:  5678,
 		},
 	}
-	filer.CreateEntry(entry3)
+	filer.CreateEntry(ctx, entry3)
 
 	// checking one upper directory
-	entries, _ = filer.ListDirectoryEntries(filer2.FullPath("/home/chris/this/is"), "", false, 100)
+	entries, _ = filer.ListDirectoryEntries(ctx, filer2.FullPath("/home/chris/this/is"), "", false, 100)
 	if len(entries) != 2 {
 		t.Errorf("list entries count: %v", len(entries))
 		return
 	}
 
 	// delete file and count
-	filer.DeleteEntryMetaAndData(file3Path, false, false)
-	entries, _ = filer.ListDirectoryEntries(filer2.FullPath("/home/chris/this/is"), "", false, 100)
+	filer.DeleteEntryMetaAndData(ctx, file3Path, false, false)
+	entries, _ = filer.ListDirectoryEntries(ctx, filer2.FullPath("/home/chris/this/is"), "", false, 100)
 	if len(entries) != 1 {
 		t.Errorf("list entries count: %v", len(entries))
 		return
 	}
 
 }
+
+func TestTransactionRollback(t *testing.T) {
+	store := &MemDbStore{}
+	store.Initialize(nil)
+
+	existing := &filer2.Entry{FullPath: "/home/chris/file1.jpg", Attr: filer2.Attr{Mode: 0440}}
+	store.InsertEntry(context.Background(), existing)
+
+	ctx, _ := store.BeginTransaction(context.Background())
+	store.InsertEntry(ctx, &filer2.Entry{FullPath: "/home/chris/file2.jpg"})
+	store.UpdateEntry(ctx, &filer2.Entry{FullPath: "/home/chris/file1.jpg", Attr: filer2.Attr{Mode: 0400}})
+	store.DeleteEntry(ctx, "/home/chris/file1.jpg")
+	if err := store.RollbackTransaction(ctx); err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+
+	if _, err := store.FindEntry(context.Background(), "/home/chris/file2.jpg"); err != filer2.ErrNotFound {
+		t.Errorf("inserted entry should be rolled back: %v", err)
+	}
+	if entry, err := store.FindEntry(context.Background(), "/home/chris/file1.jpg"); err != nil || entry.Mode != 0440 {
+		t.Errorf("deleted entry should be restored: %+v %v", entry, err)
+	}
+
+}
+
+func TestFilerStore(t *testing.T) {
+	store := &MemDbStore{}
+	store.Initialize(nil)
+	storetest.TestFilerStore(t, store)
+}
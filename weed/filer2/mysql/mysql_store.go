@@ -46,6 +46,8 @@ func (store *MysqlStore) initialize(user, password, hostname string, port int, d
 	store.SqlDelete = "DELETE FROM filemeta WHERE dirhash=? AND name=? AND directory=?"
 	store.SqlListExclusive = "SELECT NAME, meta FROM filemeta WHERE dirhash=? AND name>? AND directory=? ORDER BY NAME ASC LIMIT ?"
 	store.SqlListInclusive = "SELECT NAME, meta FROM filemeta WHERE dirhash=? AND name>=? AND directory=? ORDER BY NAME ASC LIMIT ?"
+	store.SqlListExclusiveUntil = "SELECT NAME, meta FROM filemeta WHERE dirhash=? AND name>? AND directory=? AND name<? ORDER BY NAME ASC LIMIT ?"
+	store.SqlListInclusiveUntil = "SELECT NAME, meta FROM filemeta WHERE dirhash=? AND name>=? AND directory=? AND name<? ORDER BY NAME ASC LIMIT ?"
 
 	sqlUrl := fmt.Sprintf(CONNECTION_URL_PATTERN, user, password, hostname, port, database)
 	var dbErr error
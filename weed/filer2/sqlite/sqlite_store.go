@@ -0,0 +1,84 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/filer2/abstract_sql"
+	"github.com/draleyva/seaweedfs/weed/util"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	// WAL mode lets the readers run concurrently with the writer.
+	// Transactions take the write lock upfront, to avoid failing when upgrading a read lock.
+	CONNECTION_URL_PATTERN = "file:%s?_journal_mode=WAL&_busy_timeout=%d&_txlock=immediate"
+
+	// the names are compared with the default BINARY collation,
+	// so listing is in the same byte order as the other stores
+	CREATE_TABLE_SQL = `CREATE TABLE IF NOT EXISTS filemeta (
+  dirhash     INTEGER,
+  name        VARCHAR(1000),
+  directory   TEXT,
+  meta        BLOB,
+  PRIMARY KEY (dirhash, name)
+)`
+)
+
+func init() {
+	filer2.Stores = append(filer2.Stores, &SqliteStore{})
+}
+
+type SqliteStore struct {
+	abstract_sql.AbstractSqlStore
+}
+
+func (store *SqliteStore) GetName() string {
+	return "sqlite"
+}
+
+func (store *SqliteStore) Initialize(configuration util.Configuration) (err error) {
+	busyTimeout := configuration.GetInt("busy_timeout_ms")
+	if busyTimeout <= 0 {
+		busyTimeout = 5000
+	}
+	return store.initialize(
+		configuration.GetString("dbFile"),
+		busyTimeout,
+	)
+}
+
+func (store *SqliteStore) initialize(dbFile string, busyTimeout int) (err error) {
+
+	store.SqlInsert = "INSERT INTO filemeta (dirhash,name,directory,meta) VALUES(?,?,?,?)"
+	store.SqlUpdate = "UPDATE filemeta SET meta=? WHERE dirhash=? AND name=? AND directory=?"
+	store.SqlFind = "SELECT meta FROM filemeta WHERE dirhash=? AND name=? AND directory=?"
+	store.SqlDelete = "DELETE FROM filemeta WHERE dirhash=? AND name=? AND directory=?"
+	store.SqlListExclusive = "SELECT NAME, meta FROM filemeta WHERE dirhash=? AND name>? AND directory=? ORDER BY NAME ASC LIMIT ?"
+	store.SqlListInclusive = "SELECT NAME, meta FROM filemeta WHERE dirhash=? AND name>=? AND directory=? ORDER BY NAME ASC LIMIT ?"
+	store.SqlListExclusiveUntil = "SELECT NAME, meta FROM filemeta WHERE dirhash=? AND name>? AND directory=? AND name<? ORDER BY NAME ASC LIMIT ?"
+	store.SqlListInclusiveUntil = "SELECT NAME, meta FROM filemeta WHERE dirhash=? AND name>=? AND directory=? AND name<? ORDER BY NAME ASC LIMIT ?"
+
+	if dbFile == "" {
+		return fmt.Errorf("sqlite dbFile is not configured")
+	}
+	if err = os.MkdirAll(filepath.Dir(dbFile), 0755); err != nil {
+		return fmt.Errorf("create folder for %s: %v", dbFile, err)
+	}
+
+	sqlUrl := fmt.Sprintf(CONNECTION_URL_PATTERN, dbFile, busyTimeout)
+	if store.DB, err = sql.Open("sqlite3", sqlUrl); err != nil {
+		return fmt.Errorf("can not open %s error:%v", sqlUrl, err)
+	}
+
+	if _, err = store.DB.Exec(CREATE_TABLE_SQL); err != nil {
+		store.DB.Close()
+		store.DB = nil
+		return fmt.Errorf("create table filemeta in %s: %v", dbFile, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package sqlite
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/filer2/storetest"
+)
+
+func TestFilerStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &SqliteStore{}
+	if err := store.initialize(filepath.Join(dir, "filer.db"), 5000); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	storetest.TestFilerStore(t, store)
+}
@@ -0,0 +1,75 @@
+// Package storetest checks that a filer store behaves as the filer expects,
+// run by the tests of each store on an initialized and empty store.
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+)
+
+// TestFilerStore creates, finds and lists entries through a filer on the store,
+// and checks the prefixed listing and the transaction rollback of the store.
+func TestFilerStore(t *testing.T, store filer2.FilerStore) {
+	ctx := context.Background()
+	filer := filer2.NewFiler(nil)
+	filer.SetStore(store)
+	filer.DisableDirectoryCache()
+
+	for i := 0; i < 5; i++ {
+		entry := &filer2.Entry{
+			FullPath: filer2.FullPath(fmt.Sprintf("/home/chris/file%d.jpg", i)),
+			Attr: filer2.Attr{
+				Mode: 0440,
+				Uid:  1234,
+				Gid:  5678,
+			},
+		}
+		if err := filer.CreateEntry(ctx, entry); err != nil {
+			t.Fatalf("create entry %v: %v", entry.FullPath, err)
+		}
+	}
+
+	entry, err := filer.FindEntry(ctx, filer2.FullPath("/home/chris/file3.jpg"))
+	if err != nil || entry.Uid != 1234 {
+		t.Fatalf("find entry: %v %+v", err, entry)
+	}
+
+	entries, _ := filer.ListDirectoryEntries(ctx, filer2.FullPath("/home/chris"), "file1.jpg", false, 2)
+	if len(entries) != 2 || entries[0].Name() != "file2.jpg" || entries[1].Name() != "file3.jpg" {
+		t.Fatalf("list entries: %+v", entries)
+	}
+
+	// the prefixed listing reads the name range of the prefix only
+	entries, _ = filer.ListDirectoryPrefixedEntries(ctx, filer2.FullPath("/home/chris"), "", false, 100, "file3")
+	if len(entries) != 1 || entries[0].Name() != "file3.jpg" {
+		t.Fatalf("list prefixed entries: %+v", entries)
+	}
+	entries, _ = filer.ListDirectoryPrefixedEntries(ctx, filer2.FullPath("/home/chris"), "file1.jpg", false, 2, "file")
+	if len(entries) != 2 || entries[0].Name() != "file2.jpg" || entries[1].Name() != "file3.jpg" {
+		t.Fatalf("list prefixed entries from file1.jpg: %+v", entries)
+	}
+
+	// a sub directory is not listed with its parent directory
+	entries, _ = filer.ListDirectoryEntries(ctx, filer2.FullPath("/home"), "", false, 100)
+	if len(entries) != 1 || entries[0].Name() != "chris" {
+		t.Fatalf("list /home: %+v", entries)
+	}
+
+	// rolled back changes are not visible
+	txCtx, err := store.BeginTransaction(ctx)
+	if err != nil {
+		t.Fatalf("begin transaction: %v", err)
+	}
+	store.DeleteEntry(txCtx, filer2.FullPath("/home/chris/file0.jpg"))
+	if _, err := store.FindEntry(txCtx, filer2.FullPath("/home/chris/file0.jpg")); err != filer2.ErrNotFound {
+		t.Fatalf("deleted entry is still visible in the transaction: %v", err)
+	}
+	store.RollbackTransaction(txCtx)
+	if _, err := filer.FindEntry(ctx, filer2.FullPath("/home/chris/file0.jpg")); err != nil {
+		t.Fatalf("find rolled back entry: %v", err)
+	}
+
+}
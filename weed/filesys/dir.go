@@ -39,22 +39,36 @@ func (dir *Dir) Attr(context context.Context, attr *fuse.Attr) error {
 		return nil
 	}
 
-	item := dir.wfs.listDirectoryEntriesCache.Get(dir.Path)
-	if item != nil && !item.Expired() {
-		entry := item.Value().(*filer_pb.Entry)
+	entry, err := dir.maybeLoadEntry(context)
+	if err != nil {
+		return err
+	}
+	dir.attributes = entry.Attributes
+
+	// glog.V(1).Infof("dir %s: %v", dir.Path, attributes)
+	// glog.V(1).Infof("dir %s permission: %v", dir.Path, os.FileMode(attributes.FileMode))
 
-		attr.Mtime = time.Unix(entry.Attributes.Mtime, 0)
-		attr.Ctime = time.Unix(entry.Attributes.Crtime, 0)
-		attr.Mode = os.FileMode(entry.Attributes.FileMode)
-		attr.Gid = entry.Attributes.Gid
-		attr.Uid = entry.Attributes.Uid
+	attr.Mode = os.FileMode(dir.attributes.FileMode) | os.ModeDir
 
-		return nil
+	attr.Mtime = time.Unix(dir.attributes.Mtime, 0)
+	attr.Ctime = time.Unix(dir.attributes.Crtime, 0)
+	attr.Gid = dir.attributes.Gid
+	attr.Uid = dir.attributes.Uid
+
+	return nil
+}
+
+// maybeLoadEntry returns the cached directory entry, or looks it up from the filer.
+func (dir *Dir) maybeLoadEntry(ctx context.Context) (entry *filer_pb.Entry, err error) {
+
+	item := dir.wfs.listDirectoryEntriesCache.Get(dir.Path)
+	if item != nil && !item.Expired() {
+		return item.Value().(*filer_pb.Entry), nil
 	}
 
 	parent, name := filepath.Split(dir.Path)
 
-	err := dir.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+	err = dir.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
 		request := &filer_pb.LookupDirectoryEntryRequest{
 			Directory: parent,
@@ -62,36 +76,20 @@ func (dir *Dir) Attr(context context.Context, attr *fuse.Attr) error {
 		}
 
 		glog.V(1).Infof("read dir %s attr: %v", dir.Path, request)
-		resp, err := client.LookupDirectoryEntry(context, request)
+		resp, err := client.LookupDirectoryEntry(ctx, request)
 		if err != nil {
 			glog.V(0).Infof("read dir %s attr %v: %v", dir.Path, request, err)
 			return err
 		}
 
-		if resp.Entry != nil {
-			dir.attributes = resp.Entry.Attributes
-		}
+		entry = resp.Entry
 
 		dir.wfs.listDirectoryEntriesCache.Set(dir.Path, resp.Entry, dir.wfs.option.EntryCacheTtl)
 
 		return nil
 	})
 
-	if err != nil {
-		return err
-	}
-
-	// glog.V(1).Infof("dir %s: %v", dir.Path, attributes)
-	// glog.V(1).Infof("dir %s permission: %v", dir.Path, os.FileMode(attributes.FileMode))
-
-	attr.Mode = os.FileMode(dir.attributes.FileMode) | os.ModeDir
-
-	attr.Mtime = time.Unix(dir.attributes.Mtime, 0)
-	attr.Ctime = time.Unix(dir.attributes.Crtime, 0)
-	attr.Gid = dir.attributes.Gid
-	attr.Uid = dir.attributes.Uid
-
-	return nil
+	return entry, err
 }
 
 func (dir *Dir) newFile(name string, entry *filer_pb.Entry) *File {
@@ -129,7 +127,7 @@ func (dir *Dir) Create(ctx context.Context, req *fuse.CreateRequest,
 		if err := dir.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 			if _, err := client.CreateEntry(ctx, request); err != nil {
 				glog.V(0).Infof("create %s/%s: %v", dir.Path, req.Name, err)
-				return fuse.EIO
+				return filerErrno(err)
 			}
 			return nil
 		}); err != nil {
@@ -169,7 +167,7 @@ func (dir *Dir) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, err
 		glog.V(1).Infof("mkdir: %v", request)
 		if _, err := client.CreateEntry(ctx, request); err != nil {
 			glog.V(0).Infof("mkdir %s/%s: %v", dir.Path, req.Name, err)
-			return fuse.EIO
+			return filerErrno(err)
 		}
 
 		return nil
@@ -257,6 +255,9 @@ func (dir *Dir) ReadDirAll(ctx context.Context) (ret []fuse.Dirent, err error) {
 			if entry.IsDirectory {
 				dirent := fuse.Dirent{Name: entry.Name, Type: fuse.DT_Dir}
 				ret = append(ret, dirent)
+			} else if os.FileMode(entry.Attributes.FileMode)&os.ModeSymlink != 0 {
+				dirent := fuse.Dirent{Name: entry.Name, Type: fuse.DT_Link}
+				ret = append(ret, dirent)
 			} else {
 				dirent := fuse.Dirent{Name: entry.Name, Type: fuse.DT_File}
 				ret = append(ret, dirent)
@@ -298,6 +299,15 @@ func (dir *Dir) Remove(ctx context.Context, req *fuse.RemoveRequest) error {
 func (dir *Dir) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fuse.SetattrResponse) error {
 
 	glog.V(3).Infof("%v dir setattr %+v, fh=%d", dir.Path, req, req.Handle)
+
+	entry, err := dir.maybeLoadEntry(ctx)
+	if err != nil {
+		return err
+	}
+	if dir.attributes == nil {
+		dir.attributes = entry.Attributes
+	}
+
 	if req.Valid.Mode() {
 		dir.attributes.FileMode = uint32(req.Mode)
 	}
@@ -322,6 +332,7 @@ func (dir *Dir) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fus
 			Entry: &filer_pb.Entry{
 				Name:       name,
 				Attributes: dir.attributes,
+				Extended:   entry.Extended,
 			},
 		}
 
@@ -329,7 +340,7 @@ func (dir *Dir) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fus
 		_, err := client.UpdateEntry(ctx, request)
 		if err != nil {
 			glog.V(0).Infof("UpdateEntry %s: %v", dir.Path, err)
-			return fuse.EIO
+			return filerErrno(err)
 		}
 
 		dir.wfs.listDirectoryEntriesCache.Delete(dir.Path)
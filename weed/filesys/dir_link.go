@@ -0,0 +1,108 @@
+package filesys
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"time"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+var _ = fs.NodeSymlinker(&Dir{})
+var _ = fs.NodeLinker(&Dir{})
+var _ = fs.NodeReadlinker(&File{})
+
+func (dir *Dir) Symlink(ctx context.Context, req *fuse.SymlinkRequest) (fs.Node, error) {
+
+	request := &filer_pb.CreateEntryRequest{
+		Directory: dir.Path,
+		Entry: &filer_pb.Entry{
+			Name:        req.NewName,
+			IsDirectory: false,
+			Attributes: &filer_pb.FuseAttributes{
+				Mtime:         time.Now().Unix(),
+				Crtime:        time.Now().Unix(),
+				FileMode:      uint32(os.ModeSymlink | 0777),
+				Uid:           req.Uid,
+				Gid:           req.Gid,
+				SymlinkTarget: req.Target,
+			},
+		},
+	}
+
+	err := dir.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		glog.V(1).Infof("symlink: %v", request)
+		if _, err := client.CreateEntry(ctx, request); err != nil {
+			glog.V(0).Infof("symlink %s/%s: %v", dir.Path, req.NewName, err)
+			return filerErrno(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dir.newFile(req.NewName, request.Entry), nil
+}
+
+func (dir *Dir) Link(ctx context.Context, req *fuse.LinkRequest, old fs.Node) (fs.Node, error) {
+
+	oldFile, ok := old.(*File)
+	if !ok {
+		// hard links to directories are not allowed
+		return nil, fuse.Errno(syscall.EPERM)
+	}
+
+	err := dir.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.LinkEntryRequest{
+			OldDirectory: oldFile.dir.Path,
+			OldName:      oldFile.Name,
+			NewDirectory: dir.Path,
+			NewName:      req.NewName,
+		}
+
+		glog.V(1).Infof("link: %v", request)
+		if _, err := client.LinkEntry(ctx, request); err != nil {
+			glog.V(0).Infof("link %s => %s/%s: %v", oldFile.fullpath(), dir.Path, req.NewName, err)
+			return filerErrno(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the link counter is changed
+	dir.wfs.listDirectoryEntriesCache.Delete(oldFile.fullpath())
+	if !oldFile.isOpen {
+		oldFile.entry = nil
+	}
+
+	newFile := dir.newFile(req.NewName, nil)
+	if err := newFile.maybeLoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+
+	return newFile, nil
+}
+
+func (file *File) Readlink(ctx context.Context, req *fuse.ReadlinkRequest) (string, error) {
+
+	if err := file.maybeLoadAttributes(ctx); err != nil {
+		return "", err
+	}
+
+	if os.FileMode(file.entry.Attributes.FileMode)&os.ModeSymlink == 0 {
+		return "", fuse.Errno(syscall.EINVAL)
+	}
+
+	glog.V(3).Infof("readlink %s: %s", file.fullpath(), file.entry.Attributes.SymlinkTarget)
+
+	return file.entry.Attributes.SymlinkTarget, nil
+}
@@ -0,0 +1,54 @@
+package filesys
+
+import (
+	"context"
+	"strconv"
+
+	"bazil.org/fuse"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+// the read only extended attributes of a directory with the aggregated usage under it,
+// e.g. "getfattr -n seaweedfs.dir.rbytes /mnt/some/dir". They are not listed, so they are
+// not copied by "cp -a" or "rsync -X".
+const (
+	xattrDirBytes       = "seaweedfs.dir.rbytes"
+	xattrDirFiles       = "seaweedfs.dir.rfiles"
+	xattrDirDirectories = "seaweedfs.dir.rsubdirs"
+)
+
+func isDirUsageXattr(name string) bool {
+	return name == xattrDirBytes || name == xattrDirFiles || name == xattrDirDirectories
+}
+
+func (dir *Dir) getUsageXattr(ctx context.Context, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+
+	var usage *filer_pb.DirectoryUsageResponse
+	err := dir.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) (err error) {
+		usage, err = client.DirectoryUsage(ctx, &filer_pb.DirectoryUsageRequest{
+			Directory: dir.Path,
+		})
+		return err
+	})
+	if err != nil {
+		glog.V(0).Infof("directory usage %s: %v", dir.Path, err)
+		return fuse.EIO
+	}
+
+	var value uint64
+	switch req.Name {
+	case xattrDirBytes:
+		value = usage.Bytes
+	case xattrDirFiles:
+		value = usage.FileCount
+	case xattrDirDirectories:
+		value = usage.DirectoryCount
+	}
+	resp.Xattr = []byte(strconv.FormatUint(value, 10))
+	if req.Size != 0 && uint32(len(resp.Xattr)) > req.Size {
+		return fuse.ERANGE
+	}
+
+	return nil
+}
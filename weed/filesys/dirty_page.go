@@ -206,3 +206,10 @@ func max(x, y int64) int64 {
 	}
 	return y
 }
+
+func min(x, y int64) int64 {
+	if x < y {
+		return x
+	}
+	return y
+}
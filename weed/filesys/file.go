@@ -38,7 +38,14 @@ func (file *File) Attr(ctx context.Context, attr *fuse.Attr) error {
 	}
 
 	attr.Mode = os.FileMode(file.entry.Attributes.FileMode)
-	attr.Size = filer2.TotalSize(file.entry.Chunks)
+	attr.Size = filer2.FileSize(file.entry)
+	if attr.Mode&os.ModeSymlink != 0 {
+		attr.Size = uint64(len(file.entry.Attributes.SymlinkTarget))
+	}
+	attr.Nlink = 1
+	if file.entry.HardLinkCounter > 0 {
+		attr.Nlink = uint32(file.entry.HardLinkCounter)
+	}
 	attr.Mtime = time.Unix(file.entry.Attributes.Mtime, 0)
 	attr.Gid = file.entry.Attributes.Gid
 	attr.Uid = file.entry.Attributes.Uid
@@ -83,6 +90,9 @@ func (file *File) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *f
 			// fmt.Printf("truncate %v \n", fullPath)
 			file.entry.Chunks = nil
 		}
+		if req.Size < uint64(len(file.entry.Content)) {
+			file.entry.Content = file.entry.Content[:req.Size]
+		}
 		file.entry.Attributes.FileSize = req.Size
 	}
 	if req.Valid.Mode() {
@@ -112,7 +122,7 @@ func (file *File) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *f
 		_, err := client.UpdateEntry(ctx, request)
 		if err != nil {
 			glog.V(0).Infof("UpdateEntry file %s/%s: %v", file.dir.Path, file.Name, err)
-			return fuse.EIO
+			return filerErrno(err)
 		}
 
 		return nil
@@ -151,7 +161,7 @@ func (file *File) maybeLoadAttributes(ctx context.Context) error {
 
 				file.entry = resp.Entry
 
-				glog.V(1).Infof("file attr %v %+v: %d", file.fullpath(), file.entry.Attributes, filer2.TotalSize(file.entry.Chunks))
+				glog.V(1).Infof("file attr %v %+v: %d", file.fullpath(), file.entry.Attributes, filer2.FileSize(file.entry))
 
 				file.wfs.listDirectoryEntriesCache.Set(file.fullpath(), file.entry, file.wfs.option.EntryCacheTtl)
 
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"syscall"
 )
 
 type FileHandle struct {
@@ -46,6 +47,7 @@ func (fh *FileHandle) InitializeToFile(file *File, uid, gid uint32) *FileHandle
 	}
 	return newHandle
 }
+
 var _ = fs.Handle(&FileHandle{})
 
 // var _ = fs.HandleReadAller(&FileHandle{})
@@ -58,6 +60,13 @@ func (fh *FileHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fus
 
 	glog.V(4).Infof("%s read fh %d: [%d,%d)", fh.f.fullpath(), fh.handle, req.Offset, req.Offset+int64(req.Size))
 
+	if len(fh.f.entry.Content) > 0 {
+		if req.Offset < int64(len(fh.f.entry.Content)) {
+			resp.Data = fh.f.entry.Content[req.Offset:min(req.Offset+int64(req.Size), int64(len(fh.f.entry.Content)))]
+		}
+		return nil
+	}
+
 	// this value should come from the filer instead of the old f
 	if len(fh.f.entry.Chunks) == 0 {
 		glog.V(1).Infof("empty fh %v/%v", fh.f.dir.Path, fh.f.Name)
@@ -66,7 +75,11 @@ func (fh *FileHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fus
 
 	buff := make([]byte, req.Size)
 
-	chunkViews := filer2.ViewFromChunks(fh.f.entry.Chunks, req.Offset, req.Size)
+	chunkViews, err := filer2.ViewFromChunks(filer2.LookupFileIdWithFiler(fh.f.wfs.withFilerClient), fh.f.entry.Chunks, req.Offset, req.Size)
+	if err != nil {
+		glog.V(0).Infof("%v/%v read fh: %v", fh.f.dir.Path, fh.f.Name, err)
+		return fmt.Errorf("failed to read chunk manifests: %v", err)
+	}
 
 	var vids []string
 	for _, chunkView := range chunkViews {
@@ -75,7 +88,7 @@ func (fh *FileHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fus
 
 	vid2Locations := make(map[string]*filer_pb.Locations)
 
-	err := fh.f.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+	err = fh.f.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
 		glog.V(4).Infof("read fh lookup volume id locations: %v", vids)
 		resp, err := client.LookupVolume(ctx, &filer_pb.LookupVolumeRequest{
@@ -146,9 +159,17 @@ func (fh *FileHandle) Write(ctx context.Context, req *fuse.WriteRequest, resp *f
 
 	glog.V(4).Infof("%+v/%v write fh %d: [%d,%d)", fh.f.dir.Path, fh.f.Name, fh.handle, req.Offset, req.Offset+int64(len(req.Data)))
 
+	if err := fh.moveContentToChunk(ctx); err != nil {
+		glog.Errorf("%+v/%v write fh %d: %v", fh.f.dir.Path, fh.f.Name, fh.handle, err)
+		return filerErrno(err)
+	}
+
 	chunks, err := fh.dirtyPages.AddPage(ctx, req.Offset, req.Data)
 	if err != nil {
 		glog.Errorf("%+v/%v write fh %d: [%d,%d): %v", fh.f.dir.Path, fh.f.Name, fh.handle, req.Offset, req.Offset+int64(len(req.Data)), err)
+		if util.IsQuotaExceeded(err) {
+			return fuse.Errno(syscall.ENOSPC)
+		}
 		return fmt.Errorf("write %s/%s at [%d,%d): %v", fh.f.dir.Path, fh.f.Name, req.Offset, req.Offset+int64(len(req.Data)), err)
 	}
 
@@ -189,6 +210,9 @@ func (fh *FileHandle) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 	chunk, err := fh.dirtyPages.FlushToStorage(ctx)
 	if err != nil {
 		glog.Errorf("flush %s/%s: %v", fh.f.dir.Path, fh.f.Name, err)
+		if util.IsQuotaExceeded(err) {
+			return fuse.Errno(syscall.ENOSPC)
+		}
 		return fmt.Errorf("flush %s/%s: %v", fh.f.dir.Path, fh.f.Name, err)
 	}
 	if chunk != nil {
@@ -217,13 +241,50 @@ func (fh *FileHandle) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 			glog.V(1).Infof("%s/%s chunks %d: %v [%d,%d)", fh.f.dir.Path, fh.f.Name, i, chunk.FileId, chunk.Offset, chunk.Offset+int64(chunk.Size))
 		}
 		if _, err := client.CreateEntry(ctx, request); err != nil {
+			glog.Errorf("update %s/%s: %v", fh.f.dir.Path, fh.f.Name, err)
+			if util.IsQuotaExceeded(err) {
+				return fuse.Errno(syscall.ENOSPC)
+			}
 			return fmt.Errorf("update fh: %v", err)
 		}
 
+		// the filer folds a huge chunk list into manifest chunks, so continue with the folded chunks
+		if len(fh.f.entry.Chunks) >= filer2.ManifestBatch {
+			resp, err := client.LookupDirectoryEntry(ctx, &filer_pb.LookupDirectoryEntryRequest{
+				Directory: fh.f.dir.Path,
+				Name:      fh.f.Name,
+			})
+			if err != nil {
+				return fmt.Errorf("lookup fh: %v", err)
+			}
+			fh.f.entry.Chunks = resp.Entry.Chunks
+		}
+
 		return nil
 	})
 }
 
+// moveContentToChunk uploads the content kept in the entry as the first chunk,
+// so the file can be changed by adding chunks.
+func (fh *FileHandle) moveContentToChunk(ctx context.Context) error {
+
+	if len(fh.f.entry.Content) == 0 {
+		return nil
+	}
+
+	chunk, err := fh.dirtyPages.saveToStorage(ctx, fh.f.entry.Content, 0)
+	if err != nil {
+		return fmt.Errorf("move content to chunk: %v", err)
+	}
+	glog.V(1).Infof("moved %s/%s content to %s", fh.f.dir.Path, fh.f.Name, chunk.FileId)
+
+	fh.f.entry.Chunks = append(fh.f.entry.Chunks, chunk)
+	fh.f.entry.Content = nil
+	fh.dirtyMetadata = true
+
+	return nil
+}
+
 func volumeId(fileId string) string {
 	lastCommaIndex := strings.LastIndex(fileId, ",")
 	if lastCommaIndex > 0 {
@@ -0,0 +1,84 @@
+package filesys
+
+import (
+	"context"
+	"math"
+	"strings"
+	"syscall"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/draleyva/seaweedfs/weed/util"
+)
+
+var _ = fs.FSStatfser(&WFS{})
+
+// Statfs reports the capacity from the closest directory quota covering the mount root,
+// or a large capacity if there is no such quota.
+func (wfs *WFS) Statfs(ctx context.Context, req *fuse.StatfsRequest, resp *fuse.StatfsResponse) error {
+
+	resp.Bsize = blockSize
+	resp.Frsize = blockSize
+	resp.Namelen = 1024
+	resp.Blocks = math.MaxInt64 / blockSize
+	resp.Bfree = resp.Blocks
+	resp.Bavail = resp.Blocks
+	resp.Files = math.MaxInt64
+	resp.Ffree = resp.Files
+
+	var quota *filer_pb.DirectoryQuota
+	err := wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		listResp, err := client.DirectoryQuotaList(ctx, &filer_pb.DirectoryQuotaListRequest{})
+		if err != nil {
+			return err
+		}
+		for _, q := range listResp.Quotas {
+			if !isSameOrParentDirectory(q.Directory, wfs.option.FilerMountRootPath) {
+				continue
+			}
+			if quota == nil || len(q.Directory) > len(quota.Directory) {
+				quota = q
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		glog.V(0).Infof("statfs: %v", err)
+		return fuse.EIO
+	}
+	if quota == nil {
+		return nil
+	}
+
+	if quota.MaxBytes > 0 {
+		resp.Blocks = quota.MaxBytes / blockSize
+		resp.Bfree = 0
+		if quota.Bytes < quota.MaxBytes {
+			resp.Bfree = (quota.MaxBytes - quota.Bytes) / blockSize
+		}
+		resp.Bavail = resp.Bfree
+	}
+	if quota.MaxEntryCount > 0 {
+		resp.Files = quota.MaxEntryCount
+		resp.Ffree = 0
+		if quota.EntryCount < quota.MaxEntryCount {
+			resp.Ffree = quota.MaxEntryCount - quota.EntryCount
+		}
+	}
+
+	return nil
+}
+
+func isSameOrParentDirectory(dir, p string) bool {
+	return dir == "/" || dir == p || strings.HasPrefix(p, dir+"/")
+}
+
+// filerErrno converts a filer error to ENOSPC for exceeded quotas, or EIO otherwise.
+func filerErrno(err error) error {
+	if util.IsQuotaExceeded(err) {
+		return fuse.Errno(syscall.ENOSPC)
+	}
+	return fuse.EIO
+}
@@ -0,0 +1,249 @@
+package filesys
+
+import (
+	"context"
+	"sort"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+// the extended attributes are saved in the filer entry Extended map
+
+const (
+	// XATTR_CREATE and XATTR_REPLACE flags of setxattr(2) on linux
+	xattrCreate  = 0x1
+	xattrReplace = 0x2
+)
+
+var _ = fs.NodeGetxattrer(&File{})
+var _ = fs.NodeListxattrer(&File{})
+var _ = fs.NodeSetxattrer(&File{})
+var _ = fs.NodeRemovexattrer(&File{})
+
+var _ = fs.NodeGetxattrer(&Dir{})
+var _ = fs.NodeListxattrer(&Dir{})
+var _ = fs.NodeSetxattrer(&Dir{})
+var _ = fs.NodeRemovexattrer(&Dir{})
+
+func (file *File) Getxattr(ctx context.Context, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+
+	if err := file.maybeLoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	return getxattr(file.entry, req, resp)
+}
+
+func (file *File) Listxattr(ctx context.Context, req *fuse.ListxattrRequest, resp *fuse.ListxattrResponse) error {
+
+	if err := file.maybeLoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	return listxattr(file.entry, req, resp)
+}
+
+func (file *File) Setxattr(ctx context.Context, req *fuse.SetxattrRequest) error {
+
+	if err := file.maybeLoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	if err := setxattr(file.entry, req); err != nil {
+		return err
+	}
+
+	return file.saveEntry(ctx)
+}
+
+func (file *File) Removexattr(ctx context.Context, req *fuse.RemovexattrRequest) error {
+
+	if err := file.maybeLoadAttributes(ctx); err != nil {
+		return err
+	}
+
+	if err := removexattr(file.entry, req); err != nil {
+		return err
+	}
+
+	return file.saveEntry(ctx)
+}
+
+func (file *File) saveEntry(ctx context.Context) error {
+	return file.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.UpdateEntryRequest{
+			Directory: file.dir.Path,
+			Entry:     file.entry,
+		}
+
+		glog.V(1).Infof("save file entry: %v", request)
+		_, err := client.UpdateEntry(ctx, request)
+		if err != nil {
+			glog.V(0).Infof("UpdateEntry file %s/%s: %v", file.dir.Path, file.Name, err)
+			return filerErrno(err)
+		}
+
+		file.wfs.listDirectoryEntriesCache.Set(file.fullpath(), file.entry, file.wfs.option.EntryCacheTtl)
+
+		return nil
+	})
+}
+
+func (dir *Dir) Getxattr(ctx context.Context, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+
+	if isDirUsageXattr(req.Name) {
+		return dir.getUsageXattr(ctx, req, resp)
+	}
+
+	// the root directory has no entry in the filer
+	if dir.Path == "/" {
+		return fuse.ErrNoXattr
+	}
+
+	entry, err := dir.maybeLoadEntry(ctx)
+	if err != nil {
+		return err
+	}
+
+	return getxattr(entry, req, resp)
+}
+
+func (dir *Dir) Listxattr(ctx context.Context, req *fuse.ListxattrRequest, resp *fuse.ListxattrResponse) error {
+
+	if dir.Path == "/" {
+		return nil
+	}
+
+	entry, err := dir.maybeLoadEntry(ctx)
+	if err != nil {
+		return err
+	}
+
+	return listxattr(entry, req, resp)
+}
+
+func (dir *Dir) Setxattr(ctx context.Context, req *fuse.SetxattrRequest) error {
+
+	if dir.Path == "/" || isDirUsageXattr(req.Name) {
+		return fuse.EPERM
+	}
+
+	entry, err := dir.maybeLoadEntry(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := setxattr(entry, req); err != nil {
+		return err
+	}
+
+	return dir.saveEntry(ctx, entry)
+}
+
+func (dir *Dir) Removexattr(ctx context.Context, req *fuse.RemovexattrRequest) error {
+
+	if dir.Path == "/" || isDirUsageXattr(req.Name) {
+		return fuse.EPERM
+	}
+
+	entry, err := dir.maybeLoadEntry(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := removexattr(entry, req); err != nil {
+		return err
+	}
+
+	return dir.saveEntry(ctx, entry)
+}
+
+func (dir *Dir) saveEntry(ctx context.Context, entry *filer_pb.Entry) error {
+
+	parentDir, _ := filer2.FullPath(dir.Path).DirAndName()
+
+	return dir.wfs.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.UpdateEntryRequest{
+			Directory: parentDir,
+			Entry:     entry,
+		}
+
+		glog.V(1).Infof("save directory entry: %v", request)
+		_, err := client.UpdateEntry(ctx, request)
+		if err != nil {
+			glog.V(0).Infof("UpdateEntry %s: %v", dir.Path, err)
+			return filerErrno(err)
+		}
+
+		dir.wfs.listDirectoryEntriesCache.Delete(dir.Path)
+
+		return nil
+	})
+}
+
+func getxattr(entry *filer_pb.Entry, req *fuse.GetxattrRequest, resp *fuse.GetxattrResponse) error {
+
+	value, found := entry.Extended[req.Name]
+	if !found {
+		return fuse.ErrNoXattr
+	}
+	if req.Size != 0 && uint32(len(value)) > req.Size {
+		return fuse.ERANGE
+	}
+
+	resp.Xattr = value
+
+	return nil
+}
+
+func listxattr(entry *filer_pb.Entry, req *fuse.ListxattrRequest, resp *fuse.ListxattrResponse) error {
+
+	var names []string
+	for name := range entry.Extended {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resp.Append(names...)
+	if req.Size != 0 && uint32(len(resp.Xattr)) > req.Size {
+		return fuse.ERANGE
+	}
+
+	return nil
+}
+
+func setxattr(entry *filer_pb.Entry, req *fuse.SetxattrRequest) error {
+
+	_, found := entry.Extended[req.Name]
+	if req.Flags&xattrCreate != 0 && found {
+		return fuse.EEXIST
+	}
+	if req.Flags&xattrReplace != 0 && !found {
+		return fuse.ErrNoXattr
+	}
+
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	// the request buffer is reused by the fuse server
+	entry.Extended[req.Name] = append([]byte{}, req.Xattr...)
+
+	return nil
+}
+
+func removexattr(entry *filer_pb.Entry, req *fuse.RemovexattrRequest) error {
+
+	if _, found := entry.Extended[req.Name]; !found {
+		return fuse.ErrNoXattr
+	}
+
+	delete(entry.Extended, req.Name)
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package filesys
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"bazil.org/fuse"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestXattr(t *testing.T) {
+	entry := &filer_pb.Entry{Name: "a.txt"}
+
+	get := func(name string, size uint32) ([]byte, error) {
+		resp := &fuse.GetxattrResponse{}
+		err := getxattr(entry, &fuse.GetxattrRequest{Name: name, Size: size}, resp)
+		return resp.Xattr, err
+	}
+	list := func(size uint32) ([]string, error) {
+		resp := &fuse.ListxattrResponse{}
+		err := listxattr(entry, &fuse.ListxattrRequest{Size: size}, resp)
+		return strings.Split(strings.TrimSuffix(string(resp.Xattr), "\x00"), "\x00"), err
+	}
+
+	if _, err := get("user.a", 0); err != fuse.ErrNoXattr {
+		t.Fatalf("get missing attribute: %v", err)
+	}
+	if err := removexattr(entry, &fuse.RemovexattrRequest{Name: "user.a"}); err != fuse.ErrNoXattr {
+		t.Fatalf("remove missing attribute: %v", err)
+	}
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.a", Xattr: []byte("1"), Flags: xattrReplace}); err != fuse.ErrNoXattr {
+		t.Fatalf("replace missing attribute: %v", err)
+	}
+
+	buf := []byte("value")
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.b", Xattr: buf, Flags: xattrCreate}); err != nil {
+		t.Fatalf("create attribute: %v", err)
+	}
+	copy(buf, "xxxxx")
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.a", Xattr: []byte("1")}); err != nil {
+		t.Fatalf("set attribute: %v", err)
+	}
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.b", Xattr: []byte("2"), Flags: xattrCreate}); err != fuse.EEXIST {
+		t.Fatalf("create existing attribute: %v", err)
+	}
+
+	if value, err := get("user.b", 0); err != nil || string(value) != "value" {
+		t.Fatalf("get attribute: %q %v", value, err)
+	}
+	if value, err := get("user.b", 5); err != nil || string(value) != "value" {
+		t.Fatalf("get attribute with exact size: %q %v", value, err)
+	}
+	if _, err := get("user.b", 4); err != fuse.ERANGE {
+		t.Fatalf("get attribute with small size: %v", err)
+	}
+
+	if names, err := list(0); err != nil || strings.Join(names, ",") != "user.a,user.b" {
+		t.Fatalf("list attributes: %v %v", names, err)
+	}
+	// "user.a\x00user.b\x00" is 14 bytes
+	if _, err := list(14); err != nil {
+		t.Fatalf("list attributes with exact size: %v", err)
+	}
+	if _, err := list(13); err != fuse.ERANGE {
+		t.Fatalf("list attributes with small size: %v", err)
+	}
+
+	if err := setxattr(entry, &fuse.SetxattrRequest{Name: "user.a", Xattr: []byte("3"), Flags: xattrReplace}); err != nil {
+		t.Fatalf("replace attribute: %v", err)
+	}
+	if value, _ := get("user.a", 0); string(value) != "3" {
+		t.Fatalf("replaced attribute: %q", value)
+	}
+
+	if err := removexattr(entry, &fuse.RemovexattrRequest{Name: "user.a"}); err != nil {
+		t.Fatalf("remove attribute: %v", err)
+	}
+	if _, err := get("user.a", 0); err != fuse.ErrNoXattr {
+		t.Fatalf("get removed attribute: %v", err)
+	}
+	if names, _ := list(0); strings.Join(names, ",") != "user.b" {
+		t.Fatalf("list after remove: %v", names)
+	}
+}
+
+func TestRootDirXattr(t *testing.T) {
+	ctx := context.Background()
+	dir := &Dir{Path: "/"}
+
+	if err := dir.Getxattr(ctx, &fuse.GetxattrRequest{Name: "user.a"}, &fuse.GetxattrResponse{}); err != fuse.ErrNoXattr {
+		t.Fatalf("get root attribute: %v", err)
+	}
+	resp := &fuse.ListxattrResponse{}
+	if err := dir.Listxattr(ctx, &fuse.ListxattrRequest{}, resp); err != nil || len(resp.Xattr) != 0 {
+		t.Fatalf("list root attributes: %q %v", resp.Xattr, err)
+	}
+	if err := dir.Setxattr(ctx, &fuse.SetxattrRequest{Name: "user.a"}); err != fuse.EPERM {
+		t.Fatalf("set root attribute: %v", err)
+	}
+	if err := dir.Removexattr(ctx, &fuse.RemovexattrRequest{Name: "user.a"}); err != fuse.EPERM {
+		t.Fatalf("remove root attribute: %v", err)
+	}
+	if err := (&Dir{Path: "/data"}).Setxattr(ctx, &fuse.SetxattrRequest{Name: xattrDirBytes}); err != fuse.EPERM {
+		t.Fatalf("set usage attribute: %v", err)
+	}
+}
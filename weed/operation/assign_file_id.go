@@ -1,9 +1,10 @@
 package operation
 
 import (
+	"context"
 	"fmt"
+
 	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
-	"context"
 )
 
 type VolumeAssignRequest struct {
@@ -135,3 +135,16 @@ func LookupVolumeIds(server string, vids []string) (map[string]LookupResult, err
 
 	return ret, nil
 }
+
+// ListVolumes returns the volume topology of the cluster from the master
+func ListVolumes(server string) (topologyInfo *master_pb.TopologyInfo, err error) {
+	err = withMasterServerClient(server, func(masterClient master_pb.SeaweedClient) error {
+		resp, grpcErr := masterClient.VolumeList(context.Background(), &master_pb.VolumeListRequest{})
+		if grpcErr != nil {
+			return grpcErr
+		}
+		topologyInfo = resp.TopologyInfo
+		return nil
+	})
+	return
+}
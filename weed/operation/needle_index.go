@@ -0,0 +1,34 @@
+package operation
+
+import (
+	"context"
+	"io"
+
+	"github.com/draleyva/seaweedfs/weed/pb/volume_server_pb"
+)
+
+// GetVolumeNeedleIndex calls eachEntryFn with each live needle of the volume on the volume server
+func GetVolumeNeedleIndex(server string, vid uint32, eachEntryFn func(entry *volume_server_pb.NeedleIndexEntry)) error {
+
+	return WithVolumeServerClient(server, func(client volume_server_pb.VolumeServerClient) error {
+		stream, err := client.VolumeNeedleIndexExport(context.Background(), &volume_server_pb.VolumeNeedleIndexExportRequest{
+			VolumdId: vid,
+		})
+		if err != nil {
+			return err
+		}
+
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			for _, entry := range resp.Entries {
+				eachEntryFn(entry)
+			}
+		}
+	})
+}
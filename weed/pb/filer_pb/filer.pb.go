@@ -16,13 +16,20 @@ It has these top-level messages:
 	Entry
 	EventNotification
 	FileChunk
+	FileChunkManifest
 	FuseAttributes
 	CreateEntryRequest
 	CreateEntryResponse
 	UpdateEntryRequest
 	UpdateEntryResponse
+	AppendToEntryRequest
+	AppendToEntryResponse
 	DeleteEntryRequest
 	DeleteEntryResponse
+	AtomicRenameEntryRequest
+	AtomicRenameEntryResponse
+	LinkEntryRequest
+	LinkEntryResponse
 	AssignVolumeRequest
 	AssignVolumeResponse
 	LookupVolumeRequest
@@ -31,6 +38,39 @@ It has these top-level messages:
 	LookupVolumeResponse
 	DeleteCollectionRequest
 	DeleteCollectionResponse
+	SubscribeMetadataRequest
+	SubscribeMetadataResponse
+	DirectoryQuota
+	DirectoryQuotaConfigureRequest
+	DirectoryQuotaConfigureResponse
+	DirectoryQuotaListRequest
+	DirectoryQuotaListResponse
+	DirectoryUsageRequest
+	DirectoryUsageResponse
+	TrashEntry
+	TrashListRequest
+	TrashListResponse
+	TrashRestoreRequest
+	TrashRestoreResponse
+	Snapshot
+	SnapshotCreateRequest
+	SnapshotCreateResponse
+	SnapshotDeleteRequest
+	SnapshotDeleteResponse
+	SnapshotListRequest
+	SnapshotListResponse
+	VersioningConfig
+	VersioningConfigureRequest
+	VersioningConfigureResponse
+	VersioningListRequest
+	VersioningListResponse
+	FileVersion
+	VersionListRequest
+	VersionListResponse
+	VersionRestoreRequest
+	VersionRestoreResponse
+	DefragmentRequest
+	DefragmentResponse
 */
 package filer_pb
 
@@ -100,6 +140,14 @@ type ListEntriesRequest struct {
 	StartFromFileName  string `protobuf:"bytes,3,opt,name=startFromFileName" json:"startFromFileName,omitempty"`
 	InclusiveStartFrom bool   `protobuf:"varint,4,opt,name=inclusiveStartFrom" json:"inclusiveStartFrom,omitempty"`
 	Limit              uint32 `protobuf:"varint,5,opt,name=limit" json:"limit,omitempty"`
+	// the filters are ignored if zero
+	NamePattern string `protobuf:"bytes,6,opt,name=name_pattern,json=namePattern" json:"name_pattern,omitempty"`
+	MinMtime    int64  `protobuf:"varint,7,opt,name=min_mtime,json=minMtime" json:"min_mtime,omitempty"`
+	MaxMtime    int64  `protobuf:"varint,8,opt,name=max_mtime,json=maxMtime" json:"max_mtime,omitempty"`
+	MinSize     uint64 `protobuf:"varint,9,opt,name=min_size,json=minSize" json:"min_size,omitempty"`
+	MaxSize     uint64 `protobuf:"varint,10,opt,name=max_size,json=maxSize" json:"max_size,omitempty"`
+	// the entry names are the paths relative to the directory, also for startFromFileName
+	Recursive bool `protobuf:"varint,11,opt,name=recursive" json:"recursive,omitempty"`
 }
 
 func (m *ListEntriesRequest) Reset()                    { *m = ListEntriesRequest{} }
@@ -142,8 +190,52 @@ func (m *ListEntriesRequest) GetLimit() uint32 {
 	return 0
 }
 
+func (m *ListEntriesRequest) GetNamePattern() string {
+	if m != nil {
+		return m.NamePattern
+	}
+	return ""
+}
+
+func (m *ListEntriesRequest) GetMinMtime() int64 {
+	if m != nil {
+		return m.MinMtime
+	}
+	return 0
+}
+
+func (m *ListEntriesRequest) GetMaxMtime() int64 {
+	if m != nil {
+		return m.MaxMtime
+	}
+	return 0
+}
+
+func (m *ListEntriesRequest) GetMinSize() uint64 {
+	if m != nil {
+		return m.MinSize
+	}
+	return 0
+}
+
+func (m *ListEntriesRequest) GetMaxSize() uint64 {
+	if m != nil {
+		return m.MaxSize
+	}
+	return 0
+}
+
+func (m *ListEntriesRequest) GetRecursive() bool {
+	if m != nil {
+		return m.Recursive
+	}
+	return false
+}
+
 type ListEntriesResponse struct {
 	Entries []*Entry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+	// set if the scan limit ended the listing early, to continue from as startFromFileName
+	LastScannedFileName string `protobuf:"bytes,2,opt,name=last_scanned_file_name,json=lastScannedFileName" json:"last_scanned_file_name,omitempty"`
 }
 
 func (m *ListEntriesResponse) Reset()                    { *m = ListEntriesResponse{} }
@@ -158,12 +250,22 @@ func (m *ListEntriesResponse) GetEntries() []*Entry {
 	return nil
 }
 
+func (m *ListEntriesResponse) GetLastScannedFileName() string {
+	if m != nil {
+		return m.LastScannedFileName
+	}
+	return ""
+}
+
 type Entry struct {
-	Name        string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
-	IsDirectory bool              `protobuf:"varint,2,opt,name=is_directory,json=isDirectory" json:"is_directory,omitempty"`
-	Chunks      []*FileChunk      `protobuf:"bytes,3,rep,name=chunks" json:"chunks,omitempty"`
-	Attributes  *FuseAttributes   `protobuf:"bytes,4,opt,name=attributes" json:"attributes,omitempty"`
-	Extended    map[string][]byte `protobuf:"bytes,5,rep,name=extended" json:"extended,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Name            string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	IsDirectory     bool              `protobuf:"varint,2,opt,name=is_directory,json=isDirectory" json:"is_directory,omitempty"`
+	Chunks          []*FileChunk      `protobuf:"bytes,3,rep,name=chunks" json:"chunks,omitempty"`
+	Attributes      *FuseAttributes   `protobuf:"bytes,4,opt,name=attributes" json:"attributes,omitempty"`
+	Extended        map[string][]byte `protobuf:"bytes,5,rep,name=extended" json:"extended,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	HardLinkId      string            `protobuf:"bytes,6,opt,name=hard_link_id,json=hardLinkId" json:"hard_link_id,omitempty"`
+	HardLinkCounter int32             `protobuf:"varint,7,opt,name=hard_link_counter,json=hardLinkCounter" json:"hard_link_counter,omitempty"`
+	Content         []byte            `protobuf:"bytes,8,opt,name=content,proto3" json:"content,omitempty"`
 }
 
 func (m *Entry) Reset()                    { *m = Entry{} }
@@ -206,6 +308,27 @@ func (m *Entry) GetExtended() map[string][]byte {
 	return nil
 }
 
+func (m *Entry) GetHardLinkId() string {
+	if m != nil {
+		return m.HardLinkId
+	}
+	return ""
+}
+
+func (m *Entry) GetHardLinkCounter() int32 {
+	if m != nil {
+		return m.HardLinkCounter
+	}
+	return 0
+}
+
+func (m *Entry) GetContent() []byte {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
 type EventNotification struct {
 	OldEntry     *Entry `protobuf:"bytes,1,opt,name=old_entry,json=oldEntry" json:"old_entry,omitempty"`
 	NewEntry     *Entry `protobuf:"bytes,2,opt,name=new_entry,json=newEntry" json:"new_entry,omitempty"`
@@ -239,12 +362,13 @@ func (m *EventNotification) GetDeleteChunks() bool {
 }
 
 type FileChunk struct {
-	FileId       string `protobuf:"bytes,1,opt,name=file_id,json=fileId" json:"file_id,omitempty"`
-	Offset       int64  `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
-	Size         uint64 `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
-	Mtime        int64  `protobuf:"varint,4,opt,name=mtime" json:"mtime,omitempty"`
-	ETag         string `protobuf:"bytes,5,opt,name=e_tag,json=eTag" json:"e_tag,omitempty"`
-	SourceFileId string `protobuf:"bytes,6,opt,name=source_file_id,json=sourceFileId" json:"source_file_id,omitempty"`
+	FileId          string `protobuf:"bytes,1,opt,name=file_id,json=fileId" json:"file_id,omitempty"`
+	Offset          int64  `protobuf:"varint,2,opt,name=offset" json:"offset,omitempty"`
+	Size            uint64 `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
+	Mtime           int64  `protobuf:"varint,4,opt,name=mtime" json:"mtime,omitempty"`
+	ETag            string `protobuf:"bytes,5,opt,name=e_tag,json=eTag" json:"e_tag,omitempty"`
+	SourceFileId    string `protobuf:"bytes,6,opt,name=source_file_id,json=sourceFileId" json:"source_file_id,omitempty"`
+	IsChunkManifest bool   `protobuf:"varint,7,opt,name=is_chunk_manifest,json=isChunkManifest" json:"is_chunk_manifest,omitempty"`
 }
 
 func (m *FileChunk) Reset()                    { *m = FileChunk{} }
@@ -294,23 +418,47 @@ func (m *FileChunk) GetSourceFileId() string {
 	return ""
 }
 
+func (m *FileChunk) GetIsChunkManifest() bool {
+	if m != nil {
+		return m.IsChunkManifest
+	}
+	return false
+}
+
+type FileChunkManifest struct {
+	Chunks []*FileChunk `protobuf:"bytes,1,rep,name=chunks" json:"chunks,omitempty"`
+}
+
+func (m *FileChunkManifest) Reset()                    { *m = FileChunkManifest{} }
+func (m *FileChunkManifest) String() string            { return proto.CompactTextString(m) }
+func (*FileChunkManifest) ProtoMessage()               {}
+func (*FileChunkManifest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *FileChunkManifest) GetChunks() []*FileChunk {
+	if m != nil {
+		return m.Chunks
+	}
+	return nil
+}
+
 type FuseAttributes struct {
-	FileSize    uint64 `protobuf:"varint,1,opt,name=file_size,json=fileSize" json:"file_size,omitempty"`
-	Mtime       int64  `protobuf:"varint,2,opt,name=mtime" json:"mtime,omitempty"`
-	FileMode    uint32 `protobuf:"varint,3,opt,name=file_mode,json=fileMode" json:"file_mode,omitempty"`
-	Uid         uint32 `protobuf:"varint,4,opt,name=uid" json:"uid,omitempty"`
-	Gid         uint32 `protobuf:"varint,5,opt,name=gid" json:"gid,omitempty"`
-	Crtime      int64  `protobuf:"varint,6,opt,name=crtime" json:"crtime,omitempty"`
-	Mime        string `protobuf:"bytes,7,opt,name=mime" json:"mime,omitempty"`
-	Replication string `protobuf:"bytes,8,opt,name=replication" json:"replication,omitempty"`
-	Collection  string `protobuf:"bytes,9,opt,name=collection" json:"collection,omitempty"`
-	TtlSec      int32  `protobuf:"varint,10,opt,name=ttl_sec,json=ttlSec" json:"ttl_sec,omitempty"`
+	FileSize      uint64 `protobuf:"varint,1,opt,name=file_size,json=fileSize" json:"file_size,omitempty"`
+	Mtime         int64  `protobuf:"varint,2,opt,name=mtime" json:"mtime,omitempty"`
+	FileMode      uint32 `protobuf:"varint,3,opt,name=file_mode,json=fileMode" json:"file_mode,omitempty"`
+	Uid           uint32 `protobuf:"varint,4,opt,name=uid" json:"uid,omitempty"`
+	Gid           uint32 `protobuf:"varint,5,opt,name=gid" json:"gid,omitempty"`
+	Crtime        int64  `protobuf:"varint,6,opt,name=crtime" json:"crtime,omitempty"`
+	Mime          string `protobuf:"bytes,7,opt,name=mime" json:"mime,omitempty"`
+	Replication   string `protobuf:"bytes,8,opt,name=replication" json:"replication,omitempty"`
+	Collection    string `protobuf:"bytes,9,opt,name=collection" json:"collection,omitempty"`
+	TtlSec        int32  `protobuf:"varint,10,opt,name=ttl_sec,json=ttlSec" json:"ttl_sec,omitempty"`
+	SymlinkTarget string `protobuf:"bytes,11,opt,name=symlink_target,json=symlinkTarget" json:"symlink_target,omitempty"`
 }
 
 func (m *FuseAttributes) Reset()                    { *m = FuseAttributes{} }
 func (m *FuseAttributes) String() string            { return proto.CompactTextString(m) }
 func (*FuseAttributes) ProtoMessage()               {}
-func (*FuseAttributes) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*FuseAttributes) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *FuseAttributes) GetFileSize() uint64 {
 	if m != nil {
@@ -382,6 +530,13 @@ func (m *FuseAttributes) GetTtlSec() int32 {
 	return 0
 }
 
+func (m *FuseAttributes) GetSymlinkTarget() string {
+	if m != nil {
+		return m.SymlinkTarget
+	}
+	return ""
+}
+
 type CreateEntryRequest struct {
 	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
 	Entry     *Entry `protobuf:"bytes,2,opt,name=entry" json:"entry,omitempty"`
@@ -390,7 +545,7 @@ type CreateEntryRequest struct {
 func (m *CreateEntryRequest) Reset()                    { *m = CreateEntryRequest{} }
 func (m *CreateEntryRequest) String() string            { return proto.CompactTextString(m) }
 func (*CreateEntryRequest) ProtoMessage()               {}
-func (*CreateEntryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*CreateEntryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *CreateEntryRequest) GetDirectory() string {
 	if m != nil {
@@ -412,7 +567,7 @@ type CreateEntryResponse struct {
 func (m *CreateEntryResponse) Reset()                    { *m = CreateEntryResponse{} }
 func (m *CreateEntryResponse) String() string            { return proto.CompactTextString(m) }
 func (*CreateEntryResponse) ProtoMessage()               {}
-func (*CreateEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*CreateEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 type UpdateEntryRequest struct {
 	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
@@ -422,7 +577,7 @@ type UpdateEntryRequest struct {
 func (m *UpdateEntryRequest) Reset()                    { *m = UpdateEntryRequest{} }
 func (m *UpdateEntryRequest) String() string            { return proto.CompactTextString(m) }
 func (*UpdateEntryRequest) ProtoMessage()               {}
-func (*UpdateEntryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*UpdateEntryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *UpdateEntryRequest) GetDirectory() string {
 	if m != nil {
@@ -444,7 +599,63 @@ type UpdateEntryResponse struct {
 func (m *UpdateEntryResponse) Reset()                    { *m = UpdateEntryResponse{} }
 func (m *UpdateEntryResponse) String() string            { return proto.CompactTextString(m) }
 func (*UpdateEntryResponse) ProtoMessage()               {}
-func (*UpdateEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*UpdateEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+type AppendToEntryRequest struct {
+	Directory  string          `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	EntryName  string          `protobuf:"bytes,2,opt,name=entry_name,json=entryName" json:"entry_name,omitempty"`
+	Chunks     []*FileChunk    `protobuf:"bytes,3,rep,name=chunks" json:"chunks,omitempty"`
+	Attributes *FuseAttributes `protobuf:"bytes,4,opt,name=attributes" json:"attributes,omitempty"`
+}
+
+func (m *AppendToEntryRequest) Reset()                    { *m = AppendToEntryRequest{} }
+func (m *AppendToEntryRequest) String() string            { return proto.CompactTextString(m) }
+func (*AppendToEntryRequest) ProtoMessage()               {}
+func (*AppendToEntryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *AppendToEntryRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *AppendToEntryRequest) GetEntryName() string {
+	if m != nil {
+		return m.EntryName
+	}
+	return ""
+}
+
+func (m *AppendToEntryRequest) GetChunks() []*FileChunk {
+	if m != nil {
+		return m.Chunks
+	}
+	return nil
+}
+
+func (m *AppendToEntryRequest) GetAttributes() *FuseAttributes {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+type AppendToEntryResponse struct {
+	Offset int64 `protobuf:"varint,1,opt,name=offset" json:"offset,omitempty"`
+}
+
+func (m *AppendToEntryResponse) Reset()                    { *m = AppendToEntryResponse{} }
+func (m *AppendToEntryResponse) String() string            { return proto.CompactTextString(m) }
+func (*AppendToEntryResponse) ProtoMessage()               {}
+func (*AppendToEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *AppendToEntryResponse) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
 
 type DeleteEntryRequest struct {
 	Directory    string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
@@ -452,12 +663,14 @@ type DeleteEntryRequest struct {
 	IsDirectory  bool   `protobuf:"varint,3,opt,name=is_directory,json=isDirectory" json:"is_directory,omitempty"`
 	IsDeleteData bool   `protobuf:"varint,4,opt,name=is_delete_data,json=isDeleteData" json:"is_delete_data,omitempty"`
 	IsRecursive  bool   `protobuf:"varint,5,opt,name=is_recursive,json=isRecursive" json:"is_recursive,omitempty"`
+	// with is_delete_data false, only delete the chunk manifests of the file, but not the data chunks folded in them
+	IsDeleteChunkManifests bool `protobuf:"varint,6,opt,name=is_delete_chunk_manifests,json=isDeleteChunkManifests" json:"is_delete_chunk_manifests,omitempty"`
 }
 
 func (m *DeleteEntryRequest) Reset()                    { *m = DeleteEntryRequest{} }
 func (m *DeleteEntryRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteEntryRequest) ProtoMessage()               {}
-func (*DeleteEntryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*DeleteEntryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *DeleteEntryRequest) GetDirectory() string {
 	if m != nil {
@@ -494,13 +707,116 @@ func (m *DeleteEntryRequest) GetIsRecursive() bool {
 	return false
 }
 
+func (m *DeleteEntryRequest) GetIsDeleteChunkManifests() bool {
+	if m != nil {
+		return m.IsDeleteChunkManifests
+	}
+	return false
+}
+
 type DeleteEntryResponse struct {
 }
 
 func (m *DeleteEntryResponse) Reset()                    { *m = DeleteEntryResponse{} }
 func (m *DeleteEntryResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteEntryResponse) ProtoMessage()               {}
-func (*DeleteEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*DeleteEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+type AtomicRenameEntryRequest struct {
+	OldDirectory string `protobuf:"bytes,1,opt,name=old_directory,json=oldDirectory" json:"old_directory,omitempty"`
+	OldName      string `protobuf:"bytes,2,opt,name=old_name,json=oldName" json:"old_name,omitempty"`
+	NewDirectory string `protobuf:"bytes,3,opt,name=new_directory,json=newDirectory" json:"new_directory,omitempty"`
+	NewName      string `protobuf:"bytes,4,opt,name=new_name,json=newName" json:"new_name,omitempty"`
+}
+
+func (m *AtomicRenameEntryRequest) Reset()                    { *m = AtomicRenameEntryRequest{} }
+func (m *AtomicRenameEntryRequest) String() string            { return proto.CompactTextString(m) }
+func (*AtomicRenameEntryRequest) ProtoMessage()               {}
+func (*AtomicRenameEntryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *AtomicRenameEntryRequest) GetOldDirectory() string {
+	if m != nil {
+		return m.OldDirectory
+	}
+	return ""
+}
+
+func (m *AtomicRenameEntryRequest) GetOldName() string {
+	if m != nil {
+		return m.OldName
+	}
+	return ""
+}
+
+func (m *AtomicRenameEntryRequest) GetNewDirectory() string {
+	if m != nil {
+		return m.NewDirectory
+	}
+	return ""
+}
+
+func (m *AtomicRenameEntryRequest) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+type AtomicRenameEntryResponse struct {
+}
+
+func (m *AtomicRenameEntryResponse) Reset()                    { *m = AtomicRenameEntryResponse{} }
+func (m *AtomicRenameEntryResponse) String() string            { return proto.CompactTextString(m) }
+func (*AtomicRenameEntryResponse) ProtoMessage()               {}
+func (*AtomicRenameEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+type LinkEntryRequest struct {
+	OldDirectory string `protobuf:"bytes,1,opt,name=old_directory,json=oldDirectory" json:"old_directory,omitempty"`
+	OldName      string `protobuf:"bytes,2,opt,name=old_name,json=oldName" json:"old_name,omitempty"`
+	NewDirectory string `protobuf:"bytes,3,opt,name=new_directory,json=newDirectory" json:"new_directory,omitempty"`
+	NewName      string `protobuf:"bytes,4,opt,name=new_name,json=newName" json:"new_name,omitempty"`
+}
+
+func (m *LinkEntryRequest) Reset()                    { *m = LinkEntryRequest{} }
+func (m *LinkEntryRequest) String() string            { return proto.CompactTextString(m) }
+func (*LinkEntryRequest) ProtoMessage()               {}
+func (*LinkEntryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *LinkEntryRequest) GetOldDirectory() string {
+	if m != nil {
+		return m.OldDirectory
+	}
+	return ""
+}
+
+func (m *LinkEntryRequest) GetOldName() string {
+	if m != nil {
+		return m.OldName
+	}
+	return ""
+}
+
+func (m *LinkEntryRequest) GetNewDirectory() string {
+	if m != nil {
+		return m.NewDirectory
+	}
+	return ""
+}
+
+func (m *LinkEntryRequest) GetNewName() string {
+	if m != nil {
+		return m.NewName
+	}
+	return ""
+}
+
+type LinkEntryResponse struct {
+}
+
+func (m *LinkEntryResponse) Reset()                    { *m = LinkEntryResponse{} }
+func (m *LinkEntryResponse) String() string            { return proto.CompactTextString(m) }
+func (*LinkEntryResponse) ProtoMessage()               {}
+func (*LinkEntryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 type AssignVolumeRequest struct {
 	Count       int32  `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
@@ -513,7 +829,7 @@ type AssignVolumeRequest struct {
 func (m *AssignVolumeRequest) Reset()                    { *m = AssignVolumeRequest{} }
 func (m *AssignVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*AssignVolumeRequest) ProtoMessage()               {}
-func (*AssignVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*AssignVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *AssignVolumeRequest) GetCount() int32 {
 	if m != nil {
@@ -560,7 +876,7 @@ type AssignVolumeResponse struct {
 func (m *AssignVolumeResponse) Reset()                    { *m = AssignVolumeResponse{} }
 func (m *AssignVolumeResponse) String() string            { return proto.CompactTextString(m) }
 func (*AssignVolumeResponse) ProtoMessage()               {}
-func (*AssignVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*AssignVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *AssignVolumeResponse) GetFileId() string {
 	if m != nil {
@@ -597,7 +913,7 @@ type LookupVolumeRequest struct {
 func (m *LookupVolumeRequest) Reset()                    { *m = LookupVolumeRequest{} }
 func (m *LookupVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*LookupVolumeRequest) ProtoMessage()               {}
-func (*LookupVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*LookupVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *LookupVolumeRequest) GetVolumeIds() []string {
 	if m != nil {
@@ -613,7 +929,7 @@ type Locations struct {
 func (m *Locations) Reset()                    { *m = Locations{} }
 func (m *Locations) String() string            { return proto.CompactTextString(m) }
 func (*Locations) ProtoMessage()               {}
-func (*Locations) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*Locations) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *Locations) GetLocations() []*Location {
 	if m != nil {
@@ -630,7 +946,7 @@ type Location struct {
 func (m *Location) Reset()                    { *m = Location{} }
 func (m *Location) String() string            { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()               {}
-func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 func (m *Location) GetUrl() string {
 	if m != nil {
@@ -653,7 +969,7 @@ type LookupVolumeResponse struct {
 func (m *LookupVolumeResponse) Reset()                    { *m = LookupVolumeResponse{} }
 func (m *LookupVolumeResponse) String() string            { return proto.CompactTextString(m) }
 func (*LookupVolumeResponse) ProtoMessage()               {}
-func (*LookupVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*LookupVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *LookupVolumeResponse) GetLocationsMap() map[string]*Locations {
 	if m != nil {
@@ -669,7 +985,7 @@ type DeleteCollectionRequest struct {
 func (m *DeleteCollectionRequest) Reset()                    { *m = DeleteCollectionRequest{} }
 func (m *DeleteCollectionRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteCollectionRequest) ProtoMessage()               {}
-func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*DeleteCollectionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *DeleteCollectionRequest) GetCollection() string {
 	if m != nil {
@@ -684,96 +1000,895 @@ type DeleteCollectionResponse struct {
 func (m *DeleteCollectionResponse) Reset()                    { *m = DeleteCollectionResponse{} }
 func (m *DeleteCollectionResponse) String() string            { return proto.CompactTextString(m) }
 func (*DeleteCollectionResponse) ProtoMessage()               {}
-func (*DeleteCollectionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*DeleteCollectionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
-func init() {
-	proto.RegisterType((*LookupDirectoryEntryRequest)(nil), "filer_pb.LookupDirectoryEntryRequest")
-	proto.RegisterType((*LookupDirectoryEntryResponse)(nil), "filer_pb.LookupDirectoryEntryResponse")
-	proto.RegisterType((*ListEntriesRequest)(nil), "filer_pb.ListEntriesRequest")
-	proto.RegisterType((*ListEntriesResponse)(nil), "filer_pb.ListEntriesResponse")
-	proto.RegisterType((*Entry)(nil), "filer_pb.Entry")
-	proto.RegisterType((*EventNotification)(nil), "filer_pb.EventNotification")
-	proto.RegisterType((*FileChunk)(nil), "filer_pb.FileChunk")
-	proto.RegisterType((*FuseAttributes)(nil), "filer_pb.FuseAttributes")
-	proto.RegisterType((*CreateEntryRequest)(nil), "filer_pb.CreateEntryRequest")
-	proto.RegisterType((*CreateEntryResponse)(nil), "filer_pb.CreateEntryResponse")
-	proto.RegisterType((*UpdateEntryRequest)(nil), "filer_pb.UpdateEntryRequest")
-	proto.RegisterType((*UpdateEntryResponse)(nil), "filer_pb.UpdateEntryResponse")
-	proto.RegisterType((*DeleteEntryRequest)(nil), "filer_pb.DeleteEntryRequest")
-	proto.RegisterType((*DeleteEntryResponse)(nil), "filer_pb.DeleteEntryResponse")
-	proto.RegisterType((*AssignVolumeRequest)(nil), "filer_pb.AssignVolumeRequest")
-	proto.RegisterType((*AssignVolumeResponse)(nil), "filer_pb.AssignVolumeResponse")
-	proto.RegisterType((*LookupVolumeRequest)(nil), "filer_pb.LookupVolumeRequest")
-	proto.RegisterType((*Locations)(nil), "filer_pb.Locations")
-	proto.RegisterType((*Location)(nil), "filer_pb.Location")
-	proto.RegisterType((*LookupVolumeResponse)(nil), "filer_pb.LookupVolumeResponse")
-	proto.RegisterType((*DeleteCollectionRequest)(nil), "filer_pb.DeleteCollectionRequest")
-	proto.RegisterType((*DeleteCollectionResponse)(nil), "filer_pb.DeleteCollectionResponse")
+type SubscribeMetadataRequest struct {
+	ClientName string `protobuf:"bytes,1,opt,name=client_name,json=clientName" json:"client_name,omitempty"`
+	PathPrefix string `protobuf:"bytes,2,opt,name=path_prefix,json=pathPrefix" json:"path_prefix,omitempty"`
+	// only events after this time are sent, 0 means all events in the log
+	SinceNs int64 `protobuf:"varint,3,opt,name=since_ns,json=sinceNs" json:"since_ns,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *SubscribeMetadataRequest) Reset()                    { *m = SubscribeMetadataRequest{} }
+func (m *SubscribeMetadataRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeMetadataRequest) ProtoMessage()               {}
+func (*SubscribeMetadataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *SubscribeMetadataRequest) GetClientName() string {
+	if m != nil {
+		return m.ClientName
+	}
+	return ""
+}
 
-// Client API for SeaweedFiler service
+func (m *SubscribeMetadataRequest) GetPathPrefix() string {
+	if m != nil {
+		return m.PathPrefix
+	}
+	return ""
+}
 
-type SeaweedFilerClient interface {
-	LookupDirectoryEntry(ctx context.Context, in *LookupDirectoryEntryRequest, opts ...grpc.CallOption) (*LookupDirectoryEntryResponse, error)
-	ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error)
-	CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error)
-	UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*UpdateEntryResponse, error)
-	DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*DeleteEntryResponse, error)
-	AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error)
-	LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error)
-	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+func (m *SubscribeMetadataRequest) GetSinceNs() int64 {
+	if m != nil {
+		return m.SinceNs
+	}
+	return 0
 }
 
-type seaweedFilerClient struct {
-	cc *grpc.ClientConn
+type SubscribeMetadataResponse struct {
+	Directory         string             `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	EventNotification *EventNotification `protobuf:"bytes,2,opt,name=event_notification,json=eventNotification" json:"event_notification,omitempty"`
+	TsNs              int64              `protobuf:"varint,3,opt,name=ts_ns,json=tsNs" json:"ts_ns,omitempty"`
 }
 
-func NewSeaweedFilerClient(cc *grpc.ClientConn) SeaweedFilerClient {
-	return &seaweedFilerClient{cc}
+func (m *SubscribeMetadataResponse) Reset()                    { *m = SubscribeMetadataResponse{} }
+func (m *SubscribeMetadataResponse) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeMetadataResponse) ProtoMessage()               {}
+func (*SubscribeMetadataResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *SubscribeMetadataResponse) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
 }
 
-func (c *seaweedFilerClient) LookupDirectoryEntry(ctx context.Context, in *LookupDirectoryEntryRequest, opts ...grpc.CallOption) (*LookupDirectoryEntryResponse, error) {
-	out := new(LookupDirectoryEntryResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/LookupDirectoryEntry", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *SubscribeMetadataResponse) GetEventNotification() *EventNotification {
+	if m != nil {
+		return m.EventNotification
 	}
-	return out, nil
+	return nil
 }
 
-func (c *seaweedFilerClient) ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error) {
-	out := new(ListEntriesResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/ListEntries", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *SubscribeMetadataResponse) GetTsNs() int64 {
+	if m != nil {
+		return m.TsNs
 	}
-	return out, nil
+	return 0
 }
 
-func (c *seaweedFilerClient) CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error) {
-	out := new(CreateEntryResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/CreateEntry", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+type DirectoryQuota struct {
+	Directory     string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	MaxBytes      uint64 `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes" json:"max_bytes,omitempty"`
+	MaxEntryCount uint64 `protobuf:"varint,3,opt,name=max_entry_count,json=maxEntryCount" json:"max_entry_count,omitempty"`
+	Bytes         uint64 `protobuf:"varint,4,opt,name=bytes" json:"bytes,omitempty"`
+	EntryCount    uint64 `protobuf:"varint,5,opt,name=entry_count,json=entryCount" json:"entry_count,omitempty"`
+}
+
+func (m *DirectoryQuota) Reset()                    { *m = DirectoryQuota{} }
+func (m *DirectoryQuota) String() string            { return proto.CompactTextString(m) }
+func (*DirectoryQuota) ProtoMessage()               {}
+func (*DirectoryQuota) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *DirectoryQuota) GetDirectory() string {
+	if m != nil {
+		return m.Directory
 	}
-	return out, nil
+	return ""
 }
 
-func (c *seaweedFilerClient) UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*UpdateEntryResponse, error) {
-	out := new(UpdateEntryResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/UpdateEntry", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *DirectoryQuota) GetMaxBytes() uint64 {
+	if m != nil {
+		return m.MaxBytes
 	}
-	return out, nil
+	return 0
+}
+
+func (m *DirectoryQuota) GetMaxEntryCount() uint64 {
+	if m != nil {
+		return m.MaxEntryCount
+	}
+	return 0
+}
+
+func (m *DirectoryQuota) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *DirectoryQuota) GetEntryCount() uint64 {
+	if m != nil {
+		return m.EntryCount
+	}
+	return 0
+}
+
+type DirectoryQuotaConfigureRequest struct {
+	Directory     string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	MaxBytes      uint64 `protobuf:"varint,2,opt,name=max_bytes,json=maxBytes" json:"max_bytes,omitempty"`
+	MaxEntryCount uint64 `protobuf:"varint,3,opt,name=max_entry_count,json=maxEntryCount" json:"max_entry_count,omitempty"`
+}
+
+func (m *DirectoryQuotaConfigureRequest) Reset()         { *m = DirectoryQuotaConfigureRequest{} }
+func (m *DirectoryQuotaConfigureRequest) String() string { return proto.CompactTextString(m) }
+func (*DirectoryQuotaConfigureRequest) ProtoMessage()    {}
+func (*DirectoryQuotaConfigureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{32}
+}
+
+func (m *DirectoryQuotaConfigureRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *DirectoryQuotaConfigureRequest) GetMaxBytes() uint64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *DirectoryQuotaConfigureRequest) GetMaxEntryCount() uint64 {
+	if m != nil {
+		return m.MaxEntryCount
+	}
+	return 0
+}
+
+type DirectoryQuotaConfigureResponse struct {
+}
+
+func (m *DirectoryQuotaConfigureResponse) Reset()         { *m = DirectoryQuotaConfigureResponse{} }
+func (m *DirectoryQuotaConfigureResponse) String() string { return proto.CompactTextString(m) }
+func (*DirectoryQuotaConfigureResponse) ProtoMessage()    {}
+func (*DirectoryQuotaConfigureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{33}
+}
+
+type DirectoryQuotaListRequest struct {
+}
+
+func (m *DirectoryQuotaListRequest) Reset()                    { *m = DirectoryQuotaListRequest{} }
+func (m *DirectoryQuotaListRequest) String() string            { return proto.CompactTextString(m) }
+func (*DirectoryQuotaListRequest) ProtoMessage()               {}
+func (*DirectoryQuotaListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+type DirectoryQuotaListResponse struct {
+	Quotas []*DirectoryQuota `protobuf:"bytes,1,rep,name=quotas" json:"quotas,omitempty"`
+}
+
+func (m *DirectoryQuotaListResponse) Reset()                    { *m = DirectoryQuotaListResponse{} }
+func (m *DirectoryQuotaListResponse) String() string            { return proto.CompactTextString(m) }
+func (*DirectoryQuotaListResponse) ProtoMessage()               {}
+func (*DirectoryQuotaListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *DirectoryQuotaListResponse) GetQuotas() []*DirectoryQuota {
+	if m != nil {
+		return m.Quotas
+	}
+	return nil
+}
+
+type DirectoryUsageRequest struct {
+	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+}
+
+func (m *DirectoryUsageRequest) Reset()                    { *m = DirectoryUsageRequest{} }
+func (m *DirectoryUsageRequest) String() string            { return proto.CompactTextString(m) }
+func (*DirectoryUsageRequest) ProtoMessage()               {}
+func (*DirectoryUsageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *DirectoryUsageRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+type DirectoryUsageResponse struct {
+	Bytes          uint64 `protobuf:"varint,1,opt,name=bytes" json:"bytes,omitempty"`
+	FileCount      uint64 `protobuf:"varint,2,opt,name=file_count,json=fileCount" json:"file_count,omitempty"`
+	DirectoryCount uint64 `protobuf:"varint,3,opt,name=directory_count,json=directoryCount" json:"directory_count,omitempty"`
+}
+
+func (m *DirectoryUsageResponse) Reset()                    { *m = DirectoryUsageResponse{} }
+func (m *DirectoryUsageResponse) String() string            { return proto.CompactTextString(m) }
+func (*DirectoryUsageResponse) ProtoMessage()               {}
+func (*DirectoryUsageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *DirectoryUsageResponse) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *DirectoryUsageResponse) GetFileCount() uint64 {
+	if m != nil {
+		return m.FileCount
+	}
+	return 0
+}
+
+func (m *DirectoryUsageResponse) GetDirectoryCount() uint64 {
+	if m != nil {
+		return m.DirectoryCount
+	}
+	return 0
+}
+
+type TrashEntry struct {
+	TrashPath    string `protobuf:"bytes,1,opt,name=trash_path,json=trashPath" json:"trash_path,omitempty"`
+	OriginalPath string `protobuf:"bytes,2,opt,name=original_path,json=originalPath" json:"original_path,omitempty"`
+	DeletedTsNs  int64  `protobuf:"varint,3,opt,name=deleted_ts_ns,json=deletedTsNs" json:"deleted_ts_ns,omitempty"`
+	IsDirectory  bool   `protobuf:"varint,4,opt,name=is_directory,json=isDirectory" json:"is_directory,omitempty"`
+	FileSize     uint64 `protobuf:"varint,5,opt,name=file_size,json=fileSize" json:"file_size,omitempty"`
+}
+
+func (m *TrashEntry) Reset()                    { *m = TrashEntry{} }
+func (m *TrashEntry) String() string            { return proto.CompactTextString(m) }
+func (*TrashEntry) ProtoMessage()               {}
+func (*TrashEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *TrashEntry) GetTrashPath() string {
+	if m != nil {
+		return m.TrashPath
+	}
+	return ""
+}
+
+func (m *TrashEntry) GetOriginalPath() string {
+	if m != nil {
+		return m.OriginalPath
+	}
+	return ""
+}
+
+func (m *TrashEntry) GetDeletedTsNs() int64 {
+	if m != nil {
+		return m.DeletedTsNs
+	}
+	return 0
+}
+
+func (m *TrashEntry) GetIsDirectory() bool {
+	if m != nil {
+		return m.IsDirectory
+	}
+	return false
+}
+
+func (m *TrashEntry) GetFileSize() uint64 {
+	if m != nil {
+		return m.FileSize
+	}
+	return 0
+}
+
+type TrashListRequest struct {
+}
+
+func (m *TrashListRequest) Reset()                    { *m = TrashListRequest{} }
+func (m *TrashListRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrashListRequest) ProtoMessage()               {}
+func (*TrashListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+type TrashListResponse struct {
+	Entries []*TrashEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+}
+
+func (m *TrashListResponse) Reset()                    { *m = TrashListResponse{} }
+func (m *TrashListResponse) String() string            { return proto.CompactTextString(m) }
+func (*TrashListResponse) ProtoMessage()               {}
+func (*TrashListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *TrashListResponse) GetEntries() []*TrashEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type TrashRestoreRequest struct {
+	TrashPath string `protobuf:"bytes,1,opt,name=trash_path,json=trashPath" json:"trash_path,omitempty"`
+}
+
+func (m *TrashRestoreRequest) Reset()                    { *m = TrashRestoreRequest{} }
+func (m *TrashRestoreRequest) String() string            { return proto.CompactTextString(m) }
+func (*TrashRestoreRequest) ProtoMessage()               {}
+func (*TrashRestoreRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *TrashRestoreRequest) GetTrashPath() string {
+	if m != nil {
+		return m.TrashPath
+	}
+	return ""
+}
+
+type TrashRestoreResponse struct {
+}
+
+func (m *TrashRestoreResponse) Reset()                    { *m = TrashRestoreResponse{} }
+func (m *TrashRestoreResponse) String() string            { return proto.CompactTextString(m) }
+func (*TrashRestoreResponse) ProtoMessage()               {}
+func (*TrashRestoreResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+type Snapshot struct {
+	Directory   string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	CreatedTsNs int64  `protobuf:"varint,3,opt,name=created_ts_ns,json=createdTsNs" json:"created_ts_ns,omitempty"`
+}
+
+func (m *Snapshot) Reset()                    { *m = Snapshot{} }
+func (m *Snapshot) String() string            { return proto.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()               {}
+func (*Snapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *Snapshot) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *Snapshot) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Snapshot) GetCreatedTsNs() int64 {
+	if m != nil {
+		return m.CreatedTsNs
+	}
+	return 0
+}
+
+type SnapshotCreateRequest struct {
+	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *SnapshotCreateRequest) Reset()                    { *m = SnapshotCreateRequest{} }
+func (m *SnapshotCreateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotCreateRequest) ProtoMessage()               {}
+func (*SnapshotCreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *SnapshotCreateRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *SnapshotCreateRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type SnapshotCreateResponse struct {
+}
+
+func (m *SnapshotCreateResponse) Reset()                    { *m = SnapshotCreateResponse{} }
+func (m *SnapshotCreateResponse) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotCreateResponse) ProtoMessage()               {}
+func (*SnapshotCreateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+type SnapshotDeleteRequest struct {
+	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *SnapshotDeleteRequest) Reset()                    { *m = SnapshotDeleteRequest{} }
+func (m *SnapshotDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotDeleteRequest) ProtoMessage()               {}
+func (*SnapshotDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *SnapshotDeleteRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *SnapshotDeleteRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type SnapshotDeleteResponse struct {
+}
+
+func (m *SnapshotDeleteResponse) Reset()                    { *m = SnapshotDeleteResponse{} }
+func (m *SnapshotDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotDeleteResponse) ProtoMessage()               {}
+func (*SnapshotDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+type SnapshotListRequest struct {
+}
+
+func (m *SnapshotListRequest) Reset()                    { *m = SnapshotListRequest{} }
+func (m *SnapshotListRequest) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotListRequest) ProtoMessage()               {}
+func (*SnapshotListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{48} }
+
+type SnapshotListResponse struct {
+	Snapshots []*Snapshot `protobuf:"bytes,1,rep,name=snapshots" json:"snapshots,omitempty"`
+}
+
+func (m *SnapshotListResponse) Reset()                    { *m = SnapshotListResponse{} }
+func (m *SnapshotListResponse) String() string            { return proto.CompactTextString(m) }
+func (*SnapshotListResponse) ProtoMessage()               {}
+func (*SnapshotListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *SnapshotListResponse) GetSnapshots() []*Snapshot {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+type VersioningConfig struct {
+	Directory     string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	MaxVersions   uint32 `protobuf:"varint,2,opt,name=max_versions,json=maxVersions" json:"max_versions,omitempty"`
+	MaxAgeSeconds int64  `protobuf:"varint,3,opt,name=max_age_seconds,json=maxAgeSeconds" json:"max_age_seconds,omitempty"`
+}
+
+func (m *VersioningConfig) Reset()                    { *m = VersioningConfig{} }
+func (m *VersioningConfig) String() string            { return proto.CompactTextString(m) }
+func (*VersioningConfig) ProtoMessage()               {}
+func (*VersioningConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+func (m *VersioningConfig) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *VersioningConfig) GetMaxVersions() uint32 {
+	if m != nil {
+		return m.MaxVersions
+	}
+	return 0
+}
+
+func (m *VersioningConfig) GetMaxAgeSeconds() int64 {
+	if m != nil {
+		return m.MaxAgeSeconds
+	}
+	return 0
+}
+
+type VersioningConfigureRequest struct {
+	Directory     string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	Enabled       bool   `protobuf:"varint,2,opt,name=enabled" json:"enabled,omitempty"`
+	MaxVersions   uint32 `protobuf:"varint,3,opt,name=max_versions,json=maxVersions" json:"max_versions,omitempty"`
+	MaxAgeSeconds int64  `protobuf:"varint,4,opt,name=max_age_seconds,json=maxAgeSeconds" json:"max_age_seconds,omitempty"`
+}
+
+func (m *VersioningConfigureRequest) Reset()                    { *m = VersioningConfigureRequest{} }
+func (m *VersioningConfigureRequest) String() string            { return proto.CompactTextString(m) }
+func (*VersioningConfigureRequest) ProtoMessage()               {}
+func (*VersioningConfigureRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *VersioningConfigureRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *VersioningConfigureRequest) GetEnabled() bool {
+	if m != nil {
+		return m.Enabled
+	}
+	return false
+}
+
+func (m *VersioningConfigureRequest) GetMaxVersions() uint32 {
+	if m != nil {
+		return m.MaxVersions
+	}
+	return 0
+}
+
+func (m *VersioningConfigureRequest) GetMaxAgeSeconds() int64 {
+	if m != nil {
+		return m.MaxAgeSeconds
+	}
+	return 0
+}
+
+type VersioningConfigureResponse struct {
+}
+
+func (m *VersioningConfigureResponse) Reset()                    { *m = VersioningConfigureResponse{} }
+func (m *VersioningConfigureResponse) String() string            { return proto.CompactTextString(m) }
+func (*VersioningConfigureResponse) ProtoMessage()               {}
+func (*VersioningConfigureResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+type VersioningListRequest struct {
+}
+
+func (m *VersioningListRequest) Reset()                    { *m = VersioningListRequest{} }
+func (m *VersioningListRequest) String() string            { return proto.CompactTextString(m) }
+func (*VersioningListRequest) ProtoMessage()               {}
+func (*VersioningListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+type VersioningListResponse struct {
+	Configs []*VersioningConfig `protobuf:"bytes,1,rep,name=configs" json:"configs,omitempty"`
+}
+
+func (m *VersioningListResponse) Reset()                    { *m = VersioningListResponse{} }
+func (m *VersioningListResponse) String() string            { return proto.CompactTextString(m) }
+func (*VersioningListResponse) ProtoMessage()               {}
+func (*VersioningListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+func (m *VersioningListResponse) GetConfigs() []*VersioningConfig {
+	if m != nil {
+		return m.Configs
+	}
+	return nil
+}
+
+type FileVersion struct {
+	VersionId    string `protobuf:"bytes,1,opt,name=version_id,json=versionId" json:"version_id,omitempty"`
+	ReplacedTsNs int64  `protobuf:"varint,2,opt,name=replaced_ts_ns,json=replacedTsNs" json:"replaced_ts_ns,omitempty"`
+	Entry        *Entry `protobuf:"bytes,3,opt,name=entry" json:"entry,omitempty"`
+}
+
+func (m *FileVersion) Reset()                    { *m = FileVersion{} }
+func (m *FileVersion) String() string            { return proto.CompactTextString(m) }
+func (*FileVersion) ProtoMessage()               {}
+func (*FileVersion) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *FileVersion) GetVersionId() string {
+	if m != nil {
+		return m.VersionId
+	}
+	return ""
+}
+
+func (m *FileVersion) GetReplacedTsNs() int64 {
+	if m != nil {
+		return m.ReplacedTsNs
+	}
+	return 0
+}
+
+func (m *FileVersion) GetEntry() *Entry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+type VersionListRequest struct {
+	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *VersionListRequest) Reset()                    { *m = VersionListRequest{} }
+func (m *VersionListRequest) String() string            { return proto.CompactTextString(m) }
+func (*VersionListRequest) ProtoMessage()               {}
+func (*VersionListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *VersionListRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *VersionListRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type VersionListResponse struct {
+	Versions []*FileVersion `protobuf:"bytes,1,rep,name=versions" json:"versions,omitempty"`
+}
+
+func (m *VersionListResponse) Reset()                    { *m = VersionListResponse{} }
+func (m *VersionListResponse) String() string            { return proto.CompactTextString(m) }
+func (*VersionListResponse) ProtoMessage()               {}
+func (*VersionListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{57} }
+
+func (m *VersionListResponse) GetVersions() []*FileVersion {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
+}
+
+type VersionRestoreRequest struct {
+	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	VersionId string `protobuf:"bytes,3,opt,name=version_id,json=versionId" json:"version_id,omitempty"`
+}
+
+func (m *VersionRestoreRequest) Reset()                    { *m = VersionRestoreRequest{} }
+func (m *VersionRestoreRequest) String() string            { return proto.CompactTextString(m) }
+func (*VersionRestoreRequest) ProtoMessage()               {}
+func (*VersionRestoreRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *VersionRestoreRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *VersionRestoreRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *VersionRestoreRequest) GetVersionId() string {
+	if m != nil {
+		return m.VersionId
+	}
+	return ""
+}
+
+type VersionRestoreResponse struct {
+}
+
+func (m *VersionRestoreResponse) Reset()                    { *m = VersionRestoreResponse{} }
+func (m *VersionRestoreResponse) String() string            { return proto.CompactTextString(m) }
+func (*VersionRestoreResponse) ProtoMessage()               {}
+func (*VersionRestoreResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{59} }
+
+type DefragmentRequest struct {
+	Directory string `protobuf:"bytes,1,opt,name=directory" json:"directory,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name" json:"name,omitempty"`
+	MinChunks uint32 `protobuf:"varint,3,opt,name=min_chunks,json=minChunks" json:"min_chunks,omitempty"`
+	MaxMb     int32  `protobuf:"varint,4,opt,name=max_mb,json=maxMb" json:"max_mb,omitempty"`
+}
+
+func (m *DefragmentRequest) Reset()                    { *m = DefragmentRequest{} }
+func (m *DefragmentRequest) String() string            { return proto.CompactTextString(m) }
+func (*DefragmentRequest) ProtoMessage()               {}
+func (*DefragmentRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *DefragmentRequest) GetDirectory() string {
+	if m != nil {
+		return m.Directory
+	}
+	return ""
+}
+
+func (m *DefragmentRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *DefragmentRequest) GetMinChunks() uint32 {
+	if m != nil {
+		return m.MinChunks
+	}
+	return 0
+}
+
+func (m *DefragmentRequest) GetMaxMb() int32 {
+	if m != nil {
+		return m.MaxMb
+	}
+	return 0
+}
+
+type DefragmentResponse struct {
+	FileCount     uint32 `protobuf:"varint,1,opt,name=file_count,json=fileCount" json:"file_count,omitempty"`
+	OldChunkCount uint64 `protobuf:"varint,2,opt,name=old_chunk_count,json=oldChunkCount" json:"old_chunk_count,omitempty"`
+	NewChunkCount uint64 `protobuf:"varint,3,opt,name=new_chunk_count,json=newChunkCount" json:"new_chunk_count,omitempty"`
+}
+
+func (m *DefragmentResponse) Reset()                    { *m = DefragmentResponse{} }
+func (m *DefragmentResponse) String() string            { return proto.CompactTextString(m) }
+func (*DefragmentResponse) ProtoMessage()               {}
+func (*DefragmentResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+func (m *DefragmentResponse) GetFileCount() uint32 {
+	if m != nil {
+		return m.FileCount
+	}
+	return 0
+}
+
+func (m *DefragmentResponse) GetOldChunkCount() uint64 {
+	if m != nil {
+		return m.OldChunkCount
+	}
+	return 0
+}
+
+func (m *DefragmentResponse) GetNewChunkCount() uint64 {
+	if m != nil {
+		return m.NewChunkCount
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*LookupDirectoryEntryRequest)(nil), "filer_pb.LookupDirectoryEntryRequest")
+	proto.RegisterType((*LookupDirectoryEntryResponse)(nil), "filer_pb.LookupDirectoryEntryResponse")
+	proto.RegisterType((*ListEntriesRequest)(nil), "filer_pb.ListEntriesRequest")
+	proto.RegisterType((*ListEntriesResponse)(nil), "filer_pb.ListEntriesResponse")
+	proto.RegisterType((*Entry)(nil), "filer_pb.Entry")
+	proto.RegisterType((*EventNotification)(nil), "filer_pb.EventNotification")
+	proto.RegisterType((*FileChunk)(nil), "filer_pb.FileChunk")
+	proto.RegisterType((*FileChunkManifest)(nil), "filer_pb.FileChunkManifest")
+	proto.RegisterType((*FuseAttributes)(nil), "filer_pb.FuseAttributes")
+	proto.RegisterType((*CreateEntryRequest)(nil), "filer_pb.CreateEntryRequest")
+	proto.RegisterType((*CreateEntryResponse)(nil), "filer_pb.CreateEntryResponse")
+	proto.RegisterType((*UpdateEntryRequest)(nil), "filer_pb.UpdateEntryRequest")
+	proto.RegisterType((*UpdateEntryResponse)(nil), "filer_pb.UpdateEntryResponse")
+	proto.RegisterType((*AppendToEntryRequest)(nil), "filer_pb.AppendToEntryRequest")
+	proto.RegisterType((*AppendToEntryResponse)(nil), "filer_pb.AppendToEntryResponse")
+	proto.RegisterType((*DeleteEntryRequest)(nil), "filer_pb.DeleteEntryRequest")
+	proto.RegisterType((*DeleteEntryResponse)(nil), "filer_pb.DeleteEntryResponse")
+	proto.RegisterType((*AtomicRenameEntryRequest)(nil), "filer_pb.AtomicRenameEntryRequest")
+	proto.RegisterType((*AtomicRenameEntryResponse)(nil), "filer_pb.AtomicRenameEntryResponse")
+	proto.RegisterType((*LinkEntryRequest)(nil), "filer_pb.LinkEntryRequest")
+	proto.RegisterType((*LinkEntryResponse)(nil), "filer_pb.LinkEntryResponse")
+	proto.RegisterType((*AssignVolumeRequest)(nil), "filer_pb.AssignVolumeRequest")
+	proto.RegisterType((*AssignVolumeResponse)(nil), "filer_pb.AssignVolumeResponse")
+	proto.RegisterType((*LookupVolumeRequest)(nil), "filer_pb.LookupVolumeRequest")
+	proto.RegisterType((*Locations)(nil), "filer_pb.Locations")
+	proto.RegisterType((*Location)(nil), "filer_pb.Location")
+	proto.RegisterType((*LookupVolumeResponse)(nil), "filer_pb.LookupVolumeResponse")
+	proto.RegisterType((*DeleteCollectionRequest)(nil), "filer_pb.DeleteCollectionRequest")
+	proto.RegisterType((*DeleteCollectionResponse)(nil), "filer_pb.DeleteCollectionResponse")
+	proto.RegisterType((*SubscribeMetadataRequest)(nil), "filer_pb.SubscribeMetadataRequest")
+	proto.RegisterType((*SubscribeMetadataResponse)(nil), "filer_pb.SubscribeMetadataResponse")
+	proto.RegisterType((*DirectoryQuota)(nil), "filer_pb.DirectoryQuota")
+	proto.RegisterType((*DirectoryQuotaConfigureRequest)(nil), "filer_pb.DirectoryQuotaConfigureRequest")
+	proto.RegisterType((*DirectoryQuotaConfigureResponse)(nil), "filer_pb.DirectoryQuotaConfigureResponse")
+	proto.RegisterType((*DirectoryQuotaListRequest)(nil), "filer_pb.DirectoryQuotaListRequest")
+	proto.RegisterType((*DirectoryQuotaListResponse)(nil), "filer_pb.DirectoryQuotaListResponse")
+	proto.RegisterType((*DirectoryUsageRequest)(nil), "filer_pb.DirectoryUsageRequest")
+	proto.RegisterType((*DirectoryUsageResponse)(nil), "filer_pb.DirectoryUsageResponse")
+	proto.RegisterType((*TrashEntry)(nil), "filer_pb.TrashEntry")
+	proto.RegisterType((*TrashListRequest)(nil), "filer_pb.TrashListRequest")
+	proto.RegisterType((*TrashListResponse)(nil), "filer_pb.TrashListResponse")
+	proto.RegisterType((*TrashRestoreRequest)(nil), "filer_pb.TrashRestoreRequest")
+	proto.RegisterType((*TrashRestoreResponse)(nil), "filer_pb.TrashRestoreResponse")
+	proto.RegisterType((*Snapshot)(nil), "filer_pb.Snapshot")
+	proto.RegisterType((*SnapshotCreateRequest)(nil), "filer_pb.SnapshotCreateRequest")
+	proto.RegisterType((*SnapshotCreateResponse)(nil), "filer_pb.SnapshotCreateResponse")
+	proto.RegisterType((*SnapshotDeleteRequest)(nil), "filer_pb.SnapshotDeleteRequest")
+	proto.RegisterType((*SnapshotDeleteResponse)(nil), "filer_pb.SnapshotDeleteResponse")
+	proto.RegisterType((*SnapshotListRequest)(nil), "filer_pb.SnapshotListRequest")
+	proto.RegisterType((*SnapshotListResponse)(nil), "filer_pb.SnapshotListResponse")
+	proto.RegisterType((*VersioningConfig)(nil), "filer_pb.VersioningConfig")
+	proto.RegisterType((*VersioningConfigureRequest)(nil), "filer_pb.VersioningConfigureRequest")
+	proto.RegisterType((*VersioningConfigureResponse)(nil), "filer_pb.VersioningConfigureResponse")
+	proto.RegisterType((*VersioningListRequest)(nil), "filer_pb.VersioningListRequest")
+	proto.RegisterType((*VersioningListResponse)(nil), "filer_pb.VersioningListResponse")
+	proto.RegisterType((*FileVersion)(nil), "filer_pb.FileVersion")
+	proto.RegisterType((*VersionListRequest)(nil), "filer_pb.VersionListRequest")
+	proto.RegisterType((*VersionListResponse)(nil), "filer_pb.VersionListResponse")
+	proto.RegisterType((*VersionRestoreRequest)(nil), "filer_pb.VersionRestoreRequest")
+	proto.RegisterType((*VersionRestoreResponse)(nil), "filer_pb.VersionRestoreResponse")
+	proto.RegisterType((*DefragmentRequest)(nil), "filer_pb.DefragmentRequest")
+	proto.RegisterType((*DefragmentResponse)(nil), "filer_pb.DefragmentResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for SeaweedFiler service
+
+type SeaweedFilerClient interface {
+	LookupDirectoryEntry(ctx context.Context, in *LookupDirectoryEntryRequest, opts ...grpc.CallOption) (*LookupDirectoryEntryResponse, error)
+	ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error)
+	CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error)
+	UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*UpdateEntryResponse, error)
+	AppendToEntry(ctx context.Context, in *AppendToEntryRequest, opts ...grpc.CallOption) (*AppendToEntryResponse, error)
+	DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*DeleteEntryResponse, error)
+	AtomicRenameEntry(ctx context.Context, in *AtomicRenameEntryRequest, opts ...grpc.CallOption) (*AtomicRenameEntryResponse, error)
+	LinkEntry(ctx context.Context, in *LinkEntryRequest, opts ...grpc.CallOption) (*LinkEntryResponse, error)
+	AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error)
+	LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error)
+	DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error)
+	SubscribeMetadata(ctx context.Context, in *SubscribeMetadataRequest, opts ...grpc.CallOption) (SeaweedFiler_SubscribeMetadataClient, error)
+	DirectoryQuotaConfigure(ctx context.Context, in *DirectoryQuotaConfigureRequest, opts ...grpc.CallOption) (*DirectoryQuotaConfigureResponse, error)
+	DirectoryQuotaList(ctx context.Context, in *DirectoryQuotaListRequest, opts ...grpc.CallOption) (*DirectoryQuotaListResponse, error)
+	DirectoryUsage(ctx context.Context, in *DirectoryUsageRequest, opts ...grpc.CallOption) (*DirectoryUsageResponse, error)
+	TrashList(ctx context.Context, in *TrashListRequest, opts ...grpc.CallOption) (*TrashListResponse, error)
+	TrashRestore(ctx context.Context, in *TrashRestoreRequest, opts ...grpc.CallOption) (*TrashRestoreResponse, error)
+	SnapshotCreate(ctx context.Context, in *SnapshotCreateRequest, opts ...grpc.CallOption) (*SnapshotCreateResponse, error)
+	SnapshotDelete(ctx context.Context, in *SnapshotDeleteRequest, opts ...grpc.CallOption) (*SnapshotDeleteResponse, error)
+	SnapshotList(ctx context.Context, in *SnapshotListRequest, opts ...grpc.CallOption) (*SnapshotListResponse, error)
+	VersioningConfigure(ctx context.Context, in *VersioningConfigureRequest, opts ...grpc.CallOption) (*VersioningConfigureResponse, error)
+	VersioningList(ctx context.Context, in *VersioningListRequest, opts ...grpc.CallOption) (*VersioningListResponse, error)
+	VersionList(ctx context.Context, in *VersionListRequest, opts ...grpc.CallOption) (*VersionListResponse, error)
+	VersionRestore(ctx context.Context, in *VersionRestoreRequest, opts ...grpc.CallOption) (*VersionRestoreResponse, error)
+	Defragment(ctx context.Context, in *DefragmentRequest, opts ...grpc.CallOption) (*DefragmentResponse, error)
+}
+
+type seaweedFilerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSeaweedFilerClient(cc *grpc.ClientConn) SeaweedFilerClient {
+	return &seaweedFilerClient{cc}
+}
+
+func (c *seaweedFilerClient) LookupDirectoryEntry(ctx context.Context, in *LookupDirectoryEntryRequest, opts ...grpc.CallOption) (*LookupDirectoryEntryResponse, error) {
+	out := new(LookupDirectoryEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/LookupDirectoryEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error) {
+	out := new(ListEntriesResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/ListEntries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error) {
+	out := new(CreateEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/CreateEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*UpdateEntryResponse, error) {
+	out := new(UpdateEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/UpdateEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) AppendToEntry(ctx context.Context, in *AppendToEntryRequest, opts ...grpc.CallOption) (*AppendToEntryResponse, error) {
+	out := new(AppendToEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/AppendToEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func (c *seaweedFilerClient) DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*DeleteEntryResponse, error) {
@@ -785,6 +1900,24 @@ func (c *seaweedFilerClient) DeleteEntry(ctx context.Context, in *DeleteEntryReq
 	return out, nil
 }
 
+func (c *seaweedFilerClient) AtomicRenameEntry(ctx context.Context, in *AtomicRenameEntryRequest, opts ...grpc.CallOption) (*AtomicRenameEntryResponse, error) {
+	out := new(AtomicRenameEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/AtomicRenameEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) LinkEntry(ctx context.Context, in *LinkEntryRequest, opts ...grpc.CallOption) (*LinkEntryResponse, error) {
+	out := new(LinkEntryResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/LinkEntry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *seaweedFilerClient) AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error) {
 	out := new(AssignVolumeResponse)
 	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/AssignVolume", in, out, c.cc, opts...)
@@ -803,9 +1936,158 @@ func (c *seaweedFilerClient) LookupVolume(ctx context.Context, in *LookupVolumeR
 	return out, nil
 }
 
-func (c *seaweedFilerClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
-	out := new(DeleteCollectionResponse)
-	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/DeleteCollection", in, out, c.cc, opts...)
+func (c *seaweedFilerClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	out := new(DeleteCollectionResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/DeleteCollection", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) SubscribeMetadata(ctx context.Context, in *SubscribeMetadataRequest, opts ...grpc.CallOption) (SeaweedFiler_SubscribeMetadataClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_SeaweedFiler_serviceDesc.Streams[0], c.cc, "/filer_pb.SeaweedFiler/SubscribeMetadata", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &seaweedFilerSubscribeMetadataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SeaweedFiler_SubscribeMetadataClient interface {
+	Recv() (*SubscribeMetadataResponse, error)
+	grpc.ClientStream
+}
+
+type seaweedFilerSubscribeMetadataClient struct {
+	grpc.ClientStream
+}
+
+func (x *seaweedFilerSubscribeMetadataClient) Recv() (*SubscribeMetadataResponse, error) {
+	m := new(SubscribeMetadataResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *seaweedFilerClient) DirectoryQuotaConfigure(ctx context.Context, in *DirectoryQuotaConfigureRequest, opts ...grpc.CallOption) (*DirectoryQuotaConfigureResponse, error) {
+	out := new(DirectoryQuotaConfigureResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/DirectoryQuotaConfigure", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) DirectoryQuotaList(ctx context.Context, in *DirectoryQuotaListRequest, opts ...grpc.CallOption) (*DirectoryQuotaListResponse, error) {
+	out := new(DirectoryQuotaListResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/DirectoryQuotaList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) DirectoryUsage(ctx context.Context, in *DirectoryUsageRequest, opts ...grpc.CallOption) (*DirectoryUsageResponse, error) {
+	out := new(DirectoryUsageResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/DirectoryUsage", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) TrashList(ctx context.Context, in *TrashListRequest, opts ...grpc.CallOption) (*TrashListResponse, error) {
+	out := new(TrashListResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/TrashList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) TrashRestore(ctx context.Context, in *TrashRestoreRequest, opts ...grpc.CallOption) (*TrashRestoreResponse, error) {
+	out := new(TrashRestoreResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/TrashRestore", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) SnapshotCreate(ctx context.Context, in *SnapshotCreateRequest, opts ...grpc.CallOption) (*SnapshotCreateResponse, error) {
+	out := new(SnapshotCreateResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/SnapshotCreate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) SnapshotDelete(ctx context.Context, in *SnapshotDeleteRequest, opts ...grpc.CallOption) (*SnapshotDeleteResponse, error) {
+	out := new(SnapshotDeleteResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/SnapshotDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) SnapshotList(ctx context.Context, in *SnapshotListRequest, opts ...grpc.CallOption) (*SnapshotListResponse, error) {
+	out := new(SnapshotListResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/SnapshotList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) VersioningConfigure(ctx context.Context, in *VersioningConfigureRequest, opts ...grpc.CallOption) (*VersioningConfigureResponse, error) {
+	out := new(VersioningConfigureResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/VersioningConfigure", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) VersioningList(ctx context.Context, in *VersioningListRequest, opts ...grpc.CallOption) (*VersioningListResponse, error) {
+	out := new(VersioningListResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/VersioningList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) VersionList(ctx context.Context, in *VersionListRequest, opts ...grpc.CallOption) (*VersionListResponse, error) {
+	out := new(VersionListResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/VersionList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) VersionRestore(ctx context.Context, in *VersionRestoreRequest, opts ...grpc.CallOption) (*VersionRestoreResponse, error) {
+	out := new(VersionRestoreResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/VersionRestore", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedFilerClient) Defragment(ctx context.Context, in *DefragmentRequest, opts ...grpc.CallOption) (*DefragmentResponse, error) {
+	out := new(DefragmentResponse)
+	err := grpc.Invoke(ctx, "/filer_pb.SeaweedFiler/Defragment", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -819,10 +2101,27 @@ type SeaweedFilerServer interface {
 	ListEntries(context.Context, *ListEntriesRequest) (*ListEntriesResponse, error)
 	CreateEntry(context.Context, *CreateEntryRequest) (*CreateEntryResponse, error)
 	UpdateEntry(context.Context, *UpdateEntryRequest) (*UpdateEntryResponse, error)
+	AppendToEntry(context.Context, *AppendToEntryRequest) (*AppendToEntryResponse, error)
 	DeleteEntry(context.Context, *DeleteEntryRequest) (*DeleteEntryResponse, error)
+	AtomicRenameEntry(context.Context, *AtomicRenameEntryRequest) (*AtomicRenameEntryResponse, error)
+	LinkEntry(context.Context, *LinkEntryRequest) (*LinkEntryResponse, error)
 	AssignVolume(context.Context, *AssignVolumeRequest) (*AssignVolumeResponse, error)
 	LookupVolume(context.Context, *LookupVolumeRequest) (*LookupVolumeResponse, error)
 	DeleteCollection(context.Context, *DeleteCollectionRequest) (*DeleteCollectionResponse, error)
+	SubscribeMetadata(*SubscribeMetadataRequest, SeaweedFiler_SubscribeMetadataServer) error
+	DirectoryQuotaConfigure(context.Context, *DirectoryQuotaConfigureRequest) (*DirectoryQuotaConfigureResponse, error)
+	DirectoryQuotaList(context.Context, *DirectoryQuotaListRequest) (*DirectoryQuotaListResponse, error)
+	DirectoryUsage(context.Context, *DirectoryUsageRequest) (*DirectoryUsageResponse, error)
+	TrashList(context.Context, *TrashListRequest) (*TrashListResponse, error)
+	TrashRestore(context.Context, *TrashRestoreRequest) (*TrashRestoreResponse, error)
+	SnapshotCreate(context.Context, *SnapshotCreateRequest) (*SnapshotCreateResponse, error)
+	SnapshotDelete(context.Context, *SnapshotDeleteRequest) (*SnapshotDeleteResponse, error)
+	SnapshotList(context.Context, *SnapshotListRequest) (*SnapshotListResponse, error)
+	VersioningConfigure(context.Context, *VersioningConfigureRequest) (*VersioningConfigureResponse, error)
+	VersioningList(context.Context, *VersioningListRequest) (*VersioningListResponse, error)
+	VersionList(context.Context, *VersionListRequest) (*VersionListResponse, error)
+	VersionRestore(context.Context, *VersionRestoreRequest) (*VersionRestoreResponse, error)
+	Defragment(context.Context, *DefragmentRequest) (*DefragmentResponse, error)
 }
 
 func RegisterSeaweedFilerServer(s *grpc.Server, srv SeaweedFilerServer) {
@@ -901,6 +2200,24 @@ func _SeaweedFiler_UpdateEntry_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SeaweedFiler_AppendToEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendToEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).AppendToEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/AppendToEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).AppendToEntry(ctx, req.(*AppendToEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SeaweedFiler_DeleteEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteEntryRequest)
 	if err := dec(in); err != nil {
@@ -919,6 +2236,42 @@ func _SeaweedFiler_DeleteEntry_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SeaweedFiler_AtomicRenameEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AtomicRenameEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).AtomicRenameEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/AtomicRenameEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).AtomicRenameEntry(ctx, req.(*AtomicRenameEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_LinkEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).LinkEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/LinkEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).LinkEntry(ctx, req.(*LinkEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SeaweedFiler_AssignVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AssignVolumeRequest)
 	if err := dec(in); err != nil {
@@ -973,6 +2326,261 @@ func _SeaweedFiler_DeleteCollection_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SeaweedFiler_SubscribeMetadata_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeMetadataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SeaweedFilerServer).SubscribeMetadata(m, &seaweedFilerSubscribeMetadataServer{stream})
+}
+
+type SeaweedFiler_SubscribeMetadataServer interface {
+	Send(*SubscribeMetadataResponse) error
+	grpc.ServerStream
+}
+
+type seaweedFilerSubscribeMetadataServer struct {
+	grpc.ServerStream
+}
+
+func (x *seaweedFilerSubscribeMetadataServer) Send(m *SubscribeMetadataResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SeaweedFiler_DirectoryQuotaConfigure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DirectoryQuotaConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).DirectoryQuotaConfigure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/DirectoryQuotaConfigure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).DirectoryQuotaConfigure(ctx, req.(*DirectoryQuotaConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_DirectoryQuotaList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DirectoryQuotaListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).DirectoryQuotaList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/DirectoryQuotaList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).DirectoryQuotaList(ctx, req.(*DirectoryQuotaListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_DirectoryUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DirectoryUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).DirectoryUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/DirectoryUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).DirectoryUsage(ctx, req.(*DirectoryUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_TrashList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrashListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).TrashList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/TrashList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).TrashList(ctx, req.(*TrashListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_TrashRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrashRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).TrashRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/TrashRestore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).TrashRestore(ctx, req.(*TrashRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_SnapshotCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).SnapshotCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/SnapshotCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).SnapshotCreate(ctx, req.(*SnapshotCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_SnapshotDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).SnapshotDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/SnapshotDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).SnapshotDelete(ctx, req.(*SnapshotDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_SnapshotList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).SnapshotList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/SnapshotList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).SnapshotList(ctx, req.(*SnapshotListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_VersioningConfigure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersioningConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).VersioningConfigure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/VersioningConfigure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).VersioningConfigure(ctx, req.(*VersioningConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_VersioningList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersioningListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).VersioningList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/VersioningList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).VersioningList(ctx, req.(*VersioningListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_VersionList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).VersionList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/VersionList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).VersionList(ctx, req.(*VersionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_VersionRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).VersionRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/VersionRestore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).VersionRestore(ctx, req.(*VersionRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SeaweedFiler_Defragment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DefragmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedFilerServer).Defragment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/filer_pb.SeaweedFiler/Defragment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedFilerServer).Defragment(ctx, req.(*DefragmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _SeaweedFiler_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "filer_pb.SeaweedFiler",
 	HandlerType: (*SeaweedFilerServer)(nil),
@@ -993,10 +2601,22 @@ var _SeaweedFiler_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateEntry",
 			Handler:    _SeaweedFiler_UpdateEntry_Handler,
 		},
+		{
+			MethodName: "AppendToEntry",
+			Handler:    _SeaweedFiler_AppendToEntry_Handler,
+		},
 		{
 			MethodName: "DeleteEntry",
 			Handler:    _SeaweedFiler_DeleteEntry_Handler,
 		},
+		{
+			MethodName: "AtomicRenameEntry",
+			Handler:    _SeaweedFiler_AtomicRenameEntry_Handler,
+		},
+		{
+			MethodName: "LinkEntry",
+			Handler:    _SeaweedFiler_LinkEntry_Handler,
+		},
 		{
 			MethodName: "AssignVolume",
 			Handler:    _SeaweedFiler_AssignVolume_Handler,
@@ -1009,84 +2629,230 @@ var _SeaweedFiler_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCollection",
 			Handler:    _SeaweedFiler_DeleteCollection_Handler,
 		},
+		{
+			MethodName: "DirectoryQuotaConfigure",
+			Handler:    _SeaweedFiler_DirectoryQuotaConfigure_Handler,
+		},
+		{
+			MethodName: "DirectoryQuotaList",
+			Handler:    _SeaweedFiler_DirectoryQuotaList_Handler,
+		},
+		{
+			MethodName: "DirectoryUsage",
+			Handler:    _SeaweedFiler_DirectoryUsage_Handler,
+		},
+		{
+			MethodName: "TrashList",
+			Handler:    _SeaweedFiler_TrashList_Handler,
+		},
+		{
+			MethodName: "TrashRestore",
+			Handler:    _SeaweedFiler_TrashRestore_Handler,
+		},
+		{
+			MethodName: "SnapshotCreate",
+			Handler:    _SeaweedFiler_SnapshotCreate_Handler,
+		},
+		{
+			MethodName: "SnapshotDelete",
+			Handler:    _SeaweedFiler_SnapshotDelete_Handler,
+		},
+		{
+			MethodName: "SnapshotList",
+			Handler:    _SeaweedFiler_SnapshotList_Handler,
+		},
+		{
+			MethodName: "VersioningConfigure",
+			Handler:    _SeaweedFiler_VersioningConfigure_Handler,
+		},
+		{
+			MethodName: "VersioningList",
+			Handler:    _SeaweedFiler_VersioningList_Handler,
+		},
+		{
+			MethodName: "VersionList",
+			Handler:    _SeaweedFiler_VersionList_Handler,
+		},
+		{
+			MethodName: "VersionRestore",
+			Handler:    _SeaweedFiler_VersionRestore_Handler,
+		},
+		{
+			MethodName: "Defragment",
+			Handler:    _SeaweedFiler_Defragment_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeMetadata",
+			Handler:       _SeaweedFiler_SubscribeMetadata_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "filer.proto",
 }
 
 func init() { proto.RegisterFile("filer.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1129 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x57, 0x4d, 0x6f, 0xdc, 0xc4,
-	0x1b, 0x8f, 0xf7, 0x2d, 0xeb, 0x67, 0x77, 0xf3, 0x6f, 0x66, 0xf3, 0xa7, 0xd6, 0x36, 0x29, 0x5b,
-	0xd3, 0xa2, 0x54, 0x44, 0x51, 0x15, 0x38, 0xb4, 0x54, 0x48, 0x54, 0x79, 0x91, 0x2a, 0xa5, 0xad,
-	0xe4, 0x34, 0x48, 0x88, 0x83, 0xe5, 0xd8, 0x4f, 0xc2, 0x28, 0x5e, 0x7b, 0xf1, 0x8c, 0x93, 0x96,
-	0xaf, 0xc0, 0x95, 0x2b, 0x07, 0x4e, 0xdc, 0x38, 0xf0, 0x01, 0xb8, 0xf0, 0xc5, 0xd0, 0xbc, 0xd8,
-	0x3b, 0x5e, 0x3b, 0x01, 0x84, 0xb8, 0xcd, 0xf3, 0x32, 0xcf, 0xdb, 0xfc, 0xe6, 0x37, 0x36, 0x0c,
-	0xce, 0x69, 0x8c, 0xd9, 0xee, 0x3c, 0x4b, 0x79, 0x4a, 0xfa, 0x52, 0xf0, 0xe7, 0x67, 0xee, 0x1b,
-	0xb8, 0x77, 0x9c, 0xa6, 0x97, 0xf9, 0xfc, 0x80, 0x66, 0x18, 0xf2, 0x34, 0x7b, 0x7f, 0x98, 0xf0,
-	0xec, 0xbd, 0x87, 0xdf, 0xe5, 0xc8, 0x38, 0xd9, 0x04, 0x3b, 0x2a, 0x0c, 0x8e, 0x35, 0xb5, 0xb6,
-	0x6d, 0x6f, 0xa1, 0x20, 0x04, 0x3a, 0x49, 0x30, 0x43, 0xa7, 0x25, 0x0d, 0x72, 0xed, 0x1e, 0xc2,
-	0x66, 0x73, 0x40, 0x36, 0x4f, 0x13, 0x86, 0xe4, 0x11, 0x74, 0x51, 0x28, 0x64, 0xb4, 0xc1, 0xde,
-	0xff, 0x76, 0x8b, 0x52, 0x76, 0x95, 0x9f, 0xb2, 0xba, 0xbf, 0x5b, 0x40, 0x8e, 0x29, 0xe3, 0x42,
-	0x49, 0x91, 0xfd, 0xbd, 0x7a, 0x3e, 0x80, 0xde, 0x3c, 0xc3, 0x73, 0xfa, 0x4e, 0x57, 0xa4, 0x25,
-	0xb2, 0x03, 0xeb, 0x8c, 0x07, 0x19, 0x3f, 0xca, 0xd2, 0xd9, 0x11, 0x8d, 0xf1, 0xb5, 0x28, 0xba,
-	0x2d, 0x5d, 0xea, 0x06, 0xb2, 0x0b, 0x84, 0x26, 0x61, 0x9c, 0x33, 0x7a, 0x85, 0x27, 0x85, 0xd5,
-	0xe9, 0x4c, 0xad, 0xed, 0xbe, 0xd7, 0x60, 0x21, 0x1b, 0xd0, 0x8d, 0xe9, 0x8c, 0x72, 0xa7, 0x3b,
-	0xb5, 0xb6, 0x47, 0x9e, 0x12, 0xdc, 0x2f, 0x61, 0x5c, 0xa9, 0x5f, 0xb7, 0xff, 0x18, 0x56, 0x51,
-	0xa9, 0x1c, 0x6b, 0xda, 0x6e, 0x1a, 0x40, 0x61, 0x77, 0x7f, 0x6a, 0x41, 0x57, 0xaa, 0xca, 0x39,
-	0x5b, 0x8b, 0x39, 0x93, 0x07, 0x30, 0xa4, 0xcc, 0x5f, 0x0c, 0xa3, 0x25, 0xeb, 0x1b, 0x50, 0x56,
-	0xce, 0x9d, 0x7c, 0x02, 0xbd, 0xf0, 0xdb, 0x3c, 0xb9, 0x64, 0x4e, 0x5b, 0xa6, 0x1a, 0x2f, 0x52,
-	0x89, 0x66, 0xf7, 0x85, 0xcd, 0xd3, 0x2e, 0xe4, 0x29, 0x40, 0xc0, 0x79, 0x46, 0xcf, 0x72, 0x8e,
-	0x4c, 0x76, 0x3b, 0xd8, 0x73, 0x8c, 0x0d, 0x39, 0xc3, 0x17, 0xa5, 0xdd, 0x33, 0x7c, 0xc9, 0x33,
-	0xe8, 0xe3, 0x3b, 0x8e, 0x49, 0x84, 0x91, 0xd3, 0x95, 0x89, 0xb6, 0x96, 0x7a, 0xda, 0x3d, 0xd4,
-	0x76, 0xd5, 0x61, 0xe9, 0x3e, 0x79, 0x0e, 0xa3, 0x8a, 0x89, 0xdc, 0x81, 0xf6, 0x25, 0x16, 0x27,
-	0x2b, 0x96, 0x62, 0xba, 0x57, 0x41, 0x9c, 0x2b, 0x90, 0x0d, 0x3d, 0x25, 0x7c, 0xde, 0x7a, 0x6a,
-	0xb9, 0x3f, 0x5a, 0xb0, 0x7e, 0x78, 0x85, 0x09, 0x7f, 0x9d, 0x72, 0x7a, 0x4e, 0xc3, 0x80, 0xd3,
-	0x34, 0x21, 0x3b, 0x60, 0xa7, 0x71, 0xe4, 0xdf, 0x8a, 0xb1, 0x7e, 0x1a, 0xeb, 0x7c, 0x3b, 0x60,
-	0x27, 0x78, 0xad, 0xbd, 0x5b, 0x37, 0x78, 0x27, 0x78, 0xad, 0xbc, 0x3f, 0x82, 0x51, 0x84, 0x31,
-	0x72, 0xf4, 0xcb, 0xb9, 0x8a, 0xa1, 0x0f, 0x95, 0x52, 0xce, 0x93, 0xb9, 0x3f, 0x5b, 0x60, 0x97,
-	0xe3, 0x25, 0x77, 0x61, 0x55, 0x84, 0xf3, 0x69, 0xa4, 0x9b, 0xea, 0x09, 0xf1, 0x65, 0x24, 0xb0,
-	0x9a, 0x9e, 0x9f, 0x33, 0xe4, 0x32, 0x6d, 0xdb, 0xd3, 0x92, 0x38, 0x6b, 0x46, 0xbf, 0x57, 0xf0,
-	0xec, 0x78, 0x72, 0x2d, 0x66, 0x30, 0xe3, 0x74, 0x86, 0xf2, 0x58, 0xda, 0x9e, 0x12, 0xc8, 0x18,
-	0xba, 0xe8, 0xf3, 0xe0, 0x42, 0xe2, 0xce, 0xf6, 0x3a, 0xf8, 0x36, 0xb8, 0x20, 0x0f, 0x61, 0x8d,
-	0xa5, 0x79, 0x16, 0xa2, 0x5f, 0xa4, 0xed, 0x49, 0xeb, 0x50, 0x69, 0x8f, 0x64, 0x72, 0xf7, 0x87,
-	0x16, 0xac, 0x55, 0x4f, 0x94, 0xdc, 0x03, 0x5b, 0xee, 0x90, 0xc9, 0x2d, 0x99, 0x5c, 0xb2, 0xc4,
-	0x49, 0xa5, 0x80, 0x96, 0x59, 0x40, 0xb1, 0x65, 0x96, 0x46, 0xaa, 0xde, 0x91, 0xda, 0xf2, 0x2a,
-	0x8d, 0x50, 0x9c, 0x64, 0x4e, 0x23, 0x59, 0xf1, 0xc8, 0x13, 0x4b, 0xa1, 0xb9, 0xa0, 0x91, 0xbe,
-	0x25, 0x62, 0x29, 0x66, 0x10, 0x66, 0x32, 0x6e, 0x4f, 0xcd, 0x40, 0x49, 0x62, 0x06, 0x33, 0xa1,
-	0x5d, 0x55, 0x8d, 0x89, 0x35, 0x99, 0xc2, 0x20, 0xc3, 0x79, 0xac, 0x8f, 0xd9, 0xe9, 0x4b, 0x93,
-	0xa9, 0x22, 0xf7, 0x01, 0xc2, 0x34, 0x8e, 0x31, 0x94, 0x0e, 0xb6, 0x74, 0x30, 0x34, 0xe2, 0x28,
-	0x38, 0x8f, 0x7d, 0x86, 0xa1, 0x03, 0x53, 0x6b, 0xbb, 0xeb, 0xf5, 0x38, 0x8f, 0x4f, 0x30, 0x74,
-	0xbf, 0x06, 0xb2, 0x9f, 0x61, 0xc0, 0xf1, 0x1f, 0x50, 0x5f, 0x49, 0x63, 0xad, 0x5b, 0x69, 0xec,
-	0xff, 0x30, 0xae, 0x84, 0x56, 0x2c, 0x20, 0x32, 0x9e, 0xce, 0xa3, 0xff, 0x2a, 0x63, 0x25, 0xb4,
-	0xce, 0xf8, 0x9b, 0x05, 0xe4, 0x40, 0xc2, 0xf4, 0xdf, 0xf1, 0x7b, 0x8d, 0x77, 0xda, 0x75, 0xde,
-	0x79, 0x08, 0x6b, 0xc2, 0x45, 0xdd, 0x94, 0x28, 0xe0, 0x81, 0x26, 0xcf, 0x21, 0x65, 0xaa, 0x84,
-	0x83, 0x80, 0x07, 0x3a, 0x50, 0x86, 0x61, 0x9e, 0x09, 0x3e, 0x95, 0xb8, 0x90, 0x81, 0xbc, 0x42,
-	0x25, 0x7a, 0xa9, 0xd4, 0xac, 0x7b, 0xf9, 0xc5, 0x82, 0xf1, 0x0b, 0xc6, 0xe8, 0x45, 0xf2, 0x55,
-	0x1a, 0xe7, 0x33, 0x2c, 0x9a, 0xd9, 0x80, 0x6e, 0x98, 0xe6, 0x09, 0x97, 0x8d, 0x74, 0x3d, 0x25,
-	0x2c, 0xc1, 0xa2, 0x55, 0x83, 0xc5, 0x12, 0xb0, 0xda, 0x75, 0x60, 0x19, 0xc0, 0xe9, 0x98, 0xc0,
-	0x21, 0x1f, 0xc2, 0x40, 0xb4, 0xe7, 0x87, 0x98, 0x70, 0xcc, 0xf4, 0x3d, 0x04, 0xa1, 0xda, 0x97,
-	0x1a, 0xf7, 0x0a, 0x36, 0xaa, 0x85, 0xea, 0x57, 0xe0, 0x46, 0x56, 0x10, 0xb7, 0x26, 0x8b, 0x75,
-	0x95, 0x62, 0x49, 0xb6, 0x00, 0xe6, 0xf9, 0x59, 0x4c, 0x43, 0x5f, 0x18, 0x54, 0x75, 0xb6, 0xd2,
-	0x9c, 0x66, 0xf1, 0xa2, 0xe7, 0x8e, 0xd1, 0xb3, 0xfb, 0x19, 0x8c, 0xd5, 0x23, 0x5c, 0x1d, 0xd0,
-	0x16, 0xc0, 0x95, 0x54, 0xf8, 0x34, 0x52, 0xef, 0x8f, 0xed, 0xd9, 0x4a, 0xf3, 0x32, 0x62, 0xee,
-	0x17, 0x60, 0x1f, 0xa7, 0xaa, 0x67, 0x46, 0x9e, 0x80, 0x1d, 0x17, 0x82, 0x7e, 0xaa, 0xc8, 0x02,
-	0x72, 0x85, 0x9f, 0xb7, 0x70, 0x72, 0x9f, 0x43, 0xbf, 0x50, 0x17, 0x7d, 0x58, 0x37, 0xf5, 0xd1,
-	0x5a, 0xea, 0xc3, 0xfd, 0xc3, 0x82, 0x8d, 0x6a, 0xc9, 0x7a, 0x54, 0xa7, 0x30, 0x2a, 0x53, 0xf8,
-	0xb3, 0x60, 0xae, 0x6b, 0x79, 0x62, 0xd6, 0x52, 0xdf, 0x56, 0x16, 0xc8, 0x5e, 0x05, 0x73, 0x85,
-	0x9e, 0x61, 0x6c, 0xa8, 0x26, 0x6f, 0x61, 0xbd, 0xe6, 0xd2, 0xf0, 0xfa, 0x3c, 0x36, 0x5f, 0x9f,
-	0xca, 0x0b, 0x5a, 0xee, 0x36, 0x9f, 0xa4, 0x67, 0x70, 0x57, 0x01, 0x76, 0xbf, 0xc4, 0x57, 0x31,
-	0xfb, 0x2a, 0x0c, 0xad, 0x65, 0x18, 0xba, 0x13, 0x70, 0xea, 0x5b, 0x55, 0x33, 0x7b, 0xbf, 0x76,
-	0x61, 0x78, 0x82, 0xc1, 0x35, 0x62, 0x24, 0x08, 0x3c, 0x23, 0x17, 0xc5, 0xb0, 0xaa, 0x1f, 0x59,
-	0xe4, 0xd1, 0xf2, 0x54, 0x1a, 0xbf, 0xea, 0x26, 0x1f, 0xff, 0x95, 0x9b, 0xbe, 0x68, 0x2b, 0xe4,
-	0x18, 0x06, 0xc6, 0x57, 0x0c, 0xd9, 0x34, 0x36, 0xd6, 0x3e, 0xce, 0x26, 0x5b, 0x37, 0x58, 0xcd,
-	0x68, 0x06, 0x1b, 0x9a, 0xd1, 0xea, 0xfc, 0x6b, 0x46, 0x6b, 0xa2, 0x50, 0x19, 0xcd, 0x60, 0x3a,
-	0x33, 0x5a, 0x9d, 0x5b, 0xcd, 0x68, 0x4d, 0xf4, 0x28, 0xa3, 0x19, 0x5c, 0x63, 0x46, 0xab, 0xd3,
-	0xa6, 0x19, 0xad, 0x89, 0xa0, 0x56, 0xc8, 0x1b, 0x18, 0x9a, 0x17, 0x9f, 0x18, 0x1b, 0x1a, 0x98,
-	0x6b, 0x72, 0xff, 0x26, 0xb3, 0x19, 0xd0, 0xc4, 0xb9, 0x19, 0xb0, 0xe1, 0xa6, 0x9b, 0x01, 0x9b,
-	0xae, 0x87, 0xbb, 0x42, 0xbe, 0x81, 0x3b, 0xcb, 0x78, 0x23, 0x0f, 0x96, 0xdb, 0xaa, 0xc1, 0x78,
-	0xe2, 0xde, 0xe6, 0x52, 0x04, 0x3f, 0xeb, 0xc9, 0xdf, 0x8c, 0x4f, 0xff, 0x0c, 0x00, 0x00, 0xff,
-	0xff, 0x04, 0x12, 0x06, 0xcd, 0x75, 0x0c, 0x00, 0x00,
+	// 2529 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x1a, 0x5d, 0x6f, 0xdb, 0xc8,
+	0xb1, 0xd4, 0x87, 0x2d, 0x8e, 0x24, 0xdb, 0x5a, 0x7f, 0x44, 0xa6, 0x63, 0x5b, 0xa1, 0x93, 0xd4,
+	0xb9, 0x06, 0x6e, 0x9a, 0xbb, 0x02, 0x97, 0x1e, 0x0a, 0x34, 0x75, 0x12, 0x9c, 0x0f, 0x89, 0x2f,
+	0xa5, 0x9d, 0x03, 0x8a, 0x02, 0x65, 0x69, 0x71, 0x2d, 0x2f, 0x42, 0x91, 0x3a, 0xee, 0xca, 0x76,
+	0xee, 0x1e, 0x8b, 0xfe, 0x81, 0xa2, 0x4f, 0x7d, 0xe9, 0x63, 0x81, 0x3e, 0x16, 0x7d, 0xea, 0x6b,
+	0x9f, 0xfb, 0x53, 0x8a, 0xfe, 0x82, 0x02, 0xc5, 0x7e, 0x90, 0x5c, 0x52, 0xa4, 0xe3, 0xe4, 0xae,
+	0xc5, 0xbd, 0x71, 0x67, 0x66, 0x67, 0x67, 0x67, 0x66, 0xe7, 0x4b, 0x82, 0xf6, 0x29, 0x09, 0x70,
+	0xbc, 0x37, 0x89, 0x23, 0x16, 0xa1, 0x96, 0x58, 0xb8, 0x93, 0x13, 0xfb, 0x73, 0xd8, 0x78, 0x1e,
+	0x45, 0xaf, 0xa7, 0x93, 0x27, 0x24, 0xc6, 0x43, 0x16, 0xc5, 0x6f, 0x9e, 0x86, 0x2c, 0x7e, 0xe3,
+	0xe0, 0x2f, 0xa7, 0x98, 0x32, 0x74, 0x13, 0x4c, 0x3f, 0x41, 0xf4, 0x8d, 0x81, 0xb1, 0x6b, 0x3a,
+	0x19, 0x00, 0x21, 0x68, 0x84, 0xde, 0x18, 0xf7, 0x6b, 0x02, 0x21, 0xbe, 0xed, 0xa7, 0x70, 0xb3,
+	0x9c, 0x21, 0x9d, 0x44, 0x21, 0xc5, 0xe8, 0x0e, 0x34, 0x71, 0xc8, 0x14, 0xb7, 0xf6, 0xc3, 0xc5,
+	0xbd, 0x44, 0x94, 0x3d, 0x49, 0x27, 0xb1, 0xf6, 0xbf, 0x6b, 0x80, 0x9e, 0x13, 0xca, 0x38, 0x90,
+	0x60, 0x7a, 0x3d, 0x79, 0xd6, 0x60, 0x6e, 0x12, 0xe3, 0x53, 0x72, 0xa9, 0x24, 0x52, 0x2b, 0x74,
+	0x1f, 0x7a, 0x94, 0x79, 0x31, 0x7b, 0x16, 0x47, 0xe3, 0x67, 0x24, 0xc0, 0x87, 0x5c, 0xe8, 0xba,
+	0x20, 0x99, 0x45, 0xa0, 0x3d, 0x40, 0x24, 0x1c, 0x06, 0x53, 0x4a, 0xce, 0xf1, 0x51, 0x82, 0xed,
+	0x37, 0x06, 0xc6, 0x6e, 0xcb, 0x29, 0xc1, 0xa0, 0x15, 0x68, 0x06, 0x64, 0x4c, 0x58, 0xbf, 0x39,
+	0x30, 0x76, 0xbb, 0x8e, 0x5c, 0xa0, 0x5b, 0xd0, 0xe1, 0xfa, 0x70, 0x27, 0x1e, 0x63, 0x38, 0x0e,
+	0xfb, 0x73, 0xe2, 0xb8, 0x36, 0x87, 0xbd, 0x94, 0x20, 0xb4, 0x01, 0xe6, 0x98, 0x84, 0xee, 0x98,
+	0x91, 0x31, 0xee, 0xcf, 0x0f, 0x8c, 0xdd, 0xba, 0xd3, 0x1a, 0x93, 0xf0, 0x05, 0x5f, 0x0b, 0xa4,
+	0x77, 0xa9, 0x90, 0x2d, 0x85, 0xf4, 0x2e, 0x25, 0x72, 0x1d, 0x38, 0xa1, 0x4b, 0xc9, 0x57, 0xb8,
+	0x6f, 0x0e, 0x8c, 0xdd, 0x86, 0x33, 0x3f, 0x26, 0xe1, 0x11, 0xf9, 0x4a, 0xa2, 0xbc, 0x4b, 0x89,
+	0x02, 0x85, 0xf2, 0x2e, 0x05, 0xea, 0x26, 0x98, 0x31, 0x1e, 0x4e, 0x63, 0x2e, 0x7e, 0xbf, 0x2d,
+	0xee, 0x93, 0x01, 0xec, 0x29, 0x2c, 0xe7, 0x14, 0xae, 0xec, 0x75, 0x0f, 0xe6, 0xb1, 0x04, 0xf5,
+	0x8d, 0x41, 0xbd, 0xcc, 0x62, 0x09, 0x1e, 0x7d, 0x08, 0x6b, 0x81, 0x47, 0x99, 0x4b, 0x87, 0x5e,
+	0x18, 0x62, 0xdf, 0xe5, 0x74, 0xae, 0xe6, 0x20, 0xcb, 0x1c, 0x7b, 0x24, 0x91, 0x89, 0xb6, 0xed,
+	0xdf, 0xd5, 0xa1, 0x29, 0xf8, 0xa4, 0xde, 0x64, 0x64, 0xde, 0xc4, 0xb5, 0x48, 0xa8, 0x9b, 0x99,
+	0xbc, 0x26, 0xa4, 0x6e, 0x13, 0x9a, 0x7a, 0x17, 0xfa, 0x01, 0xcc, 0x0d, 0xcf, 0xa6, 0xe1, 0x6b,
+	0xda, 0xaf, 0x0b, 0xf9, 0x96, 0x33, 0xf9, 0xf8, 0x21, 0xfb, 0x1c, 0xe7, 0x28, 0x12, 0xf4, 0x31,
+	0x80, 0xc7, 0x58, 0x4c, 0x4e, 0xa6, 0x0c, 0x53, 0x61, 0xd3, 0xf6, 0xc3, 0xbe, 0xb6, 0x61, 0x4a,
+	0xf1, 0xe3, 0x14, 0xef, 0x68, 0xb4, 0xe8, 0x11, 0xb4, 0xf0, 0x25, 0xc3, 0xa1, 0x8f, 0xfd, 0x7e,
+	0x53, 0x1c, 0xb4, 0x59, 0x50, 0xc4, 0xde, 0x53, 0x85, 0x17, 0x2b, 0x27, 0x25, 0x47, 0x03, 0xe8,
+	0x9c, 0x79, 0xb1, 0xef, 0x06, 0x24, 0x7c, 0xed, 0x12, 0x5f, 0xb9, 0x02, 0x70, 0xd8, 0x73, 0x12,
+	0xbe, 0x3e, 0xf0, 0xd1, 0x07, 0xd0, 0xcb, 0x28, 0x86, 0xd1, 0x34, 0x64, 0x38, 0x16, 0x1e, 0xd1,
+	0x74, 0x16, 0x13, 0xb2, 0x7d, 0x09, 0x46, 0x7d, 0x98, 0x1f, 0x46, 0x21, 0xc3, 0x21, 0x13, 0x6e,
+	0xd1, 0x71, 0x92, 0xa5, 0xf5, 0x09, 0x74, 0x73, 0x22, 0xa0, 0x25, 0xa8, 0xbf, 0xc6, 0xc9, 0x3b,
+	0xe1, 0x9f, 0xdc, 0x57, 0xcf, 0xbd, 0x60, 0x2a, 0x2d, 0xd2, 0x71, 0xe4, 0xe2, 0x27, 0xb5, 0x8f,
+	0x0d, 0xfb, 0x0f, 0x06, 0xf4, 0x9e, 0x9e, 0xe3, 0x90, 0x1d, 0x46, 0x8c, 0x9c, 0x92, 0xa1, 0xc7,
+	0x48, 0x14, 0xa2, 0xfb, 0x60, 0x46, 0x81, 0xef, 0x5e, 0xf9, 0x62, 0x5b, 0x51, 0xa0, 0xce, 0xbb,
+	0x0f, 0x66, 0x88, 0x2f, 0x14, 0x75, 0xad, 0x82, 0x3a, 0xc4, 0x17, 0x92, 0x7a, 0x07, 0xba, 0x3e,
+	0x0e, 0x30, 0xc3, 0x6e, 0x6a, 0x3f, 0x6e, 0xdc, 0x8e, 0x04, 0x0a, 0xbb, 0x51, 0xfb, 0x9f, 0x06,
+	0x98, 0xa9, 0x19, 0xd1, 0x0d, 0x98, 0x17, 0x4e, 0x45, 0x7c, 0x75, 0xa9, 0x39, 0xbe, 0x3c, 0xf0,
+	0xf9, 0xcb, 0x8f, 0x4e, 0x4f, 0x29, 0x66, 0xe2, 0xd8, 0xba, 0xa3, 0x56, 0xdc, 0xa7, 0xc4, 0x4b,
+	0xa8, 0x8b, 0x97, 0x20, 0xbe, 0xb9, 0x0e, 0xe4, 0xab, 0x6a, 0x08, 0x52, 0xb9, 0x40, 0xcb, 0xd0,
+	0xc4, 0x2e, 0xf3, 0x46, 0xe2, 0x15, 0x9b, 0x4e, 0x03, 0x1f, 0x7b, 0x23, 0x74, 0x1b, 0x16, 0x68,
+	0x34, 0x8d, 0x87, 0xd8, 0x4d, 0x8e, 0x95, 0xb6, 0xeb, 0x48, 0xe8, 0x33, 0x79, 0xf8, 0x07, 0xd0,
+	0x23, 0x54, 0x5e, 0xc2, 0x1d, 0x7b, 0x21, 0x39, 0xc5, 0x94, 0x09, 0xeb, 0xb5, 0x9c, 0x45, 0x42,
+	0x85, 0xe4, 0x2f, 0x14, 0xd8, 0xfe, 0x19, 0xf4, 0xd2, 0xeb, 0x24, 0x40, 0xcd, 0x85, 0x8d, 0xb7,
+	0xba, 0xb0, 0xfd, 0xd7, 0x1a, 0x2c, 0xe4, 0xfd, 0x94, 0xc7, 0x0a, 0x21, 0x9f, 0xb8, 0xaa, 0x21,
+	0xae, 0x2a, 0x22, 0xfc, 0x51, 0xee, 0xba, 0x35, 0xfd, 0xba, 0xc9, 0x96, 0x71, 0xe4, 0x4b, 0xed,
+	0x74, 0xe5, 0x96, 0x17, 0x91, 0x8f, 0xb9, 0xdf, 0x4c, 0x89, 0x2f, 0xf4, 0xd3, 0x75, 0xf8, 0x27,
+	0x87, 0x8c, 0x88, 0xaf, 0x22, 0x1c, 0xff, 0xe4, 0x1a, 0x1f, 0xc6, 0x82, 0xef, 0x9c, 0xd4, 0xb8,
+	0x5c, 0x71, 0x8d, 0x8f, 0x93, 0x78, 0x66, 0x3a, 0xe2, 0x1b, 0x0d, 0xa0, 0x1d, 0xe3, 0x49, 0xa0,
+	0x9c, 0x4a, 0xb8, 0xad, 0xe9, 0xe8, 0x20, 0xb4, 0x05, 0x30, 0x8c, 0x82, 0x00, 0x0f, 0x05, 0x81,
+	0x29, 0x1f, 0x48, 0x06, 0xe1, 0x86, 0x67, 0x2c, 0x70, 0x29, 0x1e, 0x8a, 0xa0, 0xd6, 0x74, 0xe6,
+	0x18, 0x0b, 0x8e, 0xf0, 0x10, 0xdd, 0x81, 0x05, 0xfa, 0x66, 0x2c, 0xde, 0x0d, 0xf3, 0xe2, 0x11,
+	0x66, 0x22, 0xb0, 0x99, 0x4e, 0x57, 0x41, 0x8f, 0x05, 0xd0, 0xfe, 0x25, 0xa0, 0xfd, 0x18, 0x7b,
+	0x0c, 0xbf, 0x43, 0x76, 0x4b, 0x33, 0x55, 0xed, 0xca, 0x4c, 0xb5, 0x0a, 0xcb, 0x39, 0xd6, 0x32,
+	0x6e, 0xf2, 0x13, 0x5f, 0x4d, 0xfc, 0xff, 0xd5, 0x89, 0x39, 0xd6, 0xea, 0xc4, 0xbf, 0x1b, 0xb0,
+	0xf2, 0x78, 0x32, 0xc1, 0xa1, 0x7f, 0x1c, 0xbd, 0xc3, 0xa1, 0x9b, 0x00, 0x82, 0xad, 0x1e, 0xa9,
+	0x4d, 0x01, 0x11, 0xd9, 0xf0, 0xff, 0x13, 0x5e, 0xed, 0x1f, 0xc2, 0x6a, 0x41, 0x76, 0x95, 0x7f,
+	0xb2, 0x97, 0x6d, 0xe8, 0x2f, 0xdb, 0xfe, 0x97, 0x01, 0xe8, 0x89, 0x88, 0x14, 0xdf, 0xac, 0x60,
+	0x99, 0x49, 0x31, 0xf5, 0xd9, 0x14, 0x73, 0x1b, 0x16, 0x38, 0x89, 0x0c, 0x56, 0xbe, 0xc7, 0x3c,
+	0x55, 0x0d, 0x74, 0x08, 0x95, 0x22, 0x3c, 0xf1, 0x98, 0xa7, 0x18, 0x65, 0x19, 0xb6, 0x99, 0x30,
+	0x72, 0x12, 0x10, 0x7a, 0x04, 0xeb, 0x19, 0xa3, 0x7c, 0xc0, 0xa0, 0xe2, 0x1d, 0xb5, 0x9c, 0xb5,
+	0x84, 0x67, 0x2e, 0x44, 0x50, 0x6e, 0xf4, 0xdc, 0x75, 0x95, 0xd1, 0xff, 0x68, 0x40, 0xff, 0x31,
+	0x8b, 0xc6, 0x64, 0xe8, 0x60, 0x7e, 0x9d, 0x9c, 0x32, 0x76, 0xa0, 0xcb, 0xa3, 0x77, 0x51, 0x21,
+	0x9d, 0x28, 0xf0, 0xb3, 0xcb, 0xad, 0x03, 0x0f, 0xe0, 0xba, 0xf5, 0xe7, 0xa3, 0xc0, 0x17, 0xb6,
+	0xdf, 0x81, 0x2e, 0x8f, 0xe7, 0x79, 0xdd, 0x98, 0x4e, 0x27, 0xc4, 0x17, 0xb9, 0xfd, 0x9c, 0x48,
+	0xec, 0x6f, 0xc8, 0xfd, 0x21, 0xbe, 0x10, 0xb9, 0x7d, 0x03, 0xd6, 0x4b, 0x64, 0x53, 0x92, 0xff,
+	0xde, 0x80, 0x25, 0x9e, 0xd7, 0xbe, 0x53, 0x12, 0x2f, 0x43, 0x4f, 0x93, 0x49, 0x49, 0xfa, 0x67,
+	0x03, 0x96, 0x1f, 0x53, 0x4a, 0x46, 0xe1, 0x17, 0x51, 0x30, 0x1d, 0xe3, 0x44, 0xd8, 0x15, 0x68,
+	0x8a, 0x5c, 0x2d, 0x84, 0x6c, 0x3a, 0x72, 0x51, 0x08, 0x65, 0xb5, 0x99, 0x50, 0x56, 0x08, 0x86,
+	0xf5, 0xd9, 0x60, 0xa8, 0x05, 0xbb, 0x46, 0x2e, 0xd8, 0x6d, 0x43, 0x9b, 0x7b, 0x9f, 0x3b, 0xc4,
+	0xa2, 0x40, 0x90, 0x99, 0x0a, 0x38, 0x68, 0x5f, 0x40, 0xec, 0x73, 0x58, 0xc9, 0x0b, 0xaa, 0x1e,
+	0x51, 0x65, 0xde, 0xe4, 0x91, 0x3e, 0x0e, 0x94, 0x94, 0xfc, 0x93, 0x87, 0x83, 0xc9, 0xf4, 0x24,
+	0x20, 0x43, 0x97, 0x23, 0xa4, 0x74, 0xa6, 0x84, 0xbc, 0x8a, 0x83, 0xec, 0xce, 0x0d, 0xed, 0xce,
+	0xf6, 0x47, 0xb0, 0x2c, 0x8b, 0xfe, 0xbc, 0x82, 0x36, 0x01, 0xce, 0x05, 0xc0, 0x25, 0xbe, 0xcc,
+	0x6d, 0xa6, 0x63, 0x4a, 0xc8, 0x81, 0x4f, 0xed, 0x9f, 0x82, 0xf9, 0x3c, 0x92, 0x77, 0xa6, 0xe8,
+	0x01, 0x98, 0x41, 0xb2, 0x50, 0x69, 0x10, 0x65, 0x91, 0x23, 0xa1, 0x73, 0x32, 0x22, 0xfb, 0x13,
+	0x68, 0x25, 0xe0, 0xe4, 0x1e, 0x46, 0xd5, 0x3d, 0x6a, 0x85, 0x7b, 0xd8, 0xff, 0x30, 0x60, 0x25,
+	0x2f, 0xb2, 0x52, 0xd5, 0x2b, 0xe8, 0xa6, 0x47, 0xb8, 0x63, 0x6f, 0xa2, 0x64, 0x79, 0xa0, 0xcb,
+	0x32, 0xbb, 0x2d, 0x15, 0x90, 0xbe, 0xf0, 0x26, 0xd2, 0x7b, 0x3a, 0x81, 0x06, 0xb2, 0x8e, 0xa1,
+	0x37, 0x43, 0x52, 0x52, 0x9f, 0xdd, 0xd3, 0xeb, 0xb3, 0x5c, 0xb0, 0x4d, 0x77, 0xeb, 0x45, 0xdb,
+	0x23, 0xb8, 0xa1, 0x82, 0x45, 0xea, 0x5f, 0x89, 0xee, 0xf3, 0x6e, 0x68, 0x14, 0xdd, 0xd0, 0xb6,
+	0xa0, 0x3f, 0xbb, 0x55, 0x39, 0xfc, 0x05, 0xf4, 0x8f, 0xa6, 0x27, 0x74, 0x18, 0x93, 0x13, 0xfc,
+	0x02, 0x33, 0x8f, 0x7b, 0x58, 0xc2, 0x77, 0x1b, 0xda, 0xc3, 0x80, 0xe0, 0x90, 0xb9, 0x5a, 0xb1,
+	0x0e, 0x12, 0x24, 0x9e, 0xe0, 0x36, 0xb4, 0x27, 0x1e, 0x3b, 0x73, 0x73, 0x9d, 0x18, 0x70, 0xd0,
+	0x4b, 0x01, 0xe1, 0xcf, 0x8f, 0x92, 0x70, 0x88, 0xdd, 0x50, 0x96, 0x7c, 0x75, 0x67, 0x5e, 0xac,
+	0x0f, 0x29, 0x8f, 0x66, 0xeb, 0x25, 0x27, 0x2b, 0xd3, 0x5c, 0x1d, 0xdb, 0x3f, 0x03, 0x84, 0xcf,
+	0x85, 0x5c, 0x5a, 0x01, 0xab, 0xf4, 0xb8, 0xa1, 0x65, 0xd2, 0x62, 0x8d, 0xeb, 0xf4, 0x70, 0x11,
+	0xc4, 0x8b, 0x41, 0x46, 0x33, 0xf9, 0x1a, 0x8c, 0x1e, 0x52, 0xfb, 0x2f, 0x06, 0x2c, 0xa4, 0x41,
+	0xe4, 0x17, 0xd3, 0x88, 0x79, 0x6f, 0x91, 0x48, 0xb5, 0x70, 0x27, 0x6f, 0x78, 0x32, 0xac, 0xc9,
+	0xb2, 0x6c, 0xec, 0x5d, 0xfe, 0x9c, 0xaf, 0xd1, 0x5d, 0x58, 0xe4, 0x48, 0x99, 0x7a, 0xe5, 0x93,
+	0x92, 0x45, 0x6a, 0x77, 0xec, 0x5d, 0x0a, 0xf7, 0x10, 0x05, 0x3f, 0x7f, 0x70, 0x92, 0x41, 0x43,
+	0x60, 0xe5, 0x82, 0x2b, 0x59, 0xdf, 0xd9, 0x14, 0x38, 0xc0, 0xe9, 0x36, 0xfb, 0xb7, 0x06, 0x6c,
+	0xe5, 0x85, 0xdd, 0x8f, 0xc2, 0x53, 0x32, 0x9a, 0xc6, 0xf8, 0x7a, 0xa9, 0xf2, 0xdb, 0x10, 0xde,
+	0xbe, 0x05, 0xdb, 0x95, 0x42, 0x28, 0x5f, 0xdb, 0x80, 0xf5, 0x3c, 0x09, 0x6f, 0x42, 0x95, 0x88,
+	0xf6, 0x21, 0x58, 0x65, 0x48, 0xe5, 0x0f, 0x0f, 0x60, 0xee, 0x4b, 0x0e, 0x4c, 0xe2, 0x85, 0x56,
+	0x69, 0xe4, 0x77, 0x39, 0x8a, 0xce, 0xfe, 0x31, 0xac, 0xa6, 0x98, 0x57, 0xd4, 0x1b, 0x5d, 0x4f,
+	0x17, 0xf6, 0x39, 0xac, 0x15, 0xb7, 0x29, 0x11, 0x52, 0xeb, 0x18, 0xba, 0x75, 0x36, 0x01, 0x44,
+	0xb8, 0x95, 0x9a, 0x91, 0xca, 0x13, 0xe5, 0xb6, 0x34, 0xe9, 0xf7, 0x61, 0x31, 0xe5, 0x9d, 0xd3,
+	0xde, 0x42, 0x0a, 0x96, 0xea, 0xfb, 0x9b, 0x01, 0x70, 0x1c, 0x7b, 0xf4, 0x4c, 0x86, 0x8b, 0x4d,
+	0x00, 0xc6, 0x57, 0x7c, 0xa6, 0x70, 0x96, 0x48, 0x29, 0x20, 0x2f, 0x3d, 0x76, 0x26, 0x72, 0x67,
+	0x4c, 0x46, 0x24, 0xf4, 0x02, 0x49, 0x51, 0x53, 0xb9, 0x53, 0x01, 0x05, 0x91, 0x9d, 0x34, 0x5d,
+	0xbe, 0xab, 0x7b, 0x78, 0x5b, 0x01, 0x8f, 0xe9, 0x21, 0x9d, 0xa9, 0x88, 0x1a, 0xb3, 0x15, 0x51,
+	0xae, 0xe3, 0x68, 0xe6, 0x3b, 0x0e, 0x1b, 0xc1, 0x92, 0x90, 0x5a, 0xb7, 0xe4, 0x3e, 0xf4, 0x34,
+	0x98, 0xd2, 0xde, 0x5e, 0x71, 0xb6, 0xb0, 0x92, 0x59, 0x30, 0xbb, 0x77, 0x3a, 0x60, 0xe0, 0x69,
+	0x46, 0x80, 0x1d, 0x4c, 0x59, 0x14, 0xeb, 0x69, 0xe6, 0x0a, 0xbd, 0xd8, 0x6b, 0xb0, 0x92, 0xdf,
+	0xa5, 0x3c, 0xef, 0x14, 0x5a, 0x47, 0xa1, 0x37, 0xa1, 0x67, 0xd1, 0xfb, 0x94, 0x8d, 0x36, 0x74,
+	0x87, 0xa2, 0xec, 0x2f, 0x28, 0x52, 0x01, 0xb9, 0x22, 0x3f, 0x6b, 0xb4, 0x1a, 0x4b, 0x4d, 0xfb,
+	0x00, 0x56, 0x93, 0x73, 0x64, 0xa3, 0xf0, 0xfe, 0xc3, 0xb5, 0x3e, 0xac, 0x15, 0x59, 0xa9, 0xcb,
+	0x68, 0x87, 0xc8, 0xb0, 0xfe, 0xad, 0x1c, 0x92, 0xb0, 0x52, 0x87, 0xac, 0xc2, 0x72, 0x82, 0xd1,
+	0x6d, 0xfb, 0x29, 0xac, 0xe4, 0xc1, 0xe9, 0xfb, 0x34, 0xa9, 0x82, 0x97, 0xa4, 0xf4, 0x64, 0x8b,
+	0x93, 0x11, 0xd9, 0x5f, 0xc3, 0xd2, 0x17, 0x38, 0xa6, 0x24, 0x0a, 0x49, 0x38, 0x92, 0xb1, 0xe2,
+	0x2d, 0x17, 0xb8, 0x05, 0x1d, 0x1e, 0x89, 0xce, 0xe5, 0x2e, 0x19, 0xa9, 0xba, 0x4e, 0x7b, 0xec,
+	0x5d, 0x2a, 0x46, 0x69, 0xb0, 0xf2, 0x46, 0x98, 0x97, 0x54, 0x51, 0xe8, 0x27, 0xb6, 0xe2, 0xc1,
+	0xea, 0xf1, 0x08, 0x1f, 0x49, 0xa0, 0xfd, 0x27, 0x03, 0xac, 0xe2, 0xe9, 0xd7, 0x0e, 0x97, 0x7d,
+	0xee, 0xca, 0xde, 0x49, 0x80, 0x7d, 0x35, 0xa3, 0x4a, 0x96, 0x33, 0x12, 0xd6, 0xaf, 0x25, 0x61,
+	0xa3, 0x4c, 0xc2, 0x4d, 0xd8, 0x28, 0x15, 0x50, 0x99, 0xe7, 0x06, 0xac, 0x66, 0xe8, 0x7c, 0x18,
+	0x5d, 0x2b, 0x22, 0x94, 0x89, 0x3e, 0x12, 0xc3, 0xa4, 0x53, 0x32, 0x4a, 0x0c, 0x64, 0x65, 0x06,
+	0x2a, 0x1e, 0xe5, 0x24, 0xa4, 0xf6, 0x1b, 0x68, 0xf3, 0xde, 0x4f, 0x11, 0x88, 0x32, 0x4f, 0x7e,
+	0x66, 0x05, 0xa6, 0xa9, 0x20, 0x07, 0x3e, 0xef, 0x9e, 0x78, 0x75, 0xeb, 0x0d, 0xd3, 0xa7, 0x22,
+	0x27, 0x11, 0x9d, 0x04, 0x2a, 0x82, 0x4e, 0xda, 0xfb, 0xd6, 0xaf, 0xec, 0x7d, 0x9f, 0x01, 0x52,
+	0xc7, 0x6a, 0x17, 0x7c, 0x0f, 0x27, 0xff, 0x14, 0x96, 0x73, 0x7c, 0x94, 0x3e, 0x7e, 0x04, 0xad,
+	0xd4, 0x50, 0x52, 0x21, 0xab, 0xf9, 0x7e, 0x57, 0x6d, 0x72, 0x52, 0x32, 0xfb, 0x2c, 0xd5, 0x7a,
+	0x21, 0x2c, 0xbd, 0x7b, 0x4c, 0xc9, 0x2b, 0xb2, 0x5e, 0x50, 0x24, 0x7f, 0x98, 0xc5, 0x93, 0x94,
+	0xe5, 0xbf, 0x86, 0xde, 0x13, 0x7c, 0x1a, 0x7b, 0xa3, 0x31, 0x0e, 0xd9, 0x37, 0x3a, 0x9f, 0x8f,
+	0x95, 0xb5, 0x71, 0x5c, 0xd7, 0xe1, 0x23, 0x6a, 0x39, 0x8b, 0x43, 0xab, 0x30, 0x27, 0x46, 0xd2,
+	0x27, 0x49, 0xf1, 0xcf, 0xe7, 0xd1, 0x27, 0xbc, 0xd4, 0x40, 0xfa, 0xe9, 0x4a, 0x95, 0xf9, 0x24,
+	0x68, 0x48, 0x66, 0x59, 0x12, 0xbc, 0x0b, 0x8b, 0xbc, 0x89, 0x93, 0x4d, 0xb0, 0x9e, 0x28, 0x79,
+	0x03, 0x28, 0x0e, 0x4c, 0xe9, 0x78, 0xb3, 0xa6, 0xd3, 0xa9, 0x52, 0x23, 0xc4, 0x17, 0x19, 0xdd,
+	0xc3, 0xff, 0x2c, 0x41, 0xe7, 0x08, 0x7b, 0x17, 0x58, 0xce, 0x96, 0x63, 0x34, 0x4a, 0x0a, 0xfc,
+	0xfc, 0x0f, 0x11, 0xe8, 0x4e, 0xb1, 0x92, 0x2f, 0xfd, 0xe5, 0xc3, 0xba, 0xfb, 0x36, 0x32, 0xa5,
+	0xfa, 0xef, 0xa1, 0xe7, 0xd0, 0xd6, 0x06, 0xe7, 0xe8, 0xa6, 0xb6, 0x71, 0xe6, 0x07, 0x0c, 0x6b,
+	0xb3, 0x02, 0xab, 0x73, 0xd3, 0xc6, 0x49, 0x3a, 0xb7, 0xd9, 0x01, 0x96, 0xb5, 0x59, 0x81, 0xd5,
+	0xb9, 0x69, 0xa3, 0x22, 0x9d, 0xdb, 0xec, 0x70, 0xca, 0xda, 0xac, 0xc0, 0xa6, 0xdc, 0x1c, 0xe8,
+	0xe6, 0x86, 0x34, 0x68, 0x2b, 0xdb, 0x51, 0x36, 0x79, 0xb2, 0xb6, 0x2b, 0xf1, 0xba, 0x84, 0xda,
+	0x5c, 0x43, 0x97, 0x70, 0x76, 0xba, 0x63, 0x6d, 0x56, 0x60, 0x53, 0x6e, 0xbf, 0x86, 0xde, 0xcc,
+	0xc4, 0x01, 0xd9, 0x9a, 0x14, 0x15, 0xa3, 0x12, 0x6b, 0xe7, 0x4a, 0x9a, 0x94, 0xff, 0x33, 0x30,
+	0xd3, 0xf9, 0x00, 0xb2, 0x74, 0x5b, 0xe6, 0x07, 0x19, 0xd6, 0x46, 0x29, 0x2e, 0xe5, 0xf3, 0x39,
+	0x74, 0xf4, 0x46, 0x1d, 0x69, 0x17, 0x2b, 0x99, 0x34, 0x58, 0x5b, 0x55, 0x68, 0x9d, 0xa1, 0xde,
+	0x97, 0xea, 0x0c, 0x4b, 0x3a, 0x73, 0x6b, 0xab, 0x0a, 0x9d, 0x32, 0xfc, 0x15, 0x2c, 0x15, 0xfb,
+	0x43, 0x74, 0xab, 0xa8, 0xfe, 0x99, 0xb6, 0xd3, 0xb2, 0xaf, 0x22, 0x49, 0x99, 0xff, 0x06, 0x7a,
+	0x33, 0x6d, 0x9e, 0x6e, 0xa6, 0xaa, 0xee, 0xd3, 0xda, 0xb9, 0x92, 0x26, 0xe1, 0xff, 0xc0, 0x40,
+	0x13, 0xb8, 0x51, 0xd1, 0x79, 0xa0, 0xdd, 0xaa, 0x36, 0xa1, 0x98, 0xf2, 0xad, 0x7b, 0xd7, 0xa0,
+	0x4c, 0xef, 0xe4, 0x01, 0x9a, 0xed, 0x55, 0xd0, 0x4e, 0x15, 0x0b, 0x2d, 0x7d, 0x59, 0xb7, 0xaf,
+	0x26, 0x4a, 0x8f, 0x78, 0x05, 0x0b, 0xf9, 0x3e, 0x04, 0x6d, 0x97, 0xec, 0xd4, 0x1b, 0x1b, 0x6b,
+	0x50, 0x4d, 0xa0, 0x3b, 0x75, 0x5a, 0x9b, 0xeb, 0x4e, 0x5d, 0x2c, 0xe2, 0xad, 0x8d, 0x52, 0x9c,
+	0xee, 0x83, 0x7a, 0xa1, 0xad, 0xfb, 0x60, 0x49, 0xd9, 0x6e, 0x6d, 0x55, 0xa1, 0xf5, 0xfb, 0xe6,
+	0xcb, 0x5d, 0xfd, 0xbe, 0xa5, 0x35, 0xb5, 0x35, 0xa8, 0x26, 0x28, 0x63, 0x2b, 0x7d, 0xb4, 0x8c,
+	0x6d, 0xae, 0x8a, 0xb6, 0x06, 0xd5, 0x04, 0xfa, 0xf5, 0xf5, 0x32, 0x58, 0xbf, 0x7e, 0x49, 0xd5,
+	0x6c, 0x6d, 0x55, 0xa1, 0x53, 0x86, 0x3e, 0x2c, 0x17, 0x6b, 0x30, 0xee, 0xbf, 0xb7, 0xab, 0x4b,
+	0x34, 0xcd, 0x77, 0xef, 0xbc, 0x85, 0x4a, 0xd7, 0x46, 0xbe, 0x38, 0xd4, 0xb5, 0x51, 0x5a, 0x4f,
+	0x5a, 0x83, 0x6a, 0x02, 0x3d, 0xae, 0x6b, 0x05, 0x96, 0x1e, 0xd7, 0x67, 0xeb, 0x37, 0x6b, 0xb3,
+	0x02, 0x5b, 0x22, 0x64, 0xe2, 0x5c, 0xb3, 0x42, 0x16, 0xdc, 0x6b, 0x50, 0x4d, 0x90, 0xb2, 0x3d,
+	0x00, 0xc8, 0x2a, 0x17, 0xb4, 0xa1, 0xc7, 0xae, 0x42, 0x35, 0x65, 0xdd, 0x2c, 0x47, 0x26, 0xac,
+	0x4e, 0xe6, 0xc4, 0x3f, 0x2b, 0x3e, 0xfc, 0xef, 0x00, 0xf0, 0x43, 0x4f, 0x4c, 0x68, 0x21, 0x00,
+	0x00,
 }
@@ -16,11 +16,40 @@ It has these top-level messages:
 	SuperBlockExtra
 	ClientListenRequest
 	VolumeLocation
+	SubscribeTopologyEventsRequest
+	TopologyEvent
 	LookupVolumeRequest
 	LookupVolumeResponse
 	Location
 	AssignRequest
 	AssignResponse
+	DataNodeInfo
+	RackInfo
+	DataCenterInfo
+	TopologyInfo
+	VolumeListRequest
+	VolumeListResponse
+	VolumeGrowRequest
+	VolumeGrowResponse
+	VacuumRequest
+	VacuumResponse
+	VacuumRun
+	VacuumPauseRequest
+	VacuumPauseResponse
+	VacuumResumeRequest
+	VacuumResumeResponse
+	VacuumStatusRequest
+	VacuumStatusResponse
+	CollectionPolicy
+	Collection
+	CollectionListRequest
+	CollectionListResponse
+	CollectionDeleteRequest
+	CollectionDeleteResponse
+	CollectionConfigureRequest
+	CollectionConfigureResponse
+	GetMasterConfigurationRequest
+	GetMasterConfigurationResponse
 */
 package master_pb
 
@@ -44,6 +73,54 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type TopologyEvent_EventType int32
+
+const (
+	TopologyEvent_UNKNOWN            TopologyEvent_EventType = 0
+	TopologyEvent_DATA_NODE_JOINED   TopologyEvent_EventType = 1
+	TopologyEvent_DATA_NODE_LEFT     TopologyEvent_EventType = 2
+	TopologyEvent_VOLUME_CREATED     TopologyEvent_EventType = 3
+	TopologyEvent_VOLUME_DELETED     TopologyEvent_EventType = 4
+	TopologyEvent_VOLUME_READONLY    TopologyEvent_EventType = 5
+	TopologyEvent_VOLUME_FULL        TopologyEvent_EventType = 6
+	TopologyEvent_LEADER_CHANGED     TopologyEvent_EventType = 7
+	TopologyEvent_VACUUM_STARTED     TopologyEvent_EventType = 8
+	TopologyEvent_VACUUM_FINISHED    TopologyEvent_EventType = 9
+	TopologyEvent_VOLUME_GROW_FAILED TopologyEvent_EventType = 10
+)
+
+var TopologyEvent_EventType_name = map[int32]string{
+	0:  "UNKNOWN",
+	1:  "DATA_NODE_JOINED",
+	2:  "DATA_NODE_LEFT",
+	3:  "VOLUME_CREATED",
+	4:  "VOLUME_DELETED",
+	5:  "VOLUME_READONLY",
+	6:  "VOLUME_FULL",
+	7:  "LEADER_CHANGED",
+	8:  "VACUUM_STARTED",
+	9:  "VACUUM_FINISHED",
+	10: "VOLUME_GROW_FAILED",
+}
+var TopologyEvent_EventType_value = map[string]int32{
+	"UNKNOWN":            0,
+	"DATA_NODE_JOINED":   1,
+	"DATA_NODE_LEFT":     2,
+	"VOLUME_CREATED":     3,
+	"VOLUME_DELETED":     4,
+	"VOLUME_READONLY":    5,
+	"VOLUME_FULL":        6,
+	"LEADER_CHANGED":     7,
+	"VACUUM_STARTED":     8,
+	"VACUUM_FINISHED":    9,
+	"VOLUME_GROW_FAILED": 10,
+}
+
+func (x TopologyEvent_EventType) String() string {
+	return proto.EnumName(TopologyEvent_EventType_name, int32(x))
+}
+func (TopologyEvent_EventType) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{8, 0} }
+
 type Heartbeat struct {
 	Ip             string                      `protobuf:"bytes,1,opt,name=ip" json:"ip,omitempty"`
 	Port           uint32                      `protobuf:"varint,2,opt,name=port" json:"port,omitempty"`
@@ -375,6 +452,119 @@ func (m *VolumeLocation) GetDeletedVids() []uint32 {
 	return nil
 }
 
+type SubscribeTopologyEventsRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	// empty means all event types
+	EventTypes []TopologyEvent_EventType `protobuf:"varint,2,rep,packed,name=event_types,json=eventTypes,enum=master_pb.TopologyEvent_EventType" json:"event_types,omitempty"`
+}
+
+func (m *SubscribeTopologyEventsRequest) Reset()                    { *m = SubscribeTopologyEventsRequest{} }
+func (m *SubscribeTopologyEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SubscribeTopologyEventsRequest) ProtoMessage()               {}
+func (*SubscribeTopologyEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *SubscribeTopologyEventsRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SubscribeTopologyEventsRequest) GetEventTypes() []TopologyEvent_EventType {
+	if m != nil {
+		return m.EventTypes
+	}
+	return nil
+}
+
+type TopologyEvent struct {
+	Type       TopologyEvent_EventType `protobuf:"varint,1,opt,name=type,enum=master_pb.TopologyEvent_EventType" json:"type,omitempty"`
+	TsNs       int64                   `protobuf:"varint,2,opt,name=ts_ns,json=tsNs" json:"ts_ns,omitempty"`
+	DataCenter string                  `protobuf:"bytes,3,opt,name=data_center,json=dataCenter" json:"data_center,omitempty"`
+	Rack       string                  `protobuf:"bytes,4,opt,name=rack" json:"rack,omitempty"`
+	Url        string                  `protobuf:"bytes,5,opt,name=url" json:"url,omitempty"`
+	VolumeId   uint32                  `protobuf:"varint,6,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	Collection string                  `protobuf:"bytes,7,opt,name=collection" json:"collection,omitempty"`
+	Leader     string                  `protobuf:"bytes,8,opt,name=leader" json:"leader,omitempty"`
+	VacuumRun  *VacuumRun              `protobuf:"bytes,9,opt,name=vacuum_run,json=vacuumRun" json:"vacuum_run,omitempty"`
+	Error      string                  `protobuf:"bytes,10,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *TopologyEvent) Reset()                    { *m = TopologyEvent{} }
+func (m *TopologyEvent) String() string            { return proto.CompactTextString(m) }
+func (*TopologyEvent) ProtoMessage()               {}
+func (*TopologyEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *TopologyEvent) GetType() TopologyEvent_EventType {
+	if m != nil {
+		return m.Type
+	}
+	return TopologyEvent_UNKNOWN
+}
+
+func (m *TopologyEvent) GetTsNs() int64 {
+	if m != nil {
+		return m.TsNs
+	}
+	return 0
+}
+
+func (m *TopologyEvent) GetDataCenter() string {
+	if m != nil {
+		return m.DataCenter
+	}
+	return ""
+}
+
+func (m *TopologyEvent) GetRack() string {
+	if m != nil {
+		return m.Rack
+	}
+	return ""
+}
+
+func (m *TopologyEvent) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
+}
+
+func (m *TopologyEvent) GetVolumeId() uint32 {
+	if m != nil {
+		return m.VolumeId
+	}
+	return 0
+}
+
+func (m *TopologyEvent) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *TopologyEvent) GetLeader() string {
+	if m != nil {
+		return m.Leader
+	}
+	return ""
+}
+
+func (m *TopologyEvent) GetVacuumRun() *VacuumRun {
+	if m != nil {
+		return m.VacuumRun
+	}
+	return nil
+}
+
+func (m *TopologyEvent) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type LookupVolumeRequest struct {
 	VolumeIds  []string `protobuf:"bytes,1,rep,name=volume_ids,json=volumeIds" json:"volume_ids,omitempty"`
 	Collection string   `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
@@ -383,7 +573,7 @@ type LookupVolumeRequest struct {
 func (m *LookupVolumeRequest) Reset()                    { *m = LookupVolumeRequest{} }
 func (m *LookupVolumeRequest) String() string            { return proto.CompactTextString(m) }
 func (*LookupVolumeRequest) ProtoMessage()               {}
-func (*LookupVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*LookupVolumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *LookupVolumeRequest) GetVolumeIds() []string {
 	if m != nil {
@@ -406,7 +596,7 @@ type LookupVolumeResponse struct {
 func (m *LookupVolumeResponse) Reset()                    { *m = LookupVolumeResponse{} }
 func (m *LookupVolumeResponse) String() string            { return proto.CompactTextString(m) }
 func (*LookupVolumeResponse) ProtoMessage()               {}
-func (*LookupVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*LookupVolumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *LookupVolumeResponse) GetVolumeIdLocations() []*LookupVolumeResponse_VolumeIdLocation {
 	if m != nil {
@@ -425,7 +615,7 @@ func (m *LookupVolumeResponse_VolumeIdLocation) Reset()         { *m = LookupVol
 func (m *LookupVolumeResponse_VolumeIdLocation) String() string { return proto.CompactTextString(m) }
 func (*LookupVolumeResponse_VolumeIdLocation) ProtoMessage()    {}
 func (*LookupVolumeResponse_VolumeIdLocation) Descriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{8, 0}
+	return fileDescriptor0, []int{10, 0}
 }
 
 func (m *LookupVolumeResponse_VolumeIdLocation) GetVolumeId() string {
@@ -457,7 +647,7 @@ type Location struct {
 func (m *Location) Reset()                    { *m = Location{} }
 func (m *Location) String() string            { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()               {}
-func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*Location) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *Location) GetUrl() string {
 	if m != nil {
@@ -486,7 +676,7 @@ type AssignRequest struct {
 func (m *AssignRequest) Reset()                    { *m = AssignRequest{} }
 func (m *AssignRequest) String() string            { return proto.CompactTextString(m) }
 func (*AssignRequest) ProtoMessage()               {}
-func (*AssignRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*AssignRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *AssignRequest) GetCount() uint64 {
 	if m != nil {
@@ -548,7 +738,7 @@ type AssignResponse struct {
 func (m *AssignResponse) Reset()                    { *m = AssignResponse{} }
 func (m *AssignResponse) String() string            { return proto.CompactTextString(m) }
 func (*AssignResponse) ProtoMessage()               {}
-func (*AssignResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*AssignResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *AssignResponse) GetFid() string {
 	if m != nil {
@@ -585,178 +775,1154 @@ func (m *AssignResponse) GetError() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*Heartbeat)(nil), "master_pb.Heartbeat")
-	proto.RegisterType((*HeartbeatResponse)(nil), "master_pb.HeartbeatResponse")
-	proto.RegisterType((*VolumeInformationMessage)(nil), "master_pb.VolumeInformationMessage")
-	proto.RegisterType((*Empty)(nil), "master_pb.Empty")
-	proto.RegisterType((*SuperBlockExtra)(nil), "master_pb.SuperBlockExtra")
-	proto.RegisterType((*SuperBlockExtra_ErasureCoding)(nil), "master_pb.SuperBlockExtra.ErasureCoding")
-	proto.RegisterType((*ClientListenRequest)(nil), "master_pb.ClientListenRequest")
-	proto.RegisterType((*VolumeLocation)(nil), "master_pb.VolumeLocation")
-	proto.RegisterType((*LookupVolumeRequest)(nil), "master_pb.LookupVolumeRequest")
-	proto.RegisterType((*LookupVolumeResponse)(nil), "master_pb.LookupVolumeResponse")
-	proto.RegisterType((*LookupVolumeResponse_VolumeIdLocation)(nil), "master_pb.LookupVolumeResponse.VolumeIdLocation")
-	proto.RegisterType((*Location)(nil), "master_pb.Location")
-	proto.RegisterType((*AssignRequest)(nil), "master_pb.AssignRequest")
-	proto.RegisterType((*AssignResponse)(nil), "master_pb.AssignResponse")
+type DataNodeInfo struct {
+	Id                string                      `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Url               string                      `protobuf:"bytes,2,opt,name=url" json:"url,omitempty"`
+	PublicUrl         string                      `protobuf:"bytes,3,opt,name=public_url,json=publicUrl" json:"public_url,omitempty"`
+	VolumeCount       uint64                      `protobuf:"varint,4,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
+	MaxVolumeCount    uint64                      `protobuf:"varint,5,opt,name=max_volume_count,json=maxVolumeCount" json:"max_volume_count,omitempty"`
+	FreeVolumeCount   uint64                      `protobuf:"varint,6,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
+	ActiveVolumeCount uint64                      `protobuf:"varint,7,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
+	VolumeInfos       []*VolumeInformationMessage `protobuf:"bytes,8,rep,name=volume_infos,json=volumeInfos" json:"volume_infos,omitempty"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// Client API for Seaweed service
-
-type SeaweedClient interface {
-	SendHeartbeat(ctx context.Context, opts ...grpc.CallOption) (Seaweed_SendHeartbeatClient, error)
-	KeepConnected(ctx context.Context, opts ...grpc.CallOption) (Seaweed_KeepConnectedClient, error)
-	LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error)
-	Assign(ctx context.Context, in *AssignRequest, opts ...grpc.CallOption) (*AssignResponse, error)
-}
+func (m *DataNodeInfo) Reset()                    { *m = DataNodeInfo{} }
+func (m *DataNodeInfo) String() string            { return proto.CompactTextString(m) }
+func (*DataNodeInfo) ProtoMessage()               {}
+func (*DataNodeInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
-type seaweedClient struct {
-	cc *grpc.ClientConn
+func (m *DataNodeInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
 
-func NewSeaweedClient(cc *grpc.ClientConn) SeaweedClient {
-	return &seaweedClient{cc}
+func (m *DataNodeInfo) GetUrl() string {
+	if m != nil {
+		return m.Url
+	}
+	return ""
 }
 
-func (c *seaweedClient) SendHeartbeat(ctx context.Context, opts ...grpc.CallOption) (Seaweed_SendHeartbeatClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Seaweed_serviceDesc.Streams[0], c.cc, "/master_pb.Seaweed/SendHeartbeat", opts...)
-	if err != nil {
-		return nil, err
+func (m *DataNodeInfo) GetPublicUrl() string {
+	if m != nil {
+		return m.PublicUrl
 	}
-	x := &seaweedSendHeartbeatClient{stream}
-	return x, nil
+	return ""
 }
 
-type Seaweed_SendHeartbeatClient interface {
-	Send(*Heartbeat) error
-	Recv() (*HeartbeatResponse, error)
-	grpc.ClientStream
+func (m *DataNodeInfo) GetVolumeCount() uint64 {
+	if m != nil {
+		return m.VolumeCount
+	}
+	return 0
 }
 
-type seaweedSendHeartbeatClient struct {
-	grpc.ClientStream
+func (m *DataNodeInfo) GetMaxVolumeCount() uint64 {
+	if m != nil {
+		return m.MaxVolumeCount
+	}
+	return 0
 }
 
-func (x *seaweedSendHeartbeatClient) Send(m *Heartbeat) error {
-	return x.ClientStream.SendMsg(m)
+func (m *DataNodeInfo) GetFreeVolumeCount() uint64 {
+	if m != nil {
+		return m.FreeVolumeCount
+	}
+	return 0
 }
 
-func (x *seaweedSendHeartbeatClient) Recv() (*HeartbeatResponse, error) {
-	m := new(HeartbeatResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *DataNodeInfo) GetActiveVolumeCount() uint64 {
+	if m != nil {
+		return m.ActiveVolumeCount
 	}
-	return m, nil
+	return 0
 }
 
-func (c *seaweedClient) KeepConnected(ctx context.Context, opts ...grpc.CallOption) (Seaweed_KeepConnectedClient, error) {
-	stream, err := grpc.NewClientStream(ctx, &_Seaweed_serviceDesc.Streams[1], c.cc, "/master_pb.Seaweed/KeepConnected", opts...)
-	if err != nil {
-		return nil, err
+func (m *DataNodeInfo) GetVolumeInfos() []*VolumeInformationMessage {
+	if m != nil {
+		return m.VolumeInfos
 	}
-	x := &seaweedKeepConnectedClient{stream}
-	return x, nil
+	return nil
 }
 
-type Seaweed_KeepConnectedClient interface {
-	Send(*ClientListenRequest) error
-	Recv() (*VolumeLocation, error)
-	grpc.ClientStream
+type RackInfo struct {
+	Id                string          `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	VolumeCount       uint64          `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
+	MaxVolumeCount    uint64          `protobuf:"varint,3,opt,name=max_volume_count,json=maxVolumeCount" json:"max_volume_count,omitempty"`
+	FreeVolumeCount   uint64          `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
+	ActiveVolumeCount uint64          `protobuf:"varint,5,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
+	DataNodeInfos     []*DataNodeInfo `protobuf:"bytes,6,rep,name=data_node_infos,json=dataNodeInfos" json:"data_node_infos,omitempty"`
 }
 
-type seaweedKeepConnectedClient struct {
-	grpc.ClientStream
-}
+func (m *RackInfo) Reset()                    { *m = RackInfo{} }
+func (m *RackInfo) String() string            { return proto.CompactTextString(m) }
+func (*RackInfo) ProtoMessage()               {}
+func (*RackInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
-func (x *seaweedKeepConnectedClient) Send(m *ClientListenRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *RackInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
 
-func (x *seaweedKeepConnectedClient) Recv() (*VolumeLocation, error) {
-	m := new(VolumeLocation)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *RackInfo) GetVolumeCount() uint64 {
+	if m != nil {
+		return m.VolumeCount
 	}
-	return m, nil
+	return 0
 }
 
-func (c *seaweedClient) LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error) {
-	out := new(LookupVolumeResponse)
-	err := grpc.Invoke(ctx, "/master_pb.Seaweed/LookupVolume", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *RackInfo) GetMaxVolumeCount() uint64 {
+	if m != nil {
+		return m.MaxVolumeCount
 	}
-	return out, nil
+	return 0
 }
 
-func (c *seaweedClient) Assign(ctx context.Context, in *AssignRequest, opts ...grpc.CallOption) (*AssignResponse, error) {
-	out := new(AssignResponse)
-	err := grpc.Invoke(ctx, "/master_pb.Seaweed/Assign", in, out, c.cc, opts...)
-	if err != nil {
-		return nil, err
+func (m *RackInfo) GetFreeVolumeCount() uint64 {
+	if m != nil {
+		return m.FreeVolumeCount
 	}
-	return out, nil
+	return 0
 }
 
-// Server API for Seaweed service
+func (m *RackInfo) GetActiveVolumeCount() uint64 {
+	if m != nil {
+		return m.ActiveVolumeCount
+	}
+	return 0
+}
 
-type SeaweedServer interface {
-	SendHeartbeat(Seaweed_SendHeartbeatServer) error
-	KeepConnected(Seaweed_KeepConnectedServer) error
-	LookupVolume(context.Context, *LookupVolumeRequest) (*LookupVolumeResponse, error)
-	Assign(context.Context, *AssignRequest) (*AssignResponse, error)
+func (m *RackInfo) GetDataNodeInfos() []*DataNodeInfo {
+	if m != nil {
+		return m.DataNodeInfos
+	}
+	return nil
 }
 
-func RegisterSeaweedServer(s *grpc.Server, srv SeaweedServer) {
-	s.RegisterService(&_Seaweed_serviceDesc, srv)
+type DataCenterInfo struct {
+	Id                string      `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	VolumeCount       uint64      `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
+	MaxVolumeCount    uint64      `protobuf:"varint,3,opt,name=max_volume_count,json=maxVolumeCount" json:"max_volume_count,omitempty"`
+	FreeVolumeCount   uint64      `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
+	ActiveVolumeCount uint64      `protobuf:"varint,5,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
+	RackInfos         []*RackInfo `protobuf:"bytes,6,rep,name=rack_infos,json=rackInfos" json:"rack_infos,omitempty"`
 }
 
-func _Seaweed_SendHeartbeat_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(SeaweedServer).SendHeartbeat(&seaweedSendHeartbeatServer{stream})
+func (m *DataCenterInfo) Reset()                    { *m = DataCenterInfo{} }
+func (m *DataCenterInfo) String() string            { return proto.CompactTextString(m) }
+func (*DataCenterInfo) ProtoMessage()               {}
+func (*DataCenterInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *DataCenterInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
 
-type Seaweed_SendHeartbeatServer interface {
-	Send(*HeartbeatResponse) error
-	Recv() (*Heartbeat, error)
-	grpc.ServerStream
+func (m *DataCenterInfo) GetVolumeCount() uint64 {
+	if m != nil {
+		return m.VolumeCount
+	}
+	return 0
 }
 
-type seaweedSendHeartbeatServer struct {
-	grpc.ServerStream
+func (m *DataCenterInfo) GetMaxVolumeCount() uint64 {
+	if m != nil {
+		return m.MaxVolumeCount
+	}
+	return 0
 }
 
-func (x *seaweedSendHeartbeatServer) Send(m *HeartbeatResponse) error {
-	return x.ServerStream.SendMsg(m)
+func (m *DataCenterInfo) GetFreeVolumeCount() uint64 {
+	if m != nil {
+		return m.FreeVolumeCount
+	}
+	return 0
 }
 
-func (x *seaweedSendHeartbeatServer) Recv() (*Heartbeat, error) {
-	m := new(Heartbeat)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
-		return nil, err
+func (m *DataCenterInfo) GetActiveVolumeCount() uint64 {
+	if m != nil {
+		return m.ActiveVolumeCount
 	}
-	return m, nil
+	return 0
 }
 
-func _Seaweed_KeepConnected_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(SeaweedServer).KeepConnected(&seaweedKeepConnectedServer{stream})
+func (m *DataCenterInfo) GetRackInfos() []*RackInfo {
+	if m != nil {
+		return m.RackInfos
+	}
+	return nil
 }
 
-type Seaweed_KeepConnectedServer interface {
-	Send(*VolumeLocation) error
-	Recv() (*ClientListenRequest, error)
-	grpc.ServerStream
+type TopologyInfo struct {
+	Id                string            `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	VolumeCount       uint64            `protobuf:"varint,2,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
+	MaxVolumeCount    uint64            `protobuf:"varint,3,opt,name=max_volume_count,json=maxVolumeCount" json:"max_volume_count,omitempty"`
+	FreeVolumeCount   uint64            `protobuf:"varint,4,opt,name=free_volume_count,json=freeVolumeCount" json:"free_volume_count,omitempty"`
+	ActiveVolumeCount uint64            `protobuf:"varint,5,opt,name=active_volume_count,json=activeVolumeCount" json:"active_volume_count,omitempty"`
+	DataCenterInfos   []*DataCenterInfo `protobuf:"bytes,6,rep,name=data_center_infos,json=dataCenterInfos" json:"data_center_infos,omitempty"`
 }
 
-type seaweedKeepConnectedServer struct {
+func (m *TopologyInfo) Reset()                    { *m = TopologyInfo{} }
+func (m *TopologyInfo) String() string            { return proto.CompactTextString(m) }
+func (*TopologyInfo) ProtoMessage()               {}
+func (*TopologyInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *TopologyInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *TopologyInfo) GetVolumeCount() uint64 {
+	if m != nil {
+		return m.VolumeCount
+	}
+	return 0
+}
+
+func (m *TopologyInfo) GetMaxVolumeCount() uint64 {
+	if m != nil {
+		return m.MaxVolumeCount
+	}
+	return 0
+}
+
+func (m *TopologyInfo) GetFreeVolumeCount() uint64 {
+	if m != nil {
+		return m.FreeVolumeCount
+	}
+	return 0
+}
+
+func (m *TopologyInfo) GetActiveVolumeCount() uint64 {
+	if m != nil {
+		return m.ActiveVolumeCount
+	}
+	return 0
+}
+
+func (m *TopologyInfo) GetDataCenterInfos() []*DataCenterInfo {
+	if m != nil {
+		return m.DataCenterInfos
+	}
+	return nil
+}
+
+type VolumeListRequest struct {
+}
+
+func (m *VolumeListRequest) Reset()                    { *m = VolumeListRequest{} }
+func (m *VolumeListRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeListRequest) ProtoMessage()               {}
+func (*VolumeListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+type VolumeListResponse struct {
+	TopologyInfo      *TopologyInfo `protobuf:"bytes,1,opt,name=topology_info,json=topologyInfo" json:"topology_info,omitempty"`
+	VolumeSizeLimitMb uint64        `protobuf:"varint,2,opt,name=volume_size_limit_mb,json=volumeSizeLimitMb" json:"volume_size_limit_mb,omitempty"`
+}
+
+func (m *VolumeListResponse) Reset()                    { *m = VolumeListResponse{} }
+func (m *VolumeListResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeListResponse) ProtoMessage()               {}
+func (*VolumeListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *VolumeListResponse) GetTopologyInfo() *TopologyInfo {
+	if m != nil {
+		return m.TopologyInfo
+	}
+	return nil
+}
+
+func (m *VolumeListResponse) GetVolumeSizeLimitMb() uint64 {
+	if m != nil {
+		return m.VolumeSizeLimitMb
+	}
+	return 0
+}
+
+type VolumeGrowRequest struct {
+	Count       uint32 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+	Replication string `protobuf:"bytes,2,opt,name=replication" json:"replication,omitempty"`
+	Collection  string `protobuf:"bytes,3,opt,name=collection" json:"collection,omitempty"`
+	Ttl         string `protobuf:"bytes,4,opt,name=ttl" json:"ttl,omitempty"`
+	DataCenter  string `protobuf:"bytes,5,opt,name=data_center,json=dataCenter" json:"data_center,omitempty"`
+	Rack        string `protobuf:"bytes,6,opt,name=rack" json:"rack,omitempty"`
+	DataNode    string `protobuf:"bytes,7,opt,name=data_node,json=dataNode" json:"data_node,omitempty"`
+	Preallocate int64  `protobuf:"varint,8,opt,name=preallocate" json:"preallocate,omitempty"`
+}
+
+func (m *VolumeGrowRequest) Reset()                    { *m = VolumeGrowRequest{} }
+func (m *VolumeGrowRequest) String() string            { return proto.CompactTextString(m) }
+func (*VolumeGrowRequest) ProtoMessage()               {}
+func (*VolumeGrowRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *VolumeGrowRequest) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *VolumeGrowRequest) GetReplication() string {
+	if m != nil {
+		return m.Replication
+	}
+	return ""
+}
+
+func (m *VolumeGrowRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *VolumeGrowRequest) GetTtl() string {
+	if m != nil {
+		return m.Ttl
+	}
+	return ""
+}
+
+func (m *VolumeGrowRequest) GetDataCenter() string {
+	if m != nil {
+		return m.DataCenter
+	}
+	return ""
+}
+
+func (m *VolumeGrowRequest) GetRack() string {
+	if m != nil {
+		return m.Rack
+	}
+	return ""
+}
+
+func (m *VolumeGrowRequest) GetDataNode() string {
+	if m != nil {
+		return m.DataNode
+	}
+	return ""
+}
+
+func (m *VolumeGrowRequest) GetPreallocate() int64 {
+	if m != nil {
+		return m.Preallocate
+	}
+	return 0
+}
+
+type VolumeGrowResponse struct {
+	Count uint32 `protobuf:"varint,1,opt,name=count" json:"count,omitempty"`
+}
+
+func (m *VolumeGrowResponse) Reset()                    { *m = VolumeGrowResponse{} }
+func (m *VolumeGrowResponse) String() string            { return proto.CompactTextString(m) }
+func (*VolumeGrowResponse) ProtoMessage()               {}
+func (*VolumeGrowResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *VolumeGrowResponse) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type VacuumRequest struct {
+	GarbageThreshold float32 `protobuf:"fixed32,1,opt,name=garbage_threshold,json=garbageThreshold" json:"garbage_threshold,omitempty"`
+	Collection       string  `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+}
+
+func (m *VacuumRequest) Reset()                    { *m = VacuumRequest{} }
+func (m *VacuumRequest) String() string            { return proto.CompactTextString(m) }
+func (*VacuumRequest) ProtoMessage()               {}
+func (*VacuumRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *VacuumRequest) GetGarbageThreshold() float32 {
+	if m != nil {
+		return m.GarbageThreshold
+	}
+	return 0
+}
+
+func (m *VacuumRequest) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+type VacuumResponse struct {
+	Run *VacuumRun `protobuf:"bytes,1,opt,name=run" json:"run,omitempty"`
+}
+
+func (m *VacuumResponse) Reset()                    { *m = VacuumResponse{} }
+func (m *VacuumResponse) String() string            { return proto.CompactTextString(m) }
+func (*VacuumResponse) ProtoMessage()               {}
+func (*VacuumResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *VacuumResponse) GetRun() *VacuumRun {
+	if m != nil {
+		return m.Run
+	}
+	return nil
+}
+
+type VacuumRun struct {
+	Trigger        string `protobuf:"bytes,1,opt,name=trigger" json:"trigger,omitempty"`
+	Collection     string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	StartTimeNs    int64  `protobuf:"varint,3,opt,name=start_time_ns,json=startTimeNs" json:"start_time_ns,omitempty"`
+	EndTimeNs      int64  `protobuf:"varint,4,opt,name=end_time_ns,json=endTimeNs" json:"end_time_ns,omitempty"`
+	CheckedCount   uint32 `protobuf:"varint,5,opt,name=checked_count,json=checkedCount" json:"checked_count,omitempty"`
+	VacuumedCount  uint32 `protobuf:"varint,6,opt,name=vacuumed_count,json=vacuumedCount" json:"vacuumed_count,omitempty"`
+	FailedCount    uint32 `protobuf:"varint,7,opt,name=failed_count,json=failedCount" json:"failed_count,omitempty"`
+	ReclaimedBytes uint64 `protobuf:"varint,8,opt,name=reclaimed_bytes,json=reclaimedBytes" json:"reclaimed_bytes,omitempty"`
+	Interrupted    bool   `protobuf:"varint,9,opt,name=interrupted" json:"interrupted,omitempty"`
+}
+
+func (m *VacuumRun) Reset()                    { *m = VacuumRun{} }
+func (m *VacuumRun) String() string            { return proto.CompactTextString(m) }
+func (*VacuumRun) ProtoMessage()               {}
+func (*VacuumRun) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *VacuumRun) GetTrigger() string {
+	if m != nil {
+		return m.Trigger
+	}
+	return ""
+}
+
+func (m *VacuumRun) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *VacuumRun) GetStartTimeNs() int64 {
+	if m != nil {
+		return m.StartTimeNs
+	}
+	return 0
+}
+
+func (m *VacuumRun) GetEndTimeNs() int64 {
+	if m != nil {
+		return m.EndTimeNs
+	}
+	return 0
+}
+
+func (m *VacuumRun) GetCheckedCount() uint32 {
+	if m != nil {
+		return m.CheckedCount
+	}
+	return 0
+}
+
+func (m *VacuumRun) GetVacuumedCount() uint32 {
+	if m != nil {
+		return m.VacuumedCount
+	}
+	return 0
+}
+
+func (m *VacuumRun) GetFailedCount() uint32 {
+	if m != nil {
+		return m.FailedCount
+	}
+	return 0
+}
+
+func (m *VacuumRun) GetReclaimedBytes() uint64 {
+	if m != nil {
+		return m.ReclaimedBytes
+	}
+	return 0
+}
+
+func (m *VacuumRun) GetInterrupted() bool {
+	if m != nil {
+		return m.Interrupted
+	}
+	return false
+}
+
+type VacuumPauseRequest struct {
+}
+
+func (m *VacuumPauseRequest) Reset()                    { *m = VacuumPauseRequest{} }
+func (m *VacuumPauseRequest) String() string            { return proto.CompactTextString(m) }
+func (*VacuumPauseRequest) ProtoMessage()               {}
+func (*VacuumPauseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+type VacuumPauseResponse struct {
+}
+
+func (m *VacuumPauseResponse) Reset()                    { *m = VacuumPauseResponse{} }
+func (m *VacuumPauseResponse) String() string            { return proto.CompactTextString(m) }
+func (*VacuumPauseResponse) ProtoMessage()               {}
+func (*VacuumPauseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+type VacuumResumeRequest struct {
+}
+
+func (m *VacuumResumeRequest) Reset()                    { *m = VacuumResumeRequest{} }
+func (m *VacuumResumeRequest) String() string            { return proto.CompactTextString(m) }
+func (*VacuumResumeRequest) ProtoMessage()               {}
+func (*VacuumResumeRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+type VacuumResumeResponse struct {
+}
+
+func (m *VacuumResumeResponse) Reset()                    { *m = VacuumResumeResponse{} }
+func (m *VacuumResumeResponse) String() string            { return proto.CompactTextString(m) }
+func (*VacuumResumeResponse) ProtoMessage()               {}
+func (*VacuumResumeResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+type VacuumStatusRequest struct {
+}
+
+func (m *VacuumStatusRequest) Reset()                    { *m = VacuumStatusRequest{} }
+func (m *VacuumStatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*VacuumStatusRequest) ProtoMessage()               {}
+func (*VacuumStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+type VacuumStatusResponse struct {
+	Paused  bool         `protobuf:"varint,1,opt,name=paused" json:"paused,omitempty"`
+	Windows []string     `protobuf:"bytes,2,rep,name=windows" json:"windows,omitempty"`
+	Current *VacuumRun   `protobuf:"bytes,3,opt,name=current" json:"current,omitempty"`
+	History []*VacuumRun `protobuf:"bytes,4,rep,name=history" json:"history,omitempty"`
+}
+
+func (m *VacuumStatusResponse) Reset()                    { *m = VacuumStatusResponse{} }
+func (m *VacuumStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*VacuumStatusResponse) ProtoMessage()               {}
+func (*VacuumStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *VacuumStatusResponse) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+func (m *VacuumStatusResponse) GetWindows() []string {
+	if m != nil {
+		return m.Windows
+	}
+	return nil
+}
+
+func (m *VacuumStatusResponse) GetCurrent() *VacuumRun {
+	if m != nil {
+		return m.Current
+	}
+	return nil
+}
+
+func (m *VacuumStatusResponse) GetHistory() []*VacuumRun {
+	if m != nil {
+		return m.History
+	}
+	return nil
+}
+
+type CollectionPolicy struct {
+	Replication      string  `protobuf:"bytes,1,opt,name=replication" json:"replication,omitempty"`
+	Ttl              string  `protobuf:"bytes,2,opt,name=ttl" json:"ttl,omitempty"`
+	MaxVolumeCount   uint64  `protobuf:"varint,3,opt,name=max_volume_count,json=maxVolumeCount" json:"max_volume_count,omitempty"`
+	MaxBytes         uint64  `protobuf:"varint,4,opt,name=max_bytes,json=maxBytes" json:"max_bytes,omitempty"`
+	GarbageThreshold float32 `protobuf:"fixed32,5,opt,name=garbage_threshold,json=garbageThreshold" json:"garbage_threshold,omitempty"`
+	DataCenter       string  `protobuf:"bytes,6,opt,name=data_center,json=dataCenter" json:"data_center,omitempty"`
+}
+
+func (m *CollectionPolicy) Reset()                    { *m = CollectionPolicy{} }
+func (m *CollectionPolicy) String() string            { return proto.CompactTextString(m) }
+func (*CollectionPolicy) ProtoMessage()               {}
+func (*CollectionPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *CollectionPolicy) GetReplication() string {
+	if m != nil {
+		return m.Replication
+	}
+	return ""
+}
+
+func (m *CollectionPolicy) GetTtl() string {
+	if m != nil {
+		return m.Ttl
+	}
+	return ""
+}
+
+func (m *CollectionPolicy) GetMaxVolumeCount() uint64 {
+	if m != nil {
+		return m.MaxVolumeCount
+	}
+	return 0
+}
+
+func (m *CollectionPolicy) GetMaxBytes() uint64 {
+	if m != nil {
+		return m.MaxBytes
+	}
+	return 0
+}
+
+func (m *CollectionPolicy) GetGarbageThreshold() float32 {
+	if m != nil {
+		return m.GarbageThreshold
+	}
+	return 0
+}
+
+func (m *CollectionPolicy) GetDataCenter() string {
+	if m != nil {
+		return m.DataCenter
+	}
+	return ""
+}
+
+type Collection struct {
+	Name        string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Policy      *CollectionPolicy `protobuf:"bytes,2,opt,name=policy" json:"policy,omitempty"`
+	VolumeCount uint64            `protobuf:"varint,3,opt,name=volume_count,json=volumeCount" json:"volume_count,omitempty"`
+	Size        uint64            `protobuf:"varint,4,opt,name=size" json:"size,omitempty"`
+}
+
+func (m *Collection) Reset()                    { *m = Collection{} }
+func (m *Collection) String() string            { return proto.CompactTextString(m) }
+func (*Collection) ProtoMessage()               {}
+func (*Collection) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *Collection) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Collection) GetPolicy() *CollectionPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *Collection) GetVolumeCount() uint64 {
+	if m != nil {
+		return m.VolumeCount
+	}
+	return 0
+}
+
+func (m *Collection) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type CollectionListRequest struct {
+}
+
+func (m *CollectionListRequest) Reset()                    { *m = CollectionListRequest{} }
+func (m *CollectionListRequest) String() string            { return proto.CompactTextString(m) }
+func (*CollectionListRequest) ProtoMessage()               {}
+func (*CollectionListRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+type CollectionListResponse struct {
+	Collections []*Collection `protobuf:"bytes,1,rep,name=collections" json:"collections,omitempty"`
+}
+
+func (m *CollectionListResponse) Reset()                    { *m = CollectionListResponse{} }
+func (m *CollectionListResponse) String() string            { return proto.CompactTextString(m) }
+func (*CollectionListResponse) ProtoMessage()               {}
+func (*CollectionListResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *CollectionListResponse) GetCollections() []*Collection {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
+}
+
+type CollectionDeleteRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *CollectionDeleteRequest) Reset()                    { *m = CollectionDeleteRequest{} }
+func (m *CollectionDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*CollectionDeleteRequest) ProtoMessage()               {}
+func (*CollectionDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *CollectionDeleteRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type CollectionDeleteResponse struct {
+}
+
+func (m *CollectionDeleteResponse) Reset()                    { *m = CollectionDeleteResponse{} }
+func (m *CollectionDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*CollectionDeleteResponse) ProtoMessage()               {}
+func (*CollectionDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+type CollectionConfigureRequest struct {
+	Name         string            `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Policy       *CollectionPolicy `protobuf:"bytes,2,opt,name=policy" json:"policy,omitempty"`
+	UpdateFields []string          `protobuf:"bytes,3,rep,name=update_fields,json=updateFields" json:"update_fields,omitempty"`
+}
+
+func (m *CollectionConfigureRequest) Reset()                    { *m = CollectionConfigureRequest{} }
+func (m *CollectionConfigureRequest) String() string            { return proto.CompactTextString(m) }
+func (*CollectionConfigureRequest) ProtoMessage()               {}
+func (*CollectionConfigureRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *CollectionConfigureRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CollectionConfigureRequest) GetPolicy() *CollectionPolicy {
+	if m != nil {
+		return m.Policy
+	}
+	return nil
+}
+
+func (m *CollectionConfigureRequest) GetUpdateFields() []string {
+	if m != nil {
+		return m.UpdateFields
+	}
+	return nil
+}
+
+type CollectionConfigureResponse struct {
+}
+
+func (m *CollectionConfigureResponse) Reset()                    { *m = CollectionConfigureResponse{} }
+func (m *CollectionConfigureResponse) String() string            { return proto.CompactTextString(m) }
+func (*CollectionConfigureResponse) ProtoMessage()               {}
+func (*CollectionConfigureResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+type GetMasterConfigurationRequest struct {
+}
+
+func (m *GetMasterConfigurationRequest) Reset()                    { *m = GetMasterConfigurationRequest{} }
+func (m *GetMasterConfigurationRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetMasterConfigurationRequest) ProtoMessage()               {}
+func (*GetMasterConfigurationRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+type GetMasterConfigurationResponse struct {
+	DefaultReplication string  `protobuf:"bytes,1,opt,name=default_replication,json=defaultReplication" json:"default_replication,omitempty"`
+	VolumeSizeLimitMb  uint64  `protobuf:"varint,2,opt,name=volume_size_limit_mb,json=volumeSizeLimitMb" json:"volume_size_limit_mb,omitempty"`
+	GarbageThreshold   float32 `protobuf:"fixed32,3,opt,name=garbage_threshold,json=garbageThreshold" json:"garbage_threshold,omitempty"`
+	PulseSeconds       uint32  `protobuf:"varint,4,opt,name=pulse_seconds,json=pulseSeconds" json:"pulse_seconds,omitempty"`
+	VolumePreallocate  bool    `protobuf:"varint,5,opt,name=volume_preallocate,json=volumePreallocate" json:"volume_preallocate,omitempty"`
+}
+
+func (m *GetMasterConfigurationResponse) Reset()         { *m = GetMasterConfigurationResponse{} }
+func (m *GetMasterConfigurationResponse) String() string { return proto.CompactTextString(m) }
+func (*GetMasterConfigurationResponse) ProtoMessage()    {}
+func (*GetMasterConfigurationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{40}
+}
+
+func (m *GetMasterConfigurationResponse) GetDefaultReplication() string {
+	if m != nil {
+		return m.DefaultReplication
+	}
+	return ""
+}
+
+func (m *GetMasterConfigurationResponse) GetVolumeSizeLimitMb() uint64 {
+	if m != nil {
+		return m.VolumeSizeLimitMb
+	}
+	return 0
+}
+
+func (m *GetMasterConfigurationResponse) GetGarbageThreshold() float32 {
+	if m != nil {
+		return m.GarbageThreshold
+	}
+	return 0
+}
+
+func (m *GetMasterConfigurationResponse) GetPulseSeconds() uint32 {
+	if m != nil {
+		return m.PulseSeconds
+	}
+	return 0
+}
+
+func (m *GetMasterConfigurationResponse) GetVolumePreallocate() bool {
+	if m != nil {
+		return m.VolumePreallocate
+	}
+	return false
+}
+
+func init() {
+	proto.RegisterType((*Heartbeat)(nil), "master_pb.Heartbeat")
+	proto.RegisterType((*HeartbeatResponse)(nil), "master_pb.HeartbeatResponse")
+	proto.RegisterType((*VolumeInformationMessage)(nil), "master_pb.VolumeInformationMessage")
+	proto.RegisterType((*Empty)(nil), "master_pb.Empty")
+	proto.RegisterType((*SuperBlockExtra)(nil), "master_pb.SuperBlockExtra")
+	proto.RegisterType((*SuperBlockExtra_ErasureCoding)(nil), "master_pb.SuperBlockExtra.ErasureCoding")
+	proto.RegisterType((*ClientListenRequest)(nil), "master_pb.ClientListenRequest")
+	proto.RegisterType((*VolumeLocation)(nil), "master_pb.VolumeLocation")
+	proto.RegisterType((*SubscribeTopologyEventsRequest)(nil), "master_pb.SubscribeTopologyEventsRequest")
+	proto.RegisterType((*TopologyEvent)(nil), "master_pb.TopologyEvent")
+	proto.RegisterType((*LookupVolumeRequest)(nil), "master_pb.LookupVolumeRequest")
+	proto.RegisterType((*LookupVolumeResponse)(nil), "master_pb.LookupVolumeResponse")
+	proto.RegisterType((*LookupVolumeResponse_VolumeIdLocation)(nil), "master_pb.LookupVolumeResponse.VolumeIdLocation")
+	proto.RegisterType((*Location)(nil), "master_pb.Location")
+	proto.RegisterType((*AssignRequest)(nil), "master_pb.AssignRequest")
+	proto.RegisterType((*AssignResponse)(nil), "master_pb.AssignResponse")
+	proto.RegisterType((*DataNodeInfo)(nil), "master_pb.DataNodeInfo")
+	proto.RegisterType((*RackInfo)(nil), "master_pb.RackInfo")
+	proto.RegisterType((*DataCenterInfo)(nil), "master_pb.DataCenterInfo")
+	proto.RegisterType((*TopologyInfo)(nil), "master_pb.TopologyInfo")
+	proto.RegisterType((*VolumeListRequest)(nil), "master_pb.VolumeListRequest")
+	proto.RegisterType((*VolumeListResponse)(nil), "master_pb.VolumeListResponse")
+	proto.RegisterType((*VolumeGrowRequest)(nil), "master_pb.VolumeGrowRequest")
+	proto.RegisterType((*VolumeGrowResponse)(nil), "master_pb.VolumeGrowResponse")
+	proto.RegisterType((*VacuumRequest)(nil), "master_pb.VacuumRequest")
+	proto.RegisterType((*VacuumResponse)(nil), "master_pb.VacuumResponse")
+	proto.RegisterType((*VacuumRun)(nil), "master_pb.VacuumRun")
+	proto.RegisterType((*VacuumPauseRequest)(nil), "master_pb.VacuumPauseRequest")
+	proto.RegisterType((*VacuumPauseResponse)(nil), "master_pb.VacuumPauseResponse")
+	proto.RegisterType((*VacuumResumeRequest)(nil), "master_pb.VacuumResumeRequest")
+	proto.RegisterType((*VacuumResumeResponse)(nil), "master_pb.VacuumResumeResponse")
+	proto.RegisterType((*VacuumStatusRequest)(nil), "master_pb.VacuumStatusRequest")
+	proto.RegisterType((*VacuumStatusResponse)(nil), "master_pb.VacuumStatusResponse")
+	proto.RegisterType((*CollectionPolicy)(nil), "master_pb.CollectionPolicy")
+	proto.RegisterType((*Collection)(nil), "master_pb.Collection")
+	proto.RegisterType((*CollectionListRequest)(nil), "master_pb.CollectionListRequest")
+	proto.RegisterType((*CollectionListResponse)(nil), "master_pb.CollectionListResponse")
+	proto.RegisterType((*CollectionDeleteRequest)(nil), "master_pb.CollectionDeleteRequest")
+	proto.RegisterType((*CollectionDeleteResponse)(nil), "master_pb.CollectionDeleteResponse")
+	proto.RegisterType((*CollectionConfigureRequest)(nil), "master_pb.CollectionConfigureRequest")
+	proto.RegisterType((*CollectionConfigureResponse)(nil), "master_pb.CollectionConfigureResponse")
+	proto.RegisterType((*GetMasterConfigurationRequest)(nil), "master_pb.GetMasterConfigurationRequest")
+	proto.RegisterType((*GetMasterConfigurationResponse)(nil), "master_pb.GetMasterConfigurationResponse")
+	proto.RegisterEnum("master_pb.TopologyEvent_EventType", TopologyEvent_EventType_name, TopologyEvent_EventType_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Seaweed service
+
+type SeaweedClient interface {
+	SendHeartbeat(ctx context.Context, opts ...grpc.CallOption) (Seaweed_SendHeartbeatClient, error)
+	KeepConnected(ctx context.Context, opts ...grpc.CallOption) (Seaweed_KeepConnectedClient, error)
+	SubscribeTopologyEvents(ctx context.Context, in *SubscribeTopologyEventsRequest, opts ...grpc.CallOption) (Seaweed_SubscribeTopologyEventsClient, error)
+	LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error)
+	Assign(ctx context.Context, in *AssignRequest, opts ...grpc.CallOption) (*AssignResponse, error)
+	VolumeList(ctx context.Context, in *VolumeListRequest, opts ...grpc.CallOption) (*VolumeListResponse, error)
+	VolumeGrow(ctx context.Context, in *VolumeGrowRequest, opts ...grpc.CallOption) (*VolumeGrowResponse, error)
+	Vacuum(ctx context.Context, in *VacuumRequest, opts ...grpc.CallOption) (*VacuumResponse, error)
+	VacuumPause(ctx context.Context, in *VacuumPauseRequest, opts ...grpc.CallOption) (*VacuumPauseResponse, error)
+	VacuumResume(ctx context.Context, in *VacuumResumeRequest, opts ...grpc.CallOption) (*VacuumResumeResponse, error)
+	VacuumStatus(ctx context.Context, in *VacuumStatusRequest, opts ...grpc.CallOption) (*VacuumStatusResponse, error)
+	CollectionList(ctx context.Context, in *CollectionListRequest, opts ...grpc.CallOption) (*CollectionListResponse, error)
+	CollectionDelete(ctx context.Context, in *CollectionDeleteRequest, opts ...grpc.CallOption) (*CollectionDeleteResponse, error)
+	CollectionConfigure(ctx context.Context, in *CollectionConfigureRequest, opts ...grpc.CallOption) (*CollectionConfigureResponse, error)
+	GetMasterConfiguration(ctx context.Context, in *GetMasterConfigurationRequest, opts ...grpc.CallOption) (*GetMasterConfigurationResponse, error)
+}
+
+type seaweedClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewSeaweedClient(cc *grpc.ClientConn) SeaweedClient {
+	return &seaweedClient{cc}
+}
+
+func (c *seaweedClient) SendHeartbeat(ctx context.Context, opts ...grpc.CallOption) (Seaweed_SendHeartbeatClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Seaweed_serviceDesc.Streams[0], c.cc, "/master_pb.Seaweed/SendHeartbeat", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &seaweedSendHeartbeatClient{stream}
+	return x, nil
+}
+
+type Seaweed_SendHeartbeatClient interface {
+	Send(*Heartbeat) error
+	Recv() (*HeartbeatResponse, error)
+	grpc.ClientStream
+}
+
+type seaweedSendHeartbeatClient struct {
+	grpc.ClientStream
+}
+
+func (x *seaweedSendHeartbeatClient) Send(m *Heartbeat) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *seaweedSendHeartbeatClient) Recv() (*HeartbeatResponse, error) {
+	m := new(HeartbeatResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *seaweedClient) KeepConnected(ctx context.Context, opts ...grpc.CallOption) (Seaweed_KeepConnectedClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Seaweed_serviceDesc.Streams[1], c.cc, "/master_pb.Seaweed/KeepConnected", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &seaweedKeepConnectedClient{stream}
+	return x, nil
+}
+
+type Seaweed_KeepConnectedClient interface {
+	Send(*ClientListenRequest) error
+	Recv() (*VolumeLocation, error)
+	grpc.ClientStream
+}
+
+type seaweedKeepConnectedClient struct {
+	grpc.ClientStream
+}
+
+func (x *seaweedKeepConnectedClient) Send(m *ClientListenRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *seaweedKeepConnectedClient) Recv() (*VolumeLocation, error) {
+	m := new(VolumeLocation)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *seaweedClient) SubscribeTopologyEvents(ctx context.Context, in *SubscribeTopologyEventsRequest, opts ...grpc.CallOption) (Seaweed_SubscribeTopologyEventsClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Seaweed_serviceDesc.Streams[2], c.cc, "/master_pb.Seaweed/SubscribeTopologyEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &seaweedSubscribeTopologyEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Seaweed_SubscribeTopologyEventsClient interface {
+	Recv() (*TopologyEvent, error)
+	grpc.ClientStream
+}
+
+type seaweedSubscribeTopologyEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *seaweedSubscribeTopologyEventsClient) Recv() (*TopologyEvent, error) {
+	m := new(TopologyEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *seaweedClient) LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error) {
+	out := new(LookupVolumeResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/LookupVolume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) Assign(ctx context.Context, in *AssignRequest, opts ...grpc.CallOption) (*AssignResponse, error) {
+	out := new(AssignResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/Assign", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) VolumeList(ctx context.Context, in *VolumeListRequest, opts ...grpc.CallOption) (*VolumeListResponse, error) {
+	out := new(VolumeListResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/VolumeList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) VolumeGrow(ctx context.Context, in *VolumeGrowRequest, opts ...grpc.CallOption) (*VolumeGrowResponse, error) {
+	out := new(VolumeGrowResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/VolumeGrow", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) Vacuum(ctx context.Context, in *VacuumRequest, opts ...grpc.CallOption) (*VacuumResponse, error) {
+	out := new(VacuumResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/Vacuum", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) VacuumPause(ctx context.Context, in *VacuumPauseRequest, opts ...grpc.CallOption) (*VacuumPauseResponse, error) {
+	out := new(VacuumPauseResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/VacuumPause", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) VacuumResume(ctx context.Context, in *VacuumResumeRequest, opts ...grpc.CallOption) (*VacuumResumeResponse, error) {
+	out := new(VacuumResumeResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/VacuumResume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) VacuumStatus(ctx context.Context, in *VacuumStatusRequest, opts ...grpc.CallOption) (*VacuumStatusResponse, error) {
+	out := new(VacuumStatusResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/VacuumStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) CollectionList(ctx context.Context, in *CollectionListRequest, opts ...grpc.CallOption) (*CollectionListResponse, error) {
+	out := new(CollectionListResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/CollectionList", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) CollectionDelete(ctx context.Context, in *CollectionDeleteRequest, opts ...grpc.CallOption) (*CollectionDeleteResponse, error) {
+	out := new(CollectionDeleteResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/CollectionDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) CollectionConfigure(ctx context.Context, in *CollectionConfigureRequest, opts ...grpc.CallOption) (*CollectionConfigureResponse, error) {
+	out := new(CollectionConfigureResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/CollectionConfigure", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seaweedClient) GetMasterConfiguration(ctx context.Context, in *GetMasterConfigurationRequest, opts ...grpc.CallOption) (*GetMasterConfigurationResponse, error) {
+	out := new(GetMasterConfigurationResponse)
+	err := grpc.Invoke(ctx, "/master_pb.Seaweed/GetMasterConfiguration", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Seaweed service
+
+type SeaweedServer interface {
+	SendHeartbeat(Seaweed_SendHeartbeatServer) error
+	KeepConnected(Seaweed_KeepConnectedServer) error
+	SubscribeTopologyEvents(*SubscribeTopologyEventsRequest, Seaweed_SubscribeTopologyEventsServer) error
+	LookupVolume(context.Context, *LookupVolumeRequest) (*LookupVolumeResponse, error)
+	Assign(context.Context, *AssignRequest) (*AssignResponse, error)
+	VolumeList(context.Context, *VolumeListRequest) (*VolumeListResponse, error)
+	VolumeGrow(context.Context, *VolumeGrowRequest) (*VolumeGrowResponse, error)
+	Vacuum(context.Context, *VacuumRequest) (*VacuumResponse, error)
+	VacuumPause(context.Context, *VacuumPauseRequest) (*VacuumPauseResponse, error)
+	VacuumResume(context.Context, *VacuumResumeRequest) (*VacuumResumeResponse, error)
+	VacuumStatus(context.Context, *VacuumStatusRequest) (*VacuumStatusResponse, error)
+	CollectionList(context.Context, *CollectionListRequest) (*CollectionListResponse, error)
+	CollectionDelete(context.Context, *CollectionDeleteRequest) (*CollectionDeleteResponse, error)
+	CollectionConfigure(context.Context, *CollectionConfigureRequest) (*CollectionConfigureResponse, error)
+	GetMasterConfiguration(context.Context, *GetMasterConfigurationRequest) (*GetMasterConfigurationResponse, error)
+}
+
+func RegisterSeaweedServer(s *grpc.Server, srv SeaweedServer) {
+	s.RegisterService(&_Seaweed_serviceDesc, srv)
+}
+
+func _Seaweed_SendHeartbeat_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SeaweedServer).SendHeartbeat(&seaweedSendHeartbeatServer{stream})
+}
+
+type Seaweed_SendHeartbeatServer interface {
+	Send(*HeartbeatResponse) error
+	Recv() (*Heartbeat, error)
+	grpc.ServerStream
+}
+
+type seaweedSendHeartbeatServer struct {
+	grpc.ServerStream
+}
+
+func (x *seaweedSendHeartbeatServer) Send(m *HeartbeatResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *seaweedSendHeartbeatServer) Recv() (*Heartbeat, error) {
+	m := new(Heartbeat)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Seaweed_KeepConnected_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SeaweedServer).KeepConnected(&seaweedKeepConnectedServer{stream})
+}
+
+type Seaweed_KeepConnectedServer interface {
+	Send(*VolumeLocation) error
+	Recv() (*ClientListenRequest, error)
+	grpc.ServerStream
+}
+
+type seaweedKeepConnectedServer struct {
 	grpc.ServerStream
 }
 
@@ -772,6 +1938,27 @@ func (x *seaweedKeepConnectedServer) Recv() (*ClientListenRequest, error) {
 	return m, nil
 }
 
+func _Seaweed_SubscribeTopologyEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeTopologyEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SeaweedServer).SubscribeTopologyEvents(m, &seaweedSubscribeTopologyEventsServer{stream})
+}
+
+type Seaweed_SubscribeTopologyEventsServer interface {
+	Send(*TopologyEvent) error
+	grpc.ServerStream
+}
+
+type seaweedSubscribeTopologyEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *seaweedSubscribeTopologyEventsServer) Send(m *TopologyEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Seaweed_LookupVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LookupVolumeRequest)
 	if err := dec(in); err != nil {
@@ -808,6 +1995,186 @@ func _Seaweed_Assign_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Seaweed_VolumeList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).VolumeList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/VolumeList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).VolumeList(ctx, req.(*VolumeListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_VolumeGrow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeGrowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).VolumeGrow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/VolumeGrow",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).VolumeGrow(ctx, req.(*VolumeGrowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_Vacuum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VacuumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).Vacuum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/Vacuum",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).Vacuum(ctx, req.(*VacuumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_VacuumPause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VacuumPauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).VacuumPause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/VacuumPause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).VacuumPause(ctx, req.(*VacuumPauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_VacuumResume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VacuumResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).VacuumResume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/VacuumResume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).VacuumResume(ctx, req.(*VacuumResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_VacuumStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VacuumStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).VacuumStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/VacuumStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).VacuumStatus(ctx, req.(*VacuumStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_CollectionList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).CollectionList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/CollectionList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).CollectionList(ctx, req.(*CollectionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_CollectionDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).CollectionDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/CollectionDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).CollectionDelete(ctx, req.(*CollectionDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_CollectionConfigure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).CollectionConfigure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/CollectionConfigure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).CollectionConfigure(ctx, req.(*CollectionConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seaweed_GetMasterConfiguration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMasterConfigurationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeaweedServer).GetMasterConfiguration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/master_pb.Seaweed/GetMasterConfiguration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeaweedServer).GetMasterConfiguration(ctx, req.(*GetMasterConfigurationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Seaweed_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "master_pb.Seaweed",
 	HandlerType: (*SeaweedServer)(nil),
@@ -820,6 +2187,46 @@ var _Seaweed_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Assign",
 			Handler:    _Seaweed_Assign_Handler,
 		},
+		{
+			MethodName: "VolumeList",
+			Handler:    _Seaweed_VolumeList_Handler,
+		},
+		{
+			MethodName: "VolumeGrow",
+			Handler:    _Seaweed_VolumeGrow_Handler,
+		},
+		{
+			MethodName: "Vacuum",
+			Handler:    _Seaweed_Vacuum_Handler,
+		},
+		{
+			MethodName: "VacuumPause",
+			Handler:    _Seaweed_VacuumPause_Handler,
+		},
+		{
+			MethodName: "VacuumResume",
+			Handler:    _Seaweed_VacuumResume_Handler,
+		},
+		{
+			MethodName: "VacuumStatus",
+			Handler:    _Seaweed_VacuumStatus_Handler,
+		},
+		{
+			MethodName: "CollectionList",
+			Handler:    _Seaweed_CollectionList_Handler,
+		},
+		{
+			MethodName: "CollectionDelete",
+			Handler:    _Seaweed_CollectionDelete_Handler,
+		},
+		{
+			MethodName: "CollectionConfigure",
+			Handler:    _Seaweed_CollectionConfigure_Handler,
+		},
+		{
+			MethodName: "GetMasterConfiguration",
+			Handler:    _Seaweed_GetMasterConfiguration_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -834,6 +2241,11 @@ var _Seaweed_serviceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "SubscribeTopologyEvents",
+			Handler:       _Seaweed_SubscribeTopologyEvents_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "master.proto",
 }
@@ -841,66 +2253,150 @@ var _Seaweed_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("master.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 971 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x56, 0xcd, 0x6e, 0x23, 0x45,
-	0x17, 0x4d, 0xb7, 0x7f, 0xfb, 0x3a, 0xce, 0x38, 0x95, 0xe8, 0x53, 0x8f, 0x67, 0xbe, 0x19, 0xd3,
-	0x6c, 0x8c, 0x40, 0xd1, 0x10, 0x96, 0x08, 0x21, 0xc6, 0x0a, 0x22, 0x4a, 0x60, 0x42, 0x07, 0x66,
-	0xc1, 0xa6, 0x29, 0x77, 0xdf, 0x44, 0xa5, 0xf4, 0x1f, 0x55, 0xe5, 0xc4, 0x9e, 0x0d, 0xef, 0xc5,
-	0x82, 0x15, 0x3b, 0x1e, 0x85, 0x1d, 0x4f, 0x80, 0xea, 0xa7, 0xdb, 0x6d, 0x27, 0x61, 0x24, 0x76,
-	0x55, 0xa7, 0xef, 0xad, 0x3a, 0x75, 0xce, 0xbd, 0x55, 0x0d, 0xbb, 0x19, 0x15, 0x12, 0xf9, 0x51,
-	0xc9, 0x0b, 0x59, 0x10, 0xcf, 0xcc, 0xa2, 0x72, 0x1e, 0xfc, 0xe5, 0x82, 0xf7, 0x0d, 0x52, 0x2e,
-	0xe7, 0x48, 0x25, 0xd9, 0x03, 0x97, 0x95, 0xbe, 0x33, 0x71, 0xa6, 0x5e, 0xe8, 0xb2, 0x92, 0x10,
-	0x68, 0x97, 0x05, 0x97, 0xbe, 0x3b, 0x71, 0xa6, 0xc3, 0x50, 0x8f, 0xc9, 0xff, 0x01, 0xca, 0xc5,
-	0x3c, 0x65, 0x71, 0xb4, 0xe0, 0xa9, 0xdf, 0xd2, 0xb1, 0x9e, 0x41, 0x7e, 0xe4, 0x29, 0x99, 0xc2,
-	0x28, 0xa3, 0xcb, 0xe8, 0xb6, 0x48, 0x17, 0x19, 0x46, 0x71, 0xb1, 0xc8, 0xa5, 0xdf, 0xd6, 0xe9,
-	0x7b, 0x19, 0x5d, 0xbe, 0xd5, 0xf0, 0x4c, 0xa1, 0x64, 0xa2, 0x58, 0x2d, 0xa3, 0x2b, 0x96, 0x62,
-	0x74, 0x83, 0x2b, 0xbf, 0x33, 0x71, 0xa6, 0xed, 0x10, 0x32, 0xba, 0xfc, 0x9a, 0xa5, 0x78, 0x86,
-	0x2b, 0xf2, 0x12, 0x06, 0x09, 0x95, 0x34, 0x8a, 0x31, 0x97, 0xc8, 0xfd, 0xae, 0xde, 0x0b, 0x14,
-	0x34, 0xd3, 0x88, 0xe2, 0xc7, 0x69, 0x7c, 0xe3, 0xf7, 0xf4, 0x17, 0x3d, 0x56, 0xfc, 0x68, 0x92,
-	0xb1, 0x3c, 0xd2, 0xcc, 0xfb, 0x7a, 0x6b, 0x4f, 0x23, 0x17, 0x8a, 0xfe, 0x17, 0xd0, 0x33, 0xdc,
-	0x84, 0xef, 0x4d, 0x5a, 0xd3, 0xc1, 0xf1, 0x87, 0x47, 0xb5, 0x1a, 0x47, 0x86, 0xde, 0x69, 0x7e,
-	0x55, 0xf0, 0x8c, 0x4a, 0x56, 0xe4, 0xdf, 0xa2, 0x10, 0xf4, 0x1a, 0xc3, 0x2a, 0x87, 0x3c, 0x85,
-	0x7e, 0x8e, 0x77, 0xd1, 0x2d, 0x4b, 0x84, 0x0f, 0x93, 0xd6, 0x74, 0x18, 0xf6, 0x72, 0xbc, 0x7b,
-	0xcb, 0x12, 0x41, 0x3e, 0x80, 0xdd, 0x04, 0x53, 0x94, 0x98, 0x98, 0xcf, 0x03, 0xfd, 0x79, 0x60,
-	0x31, 0x15, 0x12, 0x08, 0xd8, 0xaf, 0xc5, 0x0e, 0x51, 0x94, 0x45, 0x2e, 0x90, 0x4c, 0xe1, 0x89,
-	0x59, 0xfd, 0x92, 0xbd, 0xc3, 0x73, 0x96, 0x31, 0xa9, 0x1d, 0x68, 0x87, 0xdb, 0x30, 0x79, 0x0e,
-	0x9e, 0xc0, 0x98, 0xa3, 0x3c, 0xc3, 0x95, 0xf6, 0xc4, 0x0b, 0xd7, 0x00, 0xf9, 0x1f, 0x74, 0x53,
-	0xa4, 0x09, 0x72, 0x6b, 0x8a, 0x9d, 0x05, 0x7f, 0xb8, 0xe0, 0x3f, 0x76, 0x30, 0xed, 0x78, 0xa2,
-	0xf7, 0x1b, 0x86, 0x2e, 0x4b, 0x94, 0xa2, 0x82, 0xbd, 0x43, 0xbd, 0x7a, 0x3b, 0xd4, 0x63, 0xf2,
-	0x02, 0x20, 0x2e, 0xd2, 0x14, 0x63, 0x95, 0x68, 0x17, 0x6f, 0x20, 0x4a, 0x71, 0x6d, 0xe2, 0xda,
-	0xec, 0x76, 0xe8, 0x29, 0xc4, 0xf8, 0x5c, 0xeb, 0x62, 0x03, 0x8c, 0xcf, 0x56, 0x17, 0x13, 0xf2,
-	0x09, 0x90, 0x4a, 0xba, 0xf9, 0xaa, 0x0e, 0xec, 0xea, 0xc0, 0x91, 0xfd, 0xf2, 0x7a, 0x55, 0x45,
-	0x3f, 0x03, 0x8f, 0x23, 0x4d, 0xa2, 0x22, 0x4f, 0x57, 0xda, 0xfa, 0x7e, 0xd8, 0x57, 0xc0, 0x9b,
-	0x3c, 0x5d, 0x91, 0x8f, 0x61, 0x9f, 0x63, 0x99, 0xb2, 0x98, 0x46, 0x65, 0x4a, 0x63, 0xcc, 0x30,
-	0xaf, 0xaa, 0x60, 0x64, 0x3f, 0x5c, 0x54, 0x38, 0xf1, 0xa1, 0x77, 0x8b, 0x5c, 0xa8, 0x63, 0x79,
-	0x3a, 0xa4, 0x9a, 0x92, 0x11, 0xb4, 0xa4, 0x4c, 0x7d, 0xd0, 0xa8, 0x1a, 0x06, 0x3d, 0xe8, 0x9c,
-	0x64, 0xa5, 0x5c, 0x05, 0xbf, 0x3b, 0xf0, 0xe4, 0x72, 0x51, 0x22, 0x7f, 0x9d, 0x16, 0xf1, 0xcd,
-	0xc9, 0x52, 0x72, 0x4a, 0xde, 0xc0, 0x1e, 0x72, 0x2a, 0x16, 0x5c, 0x71, 0x4f, 0x58, 0x7e, 0xad,
-	0x25, 0x1d, 0x1c, 0x4f, 0x1b, 0xc5, 0xb5, 0x95, 0x73, 0x74, 0x62, 0x12, 0x66, 0x3a, 0x3e, 0x1c,
-	0x62, 0x73, 0x3a, 0xfe, 0x09, 0x86, 0x1b, 0xdf, 0x95, 0x31, 0xaa, 0xf0, 0xad, 0x55, 0x7a, 0xac,
-	0x1c, 0x2f, 0x29, 0x67, 0x72, 0x65, 0x1b, 0xd4, 0xce, 0x94, 0x21, 0xb6, 0xff, 0x54, 0x1d, 0xb6,
-	0x74, 0x1d, 0x7a, 0x06, 0x39, 0x4d, 0x44, 0xf0, 0x11, 0x1c, 0xcc, 0x52, 0x86, 0xb9, 0x3c, 0x67,
-	0x42, 0x62, 0x1e, 0xe2, 0x2f, 0x0b, 0x14, 0x52, 0xed, 0x90, 0xd3, 0x0c, 0x6d, 0xfb, 0xeb, 0x71,
-	0xf0, 0x2b, 0xec, 0x99, 0xd2, 0x39, 0x2f, 0x62, 0x5d, 0x37, 0x4a, 0x18, 0xd5, 0xf7, 0x26, 0x48,
-	0x0d, 0xb7, 0x2e, 0x04, 0x77, 0xfb, 0x42, 0x68, 0x76, 0x4c, 0xeb, 0xdf, 0x3b, 0xa6, 0x7d, 0xbf,
-	0x63, 0x7e, 0x80, 0x83, 0xf3, 0xa2, 0xb8, 0x59, 0x94, 0x86, 0x46, 0xc5, 0x75, 0xf3, 0x84, 0xce,
-	0xa4, 0xa5, 0xf6, 0xac, 0x4f, 0xb8, 0x55, 0xb1, 0xee, 0x76, 0xc5, 0x06, 0x7f, 0x3b, 0x70, 0xb8,
-	0xb9, 0xac, 0xed, 0xc5, 0x9f, 0xe1, 0xa0, 0x5e, 0x37, 0x4a, 0xed, 0x99, 0xcd, 0x06, 0x83, 0xe3,
-	0x57, 0x0d, 0x33, 0x1f, 0xca, 0xae, 0xae, 0x8f, 0xa4, 0x12, 0x2b, 0xdc, 0xbf, 0xdd, 0x42, 0xc4,
-	0x78, 0x09, 0xa3, 0xed, 0x30, 0x55, 0xd0, 0xf5, 0xae, 0x56, 0xd9, 0x7e, 0x95, 0x49, 0x3e, 0x05,
-	0x6f, 0x4d, 0xc4, 0xd5, 0x44, 0x0e, 0x36, 0x88, 0xd8, 0xbd, 0xd6, 0x51, 0xe4, 0x10, 0x3a, 0xc8,
-	0x79, 0x51, 0x5d, 0x04, 0x66, 0x12, 0x7c, 0x0e, 0xfd, 0xff, 0xec, 0x62, 0xf0, 0xa7, 0x03, 0xc3,
-	0xaf, 0x84, 0x60, 0xd7, 0x75, 0xb9, 0x1c, 0x42, 0xc7, 0xb4, 0xa9, 0xb9, 0xac, 0xcc, 0x84, 0x4c,
-	0x60, 0x60, 0xbb, 0xac, 0x21, 0x7d, 0x13, 0x7a, 0xef, 0x6d, 0x62, 0x3b, 0xaf, 0x6d, 0xa8, 0x49,
-	0x99, 0x6e, 0x3f, 0x03, 0x9d, 0x47, 0x9f, 0x81, 0x6e, 0xe3, 0x19, 0x78, 0x06, 0x9e, 0x4e, 0xca,
-	0x8b, 0x04, 0xed, 0xfb, 0xd0, 0x57, 0xc0, 0x77, 0x45, 0xa2, 0xcb, 0xba, 0x3a, 0x8c, 0x35, 0x7e,
-	0x04, 0xad, 0xab, 0x5a, 0x7c, 0x35, 0xac, 0x24, 0x72, 0x1f, 0x93, 0xe8, 0xde, 0xcb, 0x57, 0x0b,
-	0xd2, 0x6e, 0x0a, 0x52, 0x7b, 0xd1, 0x69, 0x78, 0x71, 0xfc, 0x9b, 0x0b, 0xbd, 0x4b, 0xa4, 0x77,
-	0x88, 0x09, 0x39, 0x85, 0xe1, 0x25, 0xe6, 0xc9, 0xfa, 0x15, 0x3e, 0x6c, 0xd8, 0x5b, 0xa3, 0xe3,
-	0xe7, 0x0f, 0xa1, 0x15, 0xff, 0x60, 0x67, 0xea, 0xbc, 0x72, 0xc8, 0x05, 0x0c, 0xcf, 0x10, 0xcb,
-	0x59, 0x91, 0xe7, 0x18, 0x4b, 0x4c, 0xc8, 0x8b, 0x46, 0xd2, 0x03, 0x3d, 0x3f, 0x7e, 0x7a, 0xef,
-	0xf1, 0xab, 0x4a, 0xc4, 0xae, 0xf8, 0x3d, 0xec, 0x36, 0x4b, 0x7d, 0x63, 0xc1, 0x07, 0x1a, 0x73,
-	0xfc, 0xf2, 0x3d, 0x3d, 0x12, 0xec, 0x90, 0x2f, 0xa1, 0x6b, 0xc4, 0x27, 0x7e, 0x23, 0x78, 0xa3,
-	0xb8, 0x36, 0x78, 0x6d, 0x3a, 0x15, 0xec, 0xcc, 0xbb, 0xfa, 0x2f, 0xe6, 0xb3, 0x7f, 0x02, 0x00,
-	0x00, 0xff, 0xff, 0xbc, 0xef, 0x85, 0x4d, 0xd5, 0x08, 0x00, 0x00,
+	// 2318 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x59, 0xcf, 0x6f, 0xdb, 0xc8,
+	0xf5, 0x8f, 0x7e, 0x59, 0xe2, 0x93, 0x65, 0xcb, 0x63, 0x27, 0x51, 0xe4, 0xd8, 0x71, 0x18, 0xec,
+	0x7e, 0x9d, 0xdd, 0xef, 0x7a, 0x53, 0x07, 0x68, 0x0b, 0xb4, 0xc5, 0xc2, 0x6b, 0xc9, 0x89, 0x1b,
+	0x45, 0x76, 0xc6, 0x76, 0x82, 0x16, 0x2d, 0x58, 0x4a, 0x1c, 0x3b, 0x84, 0x29, 0x52, 0xe5, 0x90,
+	0xb6, 0xb5, 0x97, 0x16, 0x68, 0x4f, 0x45, 0xd1, 0xbf, 0xa3, 0x40, 0xff, 0x87, 0xa2, 0x40, 0x7b,
+	0xeb, 0x9f, 0xd0, 0x73, 0x4f, 0x3d, 0xb5, 0xf7, 0x02, 0xc5, 0xfc, 0x20, 0x39, 0xa4, 0x28, 0xdb,
+	0x9b, 0x5b, 0x2e, 0x02, 0xe7, 0xf3, 0x1e, 0xdf, 0x3c, 0xbe, 0xdf, 0x33, 0x82, 0xf9, 0x91, 0x49,
+	0x03, 0xe2, 0x6f, 0x8d, 0x7d, 0x2f, 0xf0, 0x90, 0x26, 0x56, 0xc6, 0x78, 0xa0, 0xff, 0xab, 0x08,
+	0xda, 0x4b, 0x62, 0xfa, 0xc1, 0x80, 0x98, 0x01, 0x5a, 0x80, 0xa2, 0x3d, 0x6e, 0x15, 0x36, 0x0a,
+	0x9b, 0x1a, 0x2e, 0xda, 0x63, 0x84, 0xa0, 0x3c, 0xf6, 0xfc, 0xa0, 0x55, 0xdc, 0x28, 0x6c, 0x36,
+	0x30, 0x7f, 0x46, 0x6b, 0x00, 0xe3, 0x70, 0xe0, 0xd8, 0x43, 0x23, 0xf4, 0x9d, 0x56, 0x89, 0xf3,
+	0x6a, 0x02, 0x39, 0xf1, 0x1d, 0xb4, 0x09, 0xcd, 0x91, 0x79, 0x65, 0x5c, 0x78, 0x4e, 0x38, 0x22,
+	0xc6, 0xd0, 0x0b, 0xdd, 0xa0, 0x55, 0xe6, 0xaf, 0x2f, 0x8c, 0xcc, 0xab, 0xb7, 0x1c, 0xde, 0x65,
+	0x28, 0xda, 0x60, 0x5a, 0x5d, 0x19, 0xa7, 0xb6, 0x43, 0x8c, 0x73, 0x32, 0x69, 0x55, 0x36, 0x0a,
+	0x9b, 0x65, 0x0c, 0x23, 0xf3, 0x6a, 0xcf, 0x76, 0xc8, 0x2b, 0x32, 0x41, 0x8f, 0xa0, 0x6e, 0x99,
+	0x81, 0x69, 0x0c, 0x89, 0x1b, 0x10, 0xbf, 0x35, 0xc7, 0xf7, 0x02, 0x06, 0xed, 0x72, 0x84, 0xe9,
+	0xe7, 0x9b, 0xc3, 0xf3, 0x56, 0x95, 0x53, 0xf8, 0x33, 0xd3, 0xcf, 0xb4, 0x46, 0xb6, 0x6b, 0x70,
+	0xcd, 0x6b, 0x7c, 0x6b, 0x8d, 0x23, 0x87, 0x4c, 0xfd, 0x1f, 0x41, 0x55, 0xe8, 0x46, 0x5b, 0xda,
+	0x46, 0x69, 0xb3, 0xbe, 0xfd, 0x64, 0x2b, 0xb6, 0xc6, 0x96, 0x50, 0x6f, 0xdf, 0x3d, 0xf5, 0xfc,
+	0x91, 0x19, 0xd8, 0x9e, 0xfb, 0x9a, 0x50, 0x6a, 0x9e, 0x11, 0x1c, 0xbd, 0x83, 0x1e, 0x40, 0xcd,
+	0x25, 0x97, 0xc6, 0x85, 0x6d, 0xd1, 0x16, 0x6c, 0x94, 0x36, 0x1b, 0xb8, 0xea, 0x92, 0xcb, 0xb7,
+	0xb6, 0x45, 0xd1, 0x63, 0x98, 0xb7, 0x88, 0x43, 0x02, 0x62, 0x09, 0x72, 0x9d, 0x93, 0xeb, 0x12,
+	0x63, 0x2c, 0x3a, 0x85, 0xa5, 0xd8, 0xd8, 0x98, 0xd0, 0xb1, 0xe7, 0x52, 0x82, 0x36, 0x61, 0x51,
+	0x48, 0x3f, 0xb2, 0xbf, 0x21, 0x3d, 0x7b, 0x64, 0x07, 0xdc, 0x03, 0x65, 0x9c, 0x85, 0xd1, 0x43,
+	0xd0, 0x28, 0x19, 0xfa, 0x24, 0x78, 0x45, 0x26, 0xdc, 0x27, 0x1a, 0x4e, 0x00, 0x74, 0x0f, 0xe6,
+	0x1c, 0x62, 0x5a, 0xc4, 0x97, 0x4e, 0x91, 0x2b, 0xfd, 0x6f, 0x45, 0x68, 0xcd, 0xfa, 0x30, 0xee,
+	0x71, 0x8b, 0xef, 0xd7, 0xc0, 0x45, 0xdb, 0x62, 0x16, 0xa5, 0xf6, 0x37, 0x84, 0x4b, 0x2f, 0x63,
+	0xfe, 0x8c, 0xd6, 0x01, 0x86, 0x9e, 0xe3, 0x90, 0x21, 0x7b, 0x51, 0x0a, 0x57, 0x10, 0x66, 0x71,
+	0xee, 0xc4, 0xc4, 0xd9, 0x65, 0xac, 0x31, 0x44, 0xf8, 0x39, 0xb6, 0x8b, 0x64, 0x10, 0x7e, 0x96,
+	0x76, 0x11, 0x2c, 0xff, 0x0f, 0x28, 0x32, 0xdd, 0x60, 0x12, 0x33, 0xce, 0x71, 0xc6, 0xa6, 0xa4,
+	0x7c, 0x3d, 0x89, 0xb8, 0x57, 0x41, 0xf3, 0x89, 0x69, 0x19, 0x9e, 0xeb, 0x4c, 0xb8, 0xeb, 0x6b,
+	0xb8, 0xc6, 0x80, 0x03, 0xd7, 0x99, 0xa0, 0xcf, 0x61, 0xc9, 0x27, 0x63, 0xc7, 0x1e, 0x9a, 0xc6,
+	0xd8, 0x31, 0x87, 0x64, 0x44, 0xdc, 0x28, 0x0a, 0x9a, 0x92, 0x70, 0x18, 0xe1, 0xa8, 0x05, 0xd5,
+	0x0b, 0xe2, 0x53, 0xf6, 0x59, 0x1a, 0x67, 0x89, 0x96, 0xa8, 0x09, 0xa5, 0x20, 0x70, 0x5a, 0xc0,
+	0x51, 0xf6, 0xa8, 0x57, 0xa1, 0xd2, 0x1d, 0x8d, 0x83, 0x89, 0xfe, 0xe7, 0x02, 0x2c, 0x1e, 0x85,
+	0x63, 0xe2, 0x7f, 0xed, 0x78, 0xc3, 0xf3, 0xee, 0x55, 0xe0, 0x9b, 0xe8, 0x00, 0x16, 0x88, 0x6f,
+	0xd2, 0xd0, 0x67, 0xba, 0x5b, 0xb6, 0x7b, 0xc6, 0x4d, 0x5a, 0xdf, 0xde, 0x54, 0x82, 0x2b, 0xf3,
+	0xce, 0x56, 0x57, 0xbc, 0xb0, 0xcb, 0xf9, 0x71, 0x83, 0xa8, 0xcb, 0xf6, 0x4f, 0xa1, 0x91, 0xa2,
+	0x33, 0xc7, 0xb0, 0xc0, 0x97, 0xae, 0xe2, 0xcf, 0xcc, 0xe3, 0x63, 0xd3, 0xb7, 0x83, 0x89, 0x4c,
+	0x50, 0xb9, 0x62, 0x0e, 0x91, 0xf9, 0xc7, 0xe2, 0xb0, 0xc4, 0xe3, 0x50, 0x13, 0xc8, 0xbe, 0x45,
+	0xf5, 0xa7, 0xb0, 0xbc, 0xeb, 0xd8, 0xc4, 0x0d, 0x7a, 0x36, 0x0d, 0x88, 0x8b, 0xc9, 0x2f, 0x43,
+	0x42, 0x03, 0xb6, 0x83, 0x6b, 0x8e, 0x88, 0x4c, 0x7f, 0xfe, 0xac, 0xff, 0x0a, 0x16, 0x44, 0xe8,
+	0xf4, 0xbc, 0xa1, 0x19, 0x48, 0xc3, 0xb0, 0xbc, 0x17, 0x4c, 0xec, 0x31, 0x53, 0x10, 0x8a, 0xd9,
+	0x82, 0xa0, 0x66, 0x4c, 0xe9, 0xfa, 0x8c, 0x29, 0x4f, 0x67, 0xcc, 0x04, 0xd6, 0x8f, 0xc2, 0x01,
+	0x1d, 0xfa, 0xf6, 0x80, 0x1c, 0x7b, 0x63, 0xcf, 0xf1, 0xce, 0x26, 0xdd, 0x0b, 0xe2, 0x06, 0xf4,
+	0x1a, 0xb5, 0xd1, 0x2e, 0xd4, 0x09, 0x63, 0x32, 0x82, 0xc9, 0x98, 0xd0, 0x56, 0x71, 0xa3, 0xb4,
+	0xb9, 0xb0, 0xad, 0x2b, 0xbe, 0x48, 0x89, 0xda, 0xe2, 0xbf, 0xc7, 0x93, 0x31, 0xc1, 0x40, 0xa2,
+	0x47, 0xaa, 0xff, 0xa9, 0x0c, 0x8d, 0x14, 0x1f, 0xfa, 0x2e, 0x94, 0x99, 0x40, 0xbe, 0xd5, 0xed,
+	0xe4, 0x71, 0x7e, 0xb4, 0x0c, 0x95, 0x80, 0x1a, 0x2e, 0xe5, 0xc6, 0x29, 0xe1, 0x72, 0x40, 0xfb,
+	0x34, 0x5b, 0xdc, 0x4a, 0x33, 0x8b, 0x5b, 0x59, 0x29, 0x6e, 0xd2, 0xfa, 0x95, 0xc4, 0xfa, 0xab,
+	0xa0, 0xc5, 0xbe, 0xe6, 0x19, 0xd3, 0xc0, 0xb5, 0xc8, 0xd5, 0x99, 0xcc, 0xad, 0x4e, 0x65, 0x6e,
+	0x52, 0x32, 0x6a, 0x6a, 0xc9, 0x40, 0xcf, 0x01, 0x2e, 0xcc, 0x61, 0x18, 0x8e, 0x0c, 0x3f, 0x14,
+	0xa9, 0x51, 0xdf, 0x5e, 0x51, 0xeb, 0x24, 0x27, 0xe2, 0xd0, 0xc5, 0xda, 0x45, 0xf4, 0x88, 0x56,
+	0xa0, 0x42, 0x7c, 0xdf, 0xf3, 0x79, 0xd2, 0x68, 0x58, 0x2c, 0xf4, 0x7f, 0x16, 0x40, 0x8b, 0xed,
+	0x81, 0xea, 0x50, 0x3d, 0xe9, 0xbf, 0xea, 0x1f, 0xbc, 0xeb, 0x37, 0xef, 0xa0, 0x15, 0x68, 0x76,
+	0x76, 0x8e, 0x77, 0x8c, 0xfe, 0x41, 0xa7, 0x6b, 0xfc, 0xf8, 0x60, 0xbf, 0xdf, 0xed, 0x34, 0x0b,
+	0x08, 0xc1, 0x42, 0x82, 0xf6, 0xba, 0x7b, 0xc7, 0xcd, 0x22, 0xc3, 0xde, 0x1e, 0xf4, 0x4e, 0x5e,
+	0x77, 0x8d, 0x5d, 0xdc, 0xdd, 0x39, 0xee, 0x76, 0x9a, 0x25, 0x05, 0xeb, 0x74, 0x7b, 0x5d, 0x86,
+	0x95, 0xd1, 0x32, 0x2c, 0x4a, 0x0c, 0x77, 0x77, 0x3a, 0x07, 0xfd, 0xde, 0x4f, 0x9a, 0x15, 0xb4,
+	0x08, 0x75, 0x09, 0xee, 0x9d, 0xf4, 0x7a, 0xcd, 0x39, 0xf6, 0x66, 0xaf, 0xbb, 0xd3, 0xe9, 0x62,
+	0x63, 0xf7, 0xe5, 0x4e, 0xff, 0x45, 0xb7, 0xd3, 0xac, 0x72, 0x69, 0x3b, 0xbb, 0x27, 0x27, 0xaf,
+	0x8d, 0xa3, 0xe3, 0x1d, 0xcc, 0xa4, 0xd5, 0xb8, 0x34, 0x81, 0xed, 0xed, 0xf7, 0xf7, 0x8f, 0x5e,
+	0x76, 0x3b, 0x4d, 0x0d, 0xdd, 0x03, 0x24, 0xa5, 0xbd, 0xc0, 0x07, 0xef, 0x8c, 0xbd, 0x9d, 0xfd,
+	0x5e, 0xb7, 0xd3, 0x04, 0xfd, 0x18, 0x96, 0x7b, 0x9e, 0x77, 0x1e, 0x8e, 0x45, 0xbe, 0x44, 0xd1,
+	0x99, 0x4e, 0xc5, 0xc2, 0x46, 0x89, 0x25, 0x47, 0x9c, 0x8a, 0x19, 0x07, 0x15, 0xb3, 0x0e, 0xd2,
+	0xff, 0x53, 0x80, 0x95, 0xb4, 0x58, 0xd9, 0x34, 0x7e, 0x01, 0xcb, 0xb1, 0x5c, 0xc3, 0x91, 0xc9,
+	0x29, 0x36, 0xa8, 0x6f, 0x3f, 0x53, 0x5c, 0x95, 0xf7, 0x76, 0xd4, 0xe7, 0xac, 0x28, 0xab, 0xf1,
+	0xd2, 0x45, 0x06, 0xa1, 0xed, 0x2b, 0x68, 0x66, 0xd9, 0xd2, 0xc1, 0x26, 0x12, 0x2e, 0x09, 0xb6,
+	0xef, 0x80, 0x96, 0x28, 0x52, 0xe4, 0x8a, 0x2c, 0xa7, 0x14, 0x91, 0x7b, 0x25, 0x5c, 0x49, 0xc8,
+	0x94, 0xd4, 0x90, 0xf9, 0x01, 0xd4, 0x3e, 0xb8, 0xdc, 0xe8, 0x7f, 0x2f, 0x40, 0x63, 0x87, 0x52,
+	0xfb, 0x2c, 0xae, 0x6b, 0x2b, 0x50, 0x11, 0xfd, 0x44, 0x74, 0x55, 0xb1, 0x40, 0x1b, 0x50, 0x97,
+	0xed, 0x40, 0x31, 0xbd, 0x0a, 0xdd, 0xd8, 0xf6, 0x64, 0x8b, 0x10, 0xe9, 0xc9, 0x1e, 0xb3, 0x29,
+	0x5d, 0x99, 0x99, 0xd2, 0x73, 0x4a, 0x4a, 0xaf, 0x82, 0xc6, 0x5f, 0x72, 0x3d, 0x8b, 0xc8, 0x14,
+	0xad, 0x31, 0xa0, 0xef, 0x59, 0xbc, 0xfe, 0x46, 0x1f, 0x23, 0x1d, 0xdf, 0x84, 0xd2, 0x69, 0x6c,
+	0x7c, 0xf6, 0x18, 0x99, 0xa8, 0x38, 0xcb, 0x44, 0x53, 0x23, 0x5a, 0x6c, 0x90, 0xb2, 0x6a, 0x90,
+	0xd8, 0x17, 0x15, 0xd5, 0x17, 0x7f, 0x29, 0xc2, 0x7c, 0x47, 0x6a, 0xc3, 0xc6, 0x07, 0x65, 0x60,
+	0xd0, 0x70, 0xf1, 0x43, 0x76, 0x7f, 0x0c, 0xf3, 0x53, 0xc3, 0x61, 0x19, 0xd7, 0x2f, 0x94, 0xc9,
+	0x30, 0x6f, 0x86, 0x14, 0x53, 0x43, 0x76, 0x86, 0xfc, 0x0c, 0x96, 0x4e, 0x7d, 0x42, 0xd2, 0xac,
+	0x62, 0x6e, 0x58, 0x64, 0x04, 0x95, 0x77, 0x0b, 0x96, 0xcd, 0x61, 0x60, 0x5f, 0x64, 0xb8, 0xab,
+	0x9c, 0x7b, 0x49, 0x90, 0x54, 0xfe, 0xbd, 0x58, 0x51, 0xdb, 0x3d, 0xf5, 0x68, 0xab, 0x76, 0xfb,
+	0x71, 0xb1, 0x7e, 0x11, 0x53, 0xa8, 0xfe, 0xdb, 0x22, 0xd4, 0xb0, 0x39, 0x3c, 0xcf, 0x35, 0x5f,
+	0xd6, 0x1a, 0xc5, 0xdb, 0x59, 0xa3, 0x74, 0x7b, 0x6b, 0x94, 0xbf, 0x95, 0x35, 0x2a, 0xb3, 0xac,
+	0xf1, 0x15, 0x2c, 0xc6, 0x61, 0x2a, 0x0d, 0x32, 0xc7, 0x0d, 0x72, 0x5f, 0x31, 0x88, 0x1a, 0x29,
+	0xb8, 0x61, 0x29, 0x2b, 0xaa, 0xff, 0xb7, 0x00, 0x0b, 0x9d, 0x38, 0x15, 0x3e, 0x6e, 0x63, 0x6c,
+	0x03, 0xb0, 0xdc, 0x4d, 0xd9, 0x41, 0xad, 0x75, 0x91, 0xbb, 0xb1, 0xe6, 0xcb, 0x27, 0xaa, 0xff,
+	0xa1, 0x08, 0xf3, 0xd1, 0x98, 0xf0, 0x71, 0x7f, 0x7d, 0x17, 0x96, 0x94, 0x32, 0x97, 0x32, 0xc2,
+	0x83, 0x4c, 0x30, 0x24, 0xce, 0xc6, 0x8b, 0x56, 0x6a, 0x4d, 0xf5, 0x65, 0x58, 0x92, 0xb3, 0xa5,
+	0x4d, 0x03, 0x59, 0xac, 0xf5, 0xdf, 0x14, 0x00, 0xa9, 0xa8, 0xac, 0x7a, 0x3f, 0x84, 0x46, 0x20,
+	0x6d, 0xc7, 0xf7, 0x93, 0xe3, 0xf5, 0xfd, 0x9c, 0x11, 0x8c, 0x6f, 0x36, 0x1f, 0x28, 0x2b, 0xf4,
+	0x25, 0xac, 0xc8, 0x2f, 0x63, 0xe7, 0x19, 0xc3, 0x61, 0x87, 0x29, 0x63, 0x34, 0x90, 0x16, 0x5e,
+	0xca, 0x1c, 0xb3, 0x5e, 0x0f, 0xf4, 0x7f, 0x17, 0x22, 0xdd, 0x5e, 0xf8, 0xde, 0x65, 0x6e, 0x23,
+	0x69, 0x7c, 0x54, 0x8d, 0x84, 0x69, 0x39, 0xf6, 0x89, 0xe9, 0xf0, 0xde, 0x4b, 0xf8, 0xb8, 0x57,
+	0xc2, 0x2a, 0xa4, 0x7f, 0x06, 0x48, 0xfd, 0x64, 0x69, 0xf8, 0xdc, 0x6f, 0xd6, 0x7f, 0x06, 0x0d,
+	0x39, 0x02, 0x4a, 0xd3, 0x7c, 0x0e, 0x4b, 0x67, 0xa6, 0x3f, 0x30, 0xcf, 0x88, 0x11, 0xbc, 0xf7,
+	0x09, 0x7d, 0xef, 0x39, 0x22, 0xb4, 0x8b, 0xb8, 0x29, 0x09, 0xc7, 0x11, 0x7e, 0xe3, 0xd0, 0xf3,
+	0x7d, 0x58, 0x88, 0xa4, 0x4b, 0x2d, 0x3e, 0x85, 0x12, 0x1b, 0x44, 0x0b, 0xd7, 0x0c, 0xa2, 0x8c,
+	0x41, 0xff, 0x6b, 0x11, 0xb4, 0x18, 0x62, 0xa7, 0xbb, 0xc0, 0xb7, 0xcf, 0xce, 0x88, 0x2f, 0xb3,
+	0x2c, 0x5a, 0xde, 0xa4, 0x01, 0xd2, 0xa1, 0x41, 0x03, 0xd3, 0x0f, 0x8c, 0xc0, 0x1e, 0x11, 0x36,
+	0xb8, 0x97, 0x84, 0xbd, 0x38, 0x78, 0x6c, 0x8f, 0x48, 0x9f, 0x8d, 0x6e, 0x75, 0xe2, 0x5a, 0x31,
+	0x47, 0x99, 0x73, 0x68, 0xc4, 0xb5, 0x24, 0xfd, 0x09, 0x34, 0x86, 0xef, 0xc9, 0xf0, 0x9c, 0x58,
+	0x4a, 0x3e, 0x35, 0xf0, 0xbc, 0x04, 0x45, 0x2a, 0x7d, 0x02, 0x0b, 0x62, 0x80, 0x8e, 0xb9, 0xc4,
+	0x08, 0xdf, 0x88, 0xd0, 0xf8, 0x08, 0x7d, 0x6a, 0xda, 0x4e, 0xcc, 0x54, 0xe5, 0x4c, 0x75, 0x81,
+	0x09, 0x96, 0xff, 0x83, 0x45, 0x9f, 0x0c, 0x1d, 0xd3, 0x1e, 0xc9, 0x43, 0x34, 0xe5, 0x4e, 0x2e,
+	0xe3, 0x85, 0x18, 0x66, 0x27, 0x68, 0xca, 0x22, 0xc1, 0x66, 0x31, 0xe4, 0x87, 0xe3, 0x80, 0x58,
+	0x7c, 0xb8, 0xaf, 0x61, 0x15, 0xd2, 0x57, 0x00, 0x09, 0x23, 0x1e, 0x9a, 0x21, 0x8d, 0x26, 0x59,
+	0xfd, 0x2e, 0x2c, 0xa7, 0x50, 0xe1, 0x9a, 0x04, 0xc6, 0x84, 0x26, 0x73, 0xaf, 0x7e, 0x0f, 0x56,
+	0xd2, 0x70, 0x96, 0xfd, 0x28, 0x30, 0x83, 0x30, 0x3a, 0xc4, 0xe9, 0x7f, 0x2c, 0xc0, 0x4a, 0x1a,
+	0x97, 0x9e, 0xe7, 0x47, 0xdc, 0x90, 0x12, 0x11, 0x4d, 0x35, 0x2c, 0x57, 0xcc, 0xb7, 0x97, 0xb6,
+	0x6b, 0x79, 0x97, 0x62, 0xd4, 0xd4, 0x70, 0xb4, 0x44, 0x5b, 0x50, 0x1d, 0x86, 0xbe, 0x4f, 0x64,
+	0x69, 0x9c, 0x15, 0x2f, 0x11, 0x13, 0xe3, 0x7f, 0x6f, 0xd3, 0xc0, 0xf3, 0x27, 0xfc, 0xfc, 0x39,
+	0x93, 0x5f, 0x32, 0xe9, 0xff, 0x28, 0x40, 0x73, 0x37, 0x0e, 0x95, 0x43, 0xcf, 0xb1, 0x87, 0x93,
+	0x6c, 0x11, 0x28, 0x4c, 0x17, 0x01, 0x99, 0xe4, 0xc5, 0x24, 0xc9, 0x6f, 0x5f, 0xcc, 0x57, 0x41,
+	0x63, 0x9c, 0xc2, 0xab, 0xa2, 0x88, 0xd7, 0x46, 0xe6, 0x95, 0xf0, 0x67, 0x6e, 0xea, 0x55, 0x66,
+	0xa4, 0xde, 0x4d, 0x37, 0x6a, 0xfa, 0xef, 0x0b, 0x00, 0xc9, 0xd7, 0xe5, 0x1e, 0xae, 0x9f, 0xc3,
+	0xdc, 0x98, 0x7f, 0x35, 0xff, 0x98, 0xfa, 0xf6, 0xaa, 0x62, 0xaf, 0xac, 0x61, 0xb0, 0x64, 0x9d,
+	0x6a, 0x6e, 0xa5, 0xe9, 0xe6, 0x16, 0x5d, 0x3d, 0x95, 0x93, 0xab, 0x27, 0xfd, 0x3e, 0xdc, 0x4d,
+	0x44, 0xaa, 0x7d, 0xe2, 0x0d, 0xdc, 0xcb, 0x12, 0x64, 0xc4, 0x7c, 0x0f, 0xea, 0x49, 0x26, 0x47,
+	0x27, 0xa2, 0xbb, 0xb9, 0x3a, 0x62, 0x95, 0x53, 0xff, 0x02, 0xee, 0x27, 0xa4, 0x0e, 0xbf, 0x83,
+	0xb8, 0xee, 0x6a, 0xa4, 0x0d, 0xad, 0x69, 0x76, 0x19, 0xe5, 0xbf, 0x2b, 0x40, 0x3b, 0x21, 0xee,
+	0x7a, 0xee, 0xa9, 0x7d, 0x16, 0xfa, 0xd7, 0x89, 0xfb, 0x30, 0xab, 0x3e, 0x81, 0x46, 0x38, 0xb6,
+	0xcc, 0x80, 0x18, 0xa7, 0x36, 0x71, 0xe4, 0x05, 0x8b, 0x86, 0xe7, 0x05, 0xb8, 0xc7, 0x31, 0x7d,
+	0x0d, 0x56, 0x73, 0x75, 0x91, 0xba, 0x3e, 0x82, 0xb5, 0x17, 0x24, 0x78, 0xcd, 0x37, 0x8b, 0xa8,
+	0xe2, 0xa0, 0x16, 0xb5, 0xe4, 0x22, 0xac, 0xcf, 0xe2, 0x90, 0x36, 0xff, 0x12, 0x96, 0x2d, 0x72,
+	0x6a, 0x86, 0x4e, 0x60, 0x4c, 0xa7, 0x01, 0x92, 0x24, 0x9c, 0x50, 0xbe, 0x75, 0x47, 0xce, 0x8f,
+	0xf2, 0xd2, 0x8c, 0x28, 0x7f, 0x02, 0x8d, 0x71, 0xe8, 0x50, 0x62, 0x50, 0x32, 0xf4, 0x5c, 0x8b,
+	0xca, 0x0b, 0xe8, 0x79, 0x0e, 0x1e, 0x09, 0x0c, 0x7d, 0x01, 0x48, 0xaa, 0xa0, 0x36, 0xc6, 0x0a,
+	0xaf, 0x32, 0x52, 0x81, 0xc3, 0x84, 0xb0, 0xfd, 0x6b, 0x80, 0xea, 0x11, 0x31, 0x2f, 0x09, 0xb1,
+	0xd0, 0x3e, 0x34, 0x8e, 0x88, 0x6b, 0x25, 0xf7, 0xe6, 0x6a, 0xc9, 0x88, 0xd1, 0xf6, 0xc3, 0x3c,
+	0x34, 0xb6, 0xfb, 0x9d, 0xcd, 0xc2, 0xb3, 0x02, 0x3a, 0x84, 0xc6, 0x2b, 0x42, 0xc6, 0xbb, 0x9e,
+	0xeb, 0x92, 0x61, 0x40, 0x2c, 0xb4, 0xae, 0xfa, 0x7d, 0xfa, 0x96, 0xae, 0xfd, 0x60, 0xea, 0xfc,
+	0x11, 0x9d, 0x95, 0xa5, 0xc4, 0x01, 0xdc, 0x9f, 0x71, 0x63, 0x86, 0x9e, 0xa6, 0x6e, 0x23, 0xaf,
+	0xbb, 0x55, 0x6b, 0xb7, 0x66, 0x5d, 0x6e, 0xe9, 0x77, 0x9e, 0x15, 0xd0, 0x1b, 0x98, 0x57, 0xef,
+	0x15, 0x52, 0x4a, 0xe7, 0xdc, 0x82, 0xb4, 0x1f, 0xdd, 0x70, 0x21, 0xa1, 0xdf, 0x41, 0x5f, 0xc1,
+	0x9c, 0x38, 0xe9, 0x22, 0x75, 0xeb, 0xd4, 0x49, 0xbe, 0xfd, 0x20, 0x87, 0x12, 0x0b, 0x78, 0x05,
+	0x90, 0x0c, 0x8e, 0xe8, 0xe1, 0xb4, 0x99, 0x92, 0xea, 0xd1, 0x5e, 0x9b, 0x41, 0x9d, 0x16, 0xc6,
+	0x86, 0xa1, 0x1c, 0x61, 0xca, 0x58, 0xd8, 0x5e, 0x9b, 0x41, 0x55, 0x3f, 0x4d, 0xf4, 0x91, 0xd4,
+	0xa7, 0xa5, 0x06, 0xa8, 0xf6, 0x83, 0x1c, 0x4a, 0x2c, 0xa0, 0x0f, 0x75, 0xa5, 0xf5, 0xa2, 0xb5,
+	0x29, 0x5e, 0xb5, 0x51, 0xb7, 0xd7, 0x67, 0x91, 0x63, 0x79, 0x6f, 0x60, 0x5e, 0x6d, 0xce, 0x68,
+	0x3d, 0x6f, 0xf3, 0x19, 0xee, 0xcb, 0xed, 0xea, 0x8a, 0x48, 0xd1, 0xbf, 0x73, 0x44, 0xa6, 0x1a,
+	0x7e, 0xfb, 0xd1, 0x4c, 0x7a, 0x2c, 0xf2, 0x1d, 0x2c, 0xa4, 0x4b, 0x3c, 0xda, 0xc8, 0xad, 0x89,
+	0xaa, 0x63, 0x1f, 0x5f, 0xc3, 0x11, 0x0b, 0xfe, 0x39, 0x34, 0xb3, 0x95, 0x1b, 0xe9, 0xb9, 0x2f,
+	0xa6, 0xba, 0x40, 0xfb, 0xc9, 0xb5, 0x3c, 0xb1, 0xf8, 0x53, 0x58, 0xce, 0xa9, 0xb7, 0xe8, 0x93,
+	0xdc, 0xb7, 0xb3, 0xbd, 0xa1, 0xfd, 0xe9, 0x4d, 0x6c, 0xf1, 0x3e, 0x1e, 0xdc, 0xcb, 0x2f, 0xcb,
+	0x48, 0xfd, 0xd7, 0xe1, 0xda, 0xda, 0xde, 0x7e, 0x7a, 0x0b, 0xce, 0x68, 0xc3, 0xc1, 0x1c, 0xff,
+	0xf7, 0xf0, 0xf9, 0xff, 0x06, 0x00, 0xdf, 0x8a, 0x6c, 0xcd, 0x4d, 0x1c, 0x00, 0x00,
 }
@@ -35,6 +35,9 @@ It has these top-level messages:
 	VolumeMountResponse
 	VolumeUnmountRequest
 	VolumeUnmountResponse
+	VolumeNeedleIndexExportRequest
+	VolumeNeedleIndexExportResponse
+	NeedleIndexEntry
 	VolumeUiPageRequest
 	VolumeUiPageResponse
 	DiskStatus
@@ -223,6 +226,7 @@ func (m *VacuumVolumeCommitRequest) GetVolumdId() uint32 {
 }
 
 type VacuumVolumeCommitResponse struct {
+	VolumeSize uint64 `protobuf:"varint,1,opt,name=volume_size,json=volumeSize" json:"volume_size,omitempty"`
 }
 
 func (m *VacuumVolumeCommitResponse) Reset()                    { *m = VacuumVolumeCommitResponse{} }
@@ -230,6 +234,13 @@ func (m *VacuumVolumeCommitResponse) String() string            { return proto.C
 func (*VacuumVolumeCommitResponse) ProtoMessage()               {}
 func (*VacuumVolumeCommitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
+func (m *VacuumVolumeCommitResponse) GetVolumeSize() uint64 {
+	if m != nil {
+		return m.VolumeSize
+	}
+	return 0
+}
+
 type VacuumVolumeCleanupRequest struct {
 	VolumdId uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
 }
@@ -550,13 +561,89 @@ func (m *VolumeUnmountResponse) String() string            { return proto.Compac
 func (*VolumeUnmountResponse) ProtoMessage()               {}
 func (*VolumeUnmountResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
+type VolumeNeedleIndexExportRequest struct {
+	VolumdId uint32 `protobuf:"varint,1,opt,name=volumd_id,json=volumdId" json:"volumd_id,omitempty"`
+}
+
+func (m *VolumeNeedleIndexExportRequest) Reset()         { *m = VolumeNeedleIndexExportRequest{} }
+func (m *VolumeNeedleIndexExportRequest) String() string { return proto.CompactTextString(m) }
+func (*VolumeNeedleIndexExportRequest) ProtoMessage()    {}
+func (*VolumeNeedleIndexExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{26}
+}
+
+func (m *VolumeNeedleIndexExportRequest) GetVolumdId() uint32 {
+	if m != nil {
+		return m.VolumdId
+	}
+	return 0
+}
+
+type VolumeNeedleIndexExportResponse struct {
+	Entries []*NeedleIndexEntry `protobuf:"bytes,1,rep,name=entries" json:"entries,omitempty"`
+}
+
+func (m *VolumeNeedleIndexExportResponse) Reset()         { *m = VolumeNeedleIndexExportResponse{} }
+func (m *VolumeNeedleIndexExportResponse) String() string { return proto.CompactTextString(m) }
+func (*VolumeNeedleIndexExportResponse) ProtoMessage()    {}
+func (*VolumeNeedleIndexExportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{27}
+}
+
+func (m *VolumeNeedleIndexExportResponse) GetEntries() []*NeedleIndexEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type NeedleIndexEntry struct {
+	NeedleId   uint64 `protobuf:"varint,1,opt,name=needle_id,json=needleId" json:"needle_id,omitempty"`
+	Cookie     uint32 `protobuf:"varint,2,opt,name=cookie" json:"cookie,omitempty"`
+	Size       uint32 `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
+	AppendAtNs uint64 `protobuf:"varint,4,opt,name=append_at_ns,json=appendAtNs" json:"append_at_ns,omitempty"`
+}
+
+func (m *NeedleIndexEntry) Reset()                    { *m = NeedleIndexEntry{} }
+func (m *NeedleIndexEntry) String() string            { return proto.CompactTextString(m) }
+func (*NeedleIndexEntry) ProtoMessage()               {}
+func (*NeedleIndexEntry) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *NeedleIndexEntry) GetNeedleId() uint64 {
+	if m != nil {
+		return m.NeedleId
+	}
+	return 0
+}
+
+func (m *NeedleIndexEntry) GetCookie() uint32 {
+	if m != nil {
+		return m.Cookie
+	}
+	return 0
+}
+
+func (m *NeedleIndexEntry) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *NeedleIndexEntry) GetAppendAtNs() uint64 {
+	if m != nil {
+		return m.AppendAtNs
+	}
+	return 0
+}
+
 type VolumeUiPageRequest struct {
 }
 
 func (m *VolumeUiPageRequest) Reset()                    { *m = VolumeUiPageRequest{} }
 func (m *VolumeUiPageRequest) String() string            { return proto.CompactTextString(m) }
 func (*VolumeUiPageRequest) ProtoMessage()               {}
-func (*VolumeUiPageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*VolumeUiPageRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 type VolumeUiPageResponse struct {
 }
@@ -564,7 +651,7 @@ type VolumeUiPageResponse struct {
 func (m *VolumeUiPageResponse) Reset()                    { *m = VolumeUiPageResponse{} }
 func (m *VolumeUiPageResponse) String() string            { return proto.CompactTextString(m) }
 func (*VolumeUiPageResponse) ProtoMessage()               {}
-func (*VolumeUiPageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*VolumeUiPageResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 type DiskStatus struct {
 	Dir  string `protobuf:"bytes,1,opt,name=dir" json:"dir,omitempty"`
@@ -576,7 +663,7 @@ type DiskStatus struct {
 func (m *DiskStatus) Reset()                    { *m = DiskStatus{} }
 func (m *DiskStatus) String() string            { return proto.CompactTextString(m) }
 func (*DiskStatus) ProtoMessage()               {}
-func (*DiskStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*DiskStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *DiskStatus) GetDir() string {
 	if m != nil {
@@ -619,7 +706,7 @@ type MemStatus struct {
 func (m *MemStatus) Reset()                    { *m = MemStatus{} }
 func (m *MemStatus) String() string            { return proto.CompactTextString(m) }
 func (*MemStatus) ProtoMessage()               {}
-func (*MemStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*MemStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *MemStatus) GetGoroutines() int32 {
 	if m != nil {
@@ -697,6 +784,9 @@ func init() {
 	proto.RegisterType((*VolumeMountResponse)(nil), "volume_server_pb.VolumeMountResponse")
 	proto.RegisterType((*VolumeUnmountRequest)(nil), "volume_server_pb.VolumeUnmountRequest")
 	proto.RegisterType((*VolumeUnmountResponse)(nil), "volume_server_pb.VolumeUnmountResponse")
+	proto.RegisterType((*VolumeNeedleIndexExportRequest)(nil), "volume_server_pb.VolumeNeedleIndexExportRequest")
+	proto.RegisterType((*VolumeNeedleIndexExportResponse)(nil), "volume_server_pb.VolumeNeedleIndexExportResponse")
+	proto.RegisterType((*NeedleIndexEntry)(nil), "volume_server_pb.NeedleIndexEntry")
 	proto.RegisterType((*VolumeUiPageRequest)(nil), "volume_server_pb.VolumeUiPageRequest")
 	proto.RegisterType((*VolumeUiPageResponse)(nil), "volume_server_pb.VolumeUiPageResponse")
 	proto.RegisterType((*DiskStatus)(nil), "volume_server_pb.DiskStatus")
@@ -727,6 +817,7 @@ type VolumeServerClient interface {
 	VolumeSyncData(ctx context.Context, in *VolumeSyncDataRequest, opts ...grpc.CallOption) (*VolumeSyncDataResponse, error)
 	VolumeMount(ctx context.Context, in *VolumeMountRequest, opts ...grpc.CallOption) (*VolumeMountResponse, error)
 	VolumeUnmount(ctx context.Context, in *VolumeUnmountRequest, opts ...grpc.CallOption) (*VolumeUnmountResponse, error)
+	VolumeNeedleIndexExport(ctx context.Context, in *VolumeNeedleIndexExportRequest, opts ...grpc.CallOption) (VolumeServer_VolumeNeedleIndexExportClient, error)
 }
 
 type volumeServerClient struct {
@@ -845,6 +936,38 @@ func (c *volumeServerClient) VolumeUnmount(ctx context.Context, in *VolumeUnmoun
 	return out, nil
 }
 
+func (c *volumeServerClient) VolumeNeedleIndexExport(ctx context.Context, in *VolumeNeedleIndexExportRequest, opts ...grpc.CallOption) (VolumeServer_VolumeNeedleIndexExportClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_VolumeServer_serviceDesc.Streams[0], c.cc, "/volume_server_pb.VolumeServer/VolumeNeedleIndexExport", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &volumeServerVolumeNeedleIndexExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VolumeServer_VolumeNeedleIndexExportClient interface {
+	Recv() (*VolumeNeedleIndexExportResponse, error)
+	grpc.ClientStream
+}
+
+type volumeServerVolumeNeedleIndexExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *volumeServerVolumeNeedleIndexExportClient) Recv() (*VolumeNeedleIndexExportResponse, error) {
+	m := new(VolumeNeedleIndexExportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for VolumeServer service
 
 type VolumeServerServer interface {
@@ -861,6 +984,7 @@ type VolumeServerServer interface {
 	VolumeSyncData(context.Context, *VolumeSyncDataRequest) (*VolumeSyncDataResponse, error)
 	VolumeMount(context.Context, *VolumeMountRequest) (*VolumeMountResponse, error)
 	VolumeUnmount(context.Context, *VolumeUnmountRequest) (*VolumeUnmountResponse, error)
+	VolumeNeedleIndexExport(*VolumeNeedleIndexExportRequest, VolumeServer_VolumeNeedleIndexExportServer) error
 }
 
 func RegisterVolumeServerServer(s *grpc.Server, srv VolumeServerServer) {
@@ -1083,6 +1207,27 @@ func _VolumeServer_VolumeUnmount_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VolumeServer_VolumeNeedleIndexExport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(VolumeNeedleIndexExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VolumeServerServer).VolumeNeedleIndexExport(m, &volumeServerVolumeNeedleIndexExportServer{stream})
+}
+
+type VolumeServer_VolumeNeedleIndexExportServer interface {
+	Send(*VolumeNeedleIndexExportResponse) error
+	grpc.ServerStream
+}
+
+type volumeServerVolumeNeedleIndexExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *volumeServerVolumeNeedleIndexExportServer) Send(m *VolumeNeedleIndexExportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _VolumeServer_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "volume_server_pb.VolumeServer",
 	HandlerType: (*VolumeServerServer)(nil),
@@ -1136,76 +1281,89 @@ var _VolumeServer_serviceDesc = grpc.ServiceDesc{
 			Handler:    _VolumeServer_VolumeUnmount_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "VolumeNeedleIndexExport",
+			Handler:       _VolumeServer_VolumeNeedleIndexExport_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "volume_server.proto",
 }
 
 func init() { proto.RegisterFile("volume_server.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1013 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x9c, 0x57, 0xdf, 0x72, 0xdb, 0xc4,
-	0x17, 0x8e, 0x62, 0x3b, 0x76, 0x8e, 0xed, 0x5f, 0xfd, 0x5b, 0xa7, 0x89, 0xaa, 0x42, 0x30, 0x02,
-	0x5a, 0xa7, 0x0d, 0x61, 0x68, 0x07, 0x28, 0xc3, 0x0d, 0x90, 0x00, 0x93, 0x8b, 0x4e, 0x99, 0xcd,
-	0xb4, 0xc3, 0x0c, 0x9d, 0xf1, 0x28, 0xd2, 0x3a, 0xd9, 0x89, 0x2c, 0xa9, 0xda, 0x55, 0x26, 0xe5,
-	0x0d, 0x78, 0x04, 0xae, 0xb9, 0xe1, 0x9d, 0x78, 0x19, 0x66, 0xff, 0x48, 0xd1, 0x3f, 0x57, 0x82,
-	0xbb, 0xd5, 0xb7, 0xe7, 0x9c, 0x6f, 0xcf, 0xd9, 0xb3, 0xe7, 0xb3, 0x61, 0x7a, 0x1d, 0xfa, 0xc9,
-	0x8a, 0x2c, 0x18, 0x89, 0xaf, 0x49, 0x7c, 0x14, 0xc5, 0x21, 0x0f, 0xd1, 0xa4, 0x00, 0x2e, 0xa2,
-	0x73, 0xfb, 0x33, 0x40, 0xdf, 0x3b, 0xdc, 0xbd, 0x3c, 0x21, 0x3e, 0xe1, 0x04, 0x93, 0x37, 0x09,
-	0x61, 0x1c, 0xdd, 0x83, 0xc1, 0x92, 0xfa, 0x64, 0x41, 0x3d, 0x66, 0x1a, 0xb3, 0xce, 0x7c, 0x1b,
-	0xf7, 0xc5, 0xf7, 0xa9, 0xc7, 0xec, 0x17, 0x30, 0x2d, 0x38, 0xb0, 0x28, 0x0c, 0x18, 0x41, 0xcf,
-	0xa0, 0x1f, 0x13, 0x96, 0xf8, 0x5c, 0x39, 0x0c, 0x9f, 0xec, 0x1f, 0x95, 0xb9, 0x8e, 0x32, 0x97,
-	0xc4, 0xe7, 0x38, 0x35, 0xb7, 0x29, 0x8c, 0xf2, 0x1b, 0x68, 0x0f, 0xfa, 0x9a, 0xdb, 0x34, 0x66,
-	0xc6, 0x7c, 0x1b, 0x6f, 0x29, 0x6a, 0xb4, 0x0b, 0x5b, 0x8c, 0x3b, 0x3c, 0x61, 0xe6, 0xe6, 0xcc,
-	0x98, 0xf7, 0xb0, 0xfe, 0x42, 0x3b, 0xd0, 0x23, 0x71, 0x1c, 0xc6, 0x66, 0x47, 0x9a, 0xab, 0x0f,
-	0x84, 0xa0, 0xcb, 0xe8, 0x6f, 0xc4, 0xec, 0xce, 0x8c, 0xf9, 0x18, 0xcb, 0xb5, 0xdd, 0x87, 0xde,
-	0x0f, 0xab, 0x88, 0xbf, 0xb5, 0xbf, 0x02, 0xf3, 0x95, 0xe3, 0x26, 0xc9, 0xea, 0x95, 0x3c, 0xe3,
-	0xf1, 0x25, 0x71, 0xaf, 0xd2, 0xdc, 0xef, 0xc3, 0xb6, 0x3c, 0xb9, 0x97, 0x9e, 0x60, 0x8c, 0x07,
-	0x0a, 0x38, 0xf5, 0xec, 0x6f, 0xe1, 0x5e, 0x8d, 0xa3, 0xae, 0xc1, 0x47, 0x30, 0xbe, 0x70, 0xe2,
-	0x73, 0xe7, 0x82, 0x2c, 0x62, 0x87, 0xd3, 0x50, 0x7a, 0x1b, 0x78, 0xa4, 0x41, 0x2c, 0x30, 0xfb,
-	0x57, 0xb0, 0x0a, 0x11, 0xc2, 0x55, 0xe4, 0xb8, 0xbc, 0x0d, 0x39, 0x9a, 0xc1, 0x30, 0x8a, 0x89,
-	0xe3, 0xfb, 0xa1, 0xeb, 0x70, 0x22, 0xab, 0xd0, 0xc1, 0x79, 0xc8, 0x7e, 0x1f, 0xee, 0xd7, 0x06,
-	0x57, 0x07, 0xb4, 0x9f, 0x95, 0x4e, 0x1f, 0xae, 0x56, 0xb4, 0x15, 0xb5, 0xfd, 0x5e, 0xe5, 0xd4,
-	0xd2, 0x53, 0xc7, 0xfd, 0xba, 0xb4, 0xeb, 0x13, 0x27, 0x48, 0xa2, 0x56, 0x81, 0xcb, 0x27, 0x4e,
-	0x5d, 0xb3, 0xc8, 0x7b, 0xaa, 0x39, 0x8e, 0x43, 0xdf, 0x27, 0x2e, 0xa7, 0x61, 0x90, 0x86, 0xdd,
-	0x07, 0x70, 0x33, 0x50, 0xb7, 0x4a, 0x0e, 0xb1, 0x2d, 0x30, 0xab, 0xae, 0x3a, 0xec, 0x5f, 0x06,
-	0x4c, 0xbf, 0x63, 0x8c, 0x5e, 0x04, 0x8a, 0xb6, 0x55, 0xf9, 0x8b, 0x84, 0x9b, 0x65, 0xc2, 0xf2,
-	0xf5, 0x74, 0x2a, 0xd7, 0x23, 0x2c, 0x62, 0x12, 0xf9, 0xd4, 0x75, 0x64, 0x88, 0xae, 0x0c, 0x91,
-	0x87, 0xd0, 0x04, 0x3a, 0x9c, 0xfb, 0x66, 0x4f, 0xee, 0x88, 0xa5, 0xbd, 0x0b, 0x3b, 0xc5, 0x93,
-	0xea, 0x14, 0xbe, 0x84, 0x3d, 0x85, 0x9c, 0xbd, 0x0d, 0xdc, 0x33, 0xf9, 0x12, 0x5a, 0x15, 0xfc,
-	0x6f, 0x03, 0xcc, 0xaa, 0xa3, 0xee, 0xe0, 0xa6, 0xf6, 0xfb, 0xb7, 0xa7, 0x47, 0x1f, 0xc0, 0x90,
-	0x3b, 0xd4, 0x5f, 0x84, 0xcb, 0x25, 0x23, 0xdc, 0xdc, 0x9a, 0x19, 0xf3, 0x2e, 0x06, 0x01, 0xbd,
-	0x90, 0x08, 0x3a, 0x80, 0x89, 0xab, 0xba, 0x74, 0x11, 0x93, 0x6b, 0xca, 0x44, 0xe4, 0xbe, 0x24,
-	0xbe, 0xe3, 0xa6, 0xdd, 0xab, 0x60, 0x64, 0xc3, 0x98, 0x7a, 0x37, 0x0b, 0x39, 0x1c, 0xe4, 0xd3,
-	0x1e, 0xc8, 0x68, 0x43, 0xea, 0xdd, 0xfc, 0x48, 0x7d, 0x72, 0x26, 0x5e, 0xf8, 0x17, 0xb0, 0x7b,
-	0x9b, 0xdc, 0x69, 0xe0, 0x91, 0x9b, 0x56, 0x45, 0xf9, 0x29, 0x5f, 0x4c, 0xed, 0xa6, 0x4b, 0x72,
-	0x08, 0x88, 0x0a, 0x40, 0xf1, 0xba, 0x61, 0xc0, 0x49, 0xc0, 0x65, 0x80, 0x11, 0x9e, 0xc8, 0x1d,
-	0x41, 0x7e, 0xac, 0x70, 0xfb, 0x0f, 0x03, 0xee, 0xde, 0x46, 0x3a, 0x71, 0xb8, 0xd3, 0xaa, 0xb5,
-	0x2c, 0x18, 0x64, 0xd9, 0x6f, 0xaa, 0xbd, 0xf4, 0x5b, 0x8c, 0x3d, 0x5d, 0xbd, 0x8e, 0xdc, 0xd1,
-	0x5f, 0x75, 0x03, 0x4e, 0x90, 0x04, 0x84, 0x78, 0x6a, 0x7a, 0xaa, 0x6b, 0x18, 0x28, 0xe0, 0xd4,
-	0xb3, 0xbf, 0xc9, 0xd7, 0x46, 0x1d, 0x4d, 0xe7, 0xf8, 0x21, 0x8c, 0x6a, 0xb2, 0x1b, 0x2e, 0x73,
-	0x89, 0x7d, 0x0e, 0x48, 0x39, 0x3f, 0x0f, 0x93, 0xa0, 0xdd, 0xcc, 0xb8, 0x0b, 0xd3, 0x82, 0x8b,
-	0x6e, 0xdc, 0xa7, 0xb0, 0xa3, 0xe0, 0x97, 0xc1, 0xaa, 0x75, 0xac, 0xbd, 0xb4, 0xac, 0x99, 0x93,
-	0x8e, 0x96, 0x91, 0xbc, 0xa4, 0x3f, 0x8b, 0x19, 0xab, 0x82, 0x89, 0x57, 0x53, 0x84, 0xb5, 0xf9,
-	0x2f, 0x00, 0x27, 0x94, 0x5d, 0xa9, 0xb6, 0x17, 0xfd, 0xea, 0xd1, 0x58, 0xcf, 0x0e, 0xb1, 0x14,
-	0x88, 0xe3, 0xfb, 0xf2, 0x0e, 0xba, 0x58, 0x2c, 0x45, 0x99, 0x13, 0x46, 0x3c, 0x59, 0xfc, 0x2e,
-	0x96, 0x6b, 0x81, 0x2d, 0x63, 0xa2, 0x4a, 0xdf, 0xc5, 0x72, 0x6d, 0xff, 0x69, 0xc0, 0xf6, 0x73,
-	0xb2, 0xd2, 0x91, 0xf7, 0x01, 0x2e, 0xc2, 0x38, 0x4c, 0x38, 0x0d, 0x08, 0x93, 0x04, 0x3d, 0x9c,
-	0x43, 0xfe, 0x3b, 0x8f, 0xbc, 0x76, 0xe2, 0x2f, 0xe5, 0xed, 0x76, 0xb1, 0x5c, 0x0b, 0xec, 0x92,
-	0x38, 0x91, 0x7e, 0x5e, 0x72, 0x2d, 0x54, 0x91, 0x71, 0xc7, 0xbd, 0x92, 0xaf, 0xa9, 0x8b, 0xd5,
-	0xc7, 0x93, 0xdf, 0x01, 0x46, 0xba, 0x09, 0xa4, 0x2c, 0xa3, 0xd7, 0x30, 0xcc, 0xc9, 0x39, 0xfa,
-	0xb8, 0xaa, 0xda, 0xd5, 0x9f, 0x07, 0xd6, 0x27, 0x0d, 0x56, 0xba, 0xd8, 0x1b, 0x28, 0x80, 0xff,
-	0x57, 0xe4, 0x12, 0x3d, 0xaa, 0x7a, 0xaf, 0x13, 0x63, 0xeb, 0x71, 0x2b, 0xdb, 0x8c, 0x8f, 0xc3,
-	0xb4, 0x46, 0xff, 0xd0, 0x61, 0x43, 0x94, 0x82, 0x06, 0x5b, 0x9f, 0xb6, 0xb4, 0xce, 0x58, 0xdf,
-	0x00, 0xaa, 0x8a, 0x23, 0x7a, 0xdc, 0x18, 0xe6, 0x56, 0x7c, 0xad, 0xc3, 0x76, 0xc6, 0x6b, 0x13,
-	0x55, 0xb2, 0xd9, 0x98, 0x68, 0x41, 0x98, 0x1b, 0x13, 0x2d, 0x69, 0xf1, 0x06, 0xba, 0x82, 0x49,
-	0x59, 0x52, 0xd1, 0xc1, 0xba, 0xdf, 0x79, 0x15, 0xc5, 0xb6, 0x1e, 0xb5, 0x31, 0xcd, 0xc8, 0x16,
-	0x30, 0xca, 0x0b, 0x1f, 0xaa, 0x69, 0xba, 0x1a, 0x09, 0xb7, 0x1e, 0x34, 0x99, 0xe5, 0xb3, 0x29,
-	0x0b, 0x61, 0x5d, 0x36, 0x6b, 0x54, 0xb6, 0x2e, 0x9b, 0x75, 0xba, 0x6a, 0x6f, 0xa0, 0x4b, 0xb8,
-	0x53, 0x52, 0x18, 0x34, 0x7f, 0x57, 0x80, 0xbc, 0x76, 0x59, 0x07, 0x2d, 0x2c, 0x33, 0x26, 0x02,
-	0xff, 0x2b, 0x8e, 0x79, 0xf4, 0xf0, 0x5d, 0xee, 0x39, 0x8d, 0xb2, 0xe6, 0xcd, 0x86, 0x19, 0xcd,
-	0x6b, 0x18, 0xe6, 0xa6, 0x7b, 0xdd, 0xe0, 0xa8, 0xea, 0x45, 0xdd, 0xe0, 0xa8, 0x93, 0x88, 0x0d,
-	0x74, 0x0e, 0xe3, 0xc2, 0xbc, 0x47, 0x0f, 0xd6, 0x79, 0x16, 0x55, 0xc4, 0x7a, 0xd8, 0x68, 0x97,
-	0x72, 0x9c, 0x6f, 0xc9, 0xff, 0x44, 0x4f, 0xff, 0x09, 0x00, 0x00, 0xff, 0xff, 0x60, 0xe6, 0x57,
-	0xdf, 0x2a, 0x0d, 0x00, 0x00,
+	// 1131 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0x5b, 0x6f, 0xdc, 0x44,
+	0x14, 0xc6, 0xd9, 0x4d, 0x36, 0x39, 0xbb, 0xa1, 0xcb, 0x6c, 0x92, 0x75, 0x5d, 0x91, 0x2e, 0x86,
+	0xb6, 0x9b, 0x34, 0x0d, 0xbd, 0x08, 0x68, 0x05, 0x95, 0x28, 0x49, 0x41, 0x79, 0x68, 0x8b, 0x1c,
+	0xb5, 0x42, 0xa2, 0x92, 0xe5, 0xd8, 0xb3, 0xc9, 0x68, 0xbd, 0xb6, 0xeb, 0x19, 0x47, 0x09, 0x12,
+	0x2f, 0xfc, 0x00, 0xde, 0x79, 0xe6, 0x85, 0xff, 0xc4, 0x9f, 0x41, 0x73, 0xb1, 0xe3, 0xdb, 0x76,
+	0x5d, 0xde, 0x66, 0xbe, 0x39, 0xf7, 0x73, 0x7c, 0xce, 0x31, 0x0c, 0xce, 0x43, 0x3f, 0x99, 0x61,
+	0x9b, 0xe2, 0xf8, 0x1c, 0xc7, 0xfb, 0x51, 0x1c, 0xb2, 0x10, 0xf5, 0x0b, 0xa0, 0x1d, 0x9d, 0x98,
+	0x5f, 0x02, 0xfa, 0xc1, 0x61, 0xee, 0xd9, 0x21, 0xf6, 0x31, 0xc3, 0x16, 0x7e, 0x97, 0x60, 0xca,
+	0xd0, 0x75, 0x58, 0x9d, 0x10, 0x1f, 0xdb, 0xc4, 0xa3, 0xba, 0x36, 0x6a, 0x8d, 0xd7, 0xac, 0x0e,
+	0xbf, 0x1f, 0x79, 0xd4, 0x7c, 0x05, 0x83, 0x02, 0x03, 0x8d, 0xc2, 0x80, 0x62, 0xf4, 0x18, 0x3a,
+	0x31, 0xa6, 0x89, 0xcf, 0x24, 0x43, 0xf7, 0xe1, 0xf6, 0x7e, 0x59, 0xd7, 0x7e, 0xc6, 0x92, 0xf8,
+	0xcc, 0x4a, 0xc9, 0x4d, 0x02, 0xbd, 0xfc, 0x03, 0x1a, 0x42, 0x47, 0xe9, 0xd6, 0xb5, 0x91, 0x36,
+	0x5e, 0xb3, 0x56, 0xa4, 0x6a, 0xb4, 0x05, 0x2b, 0x94, 0x39, 0x2c, 0xa1, 0xfa, 0xd2, 0x48, 0x1b,
+	0x2f, 0x5b, 0xea, 0x86, 0x36, 0x60, 0x19, 0xc7, 0x71, 0x18, 0xeb, 0x2d, 0x41, 0x2e, 0x2f, 0x08,
+	0x41, 0x9b, 0x92, 0xdf, 0xb0, 0xde, 0x1e, 0x69, 0xe3, 0x75, 0x4b, 0x9c, 0xcd, 0x0e, 0x2c, 0x3f,
+	0x9f, 0x45, 0xec, 0xd2, 0xfc, 0x06, 0xf4, 0x37, 0x8e, 0x9b, 0x24, 0xb3, 0x37, 0xc2, 0xc6, 0x83,
+	0x33, 0xec, 0x4e, 0x53, 0xdf, 0x6f, 0xc0, 0x9a, 0xb0, 0xdc, 0x4b, 0x2d, 0x58, 0xb7, 0x56, 0x25,
+	0x70, 0xe4, 0x99, 0xdf, 0xc3, 0xf5, 0x1a, 0x46, 0x15, 0x83, 0xcf, 0x61, 0xfd, 0xd4, 0x89, 0x4f,
+	0x9c, 0x53, 0x6c, 0xc7, 0x0e, 0x23, 0xa1, 0xe0, 0xd6, 0xac, 0x9e, 0x02, 0x2d, 0x8e, 0x99, 0xbf,
+	0x82, 0x51, 0x90, 0x10, 0xce, 0x22, 0xc7, 0x65, 0x4d, 0x94, 0xa3, 0x11, 0x74, 0xa3, 0x18, 0x3b,
+	0xbe, 0x1f, 0xba, 0x0e, 0xc3, 0x22, 0x0a, 0x2d, 0x2b, 0x0f, 0x99, 0x9f, 0xc2, 0x8d, 0x5a, 0xe1,
+	0xd2, 0x40, 0xf3, 0x71, 0xc9, 0xfa, 0x70, 0x36, 0x23, 0x8d, 0x54, 0x9b, 0x4f, 0xc1, 0xa8, 0xe3,
+	0x54, 0x8e, 0xdf, 0x84, 0x6e, 0x9a, 0x6c, 0x1e, 0x72, 0xce, 0xdc, 0xb6, 0x40, 0x42, 0xc7, 0x3c,
+	0xf0, 0x4f, 0x4a, 0xec, 0x3e, 0x76, 0x82, 0x24, 0x6a, 0xa4, 0xb9, 0xec, 0x52, 0xca, 0xaa, 0x5c,
+	0x7a, 0x02, 0x43, 0x59, 0x3d, 0x07, 0xa1, 0xef, 0x63, 0x97, 0x91, 0x30, 0x48, 0xc5, 0x6e, 0x03,
+	0xb8, 0x19, 0xa8, 0x6a, 0x29, 0x87, 0x98, 0x06, 0xe8, 0x55, 0x56, 0x25, 0xf6, 0x1f, 0x0d, 0x06,
+	0xcf, 0x28, 0x25, 0xa7, 0x81, 0x54, 0xdb, 0x28, 0x3f, 0x45, 0x85, 0x4b, 0x65, 0x85, 0xe5, 0xfc,
+	0xb5, 0x2a, 0xf9, 0xe3, 0x14, 0x31, 0x8e, 0x7c, 0xe2, 0x3a, 0x42, 0x44, 0x5b, 0x88, 0xc8, 0x43,
+	0xa8, 0x0f, 0x2d, 0xc6, 0x7c, 0x7d, 0x59, 0xbc, 0xf0, 0xa3, 0xb9, 0x05, 0x1b, 0x45, 0x4b, 0x95,
+	0x0b, 0x5f, 0xc3, 0x50, 0x22, 0xc7, 0x97, 0x81, 0x7b, 0x2c, 0x3e, 0x95, 0x46, 0x01, 0xff, 0x57,
+	0x03, 0xbd, 0xca, 0xa8, 0x32, 0xbd, 0xa8, 0x3e, 0x3f, 0xd4, 0x7a, 0x5e, 0x3a, 0xcc, 0x21, 0xbe,
+	0x1d, 0x4e, 0x26, 0x14, 0x33, 0x7d, 0x45, 0x96, 0x0e, 0x87, 0x5e, 0x09, 0x04, 0xed, 0x40, 0xdf,
+	0x95, 0x65, 0x6c, 0xc7, 0xf8, 0x9c, 0x50, 0x2e, 0xb9, 0x23, 0x14, 0x5f, 0x73, 0xd3, 0xf2, 0x96,
+	0x30, 0x32, 0x61, 0x9d, 0x78, 0x17, 0xb6, 0xe8, 0x1e, 0xa2, 0x10, 0x57, 0x85, 0xb4, 0x2e, 0xf1,
+	0x2e, 0x7e, 0x24, 0xbe, 0xac, 0xc4, 0xaf, 0x60, 0xeb, 0xca, 0xb9, 0xa3, 0xc0, 0xc3, 0x17, 0x8d,
+	0x82, 0xf2, 0x13, 0x0c, 0x2b, 0x6c, 0x2a, 0x24, 0x7b, 0x80, 0x08, 0x07, 0xa4, 0x5e, 0x37, 0x0c,
+	0x18, 0x0e, 0x98, 0x10, 0xd0, 0xb3, 0xfa, 0xe2, 0x85, 0x2b, 0x3f, 0x90, 0xb8, 0xf9, 0x97, 0x06,
+	0x9b, 0x57, 0x92, 0x0e, 0x1d, 0xe6, 0x34, 0x2a, 0x2d, 0x03, 0x56, 0x33, 0xef, 0x97, 0xe4, 0x5b,
+	0x7a, 0xe7, 0x7d, 0x51, 0x45, 0xaf, 0x25, 0x5e, 0xd4, 0xad, 0xae, 0x03, 0x72, 0x25, 0x01, 0xc6,
+	0x9e, 0x6c, 0xaf, 0x32, 0x0d, 0xab, 0x12, 0x38, 0xf2, 0xcc, 0x6f, 0x61, 0xab, 0x6c, 0x9a, 0xf2,
+	0xf1, 0x33, 0xe8, 0xd5, 0x78, 0xd7, 0x9d, 0xe4, 0x1c, 0x7b, 0x00, 0x48, 0x32, 0xbf, 0x08, 0x93,
+	0xa0, 0x59, 0x53, 0xd9, 0x84, 0x41, 0x81, 0x45, 0x15, 0xee, 0x23, 0xd8, 0x90, 0xf0, 0xeb, 0x60,
+	0xd6, 0x58, 0xd6, 0x10, 0x36, 0x4b, 0x4c, 0x4a, 0xda, 0x53, 0xd8, 0x96, 0x0f, 0x2f, 0xa5, 0x9b,
+	0x3c, 0x21, 0xcf, 0x2f, 0xa2, 0x30, 0x6e, 0x26, 0xd7, 0x86, 0x9b, 0x73, 0xd9, 0x55, 0x70, 0xbe,
+	0x83, 0x0e, 0x0e, 0x58, 0x4c, 0x70, 0x3a, 0xfa, 0xcc, 0xea, 0xe8, 0xcb, 0x73, 0x07, 0x2c, 0xbe,
+	0xb4, 0x52, 0x16, 0xf3, 0x77, 0xe8, 0x97, 0x1f, 0x8b, 0x59, 0x92, 0xdd, 0x34, 0xcb, 0x12, 0x4f,
+	0xb7, 0x1b, 0x86, 0x53, 0x82, 0x55, 0x21, 0xa8, 0x5b, 0x96, 0xee, 0x56, 0x2e, 0xdd, 0x23, 0xe8,
+	0x39, 0x51, 0x84, 0x03, 0xcf, 0x76, 0x98, 0x1d, 0x50, 0x51, 0x0a, 0x6d, 0x0b, 0x24, 0xf6, 0x8c,
+	0xbd, 0xa4, 0x57, 0x39, 0x78, 0x4d, 0x7e, 0xe6, 0x33, 0x4a, 0xc6, 0x84, 0x37, 0x95, 0x22, 0xac,
+	0xa2, 0xf9, 0x0b, 0xc0, 0x21, 0xa1, 0x53, 0xd9, 0x15, 0xf8, 0xe7, 0xec, 0x91, 0x58, 0xb5, 0x56,
+	0x7e, 0xe4, 0x88, 0xe3, 0xfb, 0xc2, 0xb2, 0xb6, 0xc5, 0x8f, 0xdc, 0xac, 0x84, 0x62, 0x4f, 0x98,
+	0xd5, 0xb6, 0xc4, 0x99, 0x63, 0x93, 0x18, 0x63, 0x65, 0x8e, 0x38, 0x9b, 0x7f, 0x6b, 0xb0, 0xf6,
+	0x02, 0xcf, 0x94, 0xe4, 0x6d, 0x80, 0xd3, 0x30, 0x0e, 0x13, 0x46, 0x02, 0x11, 0x56, 0x3e, 0xef,
+	0x73, 0xc8, 0xff, 0xd7, 0x23, 0xc2, 0x84, 0xfd, 0x89, 0x28, 0xfe, 0xb6, 0x25, 0xce, 0x1c, 0x3b,
+	0xc3, 0x4e, 0xa4, 0xba, 0x8f, 0x38, 0xf3, 0xad, 0x82, 0x32, 0xc7, 0x9d, 0x8a, 0x66, 0xd3, 0xb6,
+	0xe4, 0xe5, 0xe1, 0x9f, 0x5d, 0xe8, 0xa9, 0x6f, 0x44, 0xe4, 0x16, 0xbd, 0x85, 0x6e, 0x6e, 0x1d,
+	0x42, 0x5f, 0x54, 0x53, 0x5f, 0x5d, 0xaf, 0x8c, 0x5b, 0x0b, 0xa8, 0x54, 0xb0, 0x3f, 0x42, 0x01,
+	0x7c, 0x52, 0x59, 0x37, 0xd0, 0x6e, 0x95, 0x7b, 0xde, 0x32, 0x63, 0xdc, 0x6d, 0x44, 0x9b, 0xe9,
+	0x63, 0x30, 0xa8, 0xd9, 0x1f, 0xd0, 0xde, 0x02, 0x29, 0x85, 0x1d, 0xc6, 0xb8, 0xd7, 0x90, 0x3a,
+	0xd3, 0xfa, 0x0e, 0x50, 0x75, 0xb9, 0x40, 0x77, 0x17, 0x8a, 0xb9, 0x5a, 0x5e, 0x8c, 0xbd, 0x66,
+	0xc4, 0x73, 0x1d, 0x95, 0x5b, 0xc5, 0x42, 0x47, 0x0b, 0x7b, 0x8b, 0x71, 0xaf, 0x21, 0x75, 0xa6,
+	0x75, 0x0a, 0xfd, 0xf2, 0xc6, 0x81, 0x76, 0xe6, 0xed, 0xc9, 0x95, 0x85, 0xc6, 0xd8, 0x6d, 0x42,
+	0x9a, 0x29, 0xb3, 0xa1, 0x97, 0xdf, 0x0b, 0x50, 0x4d, 0xd1, 0xd5, 0x6c, 0x38, 0xc6, 0xed, 0x45,
+	0x64, 0x79, 0x6f, 0xca, 0x7b, 0x42, 0x9d, 0x37, 0x73, 0x96, 0x10, 0x63, 0xb7, 0x09, 0x69, 0xa6,
+	0xec, 0x0c, 0xae, 0x95, 0x06, 0x30, 0x1a, 0xbf, 0x4f, 0x40, 0x7e, 0xb4, 0x1b, 0x3b, 0x0d, 0x28,
+	0x33, 0x4d, 0x18, 0x3e, 0x2e, 0x4e, 0x41, 0x74, 0xe7, 0x7d, 0xec, 0xb9, 0x11, 0x6e, 0x8c, 0x17,
+	0x13, 0x66, 0x6a, 0xde, 0x42, 0x37, 0x37, 0xfc, 0xea, 0x1a, 0x47, 0x75, 0x9c, 0x1a, 0xb7, 0x16,
+	0x50, 0x65, 0xd2, 0x4f, 0x60, 0xbd, 0x30, 0x0e, 0xd1, 0xed, 0x79, 0x9c, 0xc5, 0x21, 0x6b, 0xdc,
+	0x59, 0x48, 0x97, 0xe9, 0xf8, 0x43, 0x4b, 0x97, 0xa2, 0xca, 0x6c, 0x44, 0xf7, 0xe7, 0x89, 0x99,
+	0x37, 0x85, 0x8d, 0x07, 0x1f, 0xc0, 0x91, 0x9a, 0x70, 0x5f, 0x3b, 0x59, 0x11, 0x3f, 0xb6, 0x8f,
+	0xfe, 0x1b, 0x00, 0xa7, 0x9f, 0x7d, 0x4d, 0xef, 0x0e, 0x00, 0x00,
 }
@@ -98,7 +98,10 @@ func (g *AzureSink) CreateEntry(key string, entry *filer_pb.Entry) error {
 	}
 
 	totalSize := filer2.TotalSize(entry.Chunks)
-	chunkViews := filer2.ViewFromChunks(entry.Chunks, 0, int(totalSize))
+	chunkViews, err := filer2.ViewFromChunks(g.filerSource.LookupFileId, entry.Chunks, 0, int(totalSize))
+	if err != nil {
+		return err
+	}
 
 	ctx := context.Background()
 
@@ -106,11 +109,17 @@ func (g *AzureSink) CreateEntry(key string, entry *filer_pb.Entry) error {
 	// Azure Storage account's container.
 	appendBlobURL := g.containerURL.NewAppendBlobURL(key)
 
-	_, err := appendBlobURL.Create(ctx, azblob.BlobHTTPHeaders{}, azblob.Metadata{}, azblob.BlobAccessConditions{})
+	_, err = appendBlobURL.Create(ctx, azblob.BlobHTTPHeaders{}, azblob.Metadata{}, azblob.BlobAccessConditions{})
 	if err != nil {
 		return err
 	}
 
+	if len(entry.Content) > 0 {
+		if _, err := appendBlobURL.AppendBlock(ctx, bytes.NewReader(entry.Content), azblob.AppendBlobAccessConditions{}, nil); err != nil {
+			return err
+		}
+	}
+
 	for _, chunk := range chunkViews {
 
 		fileUrl, err := g.filerSource.LookupFileId(chunk.FileId)
@@ -88,7 +88,10 @@ func (g *B2Sink) CreateEntry(key string, entry *filer_pb.Entry) error {
 	}
 
 	totalSize := filer2.TotalSize(entry.Chunks)
-	chunkViews := filer2.ViewFromChunks(entry.Chunks, 0, int(totalSize))
+	chunkViews, err := filer2.ViewFromChunks(g.filerSource.LookupFileId, entry.Chunks, 0, int(totalSize))
+	if err != nil {
+		return err
+	}
 
 	ctx := context.Background()
 
@@ -100,6 +103,10 @@ func (g *B2Sink) CreateEntry(key string, entry *filer_pb.Entry) error {
 	targetObject := bucket.Object(key)
 	writer := targetObject.NewWriter(ctx)
 
+	if _, err := writer.Write(entry.Content); err != nil {
+		return err
+	}
+
 	for _, chunk := range chunkViews {
 
 		fileUrl, err := g.filerSource.LookupFileId(chunk.FileId)
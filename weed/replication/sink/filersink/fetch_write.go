@@ -6,6 +6,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/draleyva/seaweedfs/weed/filer2"
 	"github.com/draleyva/seaweedfs/weed/glog"
 	"github.com/draleyva/seaweedfs/weed/operation"
 	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
@@ -16,6 +17,10 @@ func (fs *FilerSink) replicateChunks(sourceChunks []*filer_pb.FileChunk) (replic
 	if len(sourceChunks) == 0 {
 		return
 	}
+	// the manifests only make sense in the source cluster, so their data chunks are replicated instead
+	if sourceChunks, _, err = filer2.ResolveChunkManifest(fs.filerSource.LookupFileId, sourceChunks); err != nil {
+		return nil, err
+	}
 	var wg sync.WaitGroup
 	for _, sourceChunk := range sourceChunks {
 		wg.Add(1)
@@ -95,7 +95,7 @@ func (fs *FilerSink) CreateEntry(key string, entry *filer_pb.Entry) error {
 		}
 		glog.V(1).Infof("lookup: %v", lookupRequest)
 		if resp, err := client.LookupDirectoryEntry(ctx, lookupRequest); err == nil {
-			if filer2.ETag(resp.Entry.Chunks) == filer2.ETag(entry.Chunks) {
+			if filer2.FileETag(resp.Entry) == filer2.FileETag(entry) {
 				glog.V(0).Infof("already replicated %s", key)
 				return nil
 			}
@@ -116,7 +116,9 @@ func (fs *FilerSink) CreateEntry(key string, entry *filer_pb.Entry) error {
 				Name:        name,
 				IsDirectory: entry.IsDirectory,
 				Attributes:  entry.Attributes,
+				Extended:    entry.Extended,
 				Chunks:      replicatedChunks,
+				Content:     entry.Content,
 			},
 		}
 
@@ -167,13 +169,16 @@ func (fs *FilerSink) UpdateEntry(key string, oldEntry, newEntry *filer_pb.Entry,
 		// skip if already changed
 		// this usually happens when the messages are not ordered
 		glog.V(0).Infof("late updates %s", key)
-	} else if filer2.ETag(newEntry.Chunks) == filer2.ETag(existingEntry.Chunks) {
+	} else if filer2.FileETag(newEntry) == filer2.FileETag(existingEntry) {
 		// skip if no change
 		// this usually happens when retrying the replication
 		glog.V(0).Infof("already replicated %s", key)
 	} else {
 		// find out what changed
-		deletedChunks, newChunks := compareChunks(oldEntry, newEntry)
+		deletedChunks, newChunks, err := fs.compareChunks(oldEntry, newEntry)
+		if err != nil {
+			return true, fmt.Errorf("compare %s chunks: %v", key, err)
+		}
 
 		// delete the chunks that are deleted from the source
 		if deleteIncludeChunks {
@@ -187,6 +192,11 @@ func (fs *FilerSink) UpdateEntry(key string, oldEntry, newEntry *filer_pb.Entry,
 			return true, fmt.Errorf("replicte %s chunks error: %v", key, err)
 		}
 		existingEntry.Chunks = append(existingEntry.Chunks, replicatedChunks...)
+		existingEntry.Content = newEntry.Content
+	}
+
+	if existingEntry.Attributes.Mtime <= newEntry.Attributes.Mtime {
+		existingEntry.Extended = newEntry.Extended
 	}
 
 	// save updated meta data
@@ -205,9 +215,19 @@ func (fs *FilerSink) UpdateEntry(key string, oldEntry, newEntry *filer_pb.Entry,
 	})
 
 }
-func compareChunks(oldEntry, newEntry *filer_pb.Entry) (deletedChunks, newChunks []*filer_pb.FileChunk) {
-	deletedChunks = minusChunks(oldEntry.Chunks, newEntry.Chunks)
-	newChunks = minusChunks(newEntry.Chunks, oldEntry.Chunks)
+
+// compareChunks compares the data chunks, including the chunks folded in the manifests of the source.
+func (fs *FilerSink) compareChunks(oldEntry, newEntry *filer_pb.Entry) (deletedChunks, newChunks []*filer_pb.FileChunk, err error) {
+	oldChunks, _, err := filer2.ResolveChunkManifest(fs.filerSource.LookupFileId, oldEntry.Chunks)
+	if err != nil {
+		return nil, nil, err
+	}
+	newEntryChunks, _, err := filer2.ResolveChunkManifest(fs.filerSource.LookupFileId, newEntry.Chunks)
+	if err != nil {
+		return nil, nil, err
+	}
+	deletedChunks = minusChunks(oldChunks, newEntryChunks)
+	newChunks = minusChunks(newEntryChunks, oldChunks)
 	return
 }
 
@@ -90,12 +90,19 @@ func (g *GcsSink) CreateEntry(key string, entry *filer_pb.Entry) error {
 	}
 
 	totalSize := filer2.TotalSize(entry.Chunks)
-	chunkViews := filer2.ViewFromChunks(entry.Chunks, 0, int(totalSize))
+	chunkViews, err := filer2.ViewFromChunks(g.filerSource.LookupFileId, entry.Chunks, 0, int(totalSize))
+	if err != nil {
+		return err
+	}
 
 	ctx := context.Background()
 
 	wc := g.client.Bucket(g.bucket).Object(key).NewWriter(ctx)
 
+	if _, err := wc.Write(entry.Content); err != nil {
+		return err
+	}
+
 	for _, chunk := range chunkViews {
 
 		fileUrl, err := g.filerSource.LookupFileId(chunk.FileId)
@@ -96,13 +96,20 @@ func (s3sink *S3Sink) CreateEntry(key string, entry *filer_pb.Entry) error {
 		return nil
 	}
 
-	uploadId, err := s3sink.createMultipartUpload(key, entry)
+	if len(entry.Content) > 0 {
+		return s3sink.putObject(key, entry)
+	}
+
+	totalSize := filer2.TotalSize(entry.Chunks)
+	chunkViews, err := filer2.ViewFromChunks(s3sink.filerSource.LookupFileId, entry.Chunks, 0, int(totalSize))
 	if err != nil {
 		return err
 	}
 
-	totalSize := filer2.TotalSize(entry.Chunks)
-	chunkViews := filer2.ViewFromChunks(entry.Chunks, 0, int(totalSize))
+	uploadId, err := s3sink.createMultipartUpload(key, entry)
+	if err != nil {
+		return err
+	}
 
 	var parts []*s3.CompletedPart
 	var wg sync.WaitGroup
@@ -32,6 +32,25 @@ func (s3sink *S3Sink) deleteObject(key string) error {
 
 }
 
+func (s3sink *S3Sink) putObject(key string, entry *filer_pb.Entry) error {
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(s3sink.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(entry.Attributes.Mime),
+		Body:        bytes.NewReader(entry.Content),
+	}
+
+	result, err := s3sink.conn.PutObject(input)
+
+	if err == nil {
+		glog.V(0).Infof("[%s] putObject %s: %v", s3sink.bucket, key, result)
+	} else {
+		glog.Errorf("[%s] putObject %s: %v", s3sink.bucket, key, err)
+	}
+
+	return err
+}
+
 func (s3sink *S3Sink) createMultipartUpload(key string, entry *filer_pb.Entry) (uploadId string, err error) {
 	input := &s3.CreateMultipartUploadInput{
 		Bucket:      aws.String(s3sink.bucket),
@@ -3,6 +3,7 @@ package s3api
 import (
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +16,10 @@ import (
 	"github.com/satori/go.uuid"
 )
 
+// the S3 user metadata is saved in the entry extended attributes with the http header name,
+// same as the filer does for the proxied PutObject requests
+const userMetadataPrefix = "X-Amz-Meta-"
+
 type InitiateMultipartUploadResult struct {
 	s3.CreateMultipartUploadOutput
 }
@@ -28,9 +33,12 @@ func (s3a *S3ApiServer) createMultipartUpload(input *s3.CreateMultipartUploadInp
 			entry.Extended = make(map[string][]byte)
 		}
 		entry.Extended["key"] = []byte(*input.Key)
+		for k, v := range input.Metadata {
+			entry.Extended[userMetadataPrefix+k] = []byte(aws.StringValue(v))
+		}
 	}); err != nil {
 		glog.Errorf("NewMultipartUpload error: %v", err)
-		return nil, ErrInternalError
+		return nil, filerErrorCode(err)
 	}
 
 	output = &InitiateMultipartUploadResult{
@@ -52,6 +60,12 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 
 	uploadDirectory := s3a.genUploadsFolder(*input.Bucket) + "/" + *input.UploadId
 
+	uploadEntry, err := s3a.getEntry(s3a.genUploadsFolder(*input.Bucket), *input.UploadId)
+	if err != nil {
+		glog.Errorf("completeMultipartUpload %s %s error: %v", *input.Bucket, *input.UploadId, err)
+		return nil, ErrNoSuchUpload
+	}
+
 	entries, err := s3a.list(uploadDirectory, "", "", false, 0)
 	if err != nil {
 		glog.Errorf("completeMultipartUpload %s %s error: %v", *input.Bucket, *input.UploadId, err)
@@ -60,10 +74,25 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 
 	var finalParts []*filer_pb.FileChunk
 	var offset int64
+	var manifestParts []string
 
 	for _, entry := range entries {
 		if strings.HasSuffix(entry.Name, ".part") && !entry.IsDirectory {
-			for _, chunk := range entry.Chunks {
+			// the chunks are moved to new offsets, so the manifests of a huge part are replaced by their
+			// data chunks, and the filer folds the completed object again.
+			// The manifests of the parts are deleted once the object is completed.
+			if filer2.HasChunkManifest(entry.Chunks) {
+				manifestParts = append(manifestParts, entry.Name)
+			}
+			partChunks, _, err := filer2.ResolveChunkManifest(filer2.LookupFileIdWithFiler(s3a.withFilerClient), entry.Chunks)
+			if err != nil {
+				glog.Errorf("completeMultipartUpload %s %s part %s: %v", *input.Bucket, *input.UploadId, entry.Name, err)
+				return nil, ErrInternalError
+			}
+			sort.Slice(partChunks, func(i, j int) bool {
+				return partChunks[i].Offset < partChunks[j].Offset
+			})
+			for _, chunk := range partChunks {
 				p := &filer_pb.FileChunk{
 					FileId: chunk.FileId,
 					Offset: offset,
@@ -87,11 +116,26 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 	}
 	dirName = fmt.Sprintf("%s/%s/%s", s3a.option.BucketsPath, *input.Bucket, dirName)
 
-	err = s3a.mkFile(dirName, entryName, finalParts)
+	// assemble the object inside the upload folder, then move it into place in one step
+	// so readers never see a partially completed object
+	completedName := entryName + ".completed"
+	if err = s3a.mkFile(uploadDirectory, completedName, finalParts, func(entry *filer_pb.Entry) {
+		for k, v := range uploadEntry.Extended {
+			if strings.HasPrefix(k, userMetadataPrefix) {
+				if entry.Extended == nil {
+					entry.Extended = make(map[string][]byte)
+				}
+				entry.Extended[k] = v
+			}
+		}
+	}); err != nil {
+		glog.Errorf("completeMultipartUpload %s/%s error: %v", uploadDirectory, completedName, err)
+		return nil, filerErrorCode(err)
+	}
 
-	if err != nil {
+	if err = s3a.mv(uploadDirectory, completedName, dirName, entryName); err != nil {
 		glog.Errorf("completeMultipartUpload %s/%s error: %v", dirName, entryName, err)
-		return nil, ErrInternalError
+		return nil, filerErrorCode(err)
 	}
 
 	output = &CompleteMultipartUploadResult{
@@ -102,6 +146,11 @@ func (s3a *S3ApiServer) completeMultipartUpload(input *s3.CompleteMultipartUploa
 		},
 	}
 
+	for _, name := range manifestParts {
+		if err = s3a.rmKeepingData(uploadDirectory, name); err != nil {
+			glog.V(1).Infof("completeMultipartUpload cleanup %s upload %s part %s: %v", *input.Bucket, *input.UploadId, name, err)
+		}
+	}
 	if err = s3a.rm(s3a.genUploadsFolder(*input.Bucket), *input.UploadId, true, false, true); err != nil {
 		glog.V(1).Infof("completeMultipartUpload cleanup %s upload %s: %v", *input.Bucket, *input.UploadId, err)
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/draleyva/seaweedfs/weed/glog"
 	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/draleyva/seaweedfs/weed/util"
 )
 
 func (s3a *S3ApiServer) mkdir(parentDirectoryPath string, dirName string, fn func(entry *filer_pb.Entry)) error {
@@ -43,7 +44,7 @@ func (s3a *S3ApiServer) mkdir(parentDirectoryPath string, dirName string, fn fun
 	})
 }
 
-func (s3a *S3ApiServer) mkFile(parentDirectoryPath string, fileName string, chunks []*filer_pb.FileChunk) error {
+func (s3a *S3ApiServer) mkFile(parentDirectoryPath string, fileName string, chunks []*filer_pb.FileChunk, fn func(entry *filer_pb.Entry)) error {
 	return s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
 		entry := &filer_pb.Entry{
@@ -59,6 +60,10 @@ func (s3a *S3ApiServer) mkFile(parentDirectoryPath string, fileName string, chun
 			Chunks: chunks,
 		}
 
+		if fn != nil {
+			fn(entry)
+		}
+
 		request := &filer_pb.CreateEntryRequest{
 			Directory: parentDirectoryPath,
 			Entry:     entry,
@@ -73,6 +78,25 @@ func (s3a *S3ApiServer) mkFile(parentDirectoryPath string, fileName string, chun
 	})
 }
 
+func (s3a *S3ApiServer) mv(oldParentDirectoryPath, oldName, newParentDirectoryPath, newName string) error {
+	return s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: oldParentDirectoryPath,
+			OldName:      oldName,
+			NewDirectory: newParentDirectoryPath,
+			NewName:      newName,
+		}
+
+		glog.V(1).Infof("move: %v", request)
+		if _, err := client.AtomicRenameEntry(context.Background(), request); err != nil {
+			return fmt.Errorf("move %s/%s to %s/%s: %v", oldParentDirectoryPath, oldName, newParentDirectoryPath, newName, err)
+		}
+
+		return nil
+	})
+}
+
 func (s3a *S3ApiServer) list(parentDirectoryPath, prefix, startFrom string, inclusive bool, limit int) (entries []*filer_pb.Entry, err error) {
 
 	err = s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
@@ -124,6 +148,50 @@ func (s3a *S3ApiServer) rm(parentDirectoryPath string, entryName string, isDirec
 
 }
 
+// rmKeepingData deletes the file, and only its chunk manifests, since its data chunks are used by another file.
+func (s3a *S3ApiServer) rmKeepingData(parentDirectoryPath string, entryName string) error {
+
+	return s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.DeleteEntryRequest{
+			Directory:              parentDirectoryPath,
+			Name:                   entryName,
+			IsDeleteChunkManifests: true,
+		}
+
+		glog.V(1).Infof("delete entry %v/%v: %v", parentDirectoryPath, entryName, request)
+		if _, err := client.DeleteEntry(context.Background(), request); err != nil {
+			return fmt.Errorf("delete entry %s/%s: %v", parentDirectoryPath, entryName, err)
+		}
+
+		return nil
+	})
+
+}
+
+func (s3a *S3ApiServer) getEntry(parentDirectoryPath string, entryName string) (entry *filer_pb.Entry, err error) {
+
+	err = s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+		request := &filer_pb.LookupDirectoryEntryRequest{
+			Directory: parentDirectoryPath,
+			Name:      entryName,
+		}
+
+		glog.V(4).Infof("get entry %v/%v: %v", parentDirectoryPath, entryName, request)
+		resp, err := client.LookupDirectoryEntry(context.Background(), request)
+		if err != nil {
+			return fmt.Errorf("get entry %s/%s: %v", parentDirectoryPath, entryName, err)
+		}
+
+		entry = resp.Entry
+
+		return nil
+	})
+
+	return
+}
+
 func (s3a *S3ApiServer) exists(parentDirectoryPath string, entryName string, isDirectory bool) (exists bool, err error) {
 
 	err = s3a.withFilerClient(func(client filer_pb.SeaweedFilerClient) error {
@@ -148,3 +216,11 @@ func (s3a *S3ApiServer) exists(parentDirectoryPath string, entryName string, isD
 
 	return
 }
+
+// filerErrorCode reports an exceeded filer directory quota as ErrQuotaExceeded.
+func filerErrorCode(err error) ErrorCode {
+	if util.IsQuotaExceeded(err) {
+		return ErrQuotaExceeded
+	}
+	return ErrInternalError
+}
@@ -42,6 +42,7 @@ const (
 	ErrInvalidPart
 	ErrInternalError
 	ErrNotImplemented
+	ErrQuotaExceeded
 )
 
 // error code to APIError structure, these fields carry respective
@@ -107,6 +108,11 @@ var errorCodeResponse = map[ErrorCode]APIError{
 		Description:    "The specified multipart upload does not exist. The upload ID may be invalid, or the upload may have been aborted or completed.",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrQuotaExceeded: {
+		Code:           "QuotaExceeded",
+		Description:    "The bucket has exceeded its storage quota.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
 	ErrInternalError: {
 		Code:           "InternalError",
 		Description:    "We encountered an internal error, please try again.",
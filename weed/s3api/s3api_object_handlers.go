@@ -3,6 +3,7 @@ package s3api
 import (
 	"crypto/md5"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/draleyva/seaweedfs/weed/glog"
 	"github.com/draleyva/seaweedfs/weed/server"
+	"github.com/draleyva/seaweedfs/weed/util"
 	"github.com/gorilla/mux"
 )
 
@@ -201,6 +203,9 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader
 	}
 	if ret.Error != "" {
 		glog.Errorf("upload to filer error: %v", ret.Error)
+		if util.IsQuotaExceeded(errors.New(ret.Error)) {
+			return "", ErrQuotaExceeded
+		}
 		return "", ErrInternalError
 	}
 
@@ -25,9 +25,17 @@ func (s3a *S3ApiServer) NewMultipartUploadHandler(w http.ResponseWriter, r *http
 	bucket = vars["bucket"]
 	object = vars["object"]
 
+	metadata := make(map[string]*string)
+	for header, values := range r.Header {
+		if strings.HasPrefix(header, userMetadataPrefix) && len(values) > 0 {
+			metadata[header[len(userMetadataPrefix):]] = aws.String(values[0])
+		}
+	}
+
 	response, errCode := s3a.createMultipartUpload(&s3.CreateMultipartUploadInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(object),
+		Bucket:   aws.String(bucket),
+		Key:      aws.String(object),
+		Metadata: metadata,
 	})
 
 	if errCode != ErrNone {
@@ -197,7 +205,8 @@ func (s3a *S3ApiServer) PutObjectPartHandler(w http.ResponseWriter, r *http.Requ
 		dataReader = newSignV4ChunkedReader(r)
 	}
 
-	uploadUrl := fmt.Sprintf("http://%s%s/%s/%04d.part",
+	// the completed object is assembled from the chunks of the parts, so the parts are never kept inline
+	uploadUrl := fmt.Sprintf("http://%s%s/%s/%04d.part?inline=false",
 		s3a.option.Filer, s3a.genUploadsFolder(bucket), uploadID, partID-1)
 
 	etag, errCode := s3a.putToFiler(r, uploadUrl, dataReader)
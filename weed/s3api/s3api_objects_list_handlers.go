@@ -100,6 +100,10 @@ func (s3a *S3ApiServer) listFilerEntries(bucket, originalPrefix string, maxKeys
 			StartFromFileName:  marker,
 			InclusiveStartFrom: false,
 		}
+		if dir == "" {
+			// one more for the trash directory of the bucket, which is not listed
+			request.Limit++
+		}
 
 		resp, err := client.ListEntries(context.Background(), request)
 		if err != nil {
@@ -112,6 +116,9 @@ func (s3a *S3ApiServer) listFilerEntries(bucket, originalPrefix string, maxKeys
 		var lastEntryName string
 		var isTruncated bool
 		for _, entry := range resp.Entries {
+			if dir == "" && entry.IsDirectory && entry.Name == filer2.TrashDirectoryName {
+				continue
+			}
 			counter++
 			if counter > maxKeys {
 				isTruncated = true
@@ -126,8 +133,8 @@ func (s3a *S3ApiServer) listFilerEntries(bucket, originalPrefix string, maxKeys
 				contents = append(contents, &s3.Object{
 					Key:          aws.String(fmt.Sprintf("%s%s", dir, entry.Name)),
 					LastModified: aws.Time(time.Unix(entry.Attributes.Mtime, 0)),
-					ETag:         aws.String("\"" + filer2.ETag(entry.Chunks) + "\""),
-					Size:         aws.Int64(int64(filer2.TotalSize(entry.Chunks))),
+					ETag:         aws.String("\"" + filer2.FileETag(entry) + "\""),
+					Size:         aws.Int64(int64(filer2.FileSize(entry))),
 					Owner: &s3.Owner{
 						ID:          aws.String("bcaf161ca5fb16fd081034f"),
 						DisplayName: aws.String("webfile"),
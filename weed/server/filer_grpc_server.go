@@ -18,17 +18,21 @@ import (
 
 func (fs *FilerServer) LookupDirectoryEntry(ctx context.Context, req *filer_pb.LookupDirectoryEntryRequest) (*filer_pb.LookupDirectoryEntryResponse, error) {
 
-	entry, err := fs.filer.FindEntry(filer2.FullPath(filepath.Join(req.Directory, req.Name)))
+	entry, err := fs.filer.FindEntry(ctx, filer2.FullPath(filepath.Join(req.Directory, req.Name)))
 	if err != nil {
 		return nil, fmt.Errorf("%s not found under %s: %v", req.Name, req.Directory, err)
 	}
 
 	return &filer_pb.LookupDirectoryEntryResponse{
 		Entry: &filer_pb.Entry{
-			Name:        req.Name,
-			IsDirectory: entry.IsDirectory(),
-			Attributes:  filer2.EntryAttributeToPb(entry),
-			Chunks:      entry.Chunks,
+			Name:            req.Name,
+			IsDirectory:     entry.IsDirectory(),
+			Attributes:      filer2.EntryAttributeToPb(entry),
+			Chunks:          entry.Chunks,
+			Content:         entry.Content,
+			Extended:        entry.Extended,
+			HardLinkId:      entry.HardLinkId,
+			HardLinkCounter: entry.HardLinkCounter,
 		},
 	}, nil
 }
@@ -40,39 +44,42 @@ func (fs *FilerServer) ListEntries(ctx context.Context, req *filer_pb.ListEntrie
 		limit = fs.option.DirListingLimit
 	}
 
-	resp := &filer_pb.ListEntriesResponse{}
-	lastFileName := req.StartFromFileName
-	includeLastFile := req.InclusiveStartFrom
-	for limit > 0 {
-		entries, err := fs.filer.ListDirectoryEntries(filer2.FullPath(req.Directory), lastFileName, includeLastFile, limit)
-		if err != nil {
-			return nil, err
-		}
-		if len(entries) == 0 {
-			return resp, nil
-		}
-
-		includeLastFile = false
-
-		for _, entry := range entries {
-
-			lastFileName = entry.Name()
+	options := &filer2.ListOptions{
+		Prefix:      req.Prefix,
+		NamePattern: req.NamePattern,
+		MinSize:     req.MinSize,
+		MaxSize:     req.MaxSize,
+		Recursive:   req.Recursive,
+	}
+	if req.MinMtime > 0 {
+		options.MinMtime = time.Unix(req.MinMtime, 0)
+	}
+	if req.MaxMtime > 0 {
+		options.MaxMtime = time.Unix(req.MaxMtime, 0)
+	}
 
-			if req.Prefix != "" {
-				if !strings.HasPrefix(entry.Name(), req.Prefix) {
-					continue
-				}
-			}
+	dir := filer2.FullPath(req.Directory)
+	entries, lastScanned, err := fs.filer.SearchDirectoryEntries(ctx, dir, req.StartFromFileName, req.InclusiveStartFrom, limit, options)
+	if err != nil {
+		return nil, err
+	}
 
-			resp.Entries = append(resp.Entries, &filer_pb.Entry{
-				Name:        entry.Name(),
-				IsDirectory: entry.IsDirectory(),
-				Chunks:      entry.Chunks,
-				Attributes:  filer2.EntryAttributeToPb(entry),
-			})
-			limit--
+	resp := &filer_pb.ListEntriesResponse{LastScannedFileName: lastScanned}
+	for _, entry := range entries {
+		name := entry.Name()
+		if req.Recursive {
+			name = entry.FullPath.RelativeTo(dir)
 		}
-
+		resp.Entries = append(resp.Entries, &filer_pb.Entry{
+			Name:            name,
+			IsDirectory:     entry.IsDirectory(),
+			Chunks:          entry.Chunks,
+			Content:         entry.Content,
+			Attributes:      filer2.EntryAttributeToPb(entry),
+			Extended:        entry.Extended,
+			HardLinkId:      entry.HardLinkId,
+			HardLinkCounter: entry.HardLinkCounter,
+		})
 	}
 
 	return resp, nil
@@ -110,12 +117,27 @@ func (fs *FilerServer) CreateEntry(ctx context.Context, req *filer_pb.CreateEntr
 	fullpath := filer2.FullPath(filepath.Join(req.Directory, req.Entry.Name))
 	chunks, garbages := filer2.CompactFileChunks(req.Entry.Chunks)
 
+	// the garbage chunks from the existing entry are handled by the filer, which may keep them as a version
+	existingEntry, findErr := fs.filer.FindEntry(ctx, fullpath)
+	if findErr == nil {
+		if garbages, err = fs.filer.FindUnusedChunks(garbages, existingEntry.Chunks); err != nil {
+			return &filer_pb.CreateEntryResponse{}, fmt.Errorf("create %s: %v", fullpath, err)
+		}
+	}
+
+	// the hard links are only created by LinkEntry, a client can only keep the hard link of the existing entry
+	if req.Entry.HardLinkId != "" && (findErr != nil || existingEntry.HardLinkId != req.Entry.HardLinkId) {
+		return &filer_pb.CreateEntryResponse{}, fmt.Errorf("create %s: hard link %s is not of the existing entry", fullpath, req.Entry.HardLinkId)
+	}
 	fs.filer.DeleteChunks(garbages)
 
-	err = fs.filer.CreateEntry(&filer2.Entry{
-		FullPath: fullpath,
-		Attr:     filer2.PbToEntryAttribute(req.Entry.Attributes),
-		Chunks:   chunks,
+	err = fs.filer.CreateEntry(ctx, &filer2.Entry{
+		FullPath:   fullpath,
+		Attr:       filer2.PbToEntryAttribute(req.Entry.Attributes),
+		Extended:   req.Entry.Extended,
+		Chunks:     chunks,
+		Content:    req.Entry.Content,
+		HardLinkId: req.Entry.HardLinkId,
 	})
 
 	if err == nil {
@@ -127,20 +149,25 @@ func (fs *FilerServer) CreateEntry(ctx context.Context, req *filer_pb.CreateEntr
 func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntryRequest) (*filer_pb.UpdateEntryResponse, error) {
 
 	fullpath := filepath.Join(req.Directory, req.Entry.Name)
-	entry, err := fs.filer.FindEntry(filer2.FullPath(fullpath))
+	entry, err := fs.filer.FindEntry(ctx, filer2.FullPath(fullpath))
 	if err != nil {
 		return &filer_pb.UpdateEntryResponse{}, fmt.Errorf("not found %s: %v", fullpath, err)
 	}
 
-	// remove old chunks if not included in the new ones
-	unusedChunks := filer2.FindUnusedFileChunks(entry.Chunks, req.Entry.Chunks)
-
+	// the old chunks not included in the new ones are removed by the filer, which may keep them as a version
 	chunks, garbages := filer2.CompactFileChunks(req.Entry.Chunks)
+	if garbages, err = fs.filer.FindUnusedChunks(garbages, entry.Chunks); err != nil {
+		return &filer_pb.UpdateEntryResponse{}, fmt.Errorf("update %s: %v", fullpath, err)
+	}
 
 	newEntry := &filer2.Entry{
-		FullPath: filer2.FullPath(filepath.Join(req.Directory, req.Entry.Name)),
-		Attr:     entry.Attr,
-		Chunks:   chunks,
+		FullPath:        filer2.FullPath(filepath.Join(req.Directory, req.Entry.Name)),
+		Attr:            entry.Attr,
+		Extended:        req.Entry.Extended,
+		Chunks:          chunks,
+		Content:         req.Entry.Content,
+		HardLinkId:      entry.HardLinkId,
+		HardLinkCounter: entry.HardLinkCounter,
 	}
 
 	glog.V(3).Infof("updating %s: %+v, chunks %d: %v => %+v, chunks %d: %v",
@@ -164,21 +191,48 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 		return &filer_pb.UpdateEntryResponse{}, err
 	}
 
-	if err = fs.filer.UpdateEntry(newEntry); err == nil {
-		fs.filer.DeleteChunks(unusedChunks)
+	if err = fs.filer.UpdateEntry(ctx, newEntry); err == nil {
 		fs.filer.DeleteChunks(garbages)
+		fs.filer.NotifyUpdateEvent(entry, newEntry, true)
 	}
 
-	fs.filer.NotifyUpdateEvent(entry, newEntry, true)
-
 	return &filer_pb.UpdateEntryResponse{}, err
 }
 
 func (fs *FilerServer) DeleteEntry(ctx context.Context, req *filer_pb.DeleteEntryRequest) (resp *filer_pb.DeleteEntryResponse, err error) {
-	err = fs.filer.DeleteEntryMetaAndData(filer2.FullPath(filepath.Join(req.Directory, req.Name)), req.IsRecursive, req.IsDeleteData)
+	fullpath := filer2.FullPath(filepath.Join(req.Directory, req.Name))
+	if req.IsDeleteChunkManifests && !req.IsDeleteData {
+		err = fs.filer.DeleteEntryKeepingData(ctx, fullpath)
+		return &filer_pb.DeleteEntryResponse{}, err
+	}
+	err = fs.filer.DeleteEntryMetaAndData(ctx, fullpath, req.IsRecursive, req.IsDeleteData)
 	return &filer_pb.DeleteEntryResponse{}, err
 }
 
+func (fs *FilerServer) AtomicRenameEntry(ctx context.Context, req *filer_pb.AtomicRenameEntryRequest) (*filer_pb.AtomicRenameEntryResponse, error) {
+
+	oldPath := filer2.NewFullPath(req.OldDirectory, req.OldName)
+	newPath := filer2.NewFullPath(req.NewDirectory, req.NewName)
+
+	if err := fs.filer.AtomicRenameEntry(ctx, oldPath, newPath); err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.AtomicRenameEntryResponse{}, nil
+}
+
+func (fs *FilerServer) LinkEntry(ctx context.Context, req *filer_pb.LinkEntryRequest) (*filer_pb.LinkEntryResponse, error) {
+
+	oldPath := filer2.NewFullPath(req.OldDirectory, req.OldName)
+	newPath := filer2.NewFullPath(req.NewDirectory, req.NewName)
+
+	if err := fs.filer.CreateHardLink(ctx, oldPath, newPath); err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.LinkEntryResponse{}, nil
+}
+
 func (fs *FilerServer) AssignVolume(ctx context.Context, req *filer_pb.AssignVolumeRequest) (resp *filer_pb.AssignVolumeResponse, err error) {
 
 	ttlStr := ""
@@ -233,3 +287,30 @@ func (fs *FilerServer) DeleteCollection(ctx context.Context, req *filer_pb.Delet
 
 	return &filer_pb.DeleteCollectionResponse{}, err
 }
+
+func (fs *FilerServer) SubscribeMetadata(req *filer_pb.SubscribeMetadataRequest, stream filer_pb.SeaweedFiler_SubscribeMetadataServer) error {
+
+	if fs.filer.MetaLog == nil {
+		return fmt.Errorf("metadata log is not enabled on this filer")
+	}
+
+	glog.V(0).Infof("+ metadata subscriber %s prefix %s since %d", req.ClientName, req.PathPrefix, req.SinceNs)
+	defer glog.V(0).Infof("- metadata subscriber %s", req.ClientName)
+
+	return fs.filer.MetaLog.Subscribe(stream.Context(), req.SinceNs, func(resp *filer_pb.SubscribeMetadataResponse) error {
+		if !metadataEventHasPrefix(resp.EventNotification, req.PathPrefix) {
+			return nil
+		}
+		return stream.Send(resp)
+	})
+}
+
+func metadataEventHasPrefix(event *filer_pb.EventNotification, prefix string) bool {
+	if prefix == "" || prefix == "/" {
+		return true
+	}
+	if event.OldEntry != nil && strings.HasPrefix(event.OldEntry.Name, prefix) {
+		return true
+	}
+	return event.NewEntry != nil && strings.HasPrefix(event.NewEntry.Name, prefix)
+}
@@ -0,0 +1,37 @@
+package weed_server
+
+import (
+	"context"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func (fs *FilerServer) AppendToEntry(ctx context.Context, req *filer_pb.AppendToEntryRequest) (*filer_pb.AppendToEntryResponse, error) {
+
+	entry := &filer2.Entry{
+		FullPath: filer2.NewFullPath(req.Directory, req.EntryName),
+		Chunks:   req.Chunks,
+	}
+	if req.Attributes != nil {
+		entry.Attr = filer2.PbToEntryAttribute(req.Attributes)
+	} else {
+		entry.Attr = filer2.Attr{
+			Mtime:  time.Now(),
+			Crtime: time.Now(),
+			Mode:   0660,
+			Uid:    OS_UID,
+			Gid:    OS_GID,
+		}
+	}
+
+	offset, err := fs.filer.AppendToEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.AppendToEntryResponse{
+		Offset: offset,
+	}, nil
+}
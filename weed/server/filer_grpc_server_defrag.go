@@ -0,0 +1,39 @@
+package weed_server
+
+import (
+	"context"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func (fs *FilerServer) Defragment(ctx context.Context, req *filer_pb.DefragmentRequest) (*filer_pb.DefragmentResponse, error) {
+
+	chunkSize := fs.chunkSizeLimit(int(req.MaxMb))
+
+	if req.Name != "" {
+		before, after, err := fs.filer.DefragmentEntry(ctx, filer2.NewFullPath(req.Directory, req.Name), chunkSize)
+		if err != nil {
+			return nil, err
+		}
+		resp := &filer_pb.DefragmentResponse{
+			OldChunkCount: uint64(before),
+			NewChunkCount: uint64(after),
+		}
+		if after < before {
+			resp.FileCount = 1
+		}
+		return resp, nil
+	}
+
+	files, before, after, err := fs.filer.DefragmentDirectory(ctx, snapshotDirectory(req.Directory), int(req.MinChunks), chunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.DefragmentResponse{
+		FileCount:     uint32(files),
+		OldChunkCount: uint64(before),
+		NewChunkCount: uint64(after),
+	}, nil
+}
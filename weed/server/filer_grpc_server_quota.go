@@ -0,0 +1,48 @@
+package weed_server
+
+import (
+	"context"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func (fs *FilerServer) DirectoryQuotaConfigure(ctx context.Context, req *filer_pb.DirectoryQuotaConfigureRequest) (*filer_pb.DirectoryQuotaConfigureResponse, error) {
+
+	dir := filer2.FullPath(req.Directory)
+	if len(dir) > 1 && dir[len(dir)-1] == '/' {
+		dir = dir[:len(dir)-1]
+	}
+
+	if err := fs.filer.ConfigureDirectoryQuota(ctx, dir, req.MaxBytes, req.MaxEntryCount); err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.DirectoryQuotaConfigureResponse{}, nil
+}
+
+func (fs *FilerServer) DirectoryQuotaList(ctx context.Context, req *filer_pb.DirectoryQuotaListRequest) (*filer_pb.DirectoryQuotaListResponse, error) {
+
+	quotas, err := fs.filer.ListDirectoryQuotas(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.DirectoryQuotaListResponse{
+		Quotas: quotas,
+	}, nil
+}
+
+func (fs *FilerServer) DirectoryUsage(ctx context.Context, req *filer_pb.DirectoryUsageRequest) (*filer_pb.DirectoryUsageResponse, error) {
+
+	usage, err := fs.filer.DirectoryUsage(ctx, snapshotDirectory(req.Directory))
+	if err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.DirectoryUsageResponse{
+		Bytes:          usage.Bytes,
+		FileCount:      usage.FileCount,
+		DirectoryCount: usage.DirectoryCount,
+	}, nil
+}
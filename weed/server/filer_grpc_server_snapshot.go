@@ -0,0 +1,46 @@
+package weed_server
+
+import (
+	"context"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func (fs *FilerServer) SnapshotCreate(ctx context.Context, req *filer_pb.SnapshotCreateRequest) (*filer_pb.SnapshotCreateResponse, error) {
+
+	if err := fs.filer.CreateSnapshot(ctx, snapshotDirectory(req.Directory), req.Name); err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.SnapshotCreateResponse{}, nil
+}
+
+func (fs *FilerServer) SnapshotDelete(ctx context.Context, req *filer_pb.SnapshotDeleteRequest) (*filer_pb.SnapshotDeleteResponse, error) {
+
+	if err := fs.filer.DeleteSnapshot(ctx, snapshotDirectory(req.Directory), req.Name); err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.SnapshotDeleteResponse{}, nil
+}
+
+func (fs *FilerServer) SnapshotList(ctx context.Context, req *filer_pb.SnapshotListRequest) (*filer_pb.SnapshotListResponse, error) {
+
+	snapshots, err := fs.filer.ListSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.SnapshotListResponse{
+		Snapshots: snapshots,
+	}, nil
+}
+
+func snapshotDirectory(directory string) filer2.FullPath {
+	dir := filer2.FullPath(directory)
+	if len(dir) > 1 && dir[len(dir)-1] == '/' {
+		dir = dir[:len(dir)-1]
+	}
+	return dir
+}
@@ -0,0 +1,103 @@
+package weed_server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/draleyva/seaweedfs/weed/util"
+)
+
+func TestCreateEntryHardLinkId(t *testing.T) {
+	fs, cleanup := newTestFilerServer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if err := fs.filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/data/a", Attr: filer2.Attr{Mode: 0660}}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := fs.filer.CreateHardLink(ctx, "/data/a", "/data/b"); err != nil {
+		t.Fatalf("link: %v", err)
+	}
+	linked, err := fs.filer.FindEntry(ctx, "/data/a")
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+
+	// a client can not attach another entry to an existing hard link
+	if _, err := fs.CreateEntry(ctx, &filer_pb.CreateEntryRequest{
+		Directory: "/data",
+		Entry: &filer_pb.Entry{
+			Name:       "c",
+			Attributes: &filer_pb.FuseAttributes{FileMode: 0660},
+			HardLinkId: linked.HardLinkId,
+		},
+	}); err == nil {
+		t.Fatalf("created a new entry with hard link %s", linked.HardLinkId)
+	}
+
+	// saving the linked entry back with its hard link id keeps the link
+	if _, err := fs.CreateEntry(ctx, &filer_pb.CreateEntryRequest{
+		Directory: "/data",
+		Entry: &filer_pb.Entry{
+			Name:       "a",
+			Attributes: &filer_pb.FuseAttributes{FileMode: uint32(os.FileMode(0640))},
+			HardLinkId: linked.HardLinkId,
+		},
+	}); err != nil {
+		t.Fatalf("save the linked entry: %v", err)
+	}
+	if entry, err := fs.filer.FindEntry(ctx, "/data/b"); err != nil || entry.Mode != 0640 || entry.HardLinkCounter != 2 {
+		t.Errorf("the other link after saving: %+v, %v", entry, err)
+	}
+}
+
+func TestUpdateEntryFailureNotLogged(t *testing.T) {
+	fs, cleanup := newTestFilerServer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	dir, _ := ioutil.TempDir("", "seaweedfs_meta_log_test")
+	defer os.RemoveAll(dir)
+	metaLog, err := filer2.NewMetaLog(dir)
+	if err != nil {
+		t.Fatalf("create meta log: %v", err)
+	}
+	fs.filer.MetaLog = metaLog
+
+	if err := fs.filer.CreateEntry(ctx, &filer2.Entry{
+		FullPath: "/data/a",
+		Attr:     filer2.Attr{Mode: 0660},
+		Chunks:   []*filer_pb.FileChunk{{FileId: "1,01", Size: 10}},
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := fs.filer.ConfigureDirectoryQuota(ctx, "/data", 50, 0); err != nil {
+		t.Fatalf("configure quota: %v", err)
+	}
+
+	if _, err := fs.UpdateEntry(ctx, &filer_pb.UpdateEntryRequest{
+		Directory: "/data",
+		Entry: &filer_pb.Entry{
+			Name:       "a",
+			Attributes: &filer_pb.FuseAttributes{FileMode: 0660},
+			Chunks:     []*filer_pb.FileChunk{{FileId: "1,02", Size: 100}},
+		},
+	}); !util.IsQuotaExceeded(err) {
+		t.Fatalf("expected quota exceeded, got %v", err)
+	}
+
+	// the subscribers and the metadata backups do not see the failed update
+	subscribeCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	metaLog.Subscribe(subscribeCtx, 0, func(resp *filer_pb.SubscribeMetadataResponse) error {
+		if newEntry := resp.EventNotification.NewEntry; newEntry != nil && len(newEntry.Chunks) > 0 && newEntry.Chunks[0].FileId == "1,02" {
+			t.Errorf("failed update is logged: %+v", resp)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,29 @@
+package weed_server
+
+import (
+	"context"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func (fs *FilerServer) TrashList(ctx context.Context, req *filer_pb.TrashListRequest) (*filer_pb.TrashListResponse, error) {
+
+	entries, err := fs.filer.ListTrash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.TrashListResponse{
+		Entries: entries,
+	}, nil
+}
+
+func (fs *FilerServer) TrashRestore(ctx context.Context, req *filer_pb.TrashRestoreRequest) (*filer_pb.TrashRestoreResponse, error) {
+
+	if err := fs.filer.RestoreFromTrash(ctx, filer2.FullPath(req.TrashPath)); err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.TrashRestoreResponse{}, nil
+}
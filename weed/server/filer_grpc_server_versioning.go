@@ -0,0 +1,56 @@
+package weed_server
+
+import (
+	"context"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+)
+
+func (fs *FilerServer) VersioningConfigure(ctx context.Context, req *filer_pb.VersioningConfigureRequest) (*filer_pb.VersioningConfigureResponse, error) {
+
+	dir := filer2.FullPath(req.Directory)
+	if len(dir) > 1 && dir[len(dir)-1] == '/' {
+		dir = dir[:len(dir)-1]
+	}
+
+	if err := fs.filer.ConfigureVersioning(ctx, dir, req.Enabled, req.MaxVersions, time.Duration(req.MaxAgeSeconds)*time.Second); err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.VersioningConfigureResponse{}, nil
+}
+
+func (fs *FilerServer) VersioningList(ctx context.Context, req *filer_pb.VersioningListRequest) (*filer_pb.VersioningListResponse, error) {
+
+	configs, err := fs.filer.ListVersioningConfigs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.VersioningListResponse{
+		Configs: configs,
+	}, nil
+}
+
+func (fs *FilerServer) VersionList(ctx context.Context, req *filer_pb.VersionListRequest) (*filer_pb.VersionListResponse, error) {
+
+	versions, err := fs.filer.ListVersions(ctx, filer2.NewFullPath(req.Directory, req.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.VersionListResponse{
+		Versions: versions,
+	}, nil
+}
+
+func (fs *FilerServer) VersionRestore(ctx context.Context, req *filer_pb.VersionRestoreRequest) (*filer_pb.VersionRestoreResponse, error) {
+
+	if err := fs.filer.RestoreVersion(ctx, filer2.NewFullPath(req.Directory, req.Name), req.VersionId); err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.VersionRestoreResponse{}, nil
+}
@@ -2,14 +2,18 @@ package weed_server
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/draleyva/seaweedfs/weed/filer2"
+	_ "github.com/draleyva/seaweedfs/weed/filer2/badger"
+	_ "github.com/draleyva/seaweedfs/weed/filer2/bolt"
 	_ "github.com/draleyva/seaweedfs/weed/filer2/cassandra"
 	_ "github.com/draleyva/seaweedfs/weed/filer2/leveldb"
 	_ "github.com/draleyva/seaweedfs/weed/filer2/memdb"
 	_ "github.com/draleyva/seaweedfs/weed/filer2/mysql"
 	_ "github.com/draleyva/seaweedfs/weed/filer2/postgres"
 	_ "github.com/draleyva/seaweedfs/weed/filer2/redis"
+	_ "github.com/draleyva/seaweedfs/weed/filer2/sqlite"
 	"github.com/draleyva/seaweedfs/weed/glog"
 	"github.com/draleyva/seaweedfs/weed/notification"
 	_ "github.com/draleyva/seaweedfs/weed/notification/aws_sqs"
@@ -21,18 +25,28 @@ import (
 )
 
 type FilerOption struct {
-	Masters            []string
-	Collection         string
-	DefaultReplication string
-	RedirectOnRead     bool
-	DisableDirListing  bool
-	MaxMB              int
-	SecretKey          string
-	DirListingLimit    int
-	DataCenter         string
-	WhiteList          []string
+	Masters             []string
+	Collection          string
+	DefaultReplication  string
+	RedirectOnRead      bool
+	DisableDirListing   bool
+	MaxMB               int
+	SecretKey           string
+	DirListingLimit     int
+	DataCenter          string
+	WhiteList           []string
+	MetaLogDir          string
+	BucketsPath         string
+	TrashRetention      time.Duration
+	InlineMaxBytes      int
+	AppendCompactChunks int
+	DefragMinChunks     int
+	ChunkManifest       bool
 }
 
+// the size of the chunks written by the filer itself, if the filer is not started with -maxMB
+const defaultChunkSizeMB = 32
+
 type FilerServer struct {
 	option *FilerOption
 	secret security.Secret
@@ -52,13 +66,37 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 
 	fs.filer = filer2.NewFiler(option.Masters)
 
+	if option.MetaLogDir != "" {
+		if fs.filer.MetaLog, err = filer2.NewMetaLog(option.MetaLogDir); err != nil {
+			glog.Fatalf("open metadata log %s: %v", option.MetaLogDir, err)
+		}
+	}
+
+	if option.TrashRetention > 0 {
+		fs.filer.EnableTrash(option.BucketsPath, option.TrashRetention)
+	}
+
+	if option.AppendCompactChunks > 0 {
+		fs.filer.EnableAppendCompaction(option.AppendCompactChunks, fs.chunkSizeLimit(0))
+	}
+
+	if option.ChunkManifest {
+		fs.filer.EnableChunkManifest()
+	}
+
+	if option.DefragMinChunks > 0 {
+		fs.filer.EnableDefragmentation(option.DefragMinChunks, fs.chunkSizeLimit(0))
+	}
+
 	go fs.filer.KeepConnectedToMaster()
+	go fs.filer.KeepExpiringVersions()
 
 	LoadConfiguration("filer", true)
 	LoadConfiguration("notification", false)
 	v := viper.GetViper()
 
 	fs.filer.LoadConfiguration(v)
+	go fs.filer.KeepDeletingReplacedChunks()
 
 	notification.LoadConfiguration(v.Sub("notification"))
 
@@ -73,6 +111,17 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 	return fs, nil
 }
 
+// chunkSizeLimit returns the chunk size in bytes, from maxMB of the request, or else of the filer.
+func (fs *FilerServer) chunkSizeLimit(maxMB int) int64 {
+	if maxMB <= 0 {
+		maxMB = fs.option.MaxMB
+	}
+	if maxMB <= 0 {
+		maxMB = defaultChunkSizeMB
+	}
+	return int64(maxMB) * 1024 * 1024
+}
+
 func (fs *FilerServer) jwt(fileId string) security.EncodedJwt {
 	return security.GenJwt(fs.secret, fileId)
 }
@@ -1,6 +1,8 @@
 package weed_server
 
 import (
+	"bytes"
+	"context"
 	"io"
 	"net/http"
 	"net/url"
@@ -8,6 +10,7 @@ import (
 
 	"github.com/draleyva/seaweedfs/weed/filer2"
 	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/storage"
 	"github.com/draleyva/seaweedfs/weed/util"
 	"mime"
 	"mime/multipart"
@@ -21,7 +24,24 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request,
 		path = path[:len(path)-1]
 	}
 
-	entry, err := fs.filer.FindEntry(filer2.FullPath(path))
+	query := r.URL.Query()
+	if _, listVersions := query["versions"]; listVersions && r.Method == "GET" {
+		fs.listVersionsHandler(w, r, filer2.FullPath(path))
+		return
+	}
+
+	if _, du := query["du"]; du && r.Method == "GET" {
+		fs.directoryUsageHandler(w, r, filer2.FullPath(path))
+		return
+	}
+
+	var entry *filer2.Entry
+	var err error
+	if versionId := query.Get("version"); versionId != "" {
+		entry, err = fs.filer.FindVersion(context.Background(), filer2.FullPath(path), versionId)
+	} else {
+		entry, err = fs.filer.FindEntry(context.Background(), filer2.FullPath(path))
+	}
 	if err != nil {
 		if path == "/" {
 			fs.listDirectoryHandler(w, r)
@@ -41,6 +61,11 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	if len(entry.Content) > 0 {
+		fs.handleInlineContent(w, r, entry)
+		return
+	}
+
 	if len(entry.Chunks) == 0 {
 		glog.V(1).Infof("no file chunks for %s, attr=%+v", path, entry.Attr)
 		w.WriteHeader(http.StatusNoContent)
@@ -48,12 +73,13 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request,
 	}
 
 	w.Header().Set("Accept-Ranges", "bytes")
+	setExtendedHeaders(w, entry)
 	if r.Method == "HEAD" {
 		w.Header().Set("Content-Length", strconv.FormatInt(int64(filer2.TotalSize(entry.Chunks)), 10))
 		return
 	}
 
-	if len(entry.Chunks) == 1 {
+	if len(entry.Chunks) == 1 && !entry.Chunks[0].IsChunkManifest {
 		fs.handleSingleChunk(w, r, entry)
 		return
 	}
@@ -62,6 +88,49 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request,
 
 }
 
+// listVersionsHandler lists the previous versions of the file, the oldest first.
+func (fs *FilerServer) listVersionsHandler(w http.ResponseWriter, r *http.Request, p filer2.FullPath) {
+
+	versions, err := fs.filer.ListVersions(context.Background(), p)
+	if err != nil {
+		writeJsonError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJsonQuiet(w, r, http.StatusOK, struct {
+		Path     string
+		Versions interface{}
+	}{
+		string(p),
+		versions,
+	})
+}
+
+// setExtendedHeaders is the reverse of extendedFromRequest.
+func setExtendedHeaders(w http.ResponseWriter, entry *filer2.Entry) {
+	for name, value := range entry.Extended {
+		if strings.ContainsAny(string(value), "\r\n\x00") {
+			continue
+		}
+		if !strings.HasPrefix(name, s3UserMetadataPrefix) {
+			name = storage.PairNamePrefix + name
+		}
+		w.Header()[name] = []string{string(value)}
+	}
+}
+
+// handleInlineContent serves the content kept in the entry, without reading from the volume servers.
+func (fs *FilerServer) handleInlineContent(w http.ResponseWriter, r *http.Request, entry *filer2.Entry) {
+
+	setExtendedHeaders(w, entry)
+	if entry.Mime != "" {
+		w.Header().Set("Content-Type", entry.Mime)
+	}
+	setEtag(w, filer2.ContentETag(entry.Content))
+
+	http.ServeContent(w, r, entry.Name(), entry.Mtime, bytes.NewReader(entry.Content))
+}
+
 func (fs *FilerServer) handleSingleChunk(w http.ResponseWriter, r *http.Request, entry *filer2.Entry) {
 
 	fileId := entry.Chunks[0].FileId
@@ -221,7 +290,11 @@ func (fs *FilerServer) handleMultipleChunks(w http.ResponseWriter, r *http.Reque
 
 func (fs *FilerServer) writeContent(w io.Writer, entry *filer2.Entry, offset int64, size int) error {
 
-	chunkViews := filer2.ViewFromChunks(entry.Chunks, offset, size)
+	chunkViews, err := filer2.ViewFromChunks(fs.filer.MasterClient.LookupFileId, entry.Chunks, offset, size)
+	if err != nil {
+		glog.V(1).Infof("read %s: %v", entry.FullPath, err)
+		return err
+	}
 
 	fileId2Url := make(map[string]string)
 
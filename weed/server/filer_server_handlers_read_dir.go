@@ -1,9 +1,13 @@
 package weed_server
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/draleyva/seaweedfs/weed/filer2"
 	"github.com/draleyva/seaweedfs/weed/glog"
@@ -27,7 +31,13 @@ func (fs *FilerServer) listDirectoryHandler(w http.ResponseWriter, r *http.Reque
 
 	lastFileName := r.FormValue("lastFileName")
 
-	entries, err := fs.filer.ListDirectoryEntries(filer2.FullPath(path), lastFileName, false, limit)
+	options, err := listOptionsFromRequest(r)
+	if err != nil {
+		writeJsonError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	entries, lastScanned, err := fs.filer.SearchDirectoryEntries(context.Background(), filer2.FullPath(path), lastFileName, false, limit, options)
 
 	if err != nil {
 		glog.V(0).Infof("listDirectory %s %s %d: %s", path, lastFileName, limit, err)
@@ -35,13 +45,26 @@ func (fs *FilerServer) listDirectoryHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	shouldDisplayLoadMore := len(entries) == limit
-	if path == "/" {
-		path = ""
+	shouldDisplayLoadMore := len(entries) == limit || lastScanned != ""
+
+	if lastScanned != "" {
+		lastFileName = lastScanned
+	} else if len(entries) > 0 {
+		lastFileName = entries[len(entries)-1].FullPath.RelativeTo(filer2.FullPath(path))
 	}
 
-	if len(entries) > 0 {
-		lastFileName = entries[len(entries)-1].Name()
+	// the next page keeps the filters
+	loadMore := url.Values{}
+	for _, name := range []string{"prefix", "namePattern", "minMtime", "maxMtime", "minSize", "maxSize", "recursive"} {
+		if value := r.FormValue(name); value != "" {
+			loadMore.Set(name, value)
+		}
+	}
+	loadMore.Set("limit", strconv.Itoa(limit))
+	loadMore.Set("lastFileName", lastFileName)
+
+	if path == "/" {
+		path = ""
 	}
 
 	glog.V(4).Infof("listDirectory %s, last file %s, limit %d: %d items", path, lastFileName, limit, len(entries))
@@ -68,6 +91,7 @@ func (fs *FilerServer) listDirectoryHandler(w http.ResponseWriter, r *http.Reque
 			Limit                 int
 			LastFileName          string
 			ShouldDisplayLoadMore bool
+			LoadMoreUrl           string
 		}{
 			path,
 			ui.ToBreadcrumb(path),
@@ -75,6 +99,64 @@ func (fs *FilerServer) listDirectoryHandler(w http.ResponseWriter, r *http.Reque
 			limit,
 			lastFileName,
 			shouldDisplayLoadMore,
+			path + "?" + loadMore.Encode(),
 		})
 	}
 }
+
+// listOptionsFromRequest reads the listing filters "prefix", "namePattern", "minMtime", "maxMtime"
+// in unix seconds, "minSize", "maxSize", and "recursive", which lists the entries under the sub
+// directories too, with "lastFileName" being the path relative to the listed directory.
+func listOptionsFromRequest(r *http.Request) (*filer2.ListOptions, error) {
+	options := &filer2.ListOptions{
+		Prefix:      r.FormValue("prefix"),
+		NamePattern: r.FormValue("namePattern"),
+		Recursive:   r.FormValue("recursive") == "true",
+	}
+	for name, mtime := range map[string]*time.Time{"minMtime": &options.MinMtime, "maxMtime": &options.MaxMtime} {
+		if value := r.FormValue(name); value != "" {
+			seconds, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", name, err)
+			}
+			*mtime = time.Unix(seconds, 0)
+		}
+	}
+	for name, size := range map[string]*uint64{"minSize": &options.MinSize, "maxSize": &options.MaxSize} {
+		if value := r.FormValue(name); value != "" {
+			bytes, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", name, err)
+			}
+			*size = bytes
+		}
+	}
+	return options, nil
+}
+
+// directoryUsageHandler replies the total bytes, file count and directory count under the directory.
+func (fs *FilerServer) directoryUsageHandler(w http.ResponseWriter, r *http.Request, p filer2.FullPath) {
+
+	p = snapshotDirectory(string(p))
+	usage, err := fs.filer.DirectoryUsage(context.Background(), p)
+	if err == filer2.ErrNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		writeJsonError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJsonQuiet(w, r, http.StatusOK, struct {
+		Path           string
+		Bytes          uint64
+		FileCount      uint64
+		DirectoryCount uint64
+	}{
+		string(p),
+		usage.Bytes,
+		usage.FileCount,
+		usage.DirectoryCount,
+	})
+}
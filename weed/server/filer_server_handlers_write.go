@@ -1,6 +1,7 @@
 package weed_server
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -14,10 +15,13 @@ import (
 	"github.com/draleyva/seaweedfs/weed/glog"
 	"github.com/draleyva/seaweedfs/weed/operation"
 	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/draleyva/seaweedfs/weed/storage"
 	"github.com/draleyva/seaweedfs/weed/util"
 	"os"
 )
 
+const s3UserMetadataPrefix = "X-Amz-Meta-"
+
 var (
 	OS_UID = uint32(os.Getuid())
 	OS_GID = uint32(os.Getgid())
@@ -31,33 +35,6 @@ type FilerPostResult struct {
 	Url   string `json:"url,omitempty"`
 }
 
-func (fs *FilerServer) queryFileInfoByPath(w http.ResponseWriter, r *http.Request, path string) (fileId, urlLocation string, err error) {
-	var entry *filer2.Entry
-	entry, err = fs.filer.FindEntry(filer2.FullPath(path))
-	if err == filer2.ErrNotFound {
-		return "", "", nil
-	}
-
-	if err != nil {
-		glog.V(0).Infoln("failing to find path in filer store", path, err.Error())
-		writeJsonError(w, r, http.StatusInternalServerError, err)
-		return
-	}
-
-	if len(entry.Chunks) == 0 {
-		glog.V(1).Infof("empty entry: %s", path)
-		w.WriteHeader(http.StatusNoContent)
-	} else {
-		fileId = entry.Chunks[0].FileId
-		urlLocation, err = fs.filer.MasterClient.LookupFileId(fileId)
-		if err != nil {
-			glog.V(1).Infof("operation LookupFileId %s failed, err is %s", fileId, err.Error())
-			w.WriteHeader(http.StatusNotFound)
-		}
-	}
-	return
-}
-
 func (fs *FilerServer) assignNewFileInfo(w http.ResponseWriter, r *http.Request, replication, collection string, dataCenter string) (fileId, urlLocation string, err error) {
 	ar := &operation.VolumeAssignRequest{
 		Count:       1,
@@ -80,7 +57,11 @@ func (fs *FilerServer) assignNewFileInfo(w http.ResponseWriter, r *http.Request,
 	assignResult, ae := operation.Assign(fs.filer.GetMaster(), ar, altRequest)
 	if ae != nil {
 		glog.Errorf("failing to assign a file id: %v", ae)
-		writeJsonError(w, r, http.StatusInternalServerError, ae)
+		if util.IsQuotaExceeded(ae) {
+			writeJsonError(w, r, http.StatusInsufficientStorage, ae)
+		} else {
+			writeJsonError(w, r, http.StatusInternalServerError, ae)
+		}
 		err = ae
 		return
 	}
@@ -92,6 +73,11 @@ func (fs *FilerServer) assignNewFileInfo(w http.ResponseWriter, r *http.Request,
 func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 
 	query := r.URL.Query()
+	if mvFrom := query.Get("mv.from"); mvFrom != "" {
+		fs.moveHandler(w, r, mvFrom)
+		return
+	}
+
 	replication := query.Get("replication")
 	if replication == "" {
 		replication = fs.option.DefaultReplication
@@ -105,14 +91,22 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 		dataCenter = fs.option.DataCenter
 	}
 
+	if query.Get("op") == "append" {
+		fs.appendHandler(w, r, replication, collection, dataCenter)
+		return
+	}
+
 	if autoChunked := fs.autoChunk(w, r, replication, collection, dataCenter); autoChunked {
 		return
 	}
 
-	fileId, urlLocation, err := fs.queryFileInfoByPath(w, r, r.URL.Path)
-	if err == nil && fileId == "" {
-		fileId, urlLocation, err = fs.assignNewFileInfo(w, r, replication, collection, dataCenter)
+	if inlined := fs.inlineUpload(w, r, replication, collection); inlined {
+		return
 	}
+
+	// always write to a new file id, since the chunks of the existing file may be kept as a version,
+	// used by a snapshot, or still in use if the new entry is rejected
+	fileId, urlLocation, err := fs.assignNewFileInfo(w, r, replication, collection, dataCenter)
 	if err != nil || fileId == "" || urlLocation == "" {
 		return
 	}
@@ -205,11 +199,16 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 			Mtime:  time.Now().UnixNano(),
 			ETag:   etag,
 		}},
+		Extended: extendedFromRequest(r),
 	}
-	if db_err := fs.filer.CreateEntry(entry); db_err != nil {
+	if db_err := fs.filer.CreateEntry(context.Background(), entry); db_err != nil {
 		fs.filer.DeleteFileByFileId(fileId)
 		glog.V(0).Infof("failing to write %s to filer server : %v", path, db_err)
-		writeJsonError(w, r, http.StatusInternalServerError, db_err)
+		if util.IsQuotaExceeded(db_err) {
+			writeJsonError(w, r, http.StatusInsufficientStorage, db_err)
+		} else {
+			writeJsonError(w, r, http.StatusInternalServerError, db_err)
+		}
 		return
 	}
 
@@ -225,13 +224,38 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 	writeJsonQuiet(w, r, http.StatusCreated, reply)
 }
 
+// extendedFromRequest saves the "Seaweed-<name>" headers as extended attribute <name>,
+// and the "X-Amz-Meta-<name>" headers as is, for the S3 user metadata.
+func extendedFromRequest(r *http.Request) map[string][]byte {
+	var extended map[string][]byte
+	for header, values := range r.Header {
+		if len(values) == 0 {
+			continue
+		}
+		var name string
+		if strings.HasPrefix(header, storage.PairNamePrefix) {
+			name = header[len(storage.PairNamePrefix):]
+		} else if strings.HasPrefix(header, s3UserMetadataPrefix) {
+			name = header
+		}
+		if name == "" {
+			continue
+		}
+		if extended == nil {
+			extended = make(map[string][]byte)
+		}
+		extended[name] = []byte(values[0])
+	}
+	return extended
+}
+
 // curl -X DELETE http://localhost:8888/path/to
 // curl -X DELETE http://localhost:8888/path/to?recursive=true
 func (fs *FilerServer) DeleteHandler(w http.ResponseWriter, r *http.Request) {
 
 	isRecursive := r.FormValue("recursive") == "true"
 
-	err := fs.filer.DeleteEntryMetaAndData(filer2.FullPath(r.URL.Path), isRecursive, true)
+	err := fs.filer.DeleteEntryMetaAndData(context.Background(), filer2.FullPath(r.URL.Path), isRecursive, true)
 	if err != nil {
 		glog.V(1).Infoln("deleting", r.URL.Path, ":", err.Error())
 		writeJsonError(w, r, http.StatusInternalServerError, err)
@@ -240,3 +264,23 @@ func (fs *FilerServer) DeleteHandler(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// curl -X POST http://localhost:8888/path/to/new?mv.from=/path/to/old
+// curl -X POST http://localhost:8888/path/to/dir/?mv.from=/path/to/old
+func (fs *FilerServer) moveHandler(w http.ResponseWriter, r *http.Request, mvFrom string) {
+
+	oldPath := filer2.FullPath(mvFrom)
+	newPath := r.URL.Path
+	if strings.HasSuffix(newPath, "/") {
+		newPath += oldPath.Name()
+	}
+
+	err := fs.filer.AtomicRenameEntry(context.Background(), oldPath, filer2.FullPath(newPath))
+	if err != nil {
+		glog.V(1).Infoln("moving", oldPath, "to", newPath, ":", err.Error())
+		writeJsonError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,126 @@
+package weed_server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/filer_pb"
+	"github.com/draleyva/seaweedfs/weed/util"
+)
+
+type FilerAppendResult struct {
+	Name   string `json:"name,omitempty"`
+	Offset int64  `json:"offset"`
+	Size   int64  `json:"size"`
+	Error  string `json:"error,omitempty"`
+}
+
+// appendHandler uploads the request body as new chunks, and adds them at the end of the file.
+// The file is created if not found. The reply has the offset the data is appended at.
+func (fs *FilerServer) appendHandler(w http.ResponseWriter, r *http.Request, replication string, collection string, dataCenter string) {
+
+	filePath := r.URL.Path
+	if strings.HasSuffix(filePath, "/") {
+		writeJsonError(w, r, http.StatusBadRequest, errors.New("Can not append to folder "+filePath))
+		return
+	}
+
+	var body io.Reader = r.Body
+	mimeType := r.Header.Get("Content-Type")
+	if strings.HasPrefix(mimeType, "multipart/form-data") {
+		multipartReader, err := r.MultipartReader()
+		if err != nil {
+			writeJsonError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		part, err := multipartReader.NextPart()
+		if err != nil {
+			writeJsonError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		body, mimeType = part, part.Header.Get("Content-Type")
+	}
+	if mimeType == "application/octet-stream" {
+		mimeType = ""
+	}
+
+	maxMB, _ := strconv.Atoi(r.URL.Query().Get("maxMB"))
+	buf := make([]byte, fs.chunkSizeLimit(maxMB))
+	fileName := path.Base(filePath)
+
+	var chunks []*filer_pb.FileChunk
+	var size int64
+	for {
+		n, readErr := io.ReadFull(body, buf)
+		if n > 0 {
+			fileId, urlLocation, err := fs.assignNewFileInfo(w, r, replication, collection, dataCenter)
+			if err != nil {
+				fs.filer.DeleteChunks(chunks)
+				return
+			}
+			chunkName := fileName + "_chunk_" + strconv.Itoa(len(chunks)+1)
+			if err := fs.doUpload(urlLocation, w, r, buf[:n], chunkName, "application/octet-stream", fileId); err != nil {
+				fs.filer.DeleteChunks(chunks)
+				writeJsonError(w, r, http.StatusInternalServerError, err)
+				return
+			}
+			chunks = append(chunks, &filer_pb.FileChunk{
+				FileId: fileId,
+				Offset: size,
+				Size:   uint64(n),
+				Mtime:  time.Now().UnixNano(),
+			})
+			size += int64(n)
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			fs.filer.DeleteChunks(chunks)
+			writeJsonError(w, r, http.StatusInternalServerError, readErr)
+			return
+		}
+	}
+
+	glog.V(4).Infof("appending %d bytes to %s", size, filePath)
+	entry := &filer2.Entry{
+		FullPath: filer2.FullPath(filePath),
+		Attr: filer2.Attr{
+			Mtime:       time.Now(),
+			Crtime:      time.Now(),
+			Mode:        0660,
+			Uid:         OS_UID,
+			Gid:         OS_GID,
+			Mime:        mimeType,
+			Replication: replication,
+			Collection:  collection,
+			TtlSec:      int32(util.ParseInt(r.URL.Query().Get("ttl"), 0)),
+		},
+		Chunks:   chunks,
+		Extended: extendedFromRequest(r),
+	}
+	offset, err := fs.filer.AppendToEntry(context.Background(), entry)
+	if err != nil {
+		glog.V(0).Infof("failing to append to %s : %v", filePath, err)
+		if util.IsQuotaExceeded(err) {
+			writeJsonError(w, r, http.StatusInsufficientStorage, err)
+		} else {
+			writeJsonError(w, r, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	writeJsonQuiet(w, r, http.StatusOK, FilerAppendResult{
+		Name:   fileName,
+		Offset: offset,
+		Size:   size,
+	})
+}
@@ -2,6 +2,7 @@ package weed_server
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -54,7 +55,9 @@ func (fs *FilerServer) autoChunk(w http.ResponseWriter, r *http.Request, replica
 	}
 
 	reply, err := fs.doAutoChunk(w, r, contentLength, chunkSize, replication, collection, dataCenter)
-	if err != nil {
+	if err != nil && util.IsQuotaExceeded(err) {
+		writeJsonError(w, r, http.StatusInsufficientStorage, err)
+	} else if err != nil {
 		writeJsonError(w, r, http.StatusInternalServerError, err)
 	} else if reply != nil {
 		writeJsonQuiet(w, r, http.StatusCreated, reply)
@@ -163,9 +166,10 @@ func (fs *FilerServer) doAutoChunk(w http.ResponseWriter, r *http.Request, conte
 			Collection:  collection,
 			TtlSec:      int32(util.ParseInt(r.URL.Query().Get("ttl"), 0)),
 		},
-		Chunks: fileChunks,
+		Chunks:   fileChunks,
+		Extended: extendedFromRequest(r),
 	}
-	if db_err := fs.filer.CreateEntry(entry); db_err != nil {
+	if db_err := fs.filer.CreateEntry(context.Background(), entry); db_err != nil {
 		replyerr = db_err
 		filerResult.Error = db_err.Error()
 		glog.V(0).Infof("failing to write %s to filer server : %v", path, db_err)
@@ -0,0 +1,103 @@
+package weed_server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/operation"
+	"github.com/draleyva/seaweedfs/weed/storage"
+	"github.com/draleyva/seaweedfs/weed/util"
+)
+
+// the multipart form fields around the file content of a small POST upload
+const inlineFormOverhead = 1024
+
+// inlineUpload keeps the content of a small file in its entry, without writing to the volume servers.
+// For larger uploads, it returns false with the request body intact.
+func (fs *FilerServer) inlineUpload(w http.ResponseWriter, r *http.Request, replication string, collection string) bool {
+
+	query := r.URL.Query()
+	if fs.option.InlineMaxBytes <= 0 || query.Get("inline") == "false" || query.Get("cm") == "true" || query.Get("ttl") != "" {
+		return false
+	}
+
+	maxBodySize := int64(fs.option.InlineMaxBytes)
+	if r.Method == "POST" {
+		maxBodySize += inlineFormOverhead
+	}
+	if r.ContentLength > maxBodySize {
+		return false
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		writeJsonError(w, r, http.StatusInternalServerError, err)
+		return true
+	}
+	if int64(len(body)) > maxBodySize {
+		r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+		return false
+	}
+
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	fileName, data, mimeType, _, isGzipped, _, _, isChunkedFile, err := storage.ParseUpload(r)
+	if err == nil && isGzipped {
+		data, err = operation.UnGzipData(data)
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil || isChunkedFile || len(data) > fs.option.InlineMaxBytes {
+		return false
+	}
+
+	path := r.URL.Path
+	if strings.HasSuffix(path, "/") {
+		if fileName == "" {
+			glog.V(0).Infoln("Can not to write to folder", path, "without a file name!")
+			writeJsonError(w, r, http.StatusInternalServerError,
+				errors.New("Can not to write to folder "+path+" without a file name"))
+			return true
+		}
+		path += fileName
+	}
+
+	glog.V(4).Infof("saving %s with %d bytes inline", path, len(data))
+	entry := &filer2.Entry{
+		FullPath: filer2.FullPath(path),
+		Attr: filer2.Attr{
+			Mtime:       time.Now(),
+			Crtime:      time.Now(),
+			Mode:        0660,
+			Uid:         OS_UID,
+			Gid:         OS_GID,
+			Mime:        mimeType,
+			Replication: replication,
+			Collection:  collection,
+		},
+		Content:  data,
+		Extended: extendedFromRequest(r),
+	}
+	if db_err := fs.filer.CreateEntry(context.Background(), entry); db_err != nil {
+		glog.V(0).Infof("failing to write %s to filer server : %v", path, db_err)
+		if util.IsQuotaExceeded(db_err) {
+			writeJsonError(w, r, http.StatusInsufficientStorage, db_err)
+		} else {
+			writeJsonError(w, r, http.StatusInternalServerError, db_err)
+		}
+		return true
+	}
+
+	setEtag(w, filer2.ContentETag(data))
+	writeJsonQuiet(w, r, http.StatusCreated, FilerPostResult{
+		Name: fileName,
+		Size: uint32(len(data)),
+	})
+	return true
+}
@@ -0,0 +1,150 @@
+package weed_server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/filer2"
+	"github.com/draleyva/seaweedfs/weed/filer2/memdb"
+	"github.com/draleyva/seaweedfs/weed/operation"
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
+	"github.com/draleyva/seaweedfs/weed/util"
+)
+
+// testMaster assigns file ids on volume 1, served by testVolumeServer.
+type testMaster struct {
+	master_pb.SeaweedServer
+	volumeServer string
+
+	sync.Mutex
+	lastKey int
+}
+
+func (m *testMaster) KeepConnected(stream master_pb.Seaweed_KeepConnectedServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	if err := stream.Send(&master_pb.VolumeLocation{Url: m.volumeServer, PublicUrl: m.volumeServer, NewVids: []uint32{1}}); err != nil {
+		return err
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+func (m *testMaster) Assign(ctx context.Context, req *master_pb.AssignRequest) (*master_pb.AssignResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.lastKey++
+	return &master_pb.AssignResponse{
+		Fid:       fmt.Sprintf("1,%02x12345678", m.lastKey),
+		Url:       m.volumeServer,
+		PublicUrl: m.volumeServer,
+		Count:     1,
+	}, nil
+}
+
+// testVolumeServer keeps the posted request bodies in memory, by file id.
+type testVolumeServer struct {
+	sync.Mutex
+	needles map[string][]byte
+}
+
+func (v *testVolumeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.Lock()
+	defer v.Unlock()
+	fileId := strings.TrimPrefix(r.URL.Path, "/")
+	switch r.Method {
+	case "POST", "PUT":
+		data, _ := ioutil.ReadAll(r.Body)
+		v.needles[fileId] = data
+		json.NewEncoder(w).Encode(operation.UploadResult{Name: fileId, Size: uint32(len(data))})
+	case "GET":
+		data, found := v.needles[fileId]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write(data)
+	case "DELETE":
+		delete(v.needles, fileId)
+	}
+}
+
+func newTestFilerServer(t *testing.T) (fs *FilerServer, cleanup func()) {
+
+	volumeServer := httptest.NewServer(&testVolumeServer{needles: make(map[string][]byte)})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcS := util.NewGrpcServer()
+	master_pb.RegisterSeaweedServer(grpcS, &testMaster{volumeServer: strings.TrimPrefix(volumeServer.URL, "http://")})
+	go grpcS.Serve(listener)
+
+	store := &memdb.MemDbStore{}
+	store.Initialize(nil)
+	fs = &FilerServer{
+		option: &FilerOption{},
+		filer:  filer2.NewFiler([]string{listener.Addr().String()}),
+	}
+	fs.filer.SetStore(store)
+	fs.filer.DisableDirectoryCache()
+	go fs.filer.KeepConnectedToMaster()
+	fs.filer.MasterClient.WaitUntilConnected()
+
+	return fs, func() {
+		grpcS.Stop()
+		volumeServer.Close()
+	}
+}
+
+func testGet(fs *FilerServer, url string) (int, string) {
+	w := httptest.NewRecorder()
+	fs.GetOrHeadHandler(w, httptest.NewRequest("GET", url, nil), true)
+	return w.Code, w.Body.String()
+}
+
+func TestPostOverwriteKeepsVersion(t *testing.T) {
+	fs, cleanup := newTestFilerServer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if err := fs.filer.CreateEntry(ctx, &filer2.Entry{FullPath: "/docs", Attr: filer2.Attr{Mode: os.ModeDir | 0770}}); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := fs.filer.ConfigureVersioning(ctx, "/docs", true, 0, 0); err != nil {
+		t.Fatalf("configure versioning: %v", err)
+	}
+
+	for _, content := range []string{"version one", "version two"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("POST", "/docs/a.txt", strings.NewReader(content))
+		r.Header.Set("Content-Type", "application/octet-stream")
+		fs.PostHandler(w, r)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("post %s: %d %s", content, w.Code, w.Body.String())
+		}
+	}
+
+	if code, body := testGet(fs, "/docs/a.txt"); code != http.StatusOK || body != "version two" {
+		t.Errorf("read the file: %d %q", code, body)
+	}
+
+	versions, err := fs.filer.ListVersions(ctx, "/docs/a.txt")
+	if err != nil || len(versions) != 1 {
+		t.Fatalf("list versions: %+v, %v", versions, err)
+	}
+	if code, body := testGet(fs, "/docs/a.txt?version="+versions[0].VersionId); code != http.StatusOK || body != "version one" {
+		t.Errorf("read version 1: %d %q", code, body)
+	}
+}
@@ -103,7 +103,7 @@ var StatusTpl = template.Must(template.New("status").Funcs(funcMap).Parse(`<!DOC
 
 		{{if .ShouldDisplayLoadMore}}
 		<div class="row">
-		<a href={{ print .Path "?limit=" .Limit	"&lastFileName=" .LastFileName}} >
+		<a href="{{ .LoadMoreUrl }}" >
 		Load more
 		</a>
 		</div>
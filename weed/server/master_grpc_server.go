@@ -64,6 +64,12 @@ func (ms *MasterServer) SendHeartbeat(stream master_pb.Seaweed_SendHeartbeatServ
 				int(heartbeat.Port), heartbeat.PublicUrl,
 				int(heartbeat.MaxVolumeCount))
 			glog.V(0).Infof("added volume server %v:%d", heartbeat.GetIp(), heartbeat.GetPort())
+			t.PublishEvent(&master_pb.TopologyEvent{
+				Type:       master_pb.TopologyEvent_DATA_NODE_JOINED,
+				DataCenter: dcName,
+				Rack:       rackName,
+				Url:        dn.Url(),
+			})
 			if err := stream.Send(&master_pb.HeartbeatResponse{
 				VolumeSizeLimit: uint64(ms.volumeSizeLimitMB) * 1024 * 1024,
 				SecretKey:       string(ms.guard.SecretKey),
@@ -186,3 +192,47 @@ func (ms *MasterServer) KeepConnected(stream master_pb.Seaweed_KeepConnectedServ
 
 	return nil
 }
+
+// SubscribeTopologyEvents streams typed topology change events to the client,
+// e.g. data nodes joining or leaving, volume changes, leader changes and vacuum progress.
+func (ms *MasterServer) SubscribeTopologyEvents(req *master_pb.SubscribeTopologyEventsRequest, stream master_pb.Seaweed_SubscribeTopologyEventsServer) error {
+
+	if !ms.Topo.IsLeader() {
+		return raft.NotLeaderError
+	}
+
+	clientName := req.Name
+	if pr, ok := peer.FromContext(stream.Context()); ok && pr.Addr != net.Addr(nil) {
+		clientName += pr.Addr.String()
+	}
+	glog.V(0).Infof("+ topology event subscriber %v", clientName)
+
+	eventTypes := make(map[master_pb.TopologyEvent_EventType]bool)
+	for _, eventType := range req.EventTypes {
+		eventTypes[eventType] = true
+	}
+
+	eventChan, unsubscribe := ms.Topo.SubscribeEvents(clientName)
+	defer func() {
+		glog.V(0).Infof("- topology event subscriber %v", clientName)
+		unsubscribe()
+	}()
+
+	for {
+		select {
+		case event, ok := <-eventChan:
+			if !ok {
+				return nil
+			}
+			if len(eventTypes) > 0 && !eventTypes[event.Type] {
+				continue
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+
+}
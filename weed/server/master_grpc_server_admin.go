@@ -0,0 +1,259 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chrislusf/raft"
+	"github.com/draleyva/seaweedfs/weed/operation"
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
+	"github.com/draleyva/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/draleyva/seaweedfs/weed/storage"
+	"github.com/draleyva/seaweedfs/weed/topology"
+)
+
+func (ms *MasterServer) VolumeList(ctx context.Context, req *master_pb.VolumeListRequest) (*master_pb.VolumeListResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	return &master_pb.VolumeListResponse{
+		TopologyInfo:      ms.Topo.ToTopologyInfo(),
+		VolumeSizeLimitMb: uint64(ms.volumeSizeLimitMB),
+	}, nil
+}
+
+func (ms *MasterServer) VolumeGrow(ctx context.Context, req *master_pb.VolumeGrowRequest) (*master_pb.VolumeGrowResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	if req.Count == 0 {
+		return nil, fmt.Errorf("volume grow count is not specified")
+	}
+	req.Replication, req.Ttl, req.DataCenter = ms.withCollectionDefaults(req.Collection, req.Replication, req.Ttl, req.DataCenter)
+	replicaPlacement, err := storage.NewReplicaPlacementFromString(req.Replication)
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := storage.ReadTTL(req.Ttl)
+	if err != nil {
+		return nil, err
+	}
+	preallocate := ms.preallocate
+	if req.Preallocate != 0 {
+		preallocate = req.Preallocate
+	}
+
+	option := &topology.VolumeGrowOption{
+		Collection:       req.Collection,
+		ReplicaPlacement: replicaPlacement,
+		Ttl:              ttl,
+		Prealloacte:      preallocate,
+		DataCenter:       req.DataCenter,
+		Rack:             req.Rack,
+		DataNode:         req.DataNode,
+	}
+
+	requiredCount := int(req.Count) * replicaPlacement.GetCopyCount()
+	if ms.Topo.FreeSpace() < requiredCount {
+		return nil, fmt.Errorf("only %d volumes left, not enough for %d", ms.Topo.FreeSpace(), requiredCount)
+	}
+
+	count, err := ms.vg.GrowByCountAndType(int(req.Count), option, ms.Topo)
+	if err != nil {
+		return nil, err
+	}
+
+	return &master_pb.VolumeGrowResponse{
+		Count: uint32(count),
+	}, nil
+}
+
+func (ms *MasterServer) Vacuum(ctx context.Context, req *master_pb.VacuumRequest) (*master_pb.VacuumResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	garbageThreshold := ms.garbageThreshold
+	if req.GarbageThreshold > 0 {
+		garbageThreshold = float64(req.GarbageThreshold)
+	}
+
+	run, err := ms.Topo.Vacuum(req.Collection, garbageThreshold, ms.preallocate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &master_pb.VacuumResponse{
+		Run: run.ToVacuumRunMessage(),
+	}, nil
+}
+
+func (ms *MasterServer) VacuumPause(ctx context.Context, req *master_pb.VacuumPauseRequest) (*master_pb.VacuumPauseResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	if err := ms.Topo.PauseVacuum(true); err != nil {
+		return nil, err
+	}
+
+	return &master_pb.VacuumPauseResponse{}, nil
+}
+
+func (ms *MasterServer) VacuumResume(ctx context.Context, req *master_pb.VacuumResumeRequest) (*master_pb.VacuumResumeResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	if err := ms.Topo.PauseVacuum(false); err != nil {
+		return nil, err
+	}
+
+	return &master_pb.VacuumResumeResponse{}, nil
+}
+
+func (ms *MasterServer) VacuumStatus(ctx context.Context, req *master_pb.VacuumStatusRequest) (*master_pb.VacuumStatusResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	scheduler := ms.Topo.VacuumScheduler
+	resp := &master_pb.VacuumStatusResponse{
+		Paused:  scheduler.IsPaused(),
+		Current: scheduler.Current().ToVacuumRunMessage(),
+	}
+	for _, window := range scheduler.Windows() {
+		resp.Windows = append(resp.Windows, window.String())
+	}
+	for _, run := range scheduler.History() {
+		resp.History = append(resp.History, run.ToVacuumRunMessage())
+	}
+
+	return resp, nil
+}
+
+func (ms *MasterServer) CollectionList(ctx context.Context, req *master_pb.CollectionListRequest) (*master_pb.CollectionListResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	resp := &master_pb.CollectionListResponse{}
+	for _, usage := range ms.Topo.ListCollectionUsages() {
+		resp.Collections = append(resp.Collections, &master_pb.Collection{
+			Name:        usage.Collection,
+			Policy:      ms.Topo.GetCollectionPolicy(usage.Collection).ToCollectionPolicyMessage(),
+			VolumeCount: usage.VolumeCount,
+			Size:        usage.Size,
+		})
+	}
+
+	return resp, nil
+}
+
+func (ms *MasterServer) CollectionDelete(ctx context.Context, req *master_pb.CollectionDeleteRequest) (*master_pb.CollectionDeleteResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	collection, ok := ms.Topo.FindCollection(req.Name)
+	if !ok {
+		return nil, fmt.Errorf("collection %s does not exist", req.Name)
+	}
+
+	if err := ms.doDeleteCollection(collection); err != nil {
+		return nil, err
+	}
+
+	return &master_pb.CollectionDeleteResponse{}, nil
+}
+
+// CollectionConfigure changes only the policy fields named in update_fields, like the /col/configure handler,
+// or replaces the whole collection policy if no fields are named.
+func (ms *MasterServer) CollectionConfigure(ctx context.Context, req *master_pb.CollectionConfigureRequest) (*master_pb.CollectionConfigureResponse, error) {
+
+	if !ms.Topo.IsLeader() {
+		return nil, raft.NotLeaderError
+	}
+
+	policy := topology.NewCollectionPolicy(req.Policy)
+	if len(req.UpdateFields) > 0 {
+		var err error
+		if policy, err = mergeCollectionPolicy(ms.Topo.GetCollectionPolicy(req.Name), req.Policy, req.UpdateFields); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ms.Topo.ConfigureCollection(req.Name, policy); err != nil {
+		return nil, err
+	}
+
+	return &master_pb.CollectionConfigureResponse{}, nil
+}
+
+// mergeCollectionPolicy changes only the named fields of the base policy to the values in the message,
+// and keeps the others. The fields are named by their proto names.
+func mergeCollectionPolicy(base *topology.CollectionPolicy, m *master_pb.CollectionPolicy, fields []string) (*topology.CollectionPolicy, error) {
+	policy := &topology.CollectionPolicy{}
+	if base != nil {
+		*policy = *base
+	}
+	if m == nil {
+		m = &master_pb.CollectionPolicy{}
+	}
+	for _, field := range fields {
+		switch field {
+		case "replication":
+			policy.Replication = m.Replication
+		case "ttl":
+			policy.Ttl = m.Ttl
+		case "max_volume_count":
+			policy.MaxVolumeCount = m.MaxVolumeCount
+		case "max_bytes":
+			policy.MaxBytes = m.MaxBytes
+		case "garbage_threshold":
+			policy.GarbageThreshold = float64(m.GarbageThreshold)
+		case "data_center":
+			policy.DataCenter = m.DataCenter
+		default:
+			return nil, fmt.Errorf("unknown collection policy field %q", field)
+		}
+	}
+	return policy, nil
+}
+
+func (ms *MasterServer) GetMasterConfiguration(ctx context.Context, req *master_pb.GetMasterConfigurationRequest) (*master_pb.GetMasterConfigurationResponse, error) {
+
+	return &master_pb.GetMasterConfigurationResponse{
+		DefaultReplication: ms.defaultReplicaPlacement,
+		VolumeSizeLimitMb:  uint64(ms.volumeSizeLimitMB),
+		GarbageThreshold:   float32(ms.garbageThreshold),
+		PulseSeconds:       uint32(ms.pulseSeconds),
+		VolumePreallocate:  ms.preallocate > 0,
+	}, nil
+}
+
+func (ms *MasterServer) doDeleteCollection(collection *topology.Collection) error {
+	for _, server := range collection.ListVolumeServers() {
+		err := operation.WithVolumeServerClient(server.Url(), func(client volume_server_pb.VolumeServerClient) error {
+			_, deleteErr := client.DeleteCollection(context.Background(), &volume_server_pb.DeleteCollectionRequest{
+				Collection: collection.Name,
+			})
+			return deleteErr
+		})
+		if err != nil {
+			return err
+		}
+	}
+	ms.Topo.DeleteCollection(collection.Name)
+	return nil
+}
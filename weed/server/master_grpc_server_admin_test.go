@@ -0,0 +1,55 @@
+package weed_server
+
+import (
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
+	"github.com/draleyva/seaweedfs/weed/topology"
+)
+
+func TestMergeCollectionPolicy(t *testing.T) {
+	base := &topology.CollectionPolicy{
+		Replication:      "001",
+		Ttl:              "3d",
+		MaxVolumeCount:   10,
+		MaxBytes:         1 << 30,
+		GarbageThreshold: 0.5,
+		DataCenter:       "dc1",
+	}
+
+	merge := func(m *master_pb.CollectionPolicy, base *topology.CollectionPolicy, fields ...string) topology.CollectionPolicy {
+		policy, err := mergeCollectionPolicy(base, m, fields)
+		if err != nil {
+			t.Fatalf("merge %v: %v", fields, err)
+		}
+		return *policy
+	}
+
+	// the unset fields are kept
+	expected := *base
+	expected.MaxBytes = 2 << 30
+	if policy := merge(&master_pb.CollectionPolicy{MaxBytes: 2 << 30}, base, "max_bytes"); policy != expected {
+		t.Fatalf("merged policy %+v, expected %+v", policy, expected)
+	}
+	if base.MaxBytes != 1<<30 {
+		t.Fatalf("base policy changed: %+v", base)
+	}
+
+	// a named field with the zero value is cleared
+	expected = *base
+	expected.Ttl = ""
+	expected.GarbageThreshold = 0
+	if policy := merge(&master_pb.CollectionPolicy{Replication: "010"}, base, "ttl", "garbage_threshold"); policy != expected {
+		t.Fatalf("cleared policy %+v, expected %+v", policy, expected)
+	}
+
+	// without a base policy, only the named fields are set
+	expected = topology.CollectionPolicy{Replication: "010", DataCenter: "dc2"}
+	if policy := merge(&master_pb.CollectionPolicy{Replication: "010", Ttl: "1d", DataCenter: "dc2"}, nil, "replication", "data_center"); policy != expected {
+		t.Fatalf("new policy %+v, expected %+v", policy, expected)
+	}
+
+	if _, err := mergeCollectionPolicy(base, nil, []string{"maxBytes"}); err == nil {
+		t.Fatalf("merged an unknown field")
+	}
+}
@@ -5,8 +5,8 @@ import (
 	"fmt"
 
 	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
-	"github.com/draleyva/seaweedfs/weed/topology"
 	"github.com/draleyva/seaweedfs/weed/storage"
+	"github.com/draleyva/seaweedfs/weed/topology"
 )
 
 func (ms *MasterServer) LookupVolume(ctx context.Context, req *master_pb.LookupVolumeRequest) (*master_pb.LookupVolumeResponse, error) {
@@ -37,9 +37,7 @@ func (ms *MasterServer) Assign(ctx context.Context, req *master_pb.AssignRequest
 		req.Count = 1
 	}
 
-	if req.Replication == "" {
-		req.Replication = ms.defaultReplicaPlacement
-	}
+	req.Replication, req.Ttl, req.DataCenter = ms.withCollectionDefaults(req.Collection, req.Replication, req.Ttl, req.DataCenter)
 	replicaPlacement, err := storage.NewReplicaPlacementFromString(req.Replication)
 	if err != nil {
 		return nil, err
@@ -59,6 +57,10 @@ func (ms *MasterServer) Assign(ctx context.Context, req *master_pb.AssignRequest
 		DataNode:         req.DataNode,
 	}
 
+	if err = ms.Topo.CheckCollectionQuota(option.Collection); err != nil {
+		return nil, err
+	}
+
 	if !ms.Topo.HasWritableVolume(option) {
 		if ms.Topo.FreeSpace() <= 0 {
 			return nil, fmt.Errorf("No free volumes left!")
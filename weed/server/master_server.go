@@ -6,6 +6,7 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/chrislusf/raft"
 	"github.com/draleyva/seaweedfs/weed/glog"
@@ -44,6 +45,10 @@ func NewMasterServer(r *mux.Router, port int, metaFolder string,
 	pulseSeconds int,
 	defaultReplicaPlacement string,
 	garbageThreshold float64,
+	vacuumWindows string,
+	vacuumMaxPerNode int,
+	vacuumMaxPerRack int,
+	vacuumInterval time.Duration,
 	whiteList []string,
 	secureKey string,
 ) *MasterServer {
@@ -67,6 +72,12 @@ func NewMasterServer(r *mux.Router, port int, metaFolder string,
 	ms.vg = topology.NewDefaultVolumeGrowth()
 	glog.V(0).Infoln("Volume Size Limit is", volumeSizeLimitMB, "MB")
 
+	windows, err := topology.ParseVacuumWindows(vacuumWindows)
+	if err != nil {
+		glog.Fatalf("vacuum windows: %v", err)
+	}
+	ms.Topo.VacuumScheduler.Configure(windows, vacuumMaxPerNode, vacuumMaxPerRack)
+
 	ms.guard = security.NewGuard(whiteList, secureKey)
 
 	handleStaticResources2(r)
@@ -76,16 +87,21 @@ func NewMasterServer(r *mux.Router, port int, metaFolder string,
 	r.HandleFunc("/dir/lookup", ms.proxyToLeader(ms.guard.WhiteList(ms.dirLookupHandler)))
 	r.HandleFunc("/dir/status", ms.proxyToLeader(ms.guard.WhiteList(ms.dirStatusHandler)))
 	r.HandleFunc("/col/delete", ms.proxyToLeader(ms.guard.WhiteList(ms.collectionDeleteHandler)))
+	r.HandleFunc("/col/configure", ms.proxyToLeader(ms.guard.WhiteList(ms.collectionConfigureHandler)))
+	r.HandleFunc("/col/usage", ms.proxyToLeader(ms.guard.WhiteList(ms.collectionUsageHandler)))
 	r.HandleFunc("/vol/grow", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeGrowHandler)))
 	r.HandleFunc("/vol/status", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeStatusHandler)))
 	r.HandleFunc("/vol/vacuum", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeVacuumHandler)))
+	r.HandleFunc("/vol/vacuum/pause", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeVacuumPauseHandler)))
+	r.HandleFunc("/vol/vacuum/resume", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeVacuumResumeHandler)))
+	r.HandleFunc("/vol/vacuum/status", ms.proxyToLeader(ms.guard.WhiteList(ms.volumeVacuumStatusHandler)))
 	r.HandleFunc("/submit", ms.guard.WhiteList(ms.submitFromMasterServerHandler))
 	r.HandleFunc("/stats/health", ms.guard.WhiteList(statsHealthHandler))
 	r.HandleFunc("/stats/counter", ms.guard.WhiteList(statsCounterHandler))
 	r.HandleFunc("/stats/memory", ms.guard.WhiteList(statsMemoryHandler))
 	r.HandleFunc("/{fileId}", ms.proxyToLeader(ms.redirectHandler))
 
-	ms.Topo.StartRefreshWritableVolumes(garbageThreshold, ms.preallocate)
+	ms.Topo.StartRefreshWritableVolumes(garbageThreshold, vacuumInterval, ms.preallocate)
 
 	return ms
 }
@@ -96,6 +112,10 @@ func (ms *MasterServer) SetRaftServer(raftServer *RaftServer) {
 		glog.V(0).Infof("event: %+v", e)
 		if ms.Topo.RaftServer.Leader() != "" {
 			glog.V(0).Infoln("[", ms.Topo.RaftServer.Name(), "]", ms.Topo.RaftServer.Leader(), "becomes leader.")
+			ms.Topo.PublishEvent(&master_pb.TopologyEvent{
+				Type:   master_pb.TopologyEvent_LEADER_CHANGED,
+				Leader: ms.Topo.RaftServer.Leader(),
+			})
 		}
 	})
 	if ms.Topo.IsLeader() {
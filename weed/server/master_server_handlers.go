@@ -71,6 +71,11 @@ func (ms *MasterServer) dirAssignHandler(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err = ms.Topo.CheckCollectionQuota(option.Collection); err != nil {
+		writeJsonQuiet(w, r, http.StatusInsufficientStorage, operation.AssignResult{Error: err.Error()})
+		return
+	}
+
 	if !ms.Topo.HasWritableVolume(option) {
 		if ms.Topo.FreeSpace() <= 0 {
 			writeJsonQuiet(w, r, http.StatusNotFound, operation.AssignResult{Error: "No free volumes left!"})
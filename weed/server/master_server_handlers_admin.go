@@ -1,7 +1,6 @@
 package weed_server
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -9,8 +8,6 @@ import (
 	"strconv"
 
 	"github.com/draleyva/seaweedfs/weed/glog"
-	"github.com/draleyva/seaweedfs/weed/operation"
-	"github.com/draleyva/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/draleyva/seaweedfs/weed/storage"
 	"github.com/draleyva/seaweedfs/weed/topology"
 	"github.com/draleyva/seaweedfs/weed/util"
@@ -22,19 +19,97 @@ func (ms *MasterServer) collectionDeleteHandler(w http.ResponseWriter, r *http.R
 		writeJsonError(w, r, http.StatusBadRequest, fmt.Errorf("collection %s does not exist", r.FormValue("collection")))
 		return
 	}
-	for _, server := range collection.ListVolumeServers() {
-		err := operation.WithVolumeServerClient(server.Url(), func(client volume_server_pb.VolumeServerClient) error {
-			_, deleteErr := client.DeleteCollection(context.Background(), &volume_server_pb.DeleteCollectionRequest{
-				Collection: collection.Name,
-			})
-			return deleteErr
-		})
-		if err != nil {
-			writeJsonError(w, r, http.StatusInternalServerError, err)
-			return
+	if err := ms.doDeleteCollection(collection); err != nil {
+		writeJsonError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
+func (ms *MasterServer) collectionConfigureHandler(w http.ResponseWriter, r *http.Request) {
+	collection := r.FormValue("collection")
+	if collection == "" {
+		writeJsonError(w, r, http.StatusBadRequest, fmt.Errorf("collection is not specified"))
+		return
+	}
+	base := ms.Topo.GetCollectionPolicy(collection)
+	if r.FormValue("reset") == "true" {
+		base = nil
+	}
+	policy, err := parseCollectionPolicy(r, base)
+	if err != nil {
+		writeJsonError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	if err = ms.Topo.ConfigureCollection(collection, policy); err != nil {
+		writeJsonError(w, r, http.StatusNotAcceptable, err)
+		return
+	}
+	writeJsonQuiet(w, r, http.StatusOK, map[string]interface{}{
+		"collection": collection,
+		"policy":     ms.Topo.GetCollectionPolicy(collection),
+	})
+}
+
+// parseCollectionPolicy changes only the policy fields given in the request, and keeps the others from the base policy.
+// An empty value clears the field.
+func parseCollectionPolicy(r *http.Request, base *topology.CollectionPolicy) (*topology.CollectionPolicy, error) {
+	policy := &topology.CollectionPolicy{}
+	if base != nil {
+		*policy = *base
+	}
+	r.ParseForm()
+	given := func(name string) (string, bool) {
+		if _, found := r.Form[name]; !found {
+			return "", false
+		}
+		return r.Form.Get(name), true
+	}
+	parseUint := func(name string, value *uint64) (err error) {
+		if s, found := given(name); found {
+			*value = 0
+			if s != "" {
+				if *value, err = strconv.ParseUint(s, 10, 64); err != nil {
+					return fmt.Errorf("%s %s: %v", name, s, err)
+				}
+			}
+		}
+		return nil
+	}
+
+	if s, found := given("replication"); found {
+		policy.Replication = s
+	}
+	if s, found := given("ttl"); found {
+		policy.Ttl = s
+	}
+	if s, found := given("dataCenter"); found {
+		policy.DataCenter = s
+	}
+	if err := parseUint("maxVolumeCount", &policy.MaxVolumeCount); err != nil {
+		return nil, err
+	}
+	if err := parseUint("maxBytes", &policy.MaxBytes); err != nil {
+		return nil, err
+	}
+	if s, found := given("garbageThreshold"); found {
+		policy.GarbageThreshold = 0
+		if s != "" {
+			var err error
+			if policy.GarbageThreshold, err = strconv.ParseFloat(s, 64); err != nil {
+				return nil, fmt.Errorf("garbageThreshold %s: %v", s, err)
+			}
 		}
 	}
-	ms.Topo.DeleteCollection(r.FormValue("collection"))
+	return policy, nil
+}
+
+func (ms *MasterServer) collectionUsageHandler(w http.ResponseWriter, r *http.Request) {
+	if collection := r.FormValue("collection"); collection != "" {
+		writeJsonQuiet(w, r, http.StatusOK, ms.Topo.GetCollectionUsage(collection))
+		return
+	}
+	writeJsonQuiet(w, r, http.StatusOK, map[string]interface{}{
+		"Collections": ms.Topo.ListCollectionUsages(),
+	})
 }
 
 func (ms *MasterServer) dirStatusHandler(w http.ResponseWriter, r *http.Request) {
@@ -56,10 +131,43 @@ func (ms *MasterServer) volumeVacuumHandler(w http.ResponseWriter, r *http.Reque
 		}
 	}
 	glog.Infoln("garbageThreshold =", gcThreshold)
-	ms.Topo.Vacuum(gcThreshold, ms.preallocate)
+	if _, err := ms.Topo.Vacuum(r.FormValue("collection"), gcThreshold, ms.preallocate); err != nil {
+		writeJsonError(w, r, http.StatusConflict, err)
+		return
+	}
 	ms.dirStatusHandler(w, r)
 }
 
+func (ms *MasterServer) volumeVacuumPauseHandler(w http.ResponseWriter, r *http.Request) {
+	if err := ms.Topo.PauseVacuum(true); err != nil {
+		writeJsonError(w, r, http.StatusNotAcceptable, err)
+		return
+	}
+	ms.volumeVacuumStatusHandler(w, r)
+}
+
+func (ms *MasterServer) volumeVacuumResumeHandler(w http.ResponseWriter, r *http.Request) {
+	if err := ms.Topo.PauseVacuum(false); err != nil {
+		writeJsonError(w, r, http.StatusNotAcceptable, err)
+		return
+	}
+	ms.volumeVacuumStatusHandler(w, r)
+}
+
+func (ms *MasterServer) volumeVacuumStatusHandler(w http.ResponseWriter, r *http.Request) {
+	scheduler := ms.Topo.VacuumScheduler
+	var windows []string
+	for _, window := range scheduler.Windows() {
+		windows = append(windows, window.String())
+	}
+	writeJsonQuiet(w, r, http.StatusOK, map[string]interface{}{
+		"Paused":  scheduler.IsPaused(),
+		"Windows": windows,
+		"Current": scheduler.Current(),
+		"History": scheduler.History(),
+	})
+}
+
 func (ms *MasterServer) volumeGrowHandler(w http.ResponseWriter, r *http.Request) {
 	count := 0
 	option, err := ms.getVolumeGrowOption(r)
@@ -139,15 +247,13 @@ func (ms *MasterServer) HasWritableVolume(option *topology.VolumeGrowOption) boo
 }
 
 func (ms *MasterServer) getVolumeGrowOption(r *http.Request) (*topology.VolumeGrowOption, error) {
-	replicationString := r.FormValue("replication")
-	if replicationString == "" {
-		replicationString = ms.defaultReplicaPlacement
-	}
+	replicationString, ttlString, dataCenter := ms.withCollectionDefaults(r.FormValue("collection"),
+		r.FormValue("replication"), r.FormValue("ttl"), r.FormValue("dataCenter"))
 	replicaPlacement, err := storage.NewReplicaPlacementFromString(replicationString)
 	if err != nil {
 		return nil, err
 	}
-	ttl, err := storage.ReadTTL(r.FormValue("ttl"))
+	ttl, err := storage.ReadTTL(ttlString)
 	if err != nil {
 		return nil, err
 	}
@@ -163,9 +269,29 @@ func (ms *MasterServer) getVolumeGrowOption(r *http.Request) (*topology.VolumeGr
 		ReplicaPlacement: replicaPlacement,
 		Ttl:              ttl,
 		Prealloacte:      preallocate,
-		DataCenter:       r.FormValue("dataCenter"),
+		DataCenter:       dataCenter,
 		Rack:             r.FormValue("rack"),
 		DataNode:         r.FormValue("dataNode"),
 	}
 	return volumeGrowOption, nil
 }
+
+// withCollectionDefaults fills in unspecified replication, ttl and data center
+// from the collection policy, and then from the master defaults.
+func (ms *MasterServer) withCollectionDefaults(collection, replication, ttl, dataCenter string) (string, string, string) {
+	if policy := ms.Topo.GetCollectionPolicy(collection); policy != nil {
+		if replication == "" {
+			replication = policy.Replication
+		}
+		if ttl == "" {
+			ttl = policy.Ttl
+		}
+		if dataCenter == "" {
+			dataCenter = policy.DataCenter
+		}
+	}
+	if replication == "" {
+		replication = ms.defaultReplicaPlacement
+	}
+	return replication, ttl, dataCenter
+}
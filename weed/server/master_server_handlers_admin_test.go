@@ -0,0 +1,56 @@
+package weed_server
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/topology"
+)
+
+func TestParseCollectionPolicy(t *testing.T) {
+	base := &topology.CollectionPolicy{
+		Replication:      "001",
+		Ttl:              "3d",
+		MaxVolumeCount:   10,
+		MaxBytes:         1 << 30,
+		GarbageThreshold: 0.5,
+		DataCenter:       "dc1",
+	}
+
+	parse := func(query string, base *topology.CollectionPolicy) topology.CollectionPolicy {
+		policy, err := parseCollectionPolicy(httptest.NewRequest("POST", "/col/configure?"+query, nil), base)
+		if err != nil {
+			t.Fatalf("parse %s: %v", query, err)
+		}
+		return *policy
+	}
+
+	// only the given fields are changed
+	expected := *base
+	expected.MaxBytes = 2 << 30
+	if policy := parse("collection=c&maxBytes=2147483648", base); policy != expected {
+		t.Fatalf("merged policy %+v, expected %+v", policy, expected)
+	}
+	if base.MaxBytes != 1<<30 {
+		t.Fatalf("base policy changed: %+v", base)
+	}
+
+	// an empty value clears the field
+	expected = *base
+	expected.Ttl = ""
+	expected.MaxVolumeCount = 0
+	expected.GarbageThreshold = 0
+	if policy := parse("collection=c&ttl=&maxVolumeCount=&garbageThreshold=", base); policy != expected {
+		t.Fatalf("cleared policy %+v, expected %+v", policy, expected)
+	}
+
+	// without a base policy, only the given fields are set
+	expected = topology.CollectionPolicy{Replication: "010", GarbageThreshold: 0.3}
+	if policy := parse("collection=c&replication=010&garbageThreshold=0.3", nil); policy != expected {
+		t.Fatalf("new policy %+v, expected %+v", policy, expected)
+	}
+
+	if _, err := parseCollectionPolicy(httptest.NewRequest("POST", "/col/configure?maxBytes=x", nil), base); err == nil {
+		t.Fatalf("parsed invalid maxBytes")
+	}
+}
@@ -40,6 +40,8 @@ func NewRaftServer(r *mux.Router, peers []string, httpAddr string, dataDir strin
 	}
 
 	raft.RegisterCommand(&topology.MaxVolumeIdCommand{})
+	raft.RegisterCommand(&topology.CollectionPolicyCommand{})
+	raft.RegisterCommand(&topology.VacuumPauseCommand{})
 
 	var err error
 	transporter := raft.NewHTTPTransporter("/cluster", 0)
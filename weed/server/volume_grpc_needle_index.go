@@ -0,0 +1,53 @@
+package weed_server
+
+import (
+	"fmt"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/draleyva/seaweedfs/weed/storage"
+	"github.com/draleyva/seaweedfs/weed/storage/types"
+)
+
+const needleIndexExportBatchSize = 1024
+
+func (vs *VolumeServer) VolumeNeedleIndexExport(req *volume_server_pb.VolumeNeedleIndexExportRequest, stream volume_server_pb.VolumeServer_VolumeNeedleIndexExportServer) error {
+
+	v := vs.store.GetVolume(storage.VolumeId(req.VolumdId))
+	if v == nil {
+		return fmt.Errorf("Not Found Volume Id %d", req.VolumdId)
+	}
+
+	resp := &volume_server_pb.VolumeNeedleIndexExportResponse{}
+	count := 0
+	err := v.VisitNeedles(func(n *storage.Needle, appendAtNs uint64) error {
+		resp.Entries = append(resp.Entries, &volume_server_pb.NeedleIndexEntry{
+			NeedleId:   types.NeedleIdToUint64(n.Id),
+			Cookie:     uint32(n.Cookie),
+			Size:       n.Size,
+			AppendAtNs: appendAtNs,
+		})
+		count++
+		if len(resp.Entries) < needleIndexExportBatchSize {
+			return nil
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+		resp = &volume_server_pb.VolumeNeedleIndexExportResponse{}
+		return nil
+	})
+	if err != nil {
+		glog.Errorf("export volume %d needle index: %v", req.VolumdId, err)
+		return err
+	}
+	if len(resp.Entries) > 0 {
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+
+	glog.V(2).Infof("export volume %d needle index: %d needles", req.VolumdId, count)
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package weed_server
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/draleyva/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/draleyva/seaweedfs/weed/storage"
+	"github.com/draleyva/seaweedfs/weed/storage/types"
+)
+
+type testNeedleIndexStream struct {
+	volume_server_pb.VolumeServer_VolumeNeedleIndexExportServer
+	responses []*volume_server_pb.VolumeNeedleIndexExportResponse
+}
+
+func (s *testNeedleIndexStream) Send(resp *volume_server_pb.VolumeNeedleIndexExportResponse) error {
+	s.responses = append(s.responses, resp)
+	return nil
+}
+
+func TestVolumeNeedleIndexExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "seaweedfs_volume_test")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	vs := &VolumeServer{store: storage.NewStore(0, "localhost", "", []string{dir}, []int{1}, storage.NeedleMapInMemory)}
+	defer vs.store.Close()
+	if err := vs.store.AddVolume(1, "", storage.NeedleMapInMemory, "000", "", 0); err != nil {
+		t.Fatalf("add volume: %v", err)
+	}
+
+	// more needles than one batch
+	needleCount := needleIndexExportBatchSize + 10
+	for i := 1; i <= needleCount; i++ {
+		n := &storage.Needle{Id: types.Uint64ToNeedleId(uint64(i)), Cookie: types.Cookie(i), Data: []byte("data")}
+		if _, err := vs.store.Write(1, n); err != nil {
+			t.Fatalf("write needle %d: %v", i, err)
+		}
+	}
+	if _, err := vs.store.Delete(1, &storage.Needle{Id: types.Uint64ToNeedleId(1)}); err != nil {
+		t.Fatalf("delete needle 1: %v", err)
+	}
+
+	stream := &testNeedleIndexStream{}
+	if err := vs.VolumeNeedleIndexExport(&volume_server_pb.VolumeNeedleIndexExportRequest{VolumdId: 1}, stream); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if len(stream.responses) != 2 {
+		t.Fatalf("expected 2 batches, but got %d", len(stream.responses))
+	}
+	exported := make(map[uint64]bool)
+	for _, resp := range stream.responses {
+		for _, entry := range resp.Entries {
+			if entry.Cookie != uint32(entry.NeedleId) || entry.Size == 0 || entry.AppendAtNs == 0 {
+				t.Errorf("unexpected entry %+v", entry)
+			}
+			exported[entry.NeedleId] = true
+		}
+	}
+	if len(exported) != needleCount-1 || exported[1] {
+		t.Errorf("exported %d needles, expected %d without needle 1", len(exported), needleCount-1)
+	}
+
+	if err := vs.VolumeNeedleIndexExport(&volume_server_pb.VolumeNeedleIndexExportRequest{VolumdId: 2}, stream); err == nil {
+		t.Errorf("expected an error for a missing volume")
+	}
+}
@@ -50,6 +50,9 @@ func (vs *VolumeServer) VacuumVolumeCommit(ctx context.Context, req *volume_serv
 		glog.Errorf("commit volume %d: %v", req.VolumdId, err)
 	} else {
 		glog.V(1).Infof("commit volume %d", req.VolumdId)
+		if v := vs.store.GetVolume(storage.VolumeId(req.VolumdId)); v != nil {
+			resp.VolumeSize = uint64(v.Size())
+		}
 	}
 
 	return resp, err
@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/draleyva/seaweedfs/weed/storage/needle"
+	. "github.com/draleyva/seaweedfs/weed/storage/types"
+	"github.com/draleyva/seaweedfs/weed/util"
+)
+
+// VisitNeedles calls fn with the header of each live needle in the volume,
+// and the time the needle was appended in nano seconds.
+// The needles are read from a snapshot of the index file, so needles written meanwhile may be missed.
+func (v *Volume) VisitNeedles(fn func(n *Needle, appendAtNs uint64) error) error {
+	indexFile, err := os.Open(v.nm.IndexFileName())
+	if err != nil {
+		return fmt.Errorf("Open volume %d index file: %v", v.Id, err)
+	}
+	defer indexFile.Close()
+	nm, err := LoadCompactNeedleMap(indexFile)
+	if err != nil {
+		return fmt.Errorf("Load volume %d index file: %v", v.Id, err)
+	}
+
+	return nm.m.Visit(func(needleValue needle.NeedleValue) error {
+		// the compact map keeps the deleted needles loaded from the index with size 0
+		if needleValue.Key == NeedleIdEmpty || needleValue.Offset == 0 || needleValue.Size == 0 || needleValue.Size == TombstoneFileSize {
+			return nil
+		}
+		n, appendAtNs, err := v.readNeedleHeader(needleValue)
+		if err != nil {
+			return fmt.Errorf("read volume %d needle %v: %v", v.Id, needleValue.Key, err)
+		}
+		return fn(n, appendAtNs)
+	})
+}
+
+// readNeedleHeader reads only the needle header and the append timestamp for version 3 volumes.
+// Older volumes do not have the timestamp, so the whole needle is read for its last modified time.
+func (v *Volume) readNeedleHeader(needleValue needle.NeedleValue) (n *Needle, appendAtNs uint64, err error) {
+	offset := int64(needleValue.Offset) * NeedlePaddingSize
+	version := v.Version()
+	n = new(Needle)
+	if version == Version3 {
+		header := make([]byte, NeedleHeaderSize)
+		if _, err = v.dataFile.ReadAt(header, offset); err != nil {
+			return nil, 0, err
+		}
+		n.ParseNeedleHeader(header)
+		timestamp := make([]byte, TimestampSize)
+		if _, err = v.dataFile.ReadAt(timestamp, offset+NeedleHeaderSize+int64(n.Size)+NeedleChecksumSize); err != nil {
+			return nil, 0, err
+		}
+		n.AppendAtNs = util.BytesToUint64(timestamp)
+		appendAtNs = n.AppendAtNs
+	} else {
+		if err = n.ReadData(v.dataFile, offset, needleValue.Size, version); err != nil {
+			return nil, 0, err
+		}
+		appendAtNs = n.LastModified * 1e9
+	}
+	if n.Id != needleValue.Key {
+		return nil, 0, fmt.Errorf("expected needle id %v, but found %v", needleValue.Key, n.Id)
+	}
+	return n, appendAtNs, nil
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/storage/types"
+)
+
+func TestVisitNeedles(t *testing.T) {
+	for _, version := range []Version{Version2, Version3} {
+		dir, err := ioutil.TempDir("", "example")
+		if err != nil {
+			t.Fatalf("temp dir creation: %v", err)
+		}
+		defer os.RemoveAll(dir)
+
+		v, err := NewVolume(dir, "", 1, NeedleMapInMemory, &ReplicaPlacement{}, &TTL{}, 0)
+		if err != nil {
+			t.Fatalf("volume creation: %v", err)
+		}
+		v.SuperBlock.version = version
+
+		start := time.Now()
+		sizes := make(map[types.NeedleId]uint32)
+		for i := 1; i <= 5; i++ {
+			n := newRandomNeedle(uint64(i))
+			n.Cookie = types.Cookie(1000 + i)
+			n.LastModified = uint64(start.Unix())
+			n.SetHasLastModifiedDate()
+			if _, err := v.writeNeedle(n); err != nil {
+				t.Fatalf("write needle %d: %v", i, err)
+			}
+			sizes[n.Id] = n.Size
+		}
+		if _, err := v.deleteNeedle(newEmptyNeedle(2)); err != nil {
+			t.Fatalf("delete needle 2: %v", err)
+		}
+		delete(sizes, types.Uint64ToNeedleId(2))
+
+		visited := make(map[types.NeedleId]bool)
+		err = v.VisitNeedles(func(n *Needle, appendAtNs uint64) error {
+			size, found := sizes[n.Id]
+			if !found || n.Size != size || n.Cookie != types.Cookie(1000+types.NeedleIdToUint64(n.Id)) {
+				t.Errorf("version %d: unexpected needle %v cookie %v size %d", version, n.Id, n.Cookie, n.Size)
+			}
+			if appendAtNs < uint64(start.Unix())*1e9 || appendAtNs > uint64(time.Now().UnixNano()) {
+				t.Errorf("version %d: needle %v append time %d out of range", version, n.Id, appendAtNs)
+			}
+			visited[n.Id] = true
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("version %d: visit needles: %v", version, err)
+		}
+		if len(visited) != len(sizes) {
+			t.Errorf("version %d: visited %d needles, expected %d", version, len(visited), len(sizes))
+		}
+		v.Close()
+	}
+}
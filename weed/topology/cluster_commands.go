@@ -29,3 +29,55 @@ func (c *MaxVolumeIdCommand) Apply(server raft.Server) (interface{}, error) {
 
 	return nil, nil
 }
+
+type CollectionPolicyCommand struct {
+	Collection string            `json:"collection"`
+	Policy     *CollectionPolicy `json:"policy,omitempty"`
+}
+
+func NewCollectionPolicyCommand(collectionName string, policy *CollectionPolicy) *CollectionPolicyCommand {
+	return &CollectionPolicyCommand{
+		Collection: collectionName,
+		Policy:     policy,
+	}
+}
+
+func (c *CollectionPolicyCommand) CommandName() string {
+	return "CollectionPolicy"
+}
+
+func (c *CollectionPolicyCommand) Apply(server raft.Server) (interface{}, error) {
+	topo := server.Context().(*Topology)
+	topo.setCollectionPolicy(c.Collection, c.Policy)
+
+	glog.V(0).Infof("collection %s policy: %+v", c.Collection, c.Policy)
+
+	return nil, nil
+}
+
+type VacuumPauseCommand struct {
+	Paused bool `json:"paused"`
+}
+
+func NewVacuumPauseCommand(paused bool) *VacuumPauseCommand {
+	return &VacuumPauseCommand{
+		Paused: paused,
+	}
+}
+
+func (c *VacuumPauseCommand) CommandName() string {
+	return "VacuumPause"
+}
+
+func (c *VacuumPauseCommand) Apply(server raft.Server) (interface{}, error) {
+	topo := server.Context().(*Topology)
+	if c.Paused {
+		topo.VacuumScheduler.Pause()
+	} else {
+		topo.VacuumScheduler.Resume()
+	}
+
+	glog.V(0).Infof("vacuum paused: %v", c.Paused)
+
+	return nil, nil
+}
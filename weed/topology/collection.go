@@ -2,6 +2,7 @@ package topology
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/draleyva/seaweedfs/weed/storage"
 	"github.com/draleyva/seaweedfs/weed/util"
@@ -11,6 +12,8 @@ type Collection struct {
 	Name                     string
 	volumeSizeLimit          uint64
 	storageType2VolumeLayout *util.ConcurrentReadMap
+	policy                   *CollectionPolicy
+	policyLock               sync.RWMutex
 }
 
 func NewCollection(name string, volumeSizeLimit uint64) *Collection {
@@ -23,6 +26,18 @@ func (c *Collection) String() string {
 	return fmt.Sprintf("Name:%s, volumeSizeLimit:%d, storageType2VolumeLayout:%v", c.Name, c.volumeSizeLimit, c.storageType2VolumeLayout)
 }
 
+func (c *Collection) Policy() *CollectionPolicy {
+	c.policyLock.RLock()
+	defer c.policyLock.RUnlock()
+	return c.policy
+}
+
+func (c *Collection) SetPolicy(policy *CollectionPolicy) {
+	c.policyLock.Lock()
+	defer c.policyLock.Unlock()
+	c.policy = policy
+}
+
 func (c *Collection) GetOrCreateVolumeLayout(rp *storage.ReplicaPlacement, ttl *storage.TTL) *VolumeLayout {
 	keyString := rp.String()
 	if ttl != nil {
@@ -45,6 +60,18 @@ func (c *Collection) Lookup(vid storage.VolumeId) []*DataNode {
 	return nil
 }
 
+// Usage returns the number of volumes and their total size, counting each volume once regardless of replicas.
+func (c *Collection) Usage() (volumeCount uint64, size uint64) {
+	for _, vl := range c.storageType2VolumeLayout.Items() {
+		if vl != nil {
+			layoutVolumeCount, layoutSize := vl.(*VolumeLayout).Usage()
+			volumeCount += layoutVolumeCount
+			size += layoutSize
+		}
+	}
+	return
+}
+
 func (c *Collection) ListVolumeServers() (nodes []*DataNode) {
 	for _, vl := range c.storageType2VolumeLayout.Items() {
 		if vl != nil {
@@ -0,0 +1,117 @@
+package topology
+
+import (
+	"fmt"
+
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
+	"github.com/draleyva/seaweedfs/weed/storage"
+)
+
+// CollectionPolicy holds the defaults and limits configured for one collection.
+// Empty or zero fields fall back to the master wide settings.
+type CollectionPolicy struct {
+	Replication      string  `json:"replication,omitempty"`
+	Ttl              string  `json:"ttl,omitempty"`
+	MaxVolumeCount   uint64  `json:"maxVolumeCount,omitempty"`
+	MaxBytes         uint64  `json:"maxBytes,omitempty"`
+	GarbageThreshold float64 `json:"garbageThreshold,omitempty"`
+	DataCenter       string  `json:"dataCenter,omitempty"`
+}
+
+func NewCollectionPolicy(m *master_pb.CollectionPolicy) *CollectionPolicy {
+	if m == nil {
+		return nil
+	}
+	return &CollectionPolicy{
+		Replication:      m.Replication,
+		Ttl:              m.Ttl,
+		MaxVolumeCount:   m.MaxVolumeCount,
+		MaxBytes:         m.MaxBytes,
+		GarbageThreshold: float64(m.GarbageThreshold),
+		DataCenter:       m.DataCenter,
+	}
+}
+
+func (p *CollectionPolicy) ToCollectionPolicyMessage() *master_pb.CollectionPolicy {
+	if p == nil {
+		return nil
+	}
+	return &master_pb.CollectionPolicy{
+		Replication:      p.Replication,
+		Ttl:              p.Ttl,
+		MaxVolumeCount:   p.MaxVolumeCount,
+		MaxBytes:         p.MaxBytes,
+		GarbageThreshold: float32(p.GarbageThreshold),
+		DataCenter:       p.DataCenter,
+	}
+}
+
+func (p *CollectionPolicy) Validate() error {
+	if p.Replication != "" {
+		if _, err := storage.NewReplicaPlacementFromString(p.Replication); err != nil {
+			return fmt.Errorf("replication %s: %v", p.Replication, err)
+		}
+	}
+	if p.Ttl != "" {
+		if _, err := storage.ReadTTL(p.Ttl); err != nil {
+			return fmt.Errorf("ttl %s: %v", p.Ttl, err)
+		}
+	}
+	if p.GarbageThreshold < 0 || p.GarbageThreshold > 1 {
+		return fmt.Errorf("garbageThreshold %f should be between 0 and 1", p.GarbageThreshold)
+	}
+	return nil
+}
+
+func (p *CollectionPolicy) isEmpty() bool {
+	return p == nil || *p == CollectionPolicy{}
+}
+
+// GetCollectionPolicy returns the policy of the collection, or nil if none is configured.
+func (t *Topology) GetCollectionPolicy(collectionName string) *CollectionPolicy {
+	t.collectionPoliciesLock.RLock()
+	defer t.collectionPoliciesLock.RUnlock()
+	return t.collectionPolicies[collectionName]
+}
+
+func (t *Topology) ListCollectionPolicies() map[string]*CollectionPolicy {
+	t.collectionPoliciesLock.RLock()
+	defer t.collectionPoliciesLock.RUnlock()
+	ret := make(map[string]*CollectionPolicy, len(t.collectionPolicies))
+	for name, policy := range t.collectionPolicies {
+		ret[name] = policy
+	}
+	return ret
+}
+
+// ConfigureCollection replicates the collection policy to all masters through raft.
+// An empty policy removes the existing one.
+func (t *Topology) ConfigureCollection(collectionName string, policy *CollectionPolicy) error {
+	if policy != nil {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+	}
+	if t.RaftServer == nil {
+		return fmt.Errorf("raft server not ready yet")
+	}
+	_, err := t.RaftServer.Do(NewCollectionPolicyCommand(collectionName, policy))
+	return err
+}
+
+func (t *Topology) setCollectionPolicy(collectionName string, policy *CollectionPolicy) {
+	if policy.isEmpty() {
+		policy = nil
+	}
+	t.collectionPoliciesLock.Lock()
+	if policy == nil {
+		delete(t.collectionPolicies, collectionName)
+	} else {
+		t.collectionPolicies[collectionName] = policy
+	}
+	t.collectionPoliciesLock.Unlock()
+
+	if c, found := t.FindCollection(collectionName); found {
+		c.SetPolicy(policy)
+	}
+}
@@ -0,0 +1,58 @@
+package topology
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/draleyva/seaweedfs/weed/util"
+)
+
+// CollectionUsage compares what a collection uses with its configured quota.
+// Zero limits mean unlimited.
+type CollectionUsage struct {
+	Collection     string `json:"collection"`
+	VolumeCount    uint64 `json:"volumeCount"`
+	MaxVolumeCount uint64 `json:"maxVolumeCount,omitempty"`
+	Size           uint64 `json:"size"`
+	MaxBytes       uint64 `json:"maxBytes,omitempty"`
+}
+
+func (t *Topology) GetCollectionUsage(collectionName string) (usage CollectionUsage) {
+	usage.Collection = collectionName
+	if c, found := t.FindCollection(collectionName); found {
+		usage.VolumeCount, usage.Size = c.Usage()
+	}
+	if policy := t.GetCollectionPolicy(collectionName); policy != nil {
+		usage.MaxVolumeCount, usage.MaxBytes = policy.MaxVolumeCount, policy.MaxBytes
+	}
+	return
+}
+
+func (t *Topology) ListCollectionUsages() (usages []CollectionUsage) {
+	collections := t.ListCollections()
+	sort.Strings(collections)
+	for _, name := range collections {
+		usages = append(usages, t.GetCollectionUsage(name))
+	}
+	return
+}
+
+// CheckCollectionQuota fails if the collection can not take any more data.
+func (t *Topology) CheckCollectionQuota(collectionName string) error {
+	usage := t.GetCollectionUsage(collectionName)
+	if usage.MaxBytes > 0 && usage.Size >= usage.MaxBytes {
+		return fmt.Errorf("collection %s %v: %d of %d bytes used",
+			collectionName, util.ErrQuotaExceeded, usage.Size, usage.MaxBytes)
+	}
+	return nil
+}
+
+// checkVolumeCountQuota fails if the collection can not have one more volume.
+func (t *Topology) checkVolumeCountQuota(collectionName string) error {
+	usage := t.GetCollectionUsage(collectionName)
+	if usage.MaxVolumeCount > 0 && usage.VolumeCount >= usage.MaxVolumeCount {
+		return fmt.Errorf("collection %s %v: %d of %d volumes used",
+			collectionName, util.ErrQuotaExceeded, usage.VolumeCount, usage.MaxVolumeCount)
+	}
+	return nil
+}
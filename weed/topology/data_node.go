@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
 	"github.com/draleyva/seaweedfs/weed/storage"
 )
 
@@ -128,3 +129,19 @@ func (dn *DataNode) ToMap() interface{} {
 	ret["PublicUrl"] = dn.PublicUrl
 	return ret
 }
+
+func (dn *DataNode) ToDataNodeInfo() *master_pb.DataNodeInfo {
+	m := &master_pb.DataNodeInfo{
+		Id:                string(dn.Id()),
+		Url:               dn.Url(),
+		PublicUrl:         dn.PublicUrl,
+		VolumeCount:       uint64(dn.GetVolumeCount()),
+		MaxVolumeCount:    uint64(dn.GetMaxVolumeCount()),
+		FreeVolumeCount:   uint64(dn.FreeSpace()),
+		ActiveVolumeCount: uint64(dn.GetActiveVolumeCount()),
+	}
+	for _, v := range dn.GetVolumes() {
+		m.VolumeInfos = append(m.VolumeInfos, v.ToVolumeInformationMessage())
+	}
+	return m
+}
@@ -3,6 +3,8 @@ package topology
 import (
 	"strconv"
 	"time"
+
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
 )
 
 type Rack struct {
@@ -58,3 +60,18 @@ func (r *Rack) ToMap() interface{} {
 	m["DataNodes"] = dns
 	return m
 }
+
+func (r *Rack) ToRackInfo() *master_pb.RackInfo {
+	m := &master_pb.RackInfo{
+		Id:                string(r.Id()),
+		VolumeCount:       uint64(r.GetVolumeCount()),
+		MaxVolumeCount:    uint64(r.GetMaxVolumeCount()),
+		FreeVolumeCount:   uint64(r.FreeSpace()),
+		ActiveVolumeCount: uint64(r.GetActiveVolumeCount()),
+	}
+	for _, c := range r.Children() {
+		dn := c.(*DataNode)
+		m.DataNodeInfos = append(m.DataNodeInfos, dn.ToDataNodeInfo())
+	}
+	return m
+}
@@ -3,6 +3,7 @@ package topology
 import (
 	"errors"
 	"math/rand"
+	"sync"
 
 	"github.com/chrislusf/raft"
 	"github.com/draleyva/seaweedfs/weed/glog"
@@ -17,6 +18,9 @@ type Topology struct {
 
 	collectionMap *util.ConcurrentReadMap
 
+	collectionPolicies     map[string]*CollectionPolicy
+	collectionPoliciesLock sync.RWMutex
+
 	pulse int64
 
 	volumeSizeLimit uint64
@@ -27,6 +31,10 @@ type Topology struct {
 
 	Configuration *Configuration
 
+	VacuumScheduler *VacuumScheduler
+
+	eventSubscribers topologyEventSubscribers
+
 	RaftServer raft.Server
 }
 
@@ -37,6 +45,8 @@ func NewTopology(id string, seq sequence.Sequencer, volumeSizeLimit uint64, puls
 	t.NodeImpl.value = t
 	t.children = make(map[NodeId]Node)
 	t.collectionMap = util.NewConcurrentReadMap()
+	t.collectionPolicies = make(map[string]*CollectionPolicy)
+	t.VacuumScheduler = NewVacuumScheduler()
 	t.pulse = int64(pulse)
 	t.volumeSizeLimit = volumeSizeLimit
 
@@ -111,7 +121,9 @@ func (t *Topology) PickForWrite(count uint64, option *VolumeGrowOption) (string,
 
 func (t *Topology) GetVolumeLayout(collectionName string, rp *storage.ReplicaPlacement, ttl *storage.TTL) *VolumeLayout {
 	return t.collectionMap.Get(collectionName, func() interface{} {
-		return NewCollection(collectionName, t.volumeSizeLimit)
+		c := NewCollection(collectionName, t.volumeSizeLimit)
+		c.SetPolicy(t.GetCollectionPolicy(collectionName))
+		return c
 	}).(*Collection).GetOrCreateVolumeLayout(rp, ttl)
 }
 
@@ -123,6 +135,22 @@ func (t *Topology) FindCollection(collectionName string) (*Collection, bool) {
 	return c.(*Collection), hasCollection
 }
 
+// ListCollections returns collections having volumes, and configured collections without volumes yet.
+func (t *Topology) ListCollections() (ret []string) {
+	found := make(map[string]bool)
+	for _, c := range t.collectionMap.Items() {
+		name := c.(*Collection).Name
+		found[name] = true
+		ret = append(ret, name)
+	}
+	for name := range t.ListCollectionPolicies() {
+		if !found[name] {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
+
 func (t *Topology) DeleteCollection(collectionName string) {
 	t.collectionMap.Delete(collectionName)
 }
@@ -160,6 +188,12 @@ func (t *Topology) SyncDataNodeRegistration(volumes []*master_pb.VolumeInformati
 			glog.V(0).Infof("Fail to convert joined volume information: %v", err)
 		}
 	}
+	var readonlyVolumes []storage.VolumeInfo
+	for _, v := range volumeInfos {
+		if existing, err := dn.GetVolumesById(v.Id); err == nil && !existing.ReadOnly && v.ReadOnly {
+			readonlyVolumes = append(readonlyVolumes, v)
+		}
+	}
 	newVolumes, deletedVolumes = dn.UpdateVolumes(volumeInfos)
 	for _, v := range volumeInfos {
 		t.RegisterVolumeLayout(v, dn)
@@ -167,5 +201,14 @@ func (t *Topology) SyncDataNodeRegistration(volumes []*master_pb.VolumeInformati
 	for _, v := range deletedVolumes {
 		t.UnRegisterVolumeLayout(v, dn)
 	}
+	for _, v := range newVolumes {
+		t.publishVolumeEvent(master_pb.TopologyEvent_VOLUME_CREATED, v, dn)
+	}
+	for _, v := range deletedVolumes {
+		t.publishVolumeEvent(master_pb.TopologyEvent_VOLUME_DELETED, v, dn)
+	}
+	for _, v := range readonlyVolumes {
+		t.publishVolumeEvent(master_pb.TopologyEvent_VOLUME_READONLY, v, dn)
+	}
 	return
 }
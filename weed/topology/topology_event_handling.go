@@ -5,10 +5,11 @@ import (
 	"time"
 
 	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
 	"github.com/draleyva/seaweedfs/weed/storage"
 )
 
-func (t *Topology) StartRefreshWritableVolumes(garbageThreshold float64, preallocate int64) {
+func (t *Topology) StartRefreshWritableVolumes(garbageThreshold float64, vacuumInterval time.Duration, preallocate int64) {
 	go func() {
 		for {
 			if t.IsLeader() {
@@ -19,10 +20,12 @@ func (t *Topology) StartRefreshWritableVolumes(garbageThreshold float64, preallo
 		}
 	}()
 	go func(garbageThreshold float64) {
-		c := time.Tick(15 * time.Minute)
+		c := time.Tick(vacuumInterval)
 		for _ = range c {
-			if t.IsLeader() {
-				t.Vacuum(garbageThreshold, preallocate)
+			if t.IsLeader() && !t.VacuumScheduler.IsPaused() {
+				if _, err := t.ScheduledVacuum(garbageThreshold, preallocate); err != nil {
+					glog.V(0).Infof("skip scheduled vacuum: %v", err)
+				}
 			}
 		}
 	}(garbageThreshold)
@@ -40,6 +43,7 @@ func (t *Topology) SetVolumeCapacityFull(volumeInfo storage.VolumeInfo) bool {
 	if !vl.SetVolumeCapacityFull(volumeInfo.Id) {
 		return false
 	}
+	t.publishVolumeEvent(master_pb.TopologyEvent_VOLUME_FULL, volumeInfo, nil)
 
 	vl.accessLock.RLock()
 	defer vl.accessLock.RUnlock()
@@ -52,6 +56,7 @@ func (t *Topology) SetVolumeCapacityFull(volumeInfo storage.VolumeInfo) bool {
 	return true
 }
 func (t *Topology) UnRegisterDataNode(dn *DataNode) {
+	t.publishDataNodeEvent(master_pb.TopologyEvent_DATA_NODE_LEFT, dn)
 	for _, v := range dn.GetVolumes() {
 		glog.V(0).Infoln("Removing Volume", v.Id, "from the dead volume server", dn.Id())
 		vl := t.GetVolumeLayout(v.Collection, v.ReplicaPlacement, v.Ttl)
@@ -0,0 +1,82 @@
+package topology
+
+import (
+	"sync"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
+	"github.com/draleyva/seaweedfs/weed/storage"
+)
+
+const topologyEventBufferSize = 256
+
+type topologyEventSubscribers struct {
+	sync.RWMutex
+	subscribers map[string]chan *master_pb.TopologyEvent
+}
+
+// SubscribeEvents registers a subscriber for topology change events.
+// Events are dropped for subscribers that can not keep up.
+// The returned function unsubscribes and closes the channel.
+func (t *Topology) SubscribeEvents(name string) (<-chan *master_pb.TopologyEvent, func()) {
+	ch := make(chan *master_pb.TopologyEvent, topologyEventBufferSize)
+	t.eventSubscribers.Lock()
+	if t.eventSubscribers.subscribers == nil {
+		t.eventSubscribers.subscribers = make(map[string]chan *master_pb.TopologyEvent)
+	}
+	if existing, found := t.eventSubscribers.subscribers[name]; found {
+		close(existing)
+	}
+	t.eventSubscribers.subscribers[name] = ch
+	t.eventSubscribers.Unlock()
+
+	return ch, func() {
+		t.eventSubscribers.Lock()
+		defer t.eventSubscribers.Unlock()
+		if t.eventSubscribers.subscribers[name] == ch {
+			delete(t.eventSubscribers.subscribers, name)
+			close(ch)
+		}
+	}
+}
+
+// PublishEvent sends the event to all subscribers without blocking.
+func (t *Topology) PublishEvent(event *master_pb.TopologyEvent) {
+	if event.TsNs == 0 {
+		event.TsNs = time.Now().UnixNano()
+	}
+	t.eventSubscribers.RLock()
+	defer t.eventSubscribers.RUnlock()
+	for name, ch := range t.eventSubscribers.subscribers {
+		select {
+		case ch <- event:
+		default:
+			glog.V(0).Infof("topology event subscriber %s is too slow, dropping %v", name, event.Type)
+		}
+	}
+}
+
+func (t *Topology) publishDataNodeEvent(eventType master_pb.TopologyEvent_EventType, dn *DataNode) {
+	event := &master_pb.TopologyEvent{
+		Type: eventType,
+		Url:  dn.Url(),
+	}
+	if dn.Parent() != nil {
+		event.Rack = string(dn.GetRack().Id())
+		event.DataCenter = string(dn.GetDataCenter().Id())
+	}
+	t.PublishEvent(event)
+}
+
+func (t *Topology) publishVolumeEvent(eventType master_pb.TopologyEvent_EventType, v storage.VolumeInfo, dn *DataNode) {
+	event := &master_pb.TopologyEvent{
+		Type:       eventType,
+		VolumeId:   uint32(v.Id),
+		Collection: v.Collection,
+	}
+	if dn != nil {
+		event.Url = dn.Url()
+	}
+	t.PublishEvent(event)
+}
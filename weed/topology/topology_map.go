@@ -24,6 +24,7 @@ func (t *Topology) ToMap() interface{} {
 		}
 	}
 	m["layouts"] = layouts
+	m["CollectionPolicies"] = t.ListCollectionPolicies()
 	return m
 }
 
@@ -54,6 +55,21 @@ func (t *Topology) ToVolumeMap() interface{} {
 	return m
 }
 
+func (t *Topology) ToTopologyInfo() *master_pb.TopologyInfo {
+	m := &master_pb.TopologyInfo{
+		Id:                string(t.Id()),
+		VolumeCount:       uint64(t.GetVolumeCount()),
+		MaxVolumeCount:    uint64(t.GetMaxVolumeCount()),
+		FreeVolumeCount:   uint64(t.FreeSpace()),
+		ActiveVolumeCount: uint64(t.GetActiveVolumeCount()),
+	}
+	for _, c := range t.Children() {
+		dc := c.(*DataCenter)
+		m.DataCenterInfos = append(m.DataCenterInfos, dc.ToDataCenterInfo())
+	}
+	return m
+}
+
 func (t *Topology) ToVolumeLocations() (volumeLocations []*master_pb.VolumeLocation) {
 	for _, c := range t.Children() {
 		dc := c.(*DataCenter)
@@ -1,10 +1,18 @@
 package topology
 
 import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/raft"
 	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
+	"github.com/draleyva/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/draleyva/seaweedfs/weed/sequence"
 	"github.com/draleyva/seaweedfs/weed/storage"
-	"testing"
+	"github.com/draleyva/seaweedfs/weed/util"
 )
 
 func TestRemoveDataCenter(t *testing.T) {
@@ -122,3 +130,306 @@ func TestAddRemoveVolume(t *testing.T) {
 	}
 
 }
+
+func TestToTopologyInfo(t *testing.T) {
+
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+
+	dc := topo.GetOrCreateDataCenter("dc1")
+	rack := dc.GetOrCreateRack("rack1")
+	dn := rack.GetOrCreateDataNode("127.0.0.1", 34534, "127.0.0.1", 25)
+
+	var volumeMessages []*master_pb.VolumeInformationMessage
+	for k := 1; k <= 3; k++ {
+		volumeMessages = append(volumeMessages, &master_pb.VolumeInformationMessage{
+			Id:         uint32(k),
+			Size:       uint64(25432),
+			Collection: "xcollection",
+			Version:    uint32(storage.CurrentVersion),
+		})
+	}
+	topo.SyncDataNodeRegistration(volumeMessages, dn)
+
+	info := topo.ToTopologyInfo()
+	if info.VolumeCount != 3 {
+		t.Errorf("unexpected volume count: %d", info.VolumeCount)
+	}
+	if len(info.DataCenterInfos) != 1 || len(info.DataCenterInfos[0].RackInfos) != 1 {
+		t.Fatalf("unexpected topology layout: %+v", info)
+	}
+	dataNodeInfos := info.DataCenterInfos[0].RackInfos[0].DataNodeInfos
+	if len(dataNodeInfos) != 1 || dataNodeInfos[0].Url != dn.Url() {
+		t.Fatalf("unexpected data nodes: %+v", dataNodeInfos)
+	}
+	if len(dataNodeInfos[0].VolumeInfos) != 3 {
+		t.Errorf("unexpected volume infos: %+v", dataNodeInfos[0].VolumeInfos)
+	}
+
+	collections := topo.ListCollections()
+	if len(collections) != 1 || collections[0] != "xcollection" {
+		t.Errorf("unexpected collections: %v", collections)
+	}
+
+}
+
+func TestCollectionPolicy(t *testing.T) {
+
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+
+	policy := &CollectionPolicy{Replication: "001", GarbageThreshold: 0.1}
+	if err := policy.Validate(); err != nil {
+		t.Fatalf("validate policy: %v", err)
+	}
+	if err := (&CollectionPolicy{Replication: "abc"}).Validate(); err == nil {
+		t.Errorf("invalid replication should fail")
+	}
+
+	topo.setCollectionPolicy("xcollection", policy)
+	if topo.GetCollectionPolicy("xcollection") != policy {
+		t.Errorf("collection policy is not set")
+	}
+
+	topo.GetVolumeLayout("xcollection", &storage.ReplicaPlacement{}, storage.EMPTY_TTL)
+	c, found := topo.FindCollection("xcollection")
+	if !found || c.Policy() != policy {
+		t.Fatalf("new collection should carry the policy")
+	}
+
+	topo.setCollectionPolicy("xcollection", &CollectionPolicy{})
+	if topo.GetCollectionPolicy("xcollection") != nil || c.Policy() != nil {
+		t.Errorf("empty policy should reset the collection policy")
+	}
+
+}
+
+func TestCollectionQuota(t *testing.T) {
+
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+
+	dc := topo.GetOrCreateDataCenter("dc1")
+	rack := dc.GetOrCreateRack("rack1")
+	dn := rack.GetOrCreateDataNode("127.0.0.1", 34534, "127.0.0.1", 25)
+
+	var volumeMessages []*master_pb.VolumeInformationMessage
+	for k := 1; k <= 2; k++ {
+		volumeMessages = append(volumeMessages, &master_pb.VolumeInformationMessage{
+			Id:         uint32(k),
+			Size:       uint64(1000),
+			Collection: "xcollection",
+			Version:    uint32(storage.CurrentVersion),
+		})
+	}
+	topo.SyncDataNodeRegistration(volumeMessages, dn)
+
+	usage := topo.GetCollectionUsage("xcollection")
+	if usage.VolumeCount != 2 || usage.Size != 2000 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+
+	if err := topo.CheckCollectionQuota("xcollection"); err != nil {
+		t.Errorf("no quota is configured: %v", err)
+	}
+
+	topo.setCollectionPolicy("xcollection", &CollectionPolicy{MaxBytes: 2000, MaxVolumeCount: 3})
+	if err := topo.CheckCollectionQuota("xcollection"); !util.IsQuotaExceeded(err) {
+		t.Errorf("expect byte quota exceeded: %v", err)
+	}
+	if err := topo.checkVolumeCountQuota("xcollection"); err != nil {
+		t.Errorf("volume count is within quota: %v", err)
+	}
+
+	topo.setCollectionPolicy("xcollection", &CollectionPolicy{MaxVolumeCount: 2})
+	if err := topo.CheckCollectionQuota("xcollection"); err != nil {
+		t.Errorf("byte quota is removed: %v", err)
+	}
+	if err := topo.checkVolumeCountQuota("xcollection"); !util.IsQuotaExceeded(err) {
+		t.Errorf("expect volume count quota exceeded: %v", err)
+	}
+
+}
+
+func TestVacuumWindows(t *testing.T) {
+
+	windows, err := ParseVacuumWindows("01:00-05:00, 22:30-02:00")
+	if err != nil {
+		t.Fatalf("parse vacuum windows: %v", err)
+	}
+	if len(windows) != 2 || windows[1].String() != "22:30-02:00" {
+		t.Fatalf("unexpected windows: %v", windows)
+	}
+
+	scheduler := NewVacuumScheduler()
+	scheduler.Configure(windows, 1, 0)
+	for clock, expected := range map[string]bool{
+		"00:30": true,
+		"03:00": true,
+		"05:00": false,
+		"12:00": false,
+		"23:00": true,
+	} {
+		at, _ := time.Parse("15:04", clock)
+		if scheduler.IsInWindow(at) != expected {
+			t.Errorf("at %s in window should be %v", clock, expected)
+		}
+	}
+
+	for _, bad := range []string{"01:00", "1-2", "25:00-26:00", "01:60-02:00"} {
+		if _, err := ParseVacuumWindows(bad); err == nil {
+			t.Errorf("expect error parsing %s", bad)
+		}
+	}
+
+	scheduler.Pause()
+	if _, err := scheduler.begin("manual", ""); err != ErrVacuumPaused {
+		t.Errorf("expect paused error: %v", err)
+	}
+	scheduler.Resume()
+	run, err := scheduler.begin("manual", "")
+	if err != nil {
+		t.Fatalf("begin vacuum: %v", err)
+	}
+	if _, err := scheduler.begin("scheduled", ""); err != ErrVacuumRunning {
+		t.Errorf("expect running error: %v", err)
+	}
+	scheduler.finish(run)
+	if history := scheduler.History(); len(history) != 1 || history[0].Trigger != "manual" {
+		t.Errorf("unexpected history: %+v", history)
+	}
+
+}
+
+// raftContextServer only provides the context, for applying the raft commands.
+type raftContextServer struct {
+	raft.Server
+	topo *Topology
+}
+
+func (s raftContextServer) Context() interface{} {
+	return s.topo
+}
+
+func TestVacuumPauseCommand(t *testing.T) {
+	// the raft log keeps the commands in json, and applies them again after a restart
+	replay := func(command *VacuumPauseCommand, topo *Topology) {
+		data, err := json.Marshal(command)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		replayed := &VacuumPauseCommand{}
+		if err = json.Unmarshal(data, replayed); err != nil {
+			t.Fatalf("unmarshal %s: %v", data, err)
+		}
+		if _, err = replayed.Apply(raftContextServer{topo: topo}); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+	}
+
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+	replay(NewVacuumPauseCommand(true), topo)
+	if !topo.VacuumScheduler.IsPaused() {
+		t.Fatalf("vacuum should be paused after replaying the log")
+	}
+	replay(NewVacuumPauseCommand(false), topo)
+	if topo.VacuumScheduler.IsPaused() {
+		t.Fatalf("vacuum should be resumed after replaying the log")
+	}
+
+	if err := topo.PauseVacuum(true); err == nil {
+		t.Fatalf("pause vacuum without raft server")
+	}
+}
+
+func TestTopologyEvents(t *testing.T) {
+
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+	events, unsubscribe := topo.SubscribeEvents("test")
+
+	dc := topo.GetOrCreateDataCenter("dc1")
+	rack := dc.GetOrCreateRack("rack1")
+	dn := rack.GetOrCreateDataNode("127.0.0.1", 34534, "127.0.0.1", 25)
+
+	volumeMessage := &master_pb.VolumeInformationMessage{
+		Id:      uint32(1),
+		Version: uint32(storage.CurrentVersion),
+	}
+	topo.SyncDataNodeRegistration([]*master_pb.VolumeInformationMessage{volumeMessage}, dn)
+	volumeMessage.ReadOnly = true
+	topo.SyncDataNodeRegistration([]*master_pb.VolumeInformationMessage{volumeMessage}, dn)
+	topo.UnRegisterDataNode(dn)
+
+	expected := []master_pb.TopologyEvent_EventType{
+		master_pb.TopologyEvent_VOLUME_CREATED,
+		master_pb.TopologyEvent_VOLUME_READONLY,
+		master_pb.TopologyEvent_DATA_NODE_LEFT,
+	}
+	for _, eventType := range expected {
+		event := <-events
+		if event.Type != eventType {
+			t.Fatalf("expect event %v, but got %v", eventType, event.Type)
+		}
+	}
+	unsubscribe()
+	if event, ok := <-events; ok {
+		t.Errorf("unexpected event %v", event.Type)
+	}
+
+}
+
+// testVolumeServer accepts all volume allocations.
+type testVolumeServer struct {
+	volume_server_pb.VolumeServerServer
+}
+
+func (v *testVolumeServer) AssignVolume(ctx context.Context, req *volume_server_pb.AssignVolumeRequest) (*volume_server_pb.AssignVolumeResponse, error) {
+	return &volume_server_pb.AssignVolumeResponse{}, nil
+}
+
+func TestGrowVolumeEvents(t *testing.T) {
+
+	// the volume server grpc port is the http port + 10000
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	grpcPort := listener.Addr().(*net.TCPAddr).Port
+	if grpcPort <= 10000 {
+		t.Skipf("grpc port %d has no matching http port", grpcPort)
+	}
+	grpcS := util.NewGrpcServer()
+	volume_server_pb.RegisterVolumeServerServer(grpcS, &testVolumeServer{})
+	go grpcS.Serve(listener)
+	defer grpcS.Stop()
+
+	topo := NewTopology("weedfs", sequence.NewMemorySequencer(), 32*1024, 5)
+	events, unsubscribe := topo.SubscribeEvents("test")
+	defer unsubscribe()
+
+	dn := topo.GetOrCreateDataCenter("dc1").GetOrCreateRack("rack1").GetOrCreateDataNode("127.0.0.1", grpcPort-10000, "127.0.0.1", 25)
+
+	rp, _ := storage.NewReplicaPlacementFromString("000")
+	option := &VolumeGrowOption{ReplicaPlacement: rp, Ttl: storage.EMPTY_TTL}
+	if err := NewDefaultVolumeGrowth().grow(topo, 7, option, dn); err != nil {
+		t.Fatalf("grow: %v", err)
+	}
+
+	select {
+	case event := <-events:
+		if event.Type != master_pb.TopologyEvent_VOLUME_CREATED || event.VolumeId != 7 {
+			t.Errorf("unexpected event %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("no event for the grown volume")
+	}
+
+	// the following heartbeat with the grown volume publishes nothing more
+	topo.SyncDataNodeRegistration([]*master_pb.VolumeInformationMessage{{
+		Id:               7,
+		Version:          uint32(storage.CurrentVersion),
+		ReplicaPlacement: uint32(rp.Byte()),
+	}}, dn)
+	select {
+	case event := <-events:
+		t.Errorf("unexpected event %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
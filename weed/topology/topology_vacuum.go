@@ -2,10 +2,13 @@ package topology
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/draleyva/seaweedfs/weed/glog"
 	"github.com/draleyva/seaweedfs/weed/operation"
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
 	"github.com/draleyva/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/draleyva/seaweedfs/weed/storage"
 )
@@ -51,7 +54,8 @@ func batchVacuumVolumeCompact(vl *VolumeLayout, vid storage.VolumeId, locationli
 			glog.V(0).Infoln(index, "Start vacuuming", vid, "on", url)
 			err := operation.WithVolumeServerClient(url, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
 				_, err := volumeServerClient.VacuumVolumeCompact(context.Background(), &volume_server_pb.VacuumVolumeCompactRequest{
-					VolumdId: uint32(vid),
+					VolumdId:    uint32(vid),
+					Preallocate: preallocate,
 				})
 				return err
 			})
@@ -76,14 +80,21 @@ func batchVacuumVolumeCompact(vl *VolumeLayout, vid storage.VolumeId, locationli
 	}
 	return isVacuumSuccess
 }
-func batchVacuumVolumeCommit(vl *VolumeLayout, vid storage.VolumeId, locationlist *VolumeLocationList) bool {
-	isCommitSuccess := true
+func batchVacuumVolumeCommit(vl *VolumeLayout, vid storage.VolumeId, locationlist *VolumeLocationList) (isCommitSuccess bool, reclaimedBytes uint64) {
+	isCommitSuccess = true
 	for _, dn := range locationlist.list {
 		glog.V(0).Infoln("Start Commiting vacuum", vid, "on", dn.Url())
+		var sizeBefore, sizeAfter uint64
+		if v, err := dn.GetVolumesById(vid); err == nil {
+			sizeBefore = v.Size
+		}
 		err := operation.WithVolumeServerClient(dn.Url(), func(volumeServerClient volume_server_pb.VolumeServerClient) error {
-			_, err := volumeServerClient.VacuumVolumeCommit(context.Background(), &volume_server_pb.VacuumVolumeCommitRequest{
+			resp, err := volumeServerClient.VacuumVolumeCommit(context.Background(), &volume_server_pb.VacuumVolumeCommitRequest{
 				VolumdId: uint32(vid),
 			})
+			if err == nil {
+				sizeAfter = resp.VolumeSize
+			}
 			return err
 		})
 		if err != nil {
@@ -91,12 +102,15 @@ func batchVacuumVolumeCommit(vl *VolumeLayout, vid storage.VolumeId, locationlis
 			isCommitSuccess = false
 		} else {
 			glog.V(0).Infof("Complete Commiting vacuum %d on %s", vid, dn.Url())
+			if sizeAfter > 0 && sizeBefore > sizeAfter {
+				reclaimedBytes += sizeBefore - sizeAfter
+			}
 		}
 		if isCommitSuccess {
 			vl.SetVolumeAvailable(dn, vid)
 		}
 	}
-	return isCommitSuccess
+	return
 }
 func batchVacuumVolumeCleanup(vl *VolumeLayout, vid storage.VolumeId, locationlist *VolumeLocationList) {
 	for _, dn := range locationlist.list {
@@ -115,21 +129,76 @@ func batchVacuumVolumeCleanup(vl *VolumeLayout, vid storage.VolumeId, locationli
 	}
 }
 
-func (t *Topology) Vacuum(garbageThreshold float64, preallocate int64) int {
-	glog.V(0).Infof("Start vacuum on demand with threshold: %f", garbageThreshold)
+// Vacuum checks and compacts volumes on demand, ignoring the vacuum windows.
+// An empty collection means all collections.
+func (t *Topology) Vacuum(collection string, garbageThreshold float64, preallocate int64) (*VacuumRun, error) {
+	return t.vacuum("manual", collection, garbageThreshold, preallocate, false)
+}
+
+// ScheduledVacuum is like Vacuum, but only runs, and keeps compacting, within the vacuum windows.
+func (t *Topology) ScheduledVacuum(garbageThreshold float64, preallocate int64) (*VacuumRun, error) {
+	if !t.VacuumScheduler.IsInWindow(time.Now()) {
+		return nil, nil
+	}
+	return t.vacuum("scheduled", "", garbageThreshold, preallocate, true)
+}
+
+// PauseVacuum pauses or resumes vacuum on all masters through raft, so the pause is kept after restarts and leader changes.
+func (t *Topology) PauseVacuum(paused bool) error {
+	if t.RaftServer == nil {
+		return fmt.Errorf("raft server not ready yet")
+	}
+	_, err := t.RaftServer.Do(NewVacuumPauseCommand(paused))
+	return err
+}
+
+func (t *Topology) vacuum(trigger, collection string, garbageThreshold float64, preallocate int64, respectWindows bool) (*VacuumRun, error) {
+	run, err := t.VacuumScheduler.begin(trigger, collection)
+	if err != nil {
+		return nil, err
+	}
+
+	glog.V(0).Infof("Start %s vacuum with threshold: %f", trigger, garbageThreshold)
+	t.PublishEvent(&master_pb.TopologyEvent{
+		Type:       master_pb.TopologyEvent_VACUUM_STARTED,
+		Collection: collection,
+		VacuumRun:  t.VacuumScheduler.Current().ToVacuumRunMessage(),
+	})
+	var wg sync.WaitGroup
+collections:
 	for _, col := range t.collectionMap.Items() {
 		c := col.(*Collection)
+		if collection != "" && c.Name != collection {
+			continue
+		}
+		collectionGarbageThreshold := garbageThreshold
+		if policy := c.Policy(); policy != nil && policy.GarbageThreshold > 0 {
+			collectionGarbageThreshold = policy.GarbageThreshold
+		}
 		for _, vl := range c.storageType2VolumeLayout.Items() {
 			if vl != nil {
 				volumeLayout := vl.(*VolumeLayout)
-				vacuumOneVolumeLayout(volumeLayout, c, garbageThreshold, preallocate)
+				if !t.vacuumOneVolumeLayout(volumeLayout, c, collectionGarbageThreshold, preallocate, run, respectWindows, &wg) {
+					break collections
+				}
 			}
 		}
 	}
-	return 0
+	wg.Wait()
+	t.VacuumScheduler.finish(run)
+	t.PublishEvent(&master_pb.TopologyEvent{
+		Type:       master_pb.TopologyEvent_VACUUM_FINISHED,
+		Collection: collection,
+		VacuumRun:  run.ToVacuumRunMessage(),
+	})
+	glog.V(0).Infof("Finished %s vacuum: %d checked, %d vacuumed, %d failed, %d bytes reclaimed",
+		trigger, run.CheckedCount, run.VacuumedCount, run.FailedCount, run.ReclaimedBytes)
+	return run, nil
 }
 
-func vacuumOneVolumeLayout(volumeLayout *VolumeLayout, c *Collection, garbageThreshold float64, preallocate int64) {
+// vacuumOneVolumeLayout returns false if the vacuum pass is interrupted.
+func (t *Topology) vacuumOneVolumeLayout(volumeLayout *VolumeLayout, c *Collection, garbageThreshold float64, preallocate int64,
+	run *VacuumRun, respectWindows bool, wg *sync.WaitGroup) bool {
 
 	volumeLayout.accessLock.RLock()
 	tmpMap := make(map[storage.VolumeId]*VolumeLocationList)
@@ -138,10 +207,17 @@ func vacuumOneVolumeLayout(volumeLayout *VolumeLayout, c *Collection, garbageThr
 	}
 	volumeLayout.accessLock.RUnlock()
 
+	scheduler := t.VacuumScheduler
 	for vid, locationlist := range tmpMap {
 
+		if scheduler.shouldStop(respectWindows) {
+			scheduler.update(func() { run.Interrupted = true })
+			return false
+		}
+
 		volumeLayout.accessLock.RLock()
 		isReadOnly, hasValue := volumeLayout.readonlyVolumes[vid]
+		nodes := append([]*DataNode(nil), locationlist.list...)
 		volumeLayout.accessLock.RUnlock()
 
 		if hasValue && isReadOnly {
@@ -149,12 +225,31 @@ func vacuumOneVolumeLayout(volumeLayout *VolumeLayout, c *Collection, garbageThr
 		}
 
 		glog.V(0).Infof("check vacuum on collection:%s volume:%d", c.Name, vid)
-		if batchVacuumVolumeCheck(volumeLayout, vid, locationlist, garbageThreshold) {
+		scheduler.update(func() { run.CheckedCount++ })
+		if !batchVacuumVolumeCheck(volumeLayout, vid, locationlist, garbageThreshold) {
+			continue
+		}
+
+		scheduler.acquire(nodes)
+		wg.Add(1)
+		go func(vid storage.VolumeId, locationlist *VolumeLocationList) {
+			defer wg.Done()
+			defer scheduler.release(nodes)
 			if batchVacuumVolumeCompact(volumeLayout, vid, locationlist, preallocate) {
-				batchVacuumVolumeCommit(volumeLayout, vid, locationlist)
+				isCommitSuccess, reclaimedBytes := batchVacuumVolumeCommit(volumeLayout, vid, locationlist)
+				scheduler.update(func() {
+					if isCommitSuccess {
+						run.VacuumedCount++
+					} else {
+						run.FailedCount++
+					}
+					run.ReclaimedBytes += reclaimedBytes
+				})
 			} else {
 				batchVacuumVolumeCleanup(volumeLayout, vid, locationlist)
+				scheduler.update(func() { run.FailedCount++ })
 			}
-		}
+		}(vid, locationlist)
 	}
+	return true
 }
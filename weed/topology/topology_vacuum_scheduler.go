@@ -0,0 +1,297 @@
+package topology
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
+)
+
+var ErrVacuumPaused = errors.New("vacuum is paused")
+var ErrVacuumRunning = errors.New("vacuum is already running")
+
+const maxVacuumHistory = 64
+
+// VacuumWindow is a daily time range, in minutes since local midnight.
+// The range wraps around midnight if End is before Start.
+type VacuumWindow struct {
+	Start int
+	End   int
+}
+
+func (w VacuumWindow) Contains(t time.Time) bool {
+	m := t.Hour()*60 + t.Minute()
+	if w.Start <= w.End {
+		return w.Start <= m && m < w.End
+	}
+	return m >= w.Start || m < w.End
+}
+
+func (w VacuumWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.Start/60, w.Start%60, w.End/60, w.End%60)
+}
+
+// ParseVacuumWindows parses comma separated daily windows, e.g. "01:00-05:00,22:30-23:30".
+func ParseVacuumWindows(s string) (windows []VacuumWindow, err error) {
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		bounds := strings.Split(part, "-")
+		if len(bounds) != 2 {
+			return nil, fmt.Errorf("vacuum window %s should be like 01:00-05:00", part)
+		}
+		var w VacuumWindow
+		if w.Start, err = parseMinuteOfDay(bounds[0]); err != nil {
+			return nil, fmt.Errorf("vacuum window %s: %v", part, err)
+		}
+		if w.End, err = parseMinuteOfDay(bounds[1]); err != nil {
+			return nil, fmt.Errorf("vacuum window %s: %v", part, err)
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+func parseMinuteOfDay(s string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("time %s should be like 23:30", s)
+	}
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil || hour < 0 || hour > 24 {
+		return 0, fmt.Errorf("invalid hour in %s", s)
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 || hour == 24 && minute > 0 {
+		return 0, fmt.Errorf("invalid minute in %s", s)
+	}
+	return hour*60 + minute, nil
+}
+
+// VacuumRun records one pass of vacuuming.
+type VacuumRun struct {
+	Trigger        string    `json:"trigger"`
+	Collection     string    `json:"collection,omitempty"`
+	StartTime      time.Time `json:"startTime"`
+	EndTime        time.Time `json:"endTime"`
+	CheckedCount   int       `json:"checkedVolumes"`
+	VacuumedCount  int       `json:"vacuumedVolumes"`
+	FailedCount    int       `json:"failedVolumes"`
+	ReclaimedBytes uint64    `json:"reclaimedBytes"`
+	Interrupted    bool      `json:"interrupted,omitempty"`
+}
+
+func (run *VacuumRun) ToVacuumRunMessage() *master_pb.VacuumRun {
+	if run == nil {
+		return nil
+	}
+	m := &master_pb.VacuumRun{
+		Trigger:        run.Trigger,
+		Collection:     run.Collection,
+		StartTimeNs:    run.StartTime.UnixNano(),
+		CheckedCount:   uint32(run.CheckedCount),
+		VacuumedCount:  uint32(run.VacuumedCount),
+		FailedCount:    uint32(run.FailedCount),
+		ReclaimedBytes: run.ReclaimedBytes,
+		Interrupted:    run.Interrupted,
+	}
+	if !run.EndTime.IsZero() {
+		m.EndTimeNs = run.EndTime.UnixNano()
+	}
+	return m
+}
+
+// VacuumScheduler decides when vacuum can run, and limits how many volumes
+// are compacted at the same time on each data node and each rack.
+type VacuumScheduler struct {
+	sync.Mutex
+	windows    []VacuumWindow
+	maxPerNode int
+	maxPerRack int
+	paused     bool
+	current    *VacuumRun
+	history    []*VacuumRun
+
+	slotsLock       sync.Mutex
+	slotsCond       *sync.Cond
+	nodeCompactions map[*DataNode]int
+	rackCompactions map[*Rack]int
+}
+
+func NewVacuumScheduler() *VacuumScheduler {
+	s := &VacuumScheduler{
+		maxPerNode:      1,
+		nodeCompactions: make(map[*DataNode]int),
+		rackCompactions: make(map[*Rack]int),
+	}
+	s.slotsCond = sync.NewCond(&s.slotsLock)
+	return s
+}
+
+// Configure sets the daily windows for scheduled vacuum, and the compaction limits.
+// No windows means scheduled vacuum can run any time. Zero limits mean no limit.
+func (s *VacuumScheduler) Configure(windows []VacuumWindow, maxPerNode, maxPerRack int) {
+	s.Lock()
+	defer s.Unlock()
+	s.windows = windows
+	s.maxPerNode = maxPerNode
+	s.maxPerRack = maxPerRack
+}
+
+func (s *VacuumScheduler) Pause() {
+	s.Lock()
+	defer s.Unlock()
+	s.paused = true
+}
+
+func (s *VacuumScheduler) Resume() {
+	s.Lock()
+	defer s.Unlock()
+	s.paused = false
+}
+
+func (s *VacuumScheduler) IsPaused() bool {
+	s.Lock()
+	defer s.Unlock()
+	return s.paused
+}
+
+func (s *VacuumScheduler) IsInWindow(t time.Time) bool {
+	s.Lock()
+	defer s.Unlock()
+	if len(s.windows) == 0 {
+		return true
+	}
+	for _, w := range s.windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *VacuumScheduler) Windows() []VacuumWindow {
+	s.Lock()
+	defer s.Unlock()
+	return s.windows
+}
+
+// Current returns a copy of the running vacuum pass, or nil.
+func (s *VacuumScheduler) Current() *VacuumRun {
+	s.Lock()
+	defer s.Unlock()
+	if s.current == nil {
+		return nil
+	}
+	run := *s.current
+	return &run
+}
+
+// History returns finished vacuum passes, most recent first.
+func (s *VacuumScheduler) History() (runs []VacuumRun) {
+	s.Lock()
+	defer s.Unlock()
+	for i := len(s.history) - 1; i >= 0; i-- {
+		runs = append(runs, *s.history[i])
+	}
+	return
+}
+
+func (s *VacuumScheduler) begin(trigger, collection string) (*VacuumRun, error) {
+	s.Lock()
+	defer s.Unlock()
+	if s.paused {
+		return nil, ErrVacuumPaused
+	}
+	if s.current != nil {
+		return nil, ErrVacuumRunning
+	}
+	s.current = &VacuumRun{
+		Trigger:    trigger,
+		Collection: collection,
+		StartTime:  time.Now(),
+	}
+	return s.current, nil
+}
+
+func (s *VacuumScheduler) finish(run *VacuumRun) {
+	s.Lock()
+	defer s.Unlock()
+	run.EndTime = time.Now()
+	s.current = nil
+	s.history = append(s.history, run)
+	if len(s.history) > maxVacuumHistory {
+		s.history = s.history[len(s.history)-maxVacuumHistory:]
+	}
+}
+
+// update changes the running vacuum pass under lock.
+func (s *VacuumScheduler) update(fn func()) {
+	s.Lock()
+	defer s.Unlock()
+	fn()
+}
+
+// shouldStop checks whether a vacuum pass should stop starting new compactions.
+func (s *VacuumScheduler) shouldStop(respectWindows bool) bool {
+	if s.IsPaused() {
+		return true
+	}
+	return respectWindows && !s.IsInWindow(time.Now())
+}
+
+// acquire blocks until every data node and rack of the volume can take one more compaction.
+func (s *VacuumScheduler) acquire(nodes []*DataNode) {
+	s.slotsLock.Lock()
+	defer s.slotsLock.Unlock()
+	for !s.hasSlots(nodes) {
+		s.slotsCond.Wait()
+	}
+	for _, dn := range nodes {
+		s.nodeCompactions[dn]++
+		s.rackCompactions[dn.GetRack()]++
+	}
+}
+
+func (s *VacuumScheduler) release(nodes []*DataNode) {
+	s.slotsLock.Lock()
+	defer s.slotsLock.Unlock()
+	for _, dn := range nodes {
+		if s.nodeCompactions[dn]--; s.nodeCompactions[dn] <= 0 {
+			delete(s.nodeCompactions, dn)
+		}
+		rack := dn.GetRack()
+		if s.rackCompactions[rack]--; s.rackCompactions[rack] <= 0 {
+			delete(s.rackCompactions, rack)
+		}
+	}
+	s.slotsCond.Broadcast()
+}
+
+func (s *VacuumScheduler) hasSlots(nodes []*DataNode) bool {
+	s.Lock()
+	maxPerNode, maxPerRack := s.maxPerNode, s.maxPerRack
+	s.Unlock()
+	racks := make(map[*Rack]int)
+	for _, dn := range nodes {
+		if maxPerNode > 0 && s.nodeCompactions[dn] >= maxPerNode {
+			return false
+		}
+		racks[dn.GetRack()]++
+	}
+	if maxPerRack > 0 {
+		for rack, count := range racks {
+			// a volume with several replicas in one rack can always run alone
+			if s.rackCompactions[rack] > 0 && s.rackCompactions[rack]+count > maxPerRack {
+				return false
+			}
+		}
+	}
+	return true
+}
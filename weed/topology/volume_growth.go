@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/draleyva/seaweedfs/weed/glog"
+	"github.com/draleyva/seaweedfs/weed/pb/master_pb"
 	"github.com/draleyva/seaweedfs/weed/storage"
 )
 
@@ -67,9 +68,20 @@ func (vg *VolumeGrowth) GrowByCountAndType(targetCount int, option *VolumeGrowOp
 	defer vg.accessLock.Unlock()
 
 	for i := 0; i < targetCount; i++ {
+		if e := topo.checkVolumeCountQuota(option.Collection); e != nil {
+			return counter, e
+		}
 		if c, e := vg.findAndGrow(topo, option); e == nil {
 			counter += c
 		} else {
+			topo.PublishEvent(&master_pb.TopologyEvent{
+				Type:       master_pb.TopologyEvent_VOLUME_GROW_FAILED,
+				Collection: option.Collection,
+				DataCenter: option.DataCenter,
+				Rack:       option.Rack,
+				Url:        option.DataNode,
+				Error:      e.Error(),
+			})
 			return counter, e
 		}
 	}
@@ -200,8 +212,12 @@ func (vg *VolumeGrowth) grow(topo *Topology, vid storage.VolumeId, option *Volum
 				Ttl:              option.Ttl,
 				Version:          storage.CurrentVersion,
 			}
-			server.AddOrUpdateVolume(vi)
+			// the next heartbeat does not see the added volume as new, so the event is published here
+			isNew := server.AddOrUpdateVolume(vi)
 			topo.RegisterVolumeLayout(vi, server)
+			if isNew {
+				topo.publishVolumeEvent(master_pb.TopologyEvent_VOLUME_CREATED, vi, server)
+			}
 			glog.V(0).Infoln("Created Volume", vid, "on", server.NodeImpl.String())
 		} else {
 			glog.V(0).Infoln("Failed to assign volume", vid, "to", servers, "error", err)
@@ -134,6 +134,24 @@ func (vl *VolumeLayout) ListVolumeServers() (nodes []*DataNode) {
 	return
 }
 
+// Usage returns the number of volumes and their total size, as reported by the volume servers.
+func (vl *VolumeLayout) Usage() (volumeCount uint64, size uint64) {
+	vl.accessLock.RLock()
+	defer vl.accessLock.RUnlock()
+
+	for vid, location := range vl.vid2location {
+		volumeCount++
+		var volumeSize uint64
+		for _, dn := range location.list {
+			if v, err := dn.GetVolumesById(vid); err == nil && v.Size > volumeSize {
+				volumeSize = v.Size
+			}
+		}
+		size += volumeSize
+	}
+	return
+}
+
 func (vl *VolumeLayout) PickForWrite(count uint64, option *VolumeGrowOption) (*storage.VolumeId, uint64, *VolumeLocationList, error) {
 	vl.accessLock.RLock()
 	defer vl.accessLock.RUnlock()
@@ -0,0 +1,14 @@
+package util
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrQuotaExceeded is reported when a collection or a filer directory is out of its quota.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// IsQuotaExceeded checks whether the error, possibly passed through grpc or http, is caused by a quota.
+func IsQuotaExceeded(err error) bool {
+	return err != nil && strings.Contains(err.Error(), ErrQuotaExceeded.Error())
+}